@@ -30,6 +30,19 @@ type ValueMarshaler interface {
 	MarshalBSONValue() (bsontype.Type, []byte, error)
 }
 
+// ValueMarshalerWithContext is an interface implemented by types that write
+// their own BSON value using the EncodeContext of the enclosing encode, so
+// they can encode their contents with the ValueEncoders of its Registry while
+// controlling the value around them. MarshalBSONValueWithContext must write
+// exactly one value to vw.
+//
+// A type that implements ValueMarshalerWithContext is encoded with it even if
+// it also implements ValueMarshaler, Marshaler, or Proxy. As with those
+// interfaces, an encoder registered for the type itself takes precedence.
+type ValueMarshalerWithContext interface {
+	MarshalBSONValueWithContext(EncodeContext, bsonrw.ValueWriter) error
+}
+
 // Unmarshaler is an interface implemented by types that can unmarshal a BSON
 // document representation of themselves. The BSON bytes can be assumed to be
 // valid. UnmarshalBSON must copy the BSON bytes if it wishes to retain the data
@@ -107,6 +120,9 @@ func (vde ValueDecoderError) Error() string {
 type EncodeContext struct {
 	*Registry
 	MinSize bool
+	// MarshalSortedKeys causes the keys of maps to be encoded in lexicographic order instead of Go's random
+	// map iteration order, so that equal maps always produce the same bytes.
+	MarshalSortedKeys bool
 }
 
 // DecodeContext is the contextual information required for a Codec to decode a
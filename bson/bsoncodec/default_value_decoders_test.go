@@ -2201,6 +2201,28 @@ func TestDefaultValueDecoders(t *testing.T) {
 				buildDocument(bsoncore.AppendStringElement(nil, "foo", "bar")),
 				nil,
 			},
+			{
+				"inline map of int32",
+				struct {
+					A   string
+					Foo map[string]int32 `bson:",inline"`
+				}{
+					A:   "bar",
+					Foo: map[string]int32{"b": 1},
+				},
+				buildDocument(bsoncore.AppendInt32Element(bsoncore.AppendStringElement(nil, "a", "bar"), "b", 1)),
+				nil,
+			},
+			{
+				"inline map of structs",
+				struct {
+					Foo map[string]struct{ B int32 } `bson:",inline"`
+				}{
+					Foo: map[string]struct{ B int32 }{"foo": {B: 1}},
+				},
+				buildDocument(bsoncore.AppendDocumentElement(nil, "foo", bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "b", 1)))),
+				nil,
+			},
 			{
 				"alternate name bson:name",
 				struct {
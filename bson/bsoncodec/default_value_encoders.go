@@ -13,6 +13,7 @@ import (
 	"math"
 	"net/url"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
@@ -72,6 +73,7 @@ func (dve DefaultValueEncoders) RegisterDefaultEncoders(rb *RegistryBuilder) {
 		RegisterEncoder(tDecimal, ValueEncoderFunc(dve.Decimal128EncodeValue)).
 		RegisterEncoder(tJSONNumber, ValueEncoderFunc(dve.JSONNumberEncodeValue)).
 		RegisterEncoder(tURL, ValueEncoderFunc(dve.URLEncodeValue)).
+		RegisterEncoder(tValueMarshalerWithContext, ValueEncoderFunc(dve.ValueMarshalerWithContextEncodeValue)).
 		RegisterEncoder(tValueMarshaler, ValueEncoderFunc(dve.ValueMarshalerEncodeValue)).
 		RegisterEncoder(tMarshaler, ValueEncoderFunc(dve.MarshalerEncodeValue)).
 		RegisterEncoder(tProxy, ValueEncoderFunc(dve.ProxyEncodeValue)).
@@ -285,6 +287,9 @@ func (dve DefaultValueEncoders) mapEncodeValue(ec EncodeContext, dw bsonrw.Docum
 	}
 
 	keys := val.MapKeys()
+	if ec.MarshalSortedKeys {
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	}
 	for _, key := range keys {
 		if collisionFn != nil && collisionFn(key.String()) {
 			return fmt.Errorf("Key %s of inlined map conflicts with a struct field name", key)
@@ -428,6 +433,19 @@ func (dve DefaultValueEncoders) EmptyInterfaceEncodeValue(ec EncodeContext, vw b
 	return encoder.EncodeValue(ec, vw, val.Elem())
 }
 
+// ValueMarshalerWithContextEncodeValue is the ValueEncoderFunc for ValueMarshalerWithContext implementations.
+func (dve DefaultValueEncoders) ValueMarshalerWithContextEncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || !val.Type().Implements(tValueMarshalerWithContext) {
+		return ValueEncoderError{
+			Name:     "ValueMarshalerWithContextEncodeValue",
+			Types:    []reflect.Type{tValueMarshalerWithContext},
+			Received: val,
+		}
+	}
+
+	return val.Interface().(ValueMarshalerWithContext).MarshalBSONValueWithContext(ec, vw)
+}
+
 // ValueMarshalerEncodeValue is the ValueEncoderFunc for ValueMarshaler implementations.
 func (dve DefaultValueEncoders) ValueMarshalerEncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
 	if !val.IsValid() || !val.Type().Implements(tValueMarshaler) {
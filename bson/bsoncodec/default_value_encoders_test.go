@@ -484,6 +484,48 @@ func TestDefaultValueEncoders(t *testing.T) {
 				},
 			},
 		},
+		{
+			"ValueMarshalerWithContextEncodeValue",
+			ValueEncoderFunc(dve.ValueMarshalerWithContextEncodeValue),
+			[]subtest{
+				{
+					"wrong type",
+					wrong,
+					nil,
+					nil,
+					bsonrwtest.Nothing,
+					ValueEncoderError{
+						Name:     "ValueMarshalerWithContextEncodeValue",
+						Types:    []reflect.Type{tValueMarshalerWithContext},
+						Received: reflect.ValueOf(wrong),
+					},
+				},
+				{
+					"MarshalBSONValueWithContext error",
+					testValueMarshalerWithContext{err: errors.New("mbsonvc error")},
+					nil,
+					nil,
+					bsonrwtest.Nothing,
+					errors.New("mbsonvc error"),
+				},
+				{
+					"Lookup error",
+					testValueMarshalerWithContext{ret: "foo"},
+					&EncodeContext{Registry: NewRegistryBuilder().Build()},
+					nil,
+					bsonrwtest.Nothing,
+					ErrNoEncoder{Type: reflect.TypeOf("")},
+				},
+				{
+					"success",
+					testValueMarshalerWithContext{ret: "foo"},
+					&EncodeContext{Registry: buildDefaultRegistry()},
+					nil,
+					bsonrwtest.WriteString,
+					nil,
+				},
+			},
+		},
 		{
 			"ValueMarshalerEncodeValue",
 			ValueEncoderFunc(dve.ValueMarshalerEncodeValue),
@@ -1091,6 +1133,28 @@ func TestDefaultValueEncoders(t *testing.T) {
 				buildDocument(bsoncore.AppendStringElement(nil, "foo", "bar")),
 				nil,
 			},
+			{
+				"inline map of int32",
+				struct {
+					A   string
+					Foo map[string]int32 `bson:",inline"`
+				}{
+					A:   "bar",
+					Foo: map[string]int32{"b": 1},
+				},
+				buildDocument(bsoncore.AppendInt32Element(bsoncore.AppendStringElement(nil, "a", "bar"), "b", 1)),
+				nil,
+			},
+			{
+				"inline map of structs",
+				struct {
+					Foo map[string]struct{ B int32 } `bson:",inline"`
+				}{
+					Foo: map[string]struct{ B int32 }{"foo": {B: 1}},
+				},
+				buildDocument(bsoncore.AppendDocumentElement(nil, "foo", bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "b", 1)))),
+				nil,
+			},
 			{
 				"alternate name bson:name",
 				struct {
@@ -1152,6 +1216,7 @@ func TestDefaultValueEncoders(t *testing.T) {
 					AG testProxy
 					AH map[string]interface{}
 					AI primitive.CodeWithScope
+					AJ testValueMarshalerWithContext
 				}{
 					A: true,
 					B: 123,
@@ -1181,6 +1246,7 @@ func TestDefaultValueEncoders(t *testing.T) {
 					AG: testProxy{ret: struct{ Pi float64 }{Pi: 3.14159}},
 					AH: nil,
 					AI: primitive.CodeWithScope{Code: "var hello = 'world';", Scope: primitive.D{{"pi", 3.14159}}},
+					AJ: testValueMarshalerWithContext{ret: struct{ Pi float64 }{Pi: 3.14159}},
 				},
 				buildDocument(func(doc []byte) []byte {
 					doc = bsoncore.AppendBooleanElement(doc, "a", true)
@@ -1211,6 +1277,7 @@ func TestDefaultValueEncoders(t *testing.T) {
 					doc = bsoncore.AppendCodeWithScopeElement(doc, "ai",
 						"var hello = 'world';", buildDocument(bsoncore.AppendDoubleElement(nil, "pi", 3.14159)),
 					)
+					doc = bsoncore.AppendDocumentElement(doc, "aj", buildDocument(bsoncore.AppendDoubleElement(nil, "pi", 3.14159)))
 					return doc
 				}(nil)),
 				nil,
@@ -1410,6 +1477,28 @@ func (tvm testValueMarshaler) MarshalBSONValue() (bsontype.Type, []byte, error)
 	return tvm.t, tvm.buf, tvm.err
 }
 
+// testValueMarshalerWithContext encodes ret with the registry. It also implements ValueMarshaler so tests can
+// check that MarshalBSONValueWithContext takes precedence.
+type testValueMarshalerWithContext struct {
+	ret interface{}
+	err error
+}
+
+func (tvm testValueMarshalerWithContext) MarshalBSONValueWithContext(ec EncodeContext, vw bsonrw.ValueWriter) error {
+	if tvm.err != nil {
+		return tvm.err
+	}
+	encoder, err := ec.LookupEncoder(reflect.TypeOf(tvm.ret))
+	if err != nil {
+		return err
+	}
+	return encoder.EncodeValue(ec, vw, reflect.ValueOf(tvm.ret))
+}
+
+func (tvm testValueMarshalerWithContext) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return 0, nil, errors.New("MarshalBSONValue should not be called")
+}
+
 type testMarshaler struct {
 	buf []byte
 	err error
@@ -0,0 +1,94 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+)
+
+var tDuration = reflect.TypeOf(time.Duration(0))
+
+var _ ValueCodec = &DurationCodec{}
+
+// DurationCodec is the Codec used for time.Duration values when they should be stored as a count
+// of a unit other than nanoseconds. A time.Duration is encoded as a BSON int64 holding the number
+// of whole units in the duration, and decoded by multiplying the stored number by the unit.
+type DurationCodec struct {
+	unit time.Duration
+}
+
+// NewDurationCodec returns a DurationCodec that stores durations as a count of unit, e.g.
+// time.Millisecond. If unit is not positive, durations are stored in nanoseconds.
+func NewDurationCodec(unit time.Duration) *DurationCodec {
+	if unit <= 0 {
+		unit = time.Nanosecond
+	}
+	return &DurationCodec{unit: unit}
+}
+
+// RegisterDurationCodec registers a DurationCodec for time.Duration that stores durations as a
+// count of unit. Durations are truncated to a whole number of units when they are encoded.
+func (rb *RegistryBuilder) RegisterDurationCodec(unit time.Duration) *RegistryBuilder {
+	return rb.RegisterCodec(tDuration, NewDurationCodec(unit))
+}
+
+// EncodeValue is the ValueEncoderFunc for time.Duration.
+func (dc *DurationCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tDuration {
+		return ValueEncoderError{Name: "DurationCodec.EncodeValue", Types: []reflect.Type{tDuration}, Received: val}
+	}
+	return vw.WriteInt64(val.Int() / int64(dc.unit))
+}
+
+// DecodeValue is the ValueDecoderFunc for time.Duration.
+func (dc *DurationCodec) DecodeValue(dctx DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tDuration {
+		return ValueDecoderError{Name: "DurationCodec.DecodeValue", Types: []reflect.Type{tDuration}, Received: val}
+	}
+
+	var count int64
+	switch vr.Type() {
+	case bsontype.Int32:
+		i32, err := vr.ReadInt32()
+		if err != nil {
+			return err
+		}
+		count = int64(i32)
+	case bsontype.Int64:
+		i64, err := vr.ReadInt64()
+		if err != nil {
+			return err
+		}
+		count = i64
+	case bsontype.Double:
+		f64, err := vr.ReadDouble()
+		if err != nil {
+			return err
+		}
+		if !dctx.Truncate && math.Floor(f64) != f64 {
+			return fmt.Errorf("DurationCodec can only truncate float64 to a whole number of %v when truncation is enabled", dc.unit)
+		}
+		if f64 > float64(math.MaxInt64) || f64 < float64(math.MinInt64) {
+			return fmt.Errorf("%g overflows int64", f64)
+		}
+		count = int64(f64)
+	default:
+		return fmt.Errorf("cannot decode %v into a time.Duration", vr.Type())
+	}
+
+	if count > math.MaxInt64/int64(dc.unit) || count < math.MinInt64/int64(dc.unit) {
+		return fmt.Errorf("%d units of %v overflows time.Duration", count, dc.unit)
+	}
+	val.SetInt(count * int64(dc.unit))
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationCodec(t *testing.T) {
+	type durationDoc struct {
+		D time.Duration
+	}
+
+	encode := func(t *testing.T, reg *Registry, doc durationDoc) bsoncore.Document {
+		t.Helper()
+		enc, err := reg.LookupEncoder(reflect.TypeOf(doc))
+		require.NoError(t, err)
+		var sw bsonrw.SliceWriter
+		vw, err := bsonrw.NewBSONValueWriter(&sw)
+		require.NoError(t, err)
+		require.NoError(t, enc.EncodeValue(EncodeContext{Registry: reg}, vw, reflect.ValueOf(doc)))
+		return bsoncore.Document(sw)
+	}
+	decode := func(t *testing.T, reg *Registry, b []byte) (durationDoc, error) {
+		t.Helper()
+		var doc durationDoc
+		dec, err := reg.LookupDecoder(reflect.TypeOf(doc))
+		require.NoError(t, err)
+		err = dec.DecodeValue(DecodeContext{Registry: reg}, bsonrw.NewBSONDocumentReader(b), reflect.ValueOf(&doc).Elem())
+		return doc, err
+	}
+	register := func(unit time.Duration) *Registry {
+		rb := NewRegistryBuilder()
+		defaultValueEncoders.RegisterDefaultEncoders(rb)
+		defaultValueDecoders.RegisterDefaultDecoders(rb)
+		return rb.RegisterDurationCodec(unit).Build()
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		tests := []struct {
+			unit     time.Duration
+			d        time.Duration
+			expected int64
+		}{
+			{unit: 0, d: 1500 * time.Millisecond, expected: 1500000000},
+			{unit: time.Nanosecond, d: 1500 * time.Millisecond, expected: 1500000000},
+			{unit: time.Microsecond, d: 1500 * time.Millisecond, expected: 1500000},
+			{unit: time.Millisecond, d: 1500 * time.Millisecond, expected: 1500},
+			{unit: time.Second, d: 90 * time.Second, expected: 90},
+			{unit: time.Millisecond, d: -2 * time.Second, expected: -2000},
+			{unit: time.Millisecond, d: 0, expected: 0},
+		}
+
+		for _, test := range tests {
+			t.Run(test.unit.String()+" "+test.d.String(), func(t *testing.T) {
+				reg := register(test.unit)
+				doc := encode(t, reg, durationDoc{D: test.d})
+
+				val := doc.Lookup("d")
+				require.Equal(t, bsontype.Int64, val.Type)
+				require.Equal(t, test.expected, val.Int64())
+
+				decoded, err := decode(t, reg, doc)
+				require.NoError(t, err)
+				require.Equal(t, test.d, decoded.D)
+			})
+		}
+	})
+	t.Run("encoding truncates to the unit", func(t *testing.T) {
+		reg := register(time.Millisecond)
+		doc := encode(t, reg, durationDoc{D: 1500 * time.Microsecond})
+		require.Equal(t, int64(1), doc.Lookup("d").Int64())
+	})
+	t.Run("decodes other numeric types", func(t *testing.T) {
+		reg := register(time.Millisecond)
+
+		idx, b := bsoncore.AppendDocumentStart(nil)
+		b = bsoncore.AppendInt32Element(b, "d", 250)
+		b, _ = bsoncore.AppendDocumentEnd(b, idx)
+		decoded, err := decode(t, reg, b)
+		require.NoError(t, err)
+		require.Equal(t, 250*time.Millisecond, decoded.D)
+
+		idx, b = bsoncore.AppendDocumentStart(nil)
+		b = bsoncore.AppendDoubleElement(b, "d", 3)
+		b, _ = bsoncore.AppendDocumentEnd(b, idx)
+		decoded, err = decode(t, reg, b)
+		require.NoError(t, err)
+		require.Equal(t, 3*time.Millisecond, decoded.D)
+
+		idx, b = bsoncore.AppendDocumentStart(nil)
+		b = bsoncore.AppendDoubleElement(b, "d", 3.5)
+		b, _ = bsoncore.AppendDocumentEnd(b, idx)
+		_, err = decode(t, reg, b)
+		require.Error(t, err)
+	})
+	t.Run("overflow", func(t *testing.T) {
+		reg := register(time.Second)
+
+		idx, b := bsoncore.AppendDocumentStart(nil)
+		b = bsoncore.AppendInt64Element(b, "d", math.MaxInt64)
+		b, _ = bsoncore.AppendDocumentEnd(b, idx)
+		_, err := decode(t, reg, b)
+		require.Error(t, err)
+	})
+	t.Run("default registry is unchanged", func(t *testing.T) {
+		reg := buildDefaultRegistry()
+		doc := encode(t, reg, durationDoc{D: 1500 * time.Millisecond})
+		require.Equal(t, int64(1500000000), doc.Lookup("d").Int64())
+	})
+}
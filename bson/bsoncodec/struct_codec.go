@@ -93,7 +93,7 @@ func (sc *StructCodec) EncodeValue(r EncodeContext, vw bsonrw.ValueWriter, val r
 			return err
 		}
 
-		ectx := EncodeContext{Registry: r.Registry, MinSize: desc.minSize}
+		ectx := EncodeContext{Registry: r.Registry, MinSize: desc.minSize, MarshalSortedKeys: r.MarshalSortedKeys}
 		err = encoder.EncodeValue(ectx, vw2, rv)
 		if err != nil {
 			return err
@@ -172,7 +172,7 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 			elem := reflect.New(inlineMap.Type().Elem()).Elem()
 			err = decoder.DecodeValue(r, vr, elem)
 			if err != nil {
-				return err
+				return fmt.Errorf("cannot decode extra element '%s' into inline map of %s: %v", name, elem.Type(), err)
 			}
 			inlineMap.SetMapIndex(reflect.ValueOf(name), elem)
 			continue
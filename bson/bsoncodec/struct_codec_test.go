@@ -7,9 +7,12 @@
 package bsoncodec
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
+	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,3 +48,22 @@ func TestZeoerInterfaceUsedByDecoder(t *testing.T) {
 	var zp *zeroTest
 	assert.True(t, enc.isZero(zp))
 }
+
+func TestStructCodecInlineMapDecodeError(t *testing.T) {
+	type withInlineMap struct {
+		A     string
+		Extra map[string]int32 `bson:",inline"`
+	}
+
+	doc := bsoncore.BuildDocument(nil, bsoncore.AppendStringElement(bsoncore.AppendStringElement(nil, "a", "foo"), "b", "bar"))
+	var got withInlineMap
+	err := defaultStructCodec.DecodeValue(
+		DecodeContext{Registry: buildDefaultRegistry()},
+		bsonrw.NewBSONDocumentReader(doc),
+		reflect.ValueOf(&got).Elem(),
+	)
+	if err == nil {
+		t.Fatal("expected an error decoding a string into an inline map of int32")
+	}
+	assert.Contains(t, err.Error(), "cannot decode extra element 'b' into inline map of int32")
+}
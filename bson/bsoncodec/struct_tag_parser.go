@@ -35,7 +35,8 @@ func (stpf StructTagParserFunc) ParseStructTags(sf reflect.StructField) (StructT
 // The properties are defined below:
 //
 //     OmitEmpty  Only include the field if it's not set to the zero value for the type or to
-//                empty slices or maps.
+//                empty slices or maps. Values implementing Zeroer, such as time.Time and
+//                primitive.ObjectID, are omitted when their IsZero method returns true.
 //
 //     MinSize    Marshal an integer of a type larger than 32 bits value as an int32, if that's
 //                feasible while preserving the numeric value.
@@ -45,7 +46,9 @@ func (stpf StructTagParserFunc) ParseStructTags(sf reflect.StructField) (StructT
 //
 //     Inline     Inline the field, which must be a struct or a map, causing all of its fields
 //                or keys to be processed as if they were part of the outer struct. For maps,
-//                keys must not conflict with the bson keys of other struct fields.
+//                keys must not conflict with the bson keys of other struct fields. When decoding,
+//                elements that don't match another field are decoded into the map's value type
+//                using the registry, so a map[string]T only accepts elements that decode into a T.
 //
 //     Skip       This struct field should be skipped. This is usually denoted by parsing a "-"
 //                for the name.
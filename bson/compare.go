@@ -0,0 +1,365 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
+)
+
+// CompareRawValues compares a and b using the order MongoDB uses to compare BSON values and
+// returns -1 if a sorts before b, 0 if they are equivalent, and +1 if a sorts after b.
+//
+// Values of different types are ordered by type as follows, so that for example every string
+// sorts before every document:
+//
+//  1. MinKey
+//  2. Undefined and missing values (the zero RawValue)
+//  3. Null
+//  4. Numbers (int32, int64, double, decimal128)
+//  5. Symbols and strings
+//  6. Documents
+//  7. Arrays
+//  8. Binary data
+//  9. ObjectIDs
+//  10. Booleans
+//  11. Dates
+//  12. Timestamps
+//  13. Regular expressions
+//  14. DBPointers
+//  15. JavaScript code
+//  16. JavaScript code with scope
+//  17. MaxKey
+//
+// Numbers compare by their exact numeric value regardless of type, so int32(1), int64(1), 1.0
+// and a decimal128 1.00 are all equivalent, while a decimal128 0.1 sorts before the double 0.1
+// because the double is slightly larger. NaN values are equivalent to each other and sort before
+// every other number. Strings compare byte by byte, without collation. Documents and arrays
+// compare element by element, comparing the type, then the key, then the value of each element.
+//
+// Unlike Equal, which requires the values to have identical types and bytes, CompareRawValues
+// reports values of different numeric types as equivalent. Values that are not valid BSON are
+// compared by type and then byte by byte.
+func CompareRawValues(a, b RawValue) int {
+	return compareValues(convertToCoreValue(a), convertToCoreValue(b))
+}
+
+func compareValues(a, b bsoncore.Value) int {
+	if c := compareInts(canonicalType(a.Type), canonicalType(b.Type)); c != 0 {
+		return c
+	}
+	if a.Type == 0 || b.Type == 0 {
+		// A missing value brackets with undefined, neither of which have any data to compare.
+		return 0
+	}
+	if a.Validate() != nil || b.Validate() != nil {
+		if c := compareInts(int(a.Type), int(b.Type)); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.Data, b.Data)
+	}
+
+	switch a.Type {
+	case bsontype.Double, bsontype.Int32, bsontype.Int64, bsontype.Decimal128:
+		return compareNumbers(a, b)
+	case bsontype.String, bsontype.Symbol:
+		return strings.Compare(stringValue(a), stringValue(b))
+	case bsontype.EmbeddedDocument, bsontype.Array:
+		return compareDocuments(a.Data, b.Data)
+	case bsontype.Binary:
+		aSubtype, aData := a.Binary()
+		bSubtype, bData := b.Binary()
+		if c := compareInts(len(aData), len(bData)); c != 0 {
+			return c
+		}
+		if c := compareInts(int(aSubtype), int(bSubtype)); c != 0 {
+			return c
+		}
+		return bytes.Compare(aData, bData)
+	case bsontype.ObjectID, bsontype.DBPointer:
+		if c := compareInts(len(a.Data), len(b.Data)); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.Data, b.Data)
+	case bsontype.Boolean:
+		return compareInts(boolToInt(a.Boolean()), boolToInt(b.Boolean()))
+	case bsontype.DateTime:
+		return compareInt64s(a.DateTime(), b.DateTime())
+	case bsontype.Timestamp:
+		aT, aI := a.Timestamp()
+		bT, bI := b.Timestamp()
+		if aT != bT {
+			return compareInt64s(int64(aT), int64(bT))
+		}
+		return compareInt64s(int64(aI), int64(bI))
+	case bsontype.Regex:
+		aPattern, aOptions := a.Regex()
+		bPattern, bOptions := b.Regex()
+		if c := strings.Compare(aPattern, bPattern); c != 0 {
+			return c
+		}
+		return strings.Compare(aOptions, bOptions)
+	case bsontype.JavaScript:
+		return strings.Compare(a.JavaScript(), b.JavaScript())
+	case bsontype.CodeWithScope:
+		aCode, aScope := a.CodeWithScope()
+		bCode, bScope := b.CodeWithScope()
+		if c := strings.Compare(aCode, bCode); c != 0 {
+			return c
+		}
+		return compareDocuments(aScope, bScope)
+	default:
+		// MinKey, MaxKey, null, and undefined values have no data to compare.
+		return 0
+	}
+}
+
+// canonicalType returns the rank of t in the order MongoDB uses to compare values of different
+// types. Types that compare as the same type, like the numeric types, have the same rank.
+func canonicalType(t bsontype.Type) int {
+	switch t {
+	case bsontype.MinKey:
+		return -1
+	case 0, bsontype.Undefined:
+		return 0
+	case bsontype.Null:
+		return 5
+	case bsontype.Double, bsontype.Int32, bsontype.Int64, bsontype.Decimal128:
+		return 10
+	case bsontype.String, bsontype.Symbol:
+		return 15
+	case bsontype.EmbeddedDocument:
+		return 20
+	case bsontype.Array:
+		return 25
+	case bsontype.Binary:
+		return 30
+	case bsontype.ObjectID:
+		return 35
+	case bsontype.Boolean:
+		return 40
+	case bsontype.DateTime:
+		return 45
+	case bsontype.Timestamp:
+		return 47
+	case bsontype.Regex:
+		return 50
+	case bsontype.DBPointer:
+		return 55
+	case bsontype.JavaScript:
+		return 60
+	case bsontype.CodeWithScope:
+		return 65
+	case bsontype.MaxKey:
+		return 127
+	default:
+		// Unknown types sort after every known type except MaxKey.
+		return 100
+	}
+}
+
+func compareDocuments(a, b []byte) int {
+	aElems, aErr := bsoncore.Document(a).Elements()
+	bElems, bErr := bsoncore.Document(b).Elements()
+	if aErr != nil || bErr != nil {
+		return bytes.Compare(a, b)
+	}
+
+	for i := 0; i < len(aElems) && i < len(bElems); i++ {
+		aVal, bVal := aElems[i].Value(), bElems[i].Value()
+		if c := compareInts(canonicalType(aVal.Type), canonicalType(bVal.Type)); c != 0 {
+			return c
+		}
+		if c := bytes.Compare(aElems[i].KeyBytes(), bElems[i].KeyBytes()); c != 0 {
+			return c
+		}
+		if c := compareValues(aVal, bVal); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(aElems), len(bElems))
+}
+
+// maxExactFloat is the largest magnitude below which every integer can be represented exactly as
+// a float64.
+const maxExactFloat = 1 << 53
+
+func compareNumbers(a, b bsoncore.Value) int {
+	switch {
+	case isInteger(a.Type) && isInteger(b.Type):
+		return compareInt64s(intValue(a), intValue(b))
+	case a.Type == bsontype.Double && b.Type == bsontype.Double:
+		return compareFloats(a.Double(), b.Double())
+	case isInteger(a.Type) && b.Type == bsontype.Double:
+		if i := intValue(a); -maxExactFloat <= i && i <= maxExactFloat {
+			return compareFloats(float64(i), b.Double())
+		}
+	case a.Type == bsontype.Double && isInteger(b.Type):
+		if i := intValue(b); -maxExactFloat <= i && i <= maxExactFloat {
+			return compareFloats(a.Double(), float64(i))
+		}
+	}
+
+	aNum, bNum := toNumber(a), toNumber(b)
+	switch {
+	case aNum.nan && bNum.nan:
+		return 0
+	case aNum.nan:
+		return -1
+	case bNum.nan:
+		return 1
+	case aNum.inf != 0 || bNum.inf != 0:
+		return compareInts(aNum.inf, bNum.inf)
+	default:
+		return aNum.rat.Cmp(bNum.rat)
+	}
+}
+
+// number is the exact value of a BSON number. If nan is false and inf is zero, rat holds the
+// value.
+type number struct {
+	nan bool
+	inf int
+	rat *big.Rat
+}
+
+func toNumber(v bsoncore.Value) number {
+	switch v.Type {
+	case bsontype.Double:
+		f := v.Double()
+		switch {
+		case math.IsNaN(f):
+			return number{nan: true}
+		case math.IsInf(f, 1):
+			return number{inf: 1}
+		case math.IsInf(f, -1):
+			return number{inf: -1}
+		}
+		return number{rat: new(big.Rat).SetFloat64(f)}
+	case bsontype.Decimal128:
+		return decimalToNumber(v.Decimal128())
+	default:
+		return number{rat: new(big.Rat).SetInt64(intValue(v))}
+	}
+}
+
+// maxDecimalSignificand is the largest significand of a canonical decimal128 value, 10^34 - 1.
+var maxDecimalSignificand, _ = new(big.Int).SetString("9999999999999999999999999999999999", 10)
+
+// decimalToNumber returns the exact value of d following the IEEE 754-2008 decimal128 encoding.
+// Non-canonical significands are treated as zero, as the BSON specification requires.
+func decimalToNumber(d primitive.Decimal128) number {
+	h, l := d.GetBytes()
+	sign := 1
+	if h>>63&1 == 1 {
+		sign = -1
+	}
+
+	switch h >> 58 & (1<<5 - 1) {
+	case 0x1F:
+		return number{nan: true}
+	case 0x1E:
+		return number{inf: sign}
+	}
+
+	var exp int
+	significand := new(big.Int)
+	if h>>61&3 == 3 {
+		// This form is only used for significands larger than the maximum, so the value is zero.
+		exp = int(h>>47&(1<<14-1)) - 6176
+	} else {
+		exp = int(h>>49&(1<<14-1)) - 6176
+		significand.SetUint64(h & (1<<49 - 1))
+		significand.Lsh(significand, 64)
+		significand.Or(significand, new(big.Int).SetUint64(l))
+		if significand.Cmp(maxDecimalSignificand) > 0 {
+			significand.SetInt64(0)
+		}
+	}
+	if sign < 0 {
+		significand.Neg(significand)
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(absInt(exp))), nil)
+	rat := new(big.Rat)
+	if exp >= 0 {
+		rat.SetInt(significand.Mul(significand, scale))
+	} else {
+		rat.SetFrac(significand, scale)
+	}
+	return number{rat: rat}
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return -1
+	case math.IsNaN(b):
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func intValue(v bsoncore.Value) int64 {
+	if v.Type == bsontype.Int32 {
+		return int64(v.Int32())
+	}
+	return v.Int64()
+}
+
+func isInteger(t bsontype.Type) bool {
+	return t == bsontype.Int32 || t == bsontype.Int64
+}
+
+func stringValue(v bsoncore.Value) string {
+	if v.Type == bsontype.Symbol {
+		return v.Symbol()
+	}
+	return v.StringValue()
+}
+
+func compareInts(a, b int) int {
+	return compareInt64s(int64(a), int64(b))
+}
+
+func compareInt64s(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func absInt(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
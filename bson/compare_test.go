@@ -0,0 +1,153 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
+)
+
+func TestCompareRawValues(t *testing.T) {
+	int32RV := func(i int32) RawValue { return RawValue{Type: bsontype.Int32, Value: bsoncore.AppendInt32(nil, i)} }
+	int64RV := func(i int64) RawValue { return RawValue{Type: bsontype.Int64, Value: bsoncore.AppendInt64(nil, i)} }
+	doubleRV := func(f float64) RawValue { return RawValue{Type: bsontype.Double, Value: bsoncore.AppendDouble(nil, f)} }
+	decimalRV := func(s string) RawValue {
+		d, err := primitive.ParseDecimal128(s)
+		noerr(t, err)
+		return RawValue{Type: bsontype.Decimal128, Value: bsoncore.AppendDecimal128(nil, d)}
+	}
+	stringRV := func(s string) RawValue { return RawValue{Type: bsontype.String, Value: bsoncore.AppendString(nil, s)} }
+	symbolRV := func(s string) RawValue { return RawValue{Type: bsontype.Symbol, Value: bsoncore.AppendSymbol(nil, s)} }
+	docRV := func(elems ...[]byte) RawValue {
+		return RawValue{Type: bsontype.EmbeddedDocument, Value: bsoncore.BuildDocument(nil, bytes.Join(elems, nil))}
+	}
+	arrayRV := func(elems ...[]byte) RawValue {
+		return RawValue{Type: bsontype.Array, Value: bsoncore.BuildDocument(nil, bytes.Join(elems, nil))}
+	}
+	binaryRV := func(subtype byte, b []byte) RawValue {
+		return RawValue{Type: bsontype.Binary, Value: bsoncore.AppendBinary(nil, subtype, b)}
+	}
+	boolRV := func(b bool) RawValue { return RawValue{Type: bsontype.Boolean, Value: bsoncore.AppendBoolean(nil, b)} }
+	dateRV := func(dt int64) RawValue {
+		return RawValue{Type: bsontype.DateTime, Value: bsoncore.AppendDateTime(nil, dt)}
+	}
+	timestampRV := func(ts, i uint32) RawValue {
+		return RawValue{Type: bsontype.Timestamp, Value: bsoncore.AppendTimestamp(nil, ts, i)}
+	}
+	oidRV := func(b byte) RawValue {
+		return RawValue{Type: bsontype.ObjectID, Value: bsoncore.AppendObjectID(nil, primitive.ObjectID{b})}
+	}
+	regexRV := func(pattern, options string) RawValue {
+		return RawValue{Type: bsontype.Regex, Value: bsoncore.AppendRegex(nil, pattern, options)}
+	}
+	codeRV := func(code string) RawValue {
+		return RawValue{Type: bsontype.JavaScript, Value: bsoncore.AppendJavaScript(nil, code)}
+	}
+	null := RawValue{Type: bsontype.Null}
+	undefined := RawValue{Type: bsontype.Undefined}
+	minKey := RawValue{Type: bsontype.MinKey}
+	maxKey := RawValue{Type: bsontype.MaxKey}
+	missing := RawValue{}
+
+	testCases := []struct {
+		name string
+		a, b RawValue
+		want int
+	}{
+		{"int32 equal", int32RV(1), int32RV(1), 0},
+		{"int32 less", int32RV(-1), int32RV(1), -1},
+		{"int32 vs int64", int32RV(2), int64RV(1), 1},
+		{"int64 vs double equal", int64RV(1), doubleRV(1.0), 0},
+		{"int32 vs double fraction", int32RV(1), doubleRV(1.5), -1},
+		{"large int64 vs double", int64RV(math.MaxInt64), doubleRV(math.Pow(2, 63)), -1},
+		{"large int64 vs rounded double", int64RV(1<<53 + 1), doubleRV(1 << 53), 1},
+		{"negative zero", doubleRV(math.Copysign(0, -1)), int32RV(0), 0},
+		{"NaN equal", doubleRV(math.NaN()), doubleRV(math.NaN()), 0},
+		{"NaN before numbers", doubleRV(math.NaN()), doubleRV(math.Inf(-1)), -1},
+		{"NaN before ints", doubleRV(math.NaN()), int64RV(math.MinInt64), -1},
+		{"NaN after null", doubleRV(math.NaN()), null, 1},
+		{"decimal NaN vs double NaN", decimalRV("NaN"), doubleRV(math.NaN()), 0},
+		{"decimal NaN before numbers", decimalRV("NaN"), decimalRV("-Infinity"), -1},
+		{"ints before infinity", int64RV(math.MaxInt64), doubleRV(math.Inf(1)), -1},
+		{"decimal infinity vs double infinity", decimalRV("Infinity"), doubleRV(math.Inf(1)), 0},
+		{"decimal negative infinity", decimalRV("-Infinity"), int32RV(0), -1},
+		{"decimal vs int", decimalRV("1.00"), int32RV(1), 0},
+		{"decimal vs decimal", decimalRV("1.5"), decimalRV("1.50"), 0},
+		{"decimal vs double equal", decimalRV("0.5"), doubleRV(0.5), 0},
+		{"decimal vs inexact double", decimalRV("0.1"), doubleRV(0.1), -1},
+		{"large decimal", decimalRV("1E+400"), doubleRV(math.MaxFloat64), 1},
+		{"small decimal", decimalRV("-1E-400"), int32RV(0), -1},
+		{"string equal", stringRV("abc"), stringRV("abc"), 0},
+		{"string less", stringRV("abc"), stringRV("abd"), -1},
+		{"string prefix", stringRV("ab"), stringRV("abc"), -1},
+		{"string vs symbol", stringRV("abc"), symbolRV("abc"), 0},
+		{"string after numbers", stringRV(""), doubleRV(math.Inf(1)), 1},
+		{"null vs missing", null, missing, 1},
+		{"missing vs undefined", missing, undefined, 0},
+		{"missing vs missing", missing, missing, 0},
+		{"missing after minkey", missing, minKey, 1},
+		{"undefined before null", undefined, null, -1},
+		{"minkey before everything", minKey, null, -1},
+		{"maxkey after everything", maxKey, codeRV("x"), 1},
+		{"maxkey equal", maxKey, maxKey, 0},
+		{"document equal", docRV(bsoncore.AppendInt32Element(nil, "a", 1)), docRV(bsoncore.AppendDoubleElement(nil, "a", 1)), 0},
+		{"document by key", docRV(bsoncore.AppendInt32Element(nil, "a", 2)), docRV(bsoncore.AppendInt32Element(nil, "b", 1)), -1},
+		{"document by value type", docRV(bsoncore.AppendStringElement(nil, "a", "x")), docRV(bsoncore.AppendInt32Element(nil, "a", 1)), 1},
+		{"document by length", docRV(bsoncore.AppendInt32Element(nil, "a", 1)), docRV(bsoncore.AppendInt32Element(nil, "a", 1), bsoncore.AppendNullElement(nil, "b")), -1},
+		{"empty document", docRV(), docRV(), 0},
+		{"document before array", docRV(bsoncore.AppendInt32Element(nil, "a", 1)), arrayRV(), -1},
+		{"array by element", arrayRV(bsoncore.AppendInt32Element(nil, "0", 1)), arrayRV(bsoncore.AppendInt32Element(nil, "0", 2)), -1},
+		{"binary by length", binaryRV(0x80, []byte{0xFF}), binaryRV(0x00, []byte{0x00, 0x00}), -1},
+		{"binary by subtype", binaryRV(0x00, []byte{0xFF}), binaryRV(0x80, []byte{0x00}), -1},
+		{"binary by data", binaryRV(0x00, []byte{0x01}), binaryRV(0x00, []byte{0x00}), 1},
+		{"objectid", oidRV(1), oidRV(2), -1},
+		{"bool", boolRV(false), boolRV(true), -1},
+		{"bool after objectid", boolRV(false), oidRV(0xFF), 1},
+		{"date", dateRV(-1), dateRV(1), -1},
+		{"date before timestamp", dateRV(math.MaxInt64), timestampRV(0, 0), -1},
+		{"timestamp by time", timestampRV(1, 5), timestampRV(2, 0), -1},
+		{"timestamp by increment", timestampRV(1, 5), timestampRV(1, 4), 1},
+		{"timestamp unsigned", timestampRV(math.MaxUint32, 0), timestampRV(1, 0), 1},
+		{"regex by pattern", regexRV("a", "z"), regexRV("b", "a"), -1},
+		{"regex by options", regexRV("a", "i"), regexRV("a", "m"), -1},
+		{"code", codeRV("a"), codeRV("b"), -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CompareRawValues(tc.a, tc.b); got != tc.want {
+				t.Errorf("Unexpected result comparing %v and %v. got %d; want %d", tc.a, tc.b, got, tc.want)
+			}
+			if got := CompareRawValues(tc.b, tc.a); got != -tc.want {
+				t.Errorf("Unexpected result comparing %v and %v. got %d; want %d", tc.b, tc.a, got, -tc.want)
+			}
+		})
+	}
+
+	t.Run("invalid values do not panic", func(t *testing.T) {
+		invalid := RawValue{Type: bsontype.String, Value: []byte{0x01}}
+		if got := CompareRawValues(invalid, invalid); got != 0 {
+			t.Errorf("Unexpected result comparing invalid values. got %d; want 0", got)
+		}
+		if got := CompareRawValues(invalid, stringRV("a")); got != -1 {
+			t.Errorf("Unexpected result comparing invalid values. got %d; want -1", got)
+		}
+	})
+	t.Run("Equal is exact", func(t *testing.T) {
+		if int32RV(1).Equal(doubleRV(1)) {
+			t.Errorf("Expected values of different types not to be Equal")
+		}
+		if !int32RV(1).Equal(int32RV(1)) {
+			t.Errorf("Expected identical values to be Equal")
+		}
+	})
+}
@@ -9,11 +9,13 @@ package bson
 import (
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"sync"
 
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
 	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
+	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
 )
 
 // This pool is used to keep the allocations of Decoders down. This is only used for the Marshal*
@@ -25,11 +27,37 @@ var decPool = sync.Pool{
 	},
 }
 
-// A Decoder reads and decodes BSON documents from a stream. It reads from a bsonrw.ValueReader as
-// the source of BSON data.
+// maxStreamDocumentSize is the largest document a stream Decoder will read. It is the server's
+// maximum document size plus the headroom the server allows for internal documents such as oplog
+// entries, and stops a corrupt length prefix from causing a huge allocation.
+const maxStreamDocumentSize = 16*1024*1024 + 16*1024
+
+// StreamError is returned by a Decoder created with NewStreamDecoder when the stream contains bytes
+// that are not a valid BSON document. Offset is the position in the stream where the invalid
+// document begins. Once a StreamError is returned, every later call to Decode returns it as well.
+type StreamError struct {
+	Offset int64
+	Err    error
+}
+
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("invalid BSON document at offset %d of stream: %v", e.Offset, e.Err)
+}
+
+// Unwrap returns the reason the document is invalid.
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// A Decoder reads and decodes BSON documents from a stream. It reads from a bsonrw.ValueReader or,
+// if created with NewStreamDecoder, an io.Reader as the source of BSON data.
 type Decoder struct {
 	dc bsoncodec.DecodeContext
 	vr bsonrw.ValueReader
+	r  io.Reader
+
+	offset    int64
+	streamErr error
 }
 
 // NewDecoder returns a new decoder that uses the DefaultRegistry to read from vr.
@@ -44,6 +72,22 @@ func NewDecoder(vr bsonrw.ValueReader) (*Decoder, error) {
 	}, nil
 }
 
+// NewStreamDecoder returns a new decoder that uses the DefaultRegistry to read a stream of
+// concatenated BSON documents from r. Each call to Decode reads exactly one document from r, so
+// only the document being decoded is held in memory. Decode returns io.EOF once r has been fully
+// consumed, and io.ErrUnexpectedEOF if r ends partway through a document. Bytes that do not form a
+// valid document, such as garbage after the last document, cause Decode to return a *StreamError.
+func NewStreamDecoder(r io.Reader) (*Decoder, error) {
+	if r == nil {
+		return nil, errors.New("cannot create a new Decoder with a nil io.Reader")
+	}
+
+	return &Decoder{
+		dc: bsoncodec.DecodeContext{Registry: DefaultRegistry},
+		r:  r,
+	}, nil
+}
+
 // NewDecoderWithContext returns a new decoder that uses DecodeContext dc to read from vr.
 func NewDecoderWithContext(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader) (*Decoder, error) {
 	if dc.Registry == nil {
@@ -65,6 +109,14 @@ func NewDecoderWithContext(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader) (*
 // The documentation for Unmarshal contains details about of BSON into a Go
 // value.
 func (d *Decoder) Decode(val interface{}) error {
+	if d.r != nil {
+		doc, err := d.readDocument()
+		if err != nil {
+			return err
+		}
+		d.vr = bsonrw.NewBSONDocumentReader(doc)
+	}
+
 	if unmarshaler, ok := val.(Unmarshaler); ok {
 		// TODO(skriptble): Reuse a []byte here and use the AppendDocumentBytes method.
 		buf, err := bsonrw.Copier{}.CopyDocumentToBytes(d.vr)
@@ -90,6 +142,9 @@ func (d *Decoder) Decode(val interface{}) error {
 // the original construction but using vr for reading.
 func (d *Decoder) Reset(vr bsonrw.ValueReader) error {
 	d.vr = vr
+	d.r = nil
+	d.offset = 0
+	d.streamErr = nil
 	return nil
 }
 
@@ -104,3 +159,41 @@ func (d *Decoder) SetContext(dc bsoncodec.DecodeContext) error {
 	d.dc = dc
 	return nil
 }
+
+// readDocument reads the next document from the stream, checking that it is well formed before it
+// is decoded so that corruption is reported with its position in the stream.
+func (d *Decoder) readDocument() (bsoncore.Document, error) {
+	if d.streamErr != nil {
+		return nil, d.streamErr
+	}
+
+	var lengthBytes [4]byte
+	if _, err := io.ReadFull(d.r, lengthBytes[:]); err != nil {
+		return nil, err
+	}
+
+	length, _, _ := bsoncore.ReadLength(lengthBytes[:])
+	if length < 5 || length > maxStreamDocumentSize {
+		return nil, d.invalid(fmt.Errorf("document length %d is out of range", length))
+	}
+
+	doc := make(bsoncore.Document, length)
+	copy(doc, lengthBytes[:])
+	if _, err := io.ReadFull(d.r, doc[4:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, d.invalid(err)
+	}
+
+	d.offset += int64(length)
+	return doc, nil
+}
+
+func (d *Decoder) invalid(err error) error {
+	d.streamErr = &StreamError{Offset: d.offset, Err: err}
+	return d.streamErr
+}
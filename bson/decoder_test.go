@@ -9,8 +9,10 @@ package bson
 import (
 	"bytes"
 	"errors"
+	"io"
 	"reflect"
 	"testing"
+	"testing/iotest"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
@@ -263,3 +265,110 @@ func (tu *testUnmarshaler) UnmarshalBSON(d []byte) error {
 	tu.data = d
 	return tu.err
 }
+
+func TestStreamDecoder(t *testing.T) {
+	docs := [][]byte{
+		bsoncore.BuildDocument(nil, bsoncore.AppendStringElement(nil, "foo", "bar")),
+		bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "n", 42)),
+		bsoncore.BuildDocument(nil, nil),
+	}
+	stream := bytes.Join(docs, nil)
+
+	t.Run("nil reader", func(t *testing.T) {
+		_, err := NewStreamDecoder(nil)
+		if err == nil {
+			t.Errorf("Expected an error but got nil")
+		}
+	})
+	t.Run("documents spanning reads", func(t *testing.T) {
+		dec, err := NewStreamDecoder(iotest.OneByteReader(bytes.NewReader(stream)))
+		noerr(t, err)
+
+		for i, want := range docs {
+			var got Raw
+			noerr(t, dec.Decode(&got))
+			if !bytes.Equal(got, want) {
+				t.Errorf("Document %d does not match. got %v; want %v", i, got, want)
+			}
+		}
+		var extra D
+		if err := dec.Decode(&extra); err != io.EOF {
+			t.Errorf("Expected io.EOF at the end of the stream but got %v", err)
+		}
+	})
+	t.Run("decodes into types", func(t *testing.T) {
+		dec, err := NewStreamDecoder(bytes.NewReader(stream))
+		noerr(t, err)
+
+		var first struct{ Foo string }
+		noerr(t, dec.Decode(&first))
+		if first.Foo != "bar" {
+			t.Errorf("Results do not match. got %q; want %q", first.Foo, "bar")
+		}
+		var second M
+		noerr(t, dec.Decode(&second))
+		if second["n"] != int32(42) {
+			t.Errorf("Results do not match. got %v; want %v", second["n"], int32(42))
+		}
+	})
+	t.Run("truncated document", func(t *testing.T) {
+		dec, err := NewStreamDecoder(bytes.NewReader(stream[:len(docs[0])+3]))
+		noerr(t, err)
+
+		var got Raw
+		noerr(t, dec.Decode(&got))
+		if err := dec.Decode(&got); err != io.ErrUnexpectedEOF {
+			t.Errorf("Expected io.ErrUnexpectedEOF but got %v", err)
+		}
+	})
+	t.Run("truncated length", func(t *testing.T) {
+		dec, err := NewStreamDecoder(bytes.NewReader(stream[:len(docs[0])+2]))
+		noerr(t, err)
+
+		var got Raw
+		noerr(t, dec.Decode(&got))
+		if err := dec.Decode(&got); err != io.ErrUnexpectedEOF {
+			t.Errorf("Expected io.ErrUnexpectedEOF but got %v", err)
+		}
+	})
+	t.Run("trailing garbage", func(t *testing.T) {
+		garbage := []struct {
+			name  string
+			bytes []byte
+		}{
+			{"length too small", []byte{1, 0, 0, 0, 0}},
+			{"length too large", []byte{0xFF, 0xFF, 0xFF, 0x7F}},
+			{"missing null byte", []byte{6, 0, 0, 0, 0, 1}},
+			{"invalid element", []byte{8, 0, 0, 0, 0x20, 'a', 0, 0}},
+		}
+		for _, tc := range garbage {
+			t.Run(tc.name, func(t *testing.T) {
+				dec, err := NewStreamDecoder(bytes.NewReader(append(docs[0][:len(docs[0]):len(docs[0])], tc.bytes...)))
+				noerr(t, err)
+
+				var got Raw
+				noerr(t, dec.Decode(&got))
+				err = dec.Decode(&got)
+				serr, ok := err.(*StreamError)
+				if !ok {
+					t.Fatalf("Expected a *StreamError but got %T: %v", err, err)
+				}
+				if serr.Offset != int64(len(docs[0])) {
+					t.Errorf("Offsets do not match. got %d; want %d", serr.Offset, len(docs[0]))
+				}
+				if err := dec.Decode(&got); err != serr {
+					t.Errorf("Expected the same error from later calls but got %v", err)
+				}
+			})
+		}
+	})
+	t.Run("read error", func(t *testing.T) {
+		dec, err := NewStreamDecoder(iotest.TimeoutReader(iotest.HalfReader(bytes.NewReader(stream))))
+		noerr(t, err)
+
+		var got Raw
+		if err := dec.Decode(&got); err != iotest.ErrTimeout {
+			t.Errorf("Expected %v but got %v", iotest.ErrTimeout, err)
+		}
+	})
+}
@@ -8,6 +8,7 @@ package bson
 
 import (
 	"errors"
+	"io"
 	"reflect"
 	"sync"
 
@@ -25,10 +26,12 @@ var encPool = sync.Pool{
 }
 
 // An Encoder writes a serialization format to an output stream. It writes to a bsonrw.ValueWriter
-// as the destination of BSON data.
+// or, if created with NewStreamEncoder, an io.Writer as the destination of BSON data.
 type Encoder struct {
-	ec bsoncodec.EncodeContext
-	vw bsonrw.ValueWriter
+	ec  bsoncodec.EncodeContext
+	vw  bsonrw.ValueWriter
+	w   io.Writer
+	buf []byte
 }
 
 // NewEncoder returns a new encoder that uses the DefaultRegistry to write to vw.
@@ -43,6 +46,21 @@ func NewEncoder(vw bsonrw.ValueWriter) (*Encoder, error) {
 	}, nil
 }
 
+// NewStreamEncoder returns a new encoder that uses the DefaultRegistry to write documents to w one
+// after another. Each BSON document begins with its length, so the stream needs no other framing
+// and can be read back with NewStreamDecoder. Each call to Encode makes a single Write call with
+// the complete document, and nothing is written if val cannot be encoded.
+func NewStreamEncoder(w io.Writer) (*Encoder, error) {
+	if w == nil {
+		return nil, errors.New("cannot create a new Encoder with a nil io.Writer")
+	}
+
+	return &Encoder{
+		ec: bsoncodec.EncodeContext{Registry: DefaultRegistry},
+		w:  w,
+	}, nil
+}
+
 // NewEncoderWithContext returns a new encoder that uses EncodeContext ec to write to vw.
 func NewEncoderWithContext(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter) (*Encoder, error) {
 	if ec.Registry == nil {
@@ -63,6 +81,20 @@ func NewEncoderWithContext(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter) (*
 // The documentation for Marshal contains details about the conversion of Go
 // values to BSON.
 func (e *Encoder) Encode(val interface{}) error {
+	if e.w != nil {
+		buf, err := MarshalAppendWithContext(e.ec, e.buf[:0], val)
+		if err != nil {
+			return err
+		}
+		e.buf = buf
+
+		n, err := e.w.Write(buf)
+		if err == nil && n < len(buf) {
+			err = io.ErrShortWrite
+		}
+		return err
+	}
+
 	if marshaler, ok := val.(Marshaler); ok {
 		// TODO(skriptble): Should we have a MarshalAppender interface so that we can have []byte reuse?
 		buf, err := marshaler.MarshalBSON()
@@ -83,6 +115,7 @@ func (e *Encoder) Encode(val interface{}) error {
 // the original construction but using vw.
 func (e *Encoder) Reset(vw bsonrw.ValueWriter) error {
 	e.vw = vw
+	e.w = nil
 	return nil
 }
 
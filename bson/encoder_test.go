@@ -9,6 +9,7 @@ package bson
 import (
 	"bytes"
 	"errors"
+	"io"
 	"reflect"
 	"testing"
 
@@ -124,6 +125,63 @@ type testMarshaler struct {
 	err error
 }
 
+func TestStreamEncoder(t *testing.T) {
+	t.Run("nil writer", func(t *testing.T) {
+		_, err := NewStreamEncoder(nil)
+		if err == nil {
+			t.Errorf("Expected an error but got nil")
+		}
+	})
+	t.Run("round trip", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc, err := NewStreamEncoder(&buf)
+		noerr(t, err)
+		noerr(t, enc.Encode(D{{"foo", "bar"}}))
+		noerr(t, enc.Encode(struct{ N int32 }{42}))
+		noerr(t, enc.Encode(D{}))
+
+		dec, err := NewStreamDecoder(&buf)
+		noerr(t, err)
+		var first D
+		noerr(t, dec.Decode(&first))
+		if !reflect.DeepEqual(first, D{{"foo", "bar"}}) {
+			t.Errorf("Results do not match. got %v; want %v", first, D{{"foo", "bar"}})
+		}
+		var second struct{ N int32 }
+		noerr(t, dec.Decode(&second))
+		if second.N != 42 {
+			t.Errorf("Results do not match. got %d; want %d", second.N, 42)
+		}
+		var third D
+		noerr(t, dec.Decode(&third))
+		if err := dec.Decode(&third); err != io.EOF {
+			t.Errorf("Expected io.EOF at the end of the stream but got %v", err)
+		}
+	})
+	t.Run("encode error writes nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc, err := NewStreamEncoder(&buf)
+		noerr(t, err)
+		if err := enc.Encode(int32(1)); err == nil {
+			t.Errorf("Expected an error encoding a non-document but got nil")
+		}
+		if buf.Len() != 0 {
+			t.Errorf("Expected nothing to be written but got %v", buf.Bytes())
+		}
+	})
+	t.Run("short write", func(t *testing.T) {
+		enc, err := NewStreamEncoder(shortWriter{})
+		noerr(t, err)
+		if err := enc.Encode(D{{"foo", "bar"}}); err != io.ErrShortWrite {
+			t.Errorf("Expected io.ErrShortWrite but got %v", err)
+		}
+	})
+}
+
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) { return len(p) / 2, nil }
+
 func (tm testMarshaler) MarshalBSON() ([]byte, error) { return tm.buf, tm.err }
 
 func docToBytes(d interface{}) []byte {
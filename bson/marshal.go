@@ -32,6 +32,14 @@ type ValueMarshaler interface {
 	MarshalBSONValue() (bsontype.Type, []byte, error)
 }
 
+// ValueMarshalerWithContext is an interface implemented by types that write
+// their own BSON value using the encoding context, which gives them access to
+// the registry for encoding their contents. It takes precedence over
+// ValueMarshaler and Marshaler. See bsoncodec.ValueMarshalerWithContext.
+type ValueMarshalerWithContext interface {
+	MarshalBSONValueWithContext(bsoncodec.EncodeContext, bsonrw.ValueWriter) error
+}
+
 // Marshal returns the BSON encoding of val.
 //
 // Marshal will use the default registry created by NewRegistry to recursively
@@ -41,6 +49,13 @@ func Marshal(val interface{}) ([]byte, error) {
 	return MarshalWithRegistry(DefaultRegistry, val)
 }
 
+// MarshalSorted returns the BSON encoding of val using the default registry, with the keys of every map in val
+// encoded in lexicographic order. Unlike Marshal, the result is the same each time val is marshaled, which makes it
+// suitable for hashing or for writing reproducible fixtures. Struct fields are always encoded in declaration order.
+func MarshalSorted(val interface{}) ([]byte, error) {
+	return MarshalWithContext(bsoncodec.EncodeContext{Registry: DefaultRegistry, MarshalSortedKeys: true}, val)
+}
+
 // MarshalAppend will append the BSON encoding of val to dst. If dst is not
 // large enough to hold the BSON encoding of val, dst will be grown.
 func MarshalAppend(dst []byte, val interface{}) ([]byte, error) {
@@ -9,6 +9,7 @@ package bson
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
@@ -207,3 +208,69 @@ func TestMarshal_roundtripFromDoc(t *testing.T) {
 		t.Errorf("Documents to not match. got %v; want %v", after, before)
 	}
 }
+
+func TestMarshal_omitEmptyTimeAndObjectID(t *testing.T) {
+	type withZeroers struct {
+		Time time.Time          `bson:"time,omitempty"`
+		ID   primitive.ObjectID `bson:"id,omitempty"`
+		X    int32              `bson:"x"`
+	}
+
+	t.Run("zero values are omitted", func(t *testing.T) {
+		b, err := Marshal(withZeroers{X: 1})
+		require.NoError(t, err)
+
+		var doc D
+		require.NoError(t, Unmarshal(b, &doc))
+		require.Equal(t, D{{"x", int32(1)}}, doc)
+
+		var after withZeroers
+		require.NoError(t, Unmarshal(b, &after))
+		require.Equal(t, withZeroers{X: 1}, after)
+	})
+
+	t.Run("non-zero values round trip", func(t *testing.T) {
+		before := withZeroers{
+			Time: time.Unix(1546300800, int64(250*time.Millisecond)).UTC(),
+			ID:   primitive.NewObjectID(),
+			X:    1,
+		}
+		b, err := Marshal(before)
+		require.NoError(t, err)
+
+		var after withZeroers
+		require.NoError(t, Unmarshal(b, &after))
+		require.True(t, before.Time.Equal(after.Time), "expected %v, got %v", before.Time, after.Time)
+		require.Equal(t, before.ID, after.ID)
+		require.Equal(t, before.X, after.X)
+	})
+}
+
+func TestMarshalSorted(t *testing.T) {
+	type withMap struct {
+		Name   string         `bson:"name"`
+		Attrs  map[string]int `bson:"attrs"`
+		Inline map[string]int `bson:",inline"`
+	}
+	val := M{
+		"c": 1,
+		"a": D{{"z", 1}, {"y", 2}},
+		"b": []interface{}{M{"y": 1, "x": 2, "w": 3}},
+		"d": withMap{Name: "n", Attrs: map[string]int{"q": 1, "p": 2, "o": 3}, Inline: map[string]int{"k": 1, "j": 2}},
+	}
+
+	want, err := Marshal(D{
+		{"a", D{{"z", 1}, {"y", 2}}},
+		{"b", A{D{{"w", 3}, {"x", 2}, {"y", 1}}}},
+		{"c", 1},
+		{"d", D{{"name", "n"}, {"attrs", D{{"o", 3}, {"p", 2}, {"q", 1}}}, {"j", 2}, {"k", 1}}},
+	})
+	require.NoError(t, err)
+
+	// Map iteration order is random, so a single matching run would not show that the keys were sorted.
+	for i := 0; i < 20; i++ {
+		got, err := MarshalSorted(val)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+}
@@ -10,7 +10,9 @@
 package primitive
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 )
@@ -114,6 +116,282 @@ Loop:
 	return string(repr[last+pos:])
 }
 
+// These errors are returned when a Decimal128 that is not a finite number is converted to one.
+var (
+	ErrParseNaN    = errors.New("cannot parse NaN as a *big.Int")
+	ErrParseInf    = errors.New("cannot parse Infinity as a *big.Int")
+	ErrParseNegInf = errors.New("cannot parse -Infinity as a *big.Int")
+)
+
+// ErrCompareNaN is returned from an attempt to compare a NaN Decimal128, which is unordered.
+var ErrCompareNaN = errors.New("cannot compare NaN decimal128 values")
+
+const (
+	minDecimal128Exp    = -6176
+	maxDecimal128Exp    = 6111
+	maxDecimal128Digits = 34
+)
+
+// maxDecimal128Significand is the largest significand of a canonical Decimal128, 10^34 - 1.
+var maxDecimal128Significand = new(big.Int).Sub(new(big.Int).Exp(big.NewInt(10), big.NewInt(maxDecimal128Digits), nil), big.NewInt(1))
+
+// IsNaN returns true if d is NaN.
+func (d Decimal128) IsNaN() bool {
+	return d.h>>58&(1<<5-1) == 0x1F
+}
+
+// IsInf returns +1 if d is positive infinity, -1 if d is negative infinity, and 0 otherwise.
+func (d Decimal128) IsInf() int {
+	if d.h>>58&(1<<5-1) != 0x1E {
+		return 0
+	}
+	if d.h>>63&1 == 1 {
+		return -1
+	}
+	return 1
+}
+
+// BigInt returns the significand and exponent of d, so that its value is significand * 10^exp.
+// The significand is negative for negative values. The sign of a negative zero is lost, and
+// non-canonical significands are returned as zero, as the BSON specification requires. An error
+// is returned if d is NaN or infinite.
+func (d Decimal128) BigInt() (*big.Int, int, error) {
+	switch {
+	case d.IsNaN():
+		return nil, 0, ErrParseNaN
+	case d.IsInf() > 0:
+		return nil, 0, ErrParseInf
+	case d.IsInf() < 0:
+		return nil, 0, ErrParseNegInf
+	}
+
+	var h, l uint64
+	var exp int
+	if d.h>>61&3 == 3 {
+		// This form is only used for significands larger than the maximum, so the value is zero.
+		exp = int(d.h>>47&(1<<14-1)) + minDecimal128Exp
+	} else {
+		exp = int(d.h>>49&(1<<14-1)) + minDecimal128Exp
+		h, l = d.h&(1<<49-1), d.l
+	}
+
+	bi := new(big.Int).SetUint64(h)
+	bi.Lsh(bi, 64)
+	bi.Or(bi, new(big.Int).SetUint64(l))
+	if bi.Cmp(maxDecimal128Significand) > 0 {
+		bi.SetInt64(0)
+	}
+	if d.h>>63&1 == 1 {
+		bi.Neg(bi)
+	}
+	return bi, exp, nil
+}
+
+// BigFloat returns the value of d as a *big.Float with the given precision in bits, rounded to
+// nearest even if it cannot be represented exactly. Infinities and negative zero are preserved. An
+// error is returned if d is NaN.
+func (d Decimal128) BigFloat(prec uint) (*big.Float, error) {
+	switch {
+	case d.IsNaN():
+		return nil, ErrParseNaN
+	case d.IsInf() != 0:
+		return new(big.Float).SetPrec(prec).SetInf(d.IsInf() < 0), nil
+	}
+
+	bi, exp, _ := d.BigInt()
+	f := new(big.Float).SetPrec(prec)
+	if exp >= 0 {
+		f.SetInt(bi.Mul(bi, pow10(exp)))
+	} else {
+		f.Quo(new(big.Float).SetInt(bi), new(big.Float).SetInt(pow10(-exp)))
+	}
+	if bi.Sign() == 0 && d.h>>63&1 == 1 {
+		f.Neg(f)
+	}
+	return f, nil
+}
+
+// Cmp compares d and d2 numerically and returns -1 if d < d2, 0 if d == d2, and +1 if d > d2,
+// following IEEE 754-2008. Values with different exponents that represent the same number, such
+// as 1.0 and 1.00, are equal, as are positive and negative zero. Since NaN is unordered,
+// ErrCompareNaN is returned if either value is NaN.
+func (d Decimal128) Cmp(d2 Decimal128) (int, error) {
+	if d.IsNaN() || d2.IsNaN() {
+		return 0, ErrCompareNaN
+	}
+	if d.IsInf() != 0 || d2.IsInf() != 0 {
+		switch a, b := d.IsInf(), d2.IsInf(); {
+		case a < b:
+			return -1, nil
+		case a > b:
+			return 1, nil
+		case a != 0:
+			return 0, nil
+		case b > 0:
+			return -1, nil
+		default:
+			return 1, nil
+		}
+	}
+
+	a, aExp, _ := d.BigInt()
+	b, bExp, _ := d2.BigInt()
+	if a.Sign() != b.Sign() || a.Sign() == 0 {
+		return compareSigns(a.Sign(), b.Sign()), nil
+	}
+	// Scale the value with the larger exponent so that both significands share an exponent.
+	if aExp > bExp {
+		a.Mul(a, pow10(aExp-bExp))
+	} else if bExp > aExp {
+		b.Mul(b, pow10(bExp-aExp))
+	}
+	return a.Cmp(b), nil
+}
+
+func compareSigns(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ParseDecimal128FromBigInt returns a Decimal128 with the value bi * 10^exp. If bi has more than
+// 34 digits, it is rounded to 34 significant digits using round half to even. The boolean is
+// false if the value is too large to be represented.
+func ParseDecimal128FromBigInt(bi *big.Int, exp int) (Decimal128, bool) {
+	q := new(big.Int).Abs(bi)
+
+	if digits := len(q.String()); digits > maxDecimal128Digits {
+		q = roundHalfEven(q, digits-maxDecimal128Digits)
+		exp += digits - maxDecimal128Digits
+		if q.Cmp(maxDecimal128Significand) > 0 {
+			// Rounding carried into a 35th digit, which is always a zero.
+			q.Quo(q, big.NewInt(10))
+			exp++
+		}
+	}
+
+	if exp < minDecimal128Exp {
+		// Subnormal, so digits must be dropped to fit the exponent.
+		if shift := minDecimal128Exp - exp; shift > maxDecimal128Digits+1 {
+			q.SetInt64(0)
+		} else {
+			q = roundHalfEven(q, shift)
+		}
+		exp = minDecimal128Exp
+	}
+
+	ten := big.NewInt(10)
+	for exp > maxDecimal128Exp {
+		// Clamped, so the exponent can only be reduced by adding trailing zeros to the significand.
+		if q.Sign() == 0 {
+			exp = maxDecimal128Exp
+			break
+		}
+		q.Mul(q, ten)
+		if q.Cmp(maxDecimal128Significand) > 0 {
+			return dNaN, false
+		}
+		exp--
+	}
+
+	mask := new(big.Int).SetUint64(1<<64 - 1)
+	l := new(big.Int).And(q, mask).Uint64()
+	h := new(big.Int).Rsh(q, 64).Uint64()
+	h |= uint64(exp-minDecimal128Exp) & uint64(1<<14-1) << 49
+	if bi.Sign() < 0 {
+		h |= 1 << 63
+	}
+	return Decimal128{h, l}, true
+}
+
+// ParseDecimal128FromBigFloat returns the Decimal128 closest to f that uses as few significant
+// digits as possible. Values which need more than 34 significant digits are rounded to 34 digits
+// using round half to even. Infinities and negative zero are preserved. An error is returned if
+// the value is too large to be represented.
+func ParseDecimal128FromBigFloat(f *big.Float) (Decimal128, error) {
+	if f.IsInf() {
+		if f.Signbit() {
+			return dNegInf, nil
+		}
+		return dPosInf, nil
+	}
+
+	// Integers keep all of their digits. Other values use the shortest representation that rounds
+	// back to f, unless that needs too many digits, in which case the exact value is rounded to the
+	// maximum number of digits.
+	var mant string
+	var exp int
+	if bi, _ := f.Int(nil); f.IsInt() && len(strings.TrimPrefix(bi.String(), "-")) <= maxDecimal128Digits {
+		mant = bi.String()
+	} else {
+		text := f.Text('e', -1)
+		if countDigits(text) > maxDecimal128Digits {
+			text = f.Text('e', maxDecimal128Digits-1)
+		}
+
+		// The text is of the form [-]d[.ddd]e±dd.
+		idx := strings.IndexByte(text, 'e')
+		mant = strings.Replace(text[:idx], ".", "", 1)
+		var err error
+		exp, err = strconv.Atoi(text[idx+1:])
+		if err != nil {
+			return dNaN, fmt.Errorf("cannot parse %q as a decimal128", text)
+		}
+		exp -= len(strings.TrimPrefix(mant, "-")) - 1
+	}
+
+	bi, ok := new(big.Int).SetString(mant, 10)
+	if !ok {
+		return dNaN, fmt.Errorf("cannot parse %s as a decimal128", f.String())
+	}
+	d, ok := ParseDecimal128FromBigInt(bi, exp)
+	if !ok {
+		return dNaN, fmt.Errorf("%s is out of range for a decimal128", f.String())
+	}
+	if f.Signbit() {
+		d.h |= 1 << 63
+	}
+	return d, nil
+}
+
+// countDigits returns the number of digits in the mantissa of a number formatted with the 'e' verb.
+func countDigits(text string) int {
+	n := 0
+	for _, c := range text {
+		if c == 'e' {
+			break
+		}
+		if c >= '0' && c <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+// roundHalfEven divides q by 10^n, rounding to the nearest integer and to even on ties.
+func roundHalfEven(q *big.Int, n int) *big.Int {
+	div := pow10(n)
+	quo, rem := new(big.Int).QuoRem(q, div, new(big.Int))
+	switch rem.Lsh(rem, 1).Cmp(div) {
+	case 1:
+		quo.Add(quo, big.NewInt(1))
+	case 0:
+		if quo.Bit(0) == 1 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return quo
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
 func divmod(h, l uint64, div uint32) (qh, ql uint64, rem uint32) {
 	div64 := uint64(div)
 	a := h >> 32
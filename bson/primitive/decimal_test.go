@@ -0,0 +1,222 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package primitive
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const decimalCorpusDir = "../../data/bson-corpus"
+
+type decimalCorpus struct {
+	Valid []struct {
+		Description   string `json:"description"`
+		CanonicalBson string `json:"canonical_bson"`
+	} `json:"valid"`
+}
+
+func mustParseDecimal128(t *testing.T, s string) Decimal128 {
+	d, err := ParseDecimal128(s)
+	require.NoError(t, err)
+	return d
+}
+
+func TestDecimal128BigIntCorpus(t *testing.T) {
+	files, err := ioutil.ReadDir(decimalCorpusDir)
+	require.NoError(t, err)
+
+	for _, file := range files {
+		if matched, _ := path.Match("decimal128-*.json", file.Name()); !matched {
+			continue
+		}
+
+		content, err := ioutil.ReadFile(path.Join(decimalCorpusDir, file.Name()))
+		require.NoError(t, err)
+		var corpus decimalCorpus
+		require.NoError(t, json.Unmarshal(content, &corpus))
+
+		for _, tc := range corpus.Valid {
+			t.Run(file.Name()+"/"+tc.Description, func(t *testing.T) {
+				b, err := hex.DecodeString(tc.CanonicalBson)
+				require.NoError(t, err)
+				// length (4) + type (1) + "d\x00" (2), followed by the little-endian low and high halves
+				d := NewDecimal128(binary.LittleEndian.Uint64(b[15:23]), binary.LittleEndian.Uint64(b[7:15]))
+
+				bi, exp, err := d.BigInt()
+				switch {
+				case d.IsNaN():
+					require.Equal(t, ErrParseNaN, err)
+					_, err = d.Cmp(d)
+					require.Equal(t, ErrCompareNaN, err)
+					return
+				case d.IsInf() > 0:
+					require.Equal(t, ErrParseInf, err)
+				case d.IsInf() < 0:
+					require.Equal(t, ErrParseNegInf, err)
+				default:
+					require.NoError(t, err)
+					got, ok := ParseDecimal128FromBigInt(bi, exp)
+					require.True(t, ok)
+					if bi.Sign() == 0 {
+						// The sign of zero is not part of the big.Int.
+						require.Equal(t, strings.TrimPrefix(d.String(), "-"), got.String())
+					} else {
+						require.Equal(t, d, got)
+					}
+				}
+
+				cmp, err := d.Cmp(d)
+				require.NoError(t, err)
+				require.Equal(t, 0, cmp)
+
+				if d.IsInf() == 0 {
+					f, err := d.BigFloat(256)
+					require.NoError(t, err)
+					got, err := ParseDecimal128FromBigFloat(f)
+					require.NoError(t, err)
+					cmp, err = d.Cmp(got)
+					require.NoError(t, err)
+					require.Equal(t, 0, cmp, "%s round tripped as %s", d, got)
+				}
+			})
+		}
+	}
+}
+
+func TestDecimal128Cmp(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		want int
+	}{
+		{"1", "1", 0},
+		{"1.0", "1.00", 0},
+		{"1E+2", "100", 0},
+		{"0", "-0", 0},
+		{"0E+10", "0E-10", 0},
+		{"1", "2", -1},
+		{"-1", "1", -1},
+		{"-2", "-1", -1},
+		{"0.1", "0.09999999999999999999999999999999999", 1},
+		{"1E+6111", "9.999999999999999999999999999999999E+6144", -1},
+		{"1E-6176", "0", 1},
+		{"-Infinity", "-9.999999999999999999999999999999999E+6144", -1},
+		{"Infinity", "9.999999999999999999999999999999999E+6144", 1},
+		{"Infinity", "Infinity", 0},
+		{"-Infinity", "Infinity", -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			a, b := mustParseDecimal128(t, tc.a), mustParseDecimal128(t, tc.b)
+			got, err := a.Cmp(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+			got, err = b.Cmp(a)
+			require.NoError(t, err)
+			require.Equal(t, -tc.want, got)
+		})
+	}
+
+	t.Run("NaN", func(t *testing.T) {
+		_, err := mustParseDecimal128(t, "NaN").Cmp(mustParseDecimal128(t, "1"))
+		require.Equal(t, ErrCompareNaN, err)
+		_, err = mustParseDecimal128(t, "1").Cmp(mustParseDecimal128(t, "NaN"))
+		require.Equal(t, ErrCompareNaN, err)
+	})
+}
+
+func TestParseDecimal128FromBigInt(t *testing.T) {
+	bigInt := func(s string) *big.Int {
+		bi, ok := new(big.Int).SetString(s, 10)
+		require.True(t, ok)
+		return bi
+	}
+
+	testCases := []struct {
+		name string
+		bi   *big.Int
+		exp  int
+		want string
+		ok   bool
+	}{
+		{"simple", big.NewInt(12345), -2, "123.45", true},
+		{"negative", big.NewInt(-5), 3, "-5E+3", true},
+		{"max digits", bigInt("1234567890123456789012345678901234"), 0, "1234567890123456789012345678901234", true},
+		{"rounds down", bigInt("12345678901234567890123456789012341"), 0, "1.234567890123456789012345678901234E+34", true},
+		{"rounds up", bigInt("12345678901234567890123456789012346"), 0, "1.234567890123456789012345678901235E+34", true},
+		{"ties to even", bigInt("12345678901234567890123456789012345"), 0, "1.234567890123456789012345678901234E+34", true},
+		{"ties to even up", bigInt("12345678901234567890123456789012335"), 0, "1.234567890123456789012345678901234E+34", true},
+		{"rounding carries", bigInt("99999999999999999999999999999999995"), 0, "1.000000000000000000000000000000000E+35", true},
+		{"clamped", big.NewInt(1), 6112, "1.0E+6112", true},
+		{"overflow", bigInt("1234567890123456789012345678901234"), 6112, "", false},
+		{"subnormal", big.NewInt(15), -6177, "2E-6176", true},
+		{"underflow", big.NewInt(15), -7000, "0E-6176", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, ok := ParseDecimal128FromBigInt(tc.bi, tc.exp)
+			require.Equal(t, tc.ok, ok)
+			if ok {
+				require.Equal(t, tc.want, d.String())
+			}
+		})
+	}
+}
+
+func TestDecimal128BigFloat(t *testing.T) {
+	testCases := []struct {
+		name string
+		f    *big.Float
+		want string
+	}{
+		{"integer", big.NewFloat(100), "100"},
+		{"shortest", big.NewFloat(0.1), "0.1"},
+		{"negative", big.NewFloat(-2.5e-10), "-2.5E-10"},
+		{"negative zero", big.NewFloat(math.Copysign(0, -1)), "-0"},
+		{"infinity", big.NewFloat(math.Inf(1)), "Infinity"},
+		{"negative infinity", big.NewFloat(math.Inf(-1)), "-Infinity"},
+		{"rounded to 34 digits", new(big.Float).SetPrec(200).Quo(big.NewFloat(1), big.NewFloat(3)), "0.3333333333333333333333333333333333"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := ParseDecimal128FromBigFloat(tc.f)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, d.String())
+		})
+	}
+
+	t.Run("out of range", func(t *testing.T) {
+		f, _, err := big.ParseFloat("1e7000", 10, 64, big.ToNearestEven)
+		require.NoError(t, err)
+		_, err = ParseDecimal128FromBigFloat(f)
+		require.Error(t, err)
+	})
+	t.Run("to big.Float", func(t *testing.T) {
+		f, err := mustParseDecimal128(t, "-1.5E+3").BigFloat(64)
+		require.NoError(t, err)
+		require.Equal(t, "-1500", f.Text('f', -1))
+
+		f, err = mustParseDecimal128(t, "-0").BigFloat(64)
+		require.NoError(t, err)
+		require.True(t, f.Signbit())
+
+		_, err = mustParseDecimal128(t, "NaN").BigFloat(64)
+		require.Equal(t, ErrParseNaN, err)
+	})
+}
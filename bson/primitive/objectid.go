@@ -12,6 +12,7 @@ package primitive
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
@@ -32,15 +33,55 @@ type ObjectID [12]byte
 var NilObjectID ObjectID
 
 var objectIDCounter = readRandomUint32()
-var processUnique = processUniqueBytes()
 
-// NewObjectID generates a new ObjectID.
+// processUnique holds the [5]byte machine and process identifier used by NewObjectID.
+var processUnique atomic.Value
+
+func init() {
+	processUnique.Store(processUniqueBytes())
+}
+
+// NewObjectID generates a new ObjectID using the default generator. Its machine identifier is random unless it
+// has been replaced with SetObjectIDGeneratorMachineID.
 func NewObjectID() ObjectID {
+	return newObjectID(processUnique.Load().([5]byte), &objectIDCounter)
+}
+
+// SetObjectIDGeneratorMachineID replaces the machine and process identifier of the default generator used by
+// NewObjectID. ObjectIDs generated afterward embed a 5 byte value derived from id, so processes that must not
+// generate colliding ObjectIDs need distinct ids, such as a container name combined with a process id. An empty
+// id restores a random identifier. It is safe to call concurrently with NewObjectID.
+func SetObjectIDGeneratorMachineID(id []byte) {
+	processUnique.Store(machineIDBytes(id))
+}
+
+// ObjectIDGenerator generates ObjectIDs with its own machine identifier and counter. It is safe for concurrent
+// use.
+type ObjectIDGenerator struct {
+	counter uint32
+	unique  [5]byte
+}
+
+// NewObjectIDGenerator returns a generator whose machine and process identifier is derived from seed, as with
+// SetObjectIDGeneratorMachineID. An empty seed uses a random identifier. The counter starts at a random value.
+func NewObjectIDGenerator(seed []byte) *ObjectIDGenerator {
+	return &ObjectIDGenerator{
+		counter: readRandomUint32(),
+		unique:  machineIDBytes(seed),
+	}
+}
+
+// Next generates a new ObjectID.
+func (g *ObjectIDGenerator) Next() ObjectID {
+	return newObjectID(g.unique, &g.counter)
+}
+
+func newObjectID(unique [5]byte, counter *uint32) ObjectID {
 	var b [12]byte
 
 	binary.BigEndian.PutUint32(b[0:4], uint32(time.Now().Unix()))
-	copy(b[4:9], processUnique[:])
-	putUint24(b[9:12], atomic.AddUint32(&objectIDCounter, 1))
+	copy(b[4:9], unique[:])
+	putUint24(b[9:12], atomic.AddUint32(counter, 1))
 
 	return b
 }
@@ -137,6 +178,18 @@ func processUniqueBytes() [5]byte {
 	return b
 }
 
+// machineIDBytes hashes id down to the 5 bytes an ObjectID has room for, or returns random bytes if id is empty.
+func machineIDBytes(id []byte) [5]byte {
+	if len(id) == 0 {
+		return processUniqueBytes()
+	}
+
+	var b [5]byte
+	sum := sha256.Sum256(id)
+	copy(b[:], sum[:])
+	return b
+}
+
 func readRandomUint32() uint32 {
 	var b [4]byte
 	_, err := io.ReadFull(rand.Reader, b[:])
@@ -81,3 +81,28 @@ func TestCounterOverflow(t *testing.T) {
 	NewObjectID()
 	require.Equal(t, uint32(0), objectIDCounter)
 }
+
+func TestObjectIDGenerator(t *testing.T) {
+	a := NewObjectIDGenerator([]byte("host-a/1"))
+	b := NewObjectIDGenerator([]byte("host-b/1"))
+
+	first, second := a.Next(), a.Next()
+	require.Equal(t, first[4:9], second[4:9])
+	require.NotEqual(t, first, second)
+
+	same, other := NewObjectIDGenerator([]byte("host-a/1")).Next(), b.Next()
+	require.Equal(t, first[4:9], same[4:9])
+	require.NotEqual(t, first[4:9], other[4:9])
+
+	t.Run("overrides the default generator", func(t *testing.T) {
+		defer SetObjectIDGeneratorMachineID(nil)
+
+		SetObjectIDGeneratorMachineID([]byte("host-a/1"))
+		id := NewObjectID()
+		require.Equal(t, first[4:9], id[4:9])
+
+		SetObjectIDGeneratorMachineID(nil)
+		id = NewObjectID()
+		require.NotEqual(t, first[4:9], id[4:9])
+	})
+}
@@ -82,6 +82,21 @@ func (r Raw) IndexErr(index uint) (RawElement, error) {
 	return RawElement(elem), err
 }
 
+// SetValue returns a copy of the document with the value of the top-level key replaced by v, or with a new element
+// for key appended if the document does not contain it. The other elements are copied without being decoded and
+// keep their order, so this is much cheaper than unmarshaling, modifying, and marshaling the document.
+func (r Raw) SetValue(key string, v RawValue) (Raw, error) {
+	doc, err := bsoncore.Document(r).SetValue(key, convertToCoreValue(v))
+	return Raw(doc), err
+}
+
+// DeleteKey returns a copy of the document without the top-level key. Like SetValue, it does not decode the other
+// elements. If the document does not contain key, bsoncore.ErrElementNotFound is returned.
+func (r Raw) DeleteKey(key string) (Raw, error) {
+	doc, err := bsoncore.Document(r).DeleteKey(key)
+	return Raw(doc), err
+}
+
 // String implements the fmt.Stringer interface.
 func (r Raw) String() string { return bsoncore.Document(r).String() }
 
@@ -344,4 +344,23 @@ func TestRaw(t *testing.T) {
 			})
 		}
 	})
+	t.Run("SetValue and DeleteKey", func(t *testing.T) {
+		doc, err := Marshal(D{{"a", int32(1)}, {"b", "two"}, {"c", true}})
+		require.NoError(t, err)
+
+		patched, err := Raw(doc).SetValue("b", RawValue{Type: bsontype.String, Value: bsoncore.AppendString(nil, "deux")})
+		require.NoError(t, err)
+		patched, err = patched.SetValue("d", Raw(doc).Lookup("a"))
+		require.NoError(t, err)
+		patched, err = patched.DeleteKey("a")
+		require.NoError(t, err)
+		require.NoError(t, patched.Validate())
+
+		var got D
+		require.NoError(t, Unmarshal(patched, &got))
+		require.Equal(t, D{{"b", "deux"}, {"c", true}, {"d", int32(1)}}, got)
+
+		_, err = patched.DeleteKey("a")
+		require.Equal(t, bsoncore.ErrElementNotFound, err)
+	})
 }
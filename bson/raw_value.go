@@ -50,7 +50,9 @@ func (rv RawValue) Unmarshal(val interface{}) error {
 	return rv.UnmarshalWithRegistry(reg, val)
 }
 
-// Equal compares rv and rv2 and returns true if they are equal.
+// Equal compares rv and rv2 and returns true if they are equal. Values are equal only if they
+// have the same type and the same bytes; use CompareRawValues to compare values the way MongoDB
+// does, e.g. to treat int32(1) and 1.0 as equivalent.
 func (rv RawValue) Equal(rv2 RawValue) bool {
 	if rv.Type != rv2.Type {
 		return false
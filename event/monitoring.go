@@ -8,6 +8,7 @@ package event
 
 import (
 	"context"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 )
@@ -41,9 +42,46 @@ type CommandFailedEvent struct {
 	Failure string
 }
 
+// CommandRetryEvent represents an event generated when a command that failed with a retryable error is about
+// to be sent again.
+type CommandRetryEvent struct {
+	CommandName string
+	Attempt     int    // The attempt about to be made, starting at 2 for the first retry.
+	Failure     string // The error that caused the command to be retried.
+	Write       bool   // Whether the command is retried as a retryable write. Reads are not currently retried.
+}
+
 // CommandMonitor represents a monitor that is triggered for different events.
+//
+// Retried is called when a command is retried, before the CommandStartedEvent of the retried attempt.
+//
+// The command and reply documents of security-sensitive commands, such as authentication and user
+// management commands, are replaced with empty documents in the events unless DisableRedaction is
+// true. DisableRedaction should only be used for debugging, since the events will contain
+// credentials.
 type CommandMonitor struct {
-	Started   func(context.Context, *CommandStartedEvent)
-	Succeeded func(context.Context, *CommandSucceededEvent)
-	Failed    func(context.Context, *CommandFailedEvent)
+	Started          func(context.Context, *CommandStartedEvent)
+	Succeeded        func(context.Context, *CommandSucceededEvent)
+	Failed           func(context.Context, *CommandFailedEvent)
+	Retried          func(context.Context, *CommandRetryEvent)
+	DisableRedaction bool
+}
+
+// TopologyAvailabilityChangedEvent is published when a client's topology gains its first data-bearing server
+// after having none, or loses its last one. Available reports the new state and Servers holds the addresses of
+// the data-bearing servers when the change was published.
+type TopologyAvailabilityChangedEvent struct {
+	Available bool
+	Servers   []string
+}
+
+// TopologyMonitor represents a monitor that is triggered when the availability of a topology changes.
+//
+// AvailabilityChanged is called once the topology has stayed in its new state for Debounce, so a server that
+// flaps between reachable and unreachable does not produce a storm of events. If Debounce is zero, a default of
+// 500 milliseconds is used. The first event reports the topology becoming available once its servers are
+// discovered. Events are delivered in order from a single goroutine.
+type TopologyMonitor struct {
+	AvailabilityChanged func(*TopologyAvailabilityChangedEvent)
+	Debounce            time.Duration
 }
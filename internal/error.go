@@ -117,3 +117,8 @@ func (e *wrappedError) Error() string {
 func (e *wrappedError) Inner() error {
 	return e.inner
 }
+
+// Unwrap returns the inner error so wrapped errors work with errors.Is and errors.As.
+func (e *wrappedError) Unwrap() error {
+	return e.inner
+}
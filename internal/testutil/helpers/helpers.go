@@ -106,6 +106,8 @@ func VerifyConnStringOptions(t *testing.T, cs connstring.ConnString, options map
 		case "journal":
 			require.True(t, cs.JSet)
 			require.Equal(t, value, cs.J)
+		case "loadbalanced":
+			require.Equal(t, value, cs.LoadBalanced)
 		case "maxidletimems":
 			require.Equal(t, value, cs.MaxConnIdleTime)
 		case "maxpoolsize":
@@ -123,6 +125,12 @@ func VerifyConnStringOptions(t *testing.T, cs connstring.ConnString, options map
 			require.Equal(t, value, cs.SSL)
 		case "sockettimeoutms":
 			require.Equal(t, value, float64(cs.SocketTimeout/time.Millisecond))
+		case "timeoutms":
+			require.Equal(t, value, float64(cs.Timeout/time.Millisecond))
+		case "srvmaxhosts":
+			require.Equal(t, value, float64(cs.SrvMaxHosts))
+		case "srvservicename":
+			require.Equal(t, value, cs.SrvServiceName)
 		case "w":
 			if cs.WNumberSet {
 				valueInt := GetIntFromInterface(value)
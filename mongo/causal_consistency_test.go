@@ -281,6 +281,53 @@ func TestCausalConsistency(t *testing.T) {
 		}
 	})
 
+	t.Run("TestOptOutOfCausalConsistency", func(t *testing.T) {
+		// A read that opts out of causal consistency must not send afterClusterTime, but later reads still must
+
+		skipInvalidTopology(t)
+		skipIfBelow36(t)
+
+		client, _, coll, _ := createReadFuncMap(t, "OptOutDB", "OptOutColl")
+		optOuts := []CollFunction{
+			{"Find", coll, nil, func(mctx SessionContext) error {
+				_, err := coll.Find(mctx, emptyDoc, options.Find().SetCausalConsistency(false))
+				return err
+			}},
+			{"Aggregate", coll, nil, func(mctx SessionContext) error {
+				_, err := coll.Aggregate(mctx, emptyArr, options.Aggregate().SetCausalConsistency(false))
+				return err
+			}},
+		}
+
+		for _, tc := range optOuts {
+			t.Run(tc.name, func(t *testing.T) {
+				sess, err := client.StartSession(options.Session().SetCausalConsistency(true))
+				testhelpers.RequireNil(t, err, "error creating session for %s: %s", tc.name, err)
+				defer sess.EndSession(ctx)
+
+				err = WithSession(ctx, sess, func(mctx SessionContext) error {
+					return coll.FindOne(mctx, emptyDoc).err
+				})
+				testhelpers.RequireNil(t, err, "find one error for %s: %s", tc.name, err)
+
+				err = WithSession(ctx, sess, tc.f)
+				testhelpers.RequireNil(t, err, "error running %s: %s", tc.name, err)
+				testhelpers.RequireNotNil(t, ccStarted, "no started command")
+				if _, err = ccStarted.Command.LookupErr("readConcern", "afterClusterTime"); err == nil {
+					t.Fatalf("afterClusterTime sent for %s after opting out", tc.name)
+				}
+
+				currOptime := sess.OperationTime()
+				err = WithSession(ctx, sess, func(mctx SessionContext) error {
+					_, err := coll.Find(mctx, emptyDoc)
+					return err
+				})
+				testhelpers.RequireNil(t, err, "find error after %s: %s", tc.name, err)
+				compareOperationTimes(t, currOptime, getOperationTime(t, ccStarted.Command))
+			})
+		}
+	})
+
 	t.Run("TestWriteThenRead", func(t *testing.T) {
 		// Any write operation followed by findOne should include operationTime of first op in afterClusterTime parameter of
 		// second op
@@ -19,6 +19,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/session"
+	"github.com/mongodb/mongo-go-driver/x/network/address"
 	"github.com/mongodb/mongo-go-driver/x/network/command"
 	"github.com/mongodb/mongo-go-driver/x/network/description"
 )
@@ -34,6 +35,20 @@ var ErrMissingResumeToken = errors.New("cannot provide resume functionality when
 // ErrNilCursor indicates that the cursor for the change stream is nil.
 var ErrNilCursor = errors.New("cursor is nil")
 
+// ChangeStream is a Cursor over the change notifications for a collection, database, or
+// deployment, as returned by Watch.
+type ChangeStream interface {
+	Cursor
+
+	// ResumeToken returns the resume token of the last change delivered by Next, or nil if
+	// Next has not delivered a change yet. The token always reflects the change that Next
+	// last delivered, never one that has been read from the server but not yet delivered, so
+	// it can be persisted after each change is processed and later passed to
+	// options.ChangeStreamOptions.SetResumeAfter to start a new change stream immediately
+	// after that change.
+	ResumeToken() bson.Raw
+}
+
 type changeStream struct {
 	cmd      bsonx.Doc // aggregate command to run to create stream and rebuild cursor
 	pipeline bsonx.Arr
@@ -42,6 +57,7 @@ type changeStream struct {
 	db       *Database
 	ns       command.Namespace
 	cursor   Cursor
+	addr     address.Address // the server the cursor was opened on
 
 	resumeToken bsonx.Doc
 	err         error
@@ -95,6 +111,9 @@ func createCmdDocs(csType StreamType, opts *options.ChangeStreamOptions, registr
 	if opts.FullDocument != nil {
 		pipelineDoc = pipelineDoc.Append("fullDocument", bsonx.String(string(*opts.FullDocument)))
 	}
+	if opts.FullDocumentBeforeChange != nil {
+		pipelineDoc = pipelineDoc.Append("fullDocumentBeforeChange", bsonx.String(string(*opts.FullDocumentBeforeChange)))
+	}
 	if opts.MaxAwaitTime != nil {
 		ms := int64(time.Duration(*opts.MaxAwaitTime) / time.Millisecond)
 		pipelineDoc = pipelineDoc.Append("maxAwaitTimeMS", bsonx.Int64(ms))
@@ -157,12 +176,17 @@ func parseOptions(csType StreamType, opts *options.ChangeStreamOptions, registry
 }
 
 func (cs *changeStream) runCommand(ctx context.Context, replaceOptions bool) error {
-	ss, err := cs.client.topology.SelectServer(ctx, cs.db.writeSelector)
+	readSelect := description.CompositeSelector([]description.ServerSelector{
+		description.ReadPrefSelector(cs.readPref),
+		description.LatencySelector(cs.client.localThreshold),
+	})
+	ss, err := cs.client.topology.SelectServer(ctx, readSelect)
 	if err != nil {
 		return err
 	}
 
 	desc := ss.Description()
+	cs.addr = desc.Addr
 	conn, err := ss.Connection(ctx)
 	if err != nil {
 		return err
@@ -205,8 +229,8 @@ func (cs *changeStream) runCommand(ctx context.Context, replaceOptions bool) err
 	}
 	cs.cursor = cursor
 
-	// can get resume token from initial aggregate command if non-empty batch
-	// operationTime from aggregate saved in the session
+	// the resume token is not taken from the initial batch because the changes in it have not been
+	// delivered yet; operationTime from aggregate saved in the session
 	cursorValue, err := rdr.LookupErr("cursor")
 	if err != nil {
 		return err
@@ -214,33 +238,6 @@ func (cs *changeStream) runCommand(ctx context.Context, replaceOptions bool) err
 	cursorDoc := cursorValue.Document()
 
 	cs.ns = command.ParseNamespace(cursorDoc.Lookup("ns").StringValue())
-
-	batchVal := cursorDoc.Lookup("firstBatch")
-	if err != nil {
-		return err
-	}
-
-	batch := batchVal.Array()
-	elements, err := batch.Elements()
-	if err != nil {
-		return err
-	}
-
-	if len(elements) == 0 {
-		return nil // no resume token
-	}
-
-	firstElem, err := batch.IndexErr(0)
-	if err != nil {
-		return err
-	}
-
-	tokenDoc, err := bsonx.ReadDoc(firstElem.Value().Document().Lookup("_id").Document())
-	if err != nil {
-		return err
-	}
-
-	cs.resumeToken = tokenDoc
 	return nil
 }
 
@@ -406,12 +403,28 @@ func (cs *changeStream) ID() int64 {
 	return cs.cursor.ID()
 }
 
+func (cs *changeStream) RemainingBatchLength() int {
+	if cs.cursor == nil {
+		return 0
+	}
+
+	return cs.cursor.RemainingBatchLength()
+}
+
 func (cs *changeStream) Next(ctx context.Context) bool {
 	if cs.cursor == nil {
 		return false
 	}
 
 	if cs.cursor.Next(ctx) {
+		// Track the token here rather than only in DecodeBytes so it reflects every change
+		// delivered, even ones the caller doesn't decode. A missing token is reported by
+		// DecodeBytes.
+		if br, err := cs.cursor.DecodeBytes(); err == nil {
+			if tokenDoc, err := resumeTokenFromChange(br); err == nil {
+				cs.resumeToken = tokenDoc
+			}
+		}
 		return true
 	}
 
@@ -432,7 +445,17 @@ func (cs *changeStream) Next(ctx context.Context) bool {
 		IDs: []int64{cs.ID()},
 	}
 
-	_, _ = driver.KillCursors(ctx, killCursors, cs.client.topology, cs.db.writeSelector)
+	// the cursor can only be killed on the server it was opened on, which is not necessarily the primary
+	addr := cs.addr
+	cursorSelect := description.ServerSelectorFunc(func(_ description.Topology, candidates []description.Server) ([]description.Server, error) {
+		for _, s := range candidates {
+			if s.Addr == addr {
+				return []description.Server{s}, nil
+			}
+		}
+		return nil, nil
+	})
+	_, _ = driver.KillCursors(ctx, killCursors, cs.client.topology, cursorSelect)
 	cs.err = cs.runCommand(ctx, true)
 	if cs.err != nil {
 		return false
@@ -464,26 +487,45 @@ func (cs *changeStream) DecodeBytes() (bson.Raw, error) {
 		return nil, err
 	}
 
-	idVal, err := br.LookupErr("_id")
+	tokenDoc, err := resumeTokenFromChange(br)
 	if err != nil {
 		_ = cs.Close(context.Background())
+		return nil, err
+	}
+
+	cs.resumeToken = tokenDoc
+	return br, nil
+}
+
+func (cs *changeStream) ResumeToken() bson.Raw {
+	if cs.resumeToken == nil {
+		return nil
+	}
+
+	token, err := cs.resumeToken.MarshalBSON()
+	if err != nil {
+		return nil
+	}
+	return token
+}
+
+// resumeTokenFromChange returns the resume token, the _id, of a change notification.
+func resumeTokenFromChange(change bson.Raw) (bsonx.Doc, error) {
+	idVal, err := change.LookupErr("_id")
+	if err != nil {
 		return nil, ErrMissingResumeToken
 	}
 
-	var idDoc bson.Raw
 	idDoc, ok := idVal.DocumentOK()
 	if !ok {
-		_ = cs.Close(context.Background())
 		return nil, ErrMissingResumeToken
 	}
 	tokenDoc, err := bsonx.ReadDoc(idDoc)
 	if err != nil {
-		_ = cs.Close(context.Background())
 		return nil, ErrMissingResumeToken
 	}
 
-	cs.resumeToken = tokenDoc
-	return br, nil
+	return tokenDoc, nil
 }
 
 func (cs *changeStream) Err() error {
@@ -497,6 +539,10 @@ func (cs *changeStream) Err() error {
 	return cs.cursor.Err()
 }
 
+func (cs *changeStream) ForEach(ctx context.Context, fn func(bson.Raw) error) error {
+	return command.ForEach(ctx, cs, fn)
+}
+
 func (cs *changeStream) Close(ctx context.Context) error {
 	if cs.cursor == nil {
 		return nil // cursor is already closed
@@ -15,6 +15,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/internal/testutil/helpers"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver"
@@ -40,6 +41,10 @@ func (er *errorCursor) ID() int64 {
 	return 1
 }
 
+func (er *errorCursor) RemainingBatchLength() int {
+	return 0
+}
+
 func (er *errorCursor) Next(ctx context.Context) bool {
 	return false
 }
@@ -58,6 +63,10 @@ func (er *errorCursor) Err() error {
 	}
 }
 
+func (er *errorCursor) ForEach(ctx context.Context, fn func(bson.Raw) error) error {
+	return command.ForEach(ctx, er, fn)
+}
+
 func (er *errorCursor) Close(ctx context.Context) error {
 	return nil
 }
@@ -274,6 +283,9 @@ func TestChangeStream(t *testing.T) {
 		if err := cs.Err(); err != nil {
 			t.Fatalf("Wrong Err error. Expected nil got %s", err)
 		}
+		if token := cs.ResumeToken(); token != nil {
+			t.Fatalf("Wrong resume token. Expected nil got %s", token)
+		}
 		if err := cs.Close(ctx); err != nil {
 			t.Fatalf("Wrong Close error. Expected nil got %s", err)
 		}
@@ -310,6 +322,82 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 		testhelpers.RequireNotNil(t, cs.resumeToken, "no resume token found after first change")
 	})
 
+	t.Run("TestResumeTokenAtNext", func(t *testing.T) {
+		// ResumeToken must reflect the last change delivered by Next, even if it was not decoded
+
+		coll, stream := createCollectionStream(t, "ResumeTokenAtNextDB", "ResumeTokenAtNextColl", nil)
+		defer closeCursor(stream)
+
+		cs := stream.(ChangeStream)
+		coll.writeConcern = wcMajority
+		_, err := coll.InsertMany(ctx, []interface{}{bsonx.Doc{{"x", bsonx.Int32(1)}}, bsonx.Doc{{"x", bsonx.Int32(2)}}})
+		testhelpers.RequireNil(t, err, "error running insertMany: %s", err)
+
+		if !cs.Next(ctx) {
+			t.Fatalf("no change found")
+		}
+		change, err := cs.DecodeBytes()
+		testhelpers.RequireNil(t, err, "error decoding bytes: %s", err)
+		firstToken := cs.ResumeToken()
+		require.Equal(t, bson.Raw(change.Lookup("_id").Document()), firstToken)
+
+		if !cs.Next(ctx) {
+			t.Fatalf("no second change found")
+		}
+		require.NotEqual(t, firstToken, cs.ResumeToken())
+
+		// resuming after the first change must deliver the second one
+		resumed, err := coll.Watch(ctx, Pipeline{}, options.ChangeStream().SetResumeAfter(firstToken))
+		testhelpers.RequireNil(t, err, "error resuming change stream: %s", err)
+		defer closeCursor(resumed)
+
+		if !resumed.Next(ctx) {
+			t.Fatalf("no change found after resuming")
+		}
+		require.Equal(t, cs.ResumeToken(), resumed.ResumeToken())
+	})
+
+	t.Run("TestFullDocumentBeforeChange", func(t *testing.T) {
+		// Update notifications must include the pre-image when fullDocumentBeforeChange is required
+		version, err := getServerVersion(createTestDatabase(t, nil))
+		testhelpers.RequireNil(t, err, "error getting server version: %s", err)
+		if compareVersions(t, version, "6.0") < 0 {
+			t.Skip("skipping for version < 6.0")
+		}
+
+		db := createTestDatabase(t, nil)
+		coll := db.Collection("PreImagesColl", options.Collection().SetWriteConcern(wcMajority))
+		_ = coll.Drop(ctx)
+		err = db.RunCommand(ctx, bsonx.Doc{
+			{"create", bsonx.String(coll.Name())},
+			{"changeStreamPreAndPostImages", bsonx.Document(bsonx.Doc{{"enabled", bsonx.Boolean(true)}})},
+		}).Err()
+		testhelpers.RequireNil(t, err, "error creating collection: %s", err)
+		defer func() { _ = coll.Drop(ctx) }()
+
+		_, err = coll.InsertOne(ctx, bsonx.Doc{{"_id", bsonx.Int32(1)}, {"x", bsonx.Int32(1)}})
+		testhelpers.RequireNil(t, err, "error running insertOne: %s", err)
+
+		stream, err := coll.Watch(ctx, Pipeline{}, options.ChangeStream().SetFullDocumentBeforeChange(options.FullDocumentRequired))
+		testhelpers.RequireNil(t, err, "error creating stream: %s", err)
+		defer closeCursor(stream)
+
+		_, err = coll.UpdateOne(ctx, bsonx.Doc{{"_id", bsonx.Int32(1)}}, bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(2)}})}})
+		testhelpers.RequireNil(t, err, "error running updateOne: %s", err)
+
+		if !stream.Next(ctx) {
+			t.Fatalf("no change found: %v", stream.Err())
+		}
+		var change struct {
+			OperationType            string `bson:"operationType"`
+			FullDocumentBeforeChange bson.D `bson:"fullDocumentBeforeChange"`
+		}
+		err = stream.Decode(&change)
+		testhelpers.RequireNil(t, err, "error decoding change: %s", err)
+		require.Equal(t, "update", change.OperationType)
+		require.Equal(t, bson.D{{"_id", int32(1)}, {"x", int32(1)}}, change.FullDocumentBeforeChange)
+	})
+
 	t.Run("TestMissingResumeToken", func(t *testing.T) {
 		// Stream will throw an error if the server response is missing the resume token
 		idDoc := bsonx.Doc{{"_id", bsonx.Int32(0)}}
@@ -668,3 +756,16 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 		})
 	})
 }
+
+func TestChangeStreamFullDocumentBeforeChange(t *testing.T) {
+	opts := options.MergeChangeStreamOptions(options.ChangeStream().SetFullDocumentBeforeChange(options.FullDocumentWhenAvailable))
+	pipelineDoc, _, _, err := parseOptions(CollectionStream, opts, bson.DefaultRegistry)
+	testhelpers.RequireNil(t, err, "error parsing options: %s", err)
+
+	val, err := pipelineDoc.LookupErr("fullDocumentBeforeChange")
+	testhelpers.RequireNil(t, err, "fullDocumentBeforeChange not found in $changeStream stage: %s", err)
+	require.Equal(t, "whenAvailable", val.StringValue())
+	val, err = pipelineDoc.LookupErr("fullDocument")
+	testhelpers.RequireNil(t, err, "fullDocument not found in $changeStream stage: %s", err)
+	require.Equal(t, "default", val.StringValue())
+}
@@ -8,6 +8,8 @@ package mongo
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
@@ -17,7 +19,9 @@ import (
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
 	"github.com/mongodb/mongo-go-driver/tag"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver"
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/logger"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/session"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/topology"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/uuid"
@@ -28,6 +32,10 @@ import (
 
 const defaultLocalThreshold = 15 * time.Millisecond
 
+// minHeartbeatInterval is the smallest heartbeat interval a Client accepts. Servers are never checked more
+// often than this, even when server selection requests an immediate check.
+const minHeartbeatInterval = 500 * time.Millisecond
+
 // Client performs operations on a given topology.
 type Client struct {
 	id              uuid.UUID
@@ -36,12 +44,21 @@ type Client struct {
 	connString      connstring.ConnString
 	localThreshold  time.Duration
 	retryWrites     bool
+	bypassIDGen     bool
 	clock           *session.ClusterClock
 	readPreference  *readpref.ReadPref
 	readConcern     *readconcern.ReadConcern
 	writeConcern    *writeconcern.WriteConcern
 	registry        *bsoncodec.Registry
 	marshaller      BSONAppender
+	timeout         time.Duration
+	serverInfo      *serverInfoCache
+}
+
+// serverInfoCache holds the ServerInfo of each server described by Client.ServerInfo, by server address.
+type serverInfoCache struct {
+	sync.Mutex
+	infos map[string]ServerInfo
 }
 
 // Connect creates a new Client and then initializes it using the Connect method.
@@ -97,8 +114,11 @@ func (c *Client) Connect(ctx context.Context) error {
 // pool and closed before returning. If the context expires via cancellation,
 // deadline, or timeout before the in use connections have returned, the in use
 // connections will be closed, resulting in the failure of any in flight read
-// or write operations. If this method returns with no errors, all connections
-// associated with this Client have been closed.
+// or write operations, and a DisconnectError reporting how many operations were
+// still in flight is returned. Operations that need a new connection after
+// Disconnect has been called fail with ErrClientDisconnected or a server closed
+// error. All connections associated with this Client have been closed when this
+// method returns, whether or not it returns an error.
 func (c *Client) Disconnect(ctx context.Context) error {
 	c.endSessions(ctx)
 	return replaceTopologyErr(c.topology.Disconnect(ctx))
@@ -111,6 +131,8 @@ func (c *Client) Ping(ctx context.Context, rp *readpref.ReadPref) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := c.contextWithTimeout(ctx)
+	defer cancel()
 
 	if rp == nil {
 		rp = c.readPreference
@@ -120,6 +142,207 @@ func (c *Client) Ping(ctx context.Context, rp *readpref.ReadPref) error {
 	return replaceTopologyErr(err)
 }
 
+// PingAll pings every server in the client's current view of the topology concurrently and returns a result for
+// each of them. A server that cannot be reached is reported through the Err field of its result rather than
+// through the returned error, which is only set if the client is disconnected.
+func (c *Client) PingAll(ctx context.Context) ([]ServerPingResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := c.contextWithTimeout(ctx)
+	defer cancel()
+
+	servers, err := c.knownServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]ServerPingResult, len(servers))
+	selected := make([]*topology.SelectedServer, len(servers))
+	for i, desc := range servers {
+		ss, err := c.topology.FindServer(desc)
+		if err != nil {
+			return nil, replaceTopologyErr(err)
+		}
+		results[i] = ServerPingResult{Addr: desc.Addr.String(), Kind: desc.Kind.String()}
+		selected[i] = ss
+	}
+
+	var wg sync.WaitGroup
+	for i, ss := range selected {
+		if ss == nil {
+			results[i].Err = fmt.Errorf("server %s is no longer part of the topology", results[i].Addr)
+			continue
+		}
+		wg.Add(1)
+		go func(res *ServerPingResult, ss *topology.SelectedServer) {
+			defer wg.Done()
+			res.RTT, res.Err = pingServer(ctx, ss)
+		}(&results[i], ss)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// PoolStats returns a snapshot of the connection pool of every server the client currently knows about,
+// keyed by server address. The counts are sampled from the live pools without waiting for any operation,
+// so they are suitable for exporting as gauges. An empty map is returned if the client is not connected.
+func (c *Client) PoolStats() map[string]PoolStat {
+	stats := make(map[string]PoolStat)
+	for addr, ps := range c.topology.PoolStats() {
+		stats[addr.String()] = PoolStat{
+			TotalConnections:     ps.Total,
+			AvailableConnections: ps.Available,
+			PendingConnections:   ps.Pending,
+			CheckedOut:           ps.CheckedOut,
+		}
+	}
+	return stats
+}
+
+// SessionPoolStats returns a snapshot of the client's pool of server sessions. The zero value is returned if the
+// client is not connected.
+func (c *Client) SessionPoolStats() SessionPoolStat {
+	if c.topology.SessionPool == nil {
+		return SessionPoolStat{}
+	}
+
+	ps := c.topology.SessionPool.Stats()
+	return SessionPoolStat{
+		Idle:       ps.Idle,
+		CheckedOut: ps.CheckedOut,
+		Expired:    ps.Expired,
+		Discarded:  ps.Discarded,
+	}
+}
+
+// ServerLatencies returns the exponentially weighted moving average round trip time of every server the client
+// currently knows about, keyed by server address. These are the averages maintained by the client's server
+// monitors and compared against the localThresholdMS option during server selection; no server is contacted to
+// compute them. Servers whose monitors have not completed a round trip yet are omitted, and an empty map is
+// returned if the client is not connected.
+func (c *Client) ServerLatencies() map[string]time.Duration {
+	latencies := make(map[string]time.Duration)
+	for addr, rtt := range c.topology.ServerLatencies() {
+		latencies[addr.String()] = rtt
+	}
+	return latencies
+}
+
+// ServerInfo returns information about the primary, or a secondary if no primary is available. For a sharded
+// cluster it describes one of the mongos routers. The wire version and topology type come from the client's
+// current view of the topology. The version and storage engine require a buildInfo and a serverStatus command
+// the first time a server is described, and are cached until the server reports a different wire version or
+// the topology type changes.
+func (c *Client) ServerInfo(ctx context.Context) (ServerInfo, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := c.contextWithTimeout(ctx)
+	defer cancel()
+
+	ss, err := c.topology.SelectServer(ctx, description.ReadPrefSelector(readpref.PrimaryPreferred()))
+	if err != nil {
+		return ServerInfo{}, replaceTopologyErr(err)
+	}
+
+	desc := ss.Description()
+	info := ServerInfo{Addr: desc.Addr.String(), TopologyType: desc.Kind.String()}
+	if desc.WireVersion != nil {
+		info.MaxWireVersion = desc.WireVersion.Max
+	}
+
+	c.serverInfo.Lock()
+	cached, ok := c.serverInfo.infos[info.Addr]
+	c.serverInfo.Unlock()
+	if ok && cached.MaxWireVersion == info.MaxWireVersion && cached.TopologyType == info.TopologyType {
+		return cached, nil
+	}
+
+	conn, err := ss.Connection(ctx)
+	if err != nil {
+		return ServerInfo{}, replaceTopologyErr(err)
+	}
+	defer conn.Close()
+
+	cmd := command.Read{
+		DB:       "admin",
+		Command:  bsonx.Doc{{"buildInfo", bsonx.Int32(1)}},
+		ReadPref: readpref.PrimaryPreferred(),
+	}
+	rdr, err := cmd.RoundTrip(ctx, desc, conn)
+	if err != nil {
+		return ServerInfo{}, replaceTopologyErr(err)
+	}
+	if version, err := rdr.LookupErr("version"); err == nil {
+		info.Version = version.StringValue()
+	}
+
+	// serverStatus requires the clusterMonitor role and mongos has no storage engine, so an error only leaves the
+	// storage engine unknown.
+	cmd.Command = bsonx.Doc{
+		{"serverStatus", bsonx.Int32(1)},
+		{"repl", bsonx.Int32(0)},
+		{"metrics", bsonx.Int32(0)},
+		{"locks", bsonx.Int32(0)},
+	}
+	if rdr, err = cmd.RoundTrip(ctx, desc, conn); err == nil {
+		if name, err := rdr.LookupErr("storageEngine", "name"); err == nil {
+			info.StorageEngine = name.StringValue()
+		}
+	}
+
+	c.serverInfo.Lock()
+	c.serverInfo.infos[info.Addr] = info
+	c.serverInfo.Unlock()
+
+	return info, nil
+}
+
+// knownServers returns the servers in the current topology description. If the topology has not been described
+// yet, it waits for the first description that includes servers or for the context to be done.
+func (c *Client) knownServers(ctx context.Context) ([]description.Server, error) {
+	sub, err := c.topology.Subscribe()
+	if err != nil {
+		return nil, ErrClientDisconnected
+	}
+	defer func() { _ = sub.Unsubscribe() }()
+
+	for {
+		select {
+		case desc, ok := <-sub.C:
+			if !ok {
+				return nil, ErrClientDisconnected
+			}
+			if len(desc.Servers) > 0 {
+				return desc.Servers, nil
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// pingServer runs a ping command against ss and returns the time it took.
+func pingServer(ctx context.Context, ss *topology.SelectedServer) (time.Duration, error) {
+	conn, err := ss.Connection(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	cmd := command.Read{
+		DB:       "admin",
+		Command:  bsonx.Doc{{"ping", bsonx.Int32(1)}},
+		ReadPref: readpref.Nearest(),
+	}
+	start := time.Now()
+	if _, err = cmd.RoundTrip(ctx, ss.Description(), conn); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
 // StartSession starts a new session.
 func (c *Client) StartSession(opts ...*options.SessionOptions) (Session, error) {
 	if c.topology.SessionPool == nil {
@@ -144,6 +367,9 @@ func (c *Client) StartSession(opts ...*options.SessionOptions) (Session, error)
 	if sopts.DefaultReadPreference != nil {
 		coreOpts.DefaultReadPreference = sopts.DefaultReadPreference
 	}
+	if sopts.Snapshot != nil {
+		coreOpts.Snapshot = sopts.Snapshot
+	}
 
 	sess, err := session.NewClientSession(c.topology.SessionPool, c.id, session.Explicit, coreOpts)
 	if err != nil {
@@ -181,6 +407,7 @@ func newClient(cs connstring.ConnString, opts ...*options.ClientOptions) (*Clien
 		readConcern:     clientOpt.ReadConcern,
 		writeConcern:    clientOpt.WriteConcern,
 		registry:        clientOpt.Registry,
+		serverInfo:      &serverInfoCache{infos: make(map[string]ServerInfo)},
 	}
 
 	if client.connString.RetryWritesSet {
@@ -189,6 +416,16 @@ func newClient(cs connstring.ConnString, opts ...*options.ClientOptions) (*Clien
 	if clientOpt.RetryWrites != nil {
 		client.retryWrites = *clientOpt.RetryWrites
 	}
+	if clientOpt.BypassClientIDGeneration != nil {
+		client.bypassIDGen = *clientOpt.BypassClientIDGeneration
+	}
+	if client.connString.TimeoutSet {
+		client.timeout = client.connString.Timeout
+	}
+	if client.connString.HeartbeatIntervalSet && client.connString.HeartbeatInterval < minHeartbeatInterval {
+		return nil, fmt.Errorf("heartbeat interval must be at least %s, got %s",
+			minHeartbeatInterval, client.connString.HeartbeatInterval)
+	}
 
 	clientID, err := uuid.New()
 	if err != nil {
@@ -196,14 +433,29 @@ func newClient(cs connstring.ConnString, opts ...*options.ClientOptions) (*Clien
 	}
 	client.id = clientID
 
+	lo := options.Logger()
+	if clientOpt.LoggerOptions != nil {
+		lo = clientOpt.LoggerOptions
+	}
+	clientLogger := logger.New(lo.Sink, lo.MaxDocumentLength, lo.ComponentLevels)
+
 	topts := append(
 		client.topologyOptions,
-		topology.WithConnString(func(connstring.ConnString) connstring.ConnString { return client.connString }),
+		topology.WithConnString(func(connstring.ConnString) connstring.ConnString {
+			cs := client.connString
+			if client.timeout > 0 {
+				// Socket reads and writes are bounded by the operation's context deadline instead.
+				cs.SocketTimeout = 0
+				cs.SocketTimeoutSet = false
+			}
+			return cs
+		}),
 		topology.WithServerOptions(func(opts ...topology.ServerOption) []topology.ServerOption {
 			return append(opts, topology.WithClock(func(clock *session.ClusterClock) *session.ClusterClock {
 				return client.clock
 			}))
 		}),
+		topology.WithLogger(func(*logger.Logger) *logger.Logger { return clientLogger }),
 	)
 	topo, err := topology.New(topts...)
 	if err != nil {
@@ -224,6 +476,9 @@ func newClient(cs connstring.ConnString, opts ...*options.ClientOptions) (*Clien
 	if client.writeConcern == nil {
 		client.writeConcern = writeConcernFromConnString(&client.connString)
 	}
+	if err = client.writeConcern.Validate(); err != nil {
+		return nil, err
+	}
 	if client.readPreference == nil {
 		rp, err := readPreferenceFromConnString(&client.connString)
 		if err != nil {
@@ -242,6 +497,28 @@ func newClient(cs connstring.ConnString, opts ...*options.ClientOptions) (*Clien
 	return client, nil
 }
 
+// contextWithTimeout returns a context bounded by the client's operation timeout, if one is set
+// and ctx does not already have a deadline. The returned CancelFunc must be called once the
+// operation is complete.
+func (c *Client) contextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c.timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// ignoresMaxTime reports whether operation-level MaxTime options are ignored. When the client has a timeout, the
+// timeout bounds the whole operation instead, so a separate server-side limit is not sent.
+func (c *Client) ignoresMaxTime() bool {
+	return c.timeout > 0
+}
+
 func readConcernFromConnString(cs *connstring.ConnString) *readconcern.ReadConcern {
 	if len(cs.ReadConcernLevel) == 0 {
 		return nil
@@ -324,7 +601,8 @@ func (c *Client) ValidSession(sess *session.Client) error {
 	return nil
 }
 
-// Database returns a handle for a given database.
+// Database returns a handle for a given database. The read concern, write concern, read preference and registry
+// of the database default to those of the client.
 func (c *Client) Database(name string, opts ...*options.DatabaseOptions) *Database {
 	return newDatabase(c, name, opts...)
 }
@@ -339,6 +617,8 @@ func (c *Client) ListDatabases(ctx context.Context, filter interface{}, opts ...
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := c.contextWithTimeout(ctx)
+	defer cancel()
 
 	sess := sessionFromContext(ctx)
 
@@ -442,7 +722,7 @@ func (c *Client) UseSessionWithOptions(ctx context.Context, opts *options.Sessio
 // to running a raw aggregation with a $changeStream stage because it supports resumability in the case of some errors.
 // The client must have read concern majority or no read concern for a change stream to be created successfully.
 func (c *Client) Watch(ctx context.Context, pipeline interface{},
-	opts ...*options.ChangeStreamOptions) (Cursor, error) {
+	opts ...*options.ChangeStreamOptions) (ChangeStream, error) {
 
 	return newClientChangeStream(ctx, c, pipeline, opts...)
 }
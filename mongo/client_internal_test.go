@@ -8,6 +8,7 @@ package mongo
 
 import (
 	"context"
+	"net"
 	"os"
 	"path"
 	"testing"
@@ -24,10 +25,12 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
 	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
+	"github.com/mongodb/mongo-go-driver/event"
 	"github.com/mongodb/mongo-go-driver/mongo/options"
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/session"
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/topology"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/uuid"
 	"github.com/mongodb/mongo-go-driver/x/network/connstring"
 	"reflect"
@@ -73,6 +76,12 @@ func TestNewClient(t *testing.T) {
 	require.NotNil(t, c.topology)
 }
 
+func TestClient_InconsistentWriteConcern(t *testing.T) {
+	wc := writeconcern.New(writeconcern.W(0), writeconcern.J(true))
+	_, err := NewClientWithOptions("mongodb://localhost", options.Client().SetWriteConcern(wc))
+	require.Equal(t, writeconcern.ErrInconsistent, err)
+}
+
 func TestClient_Database(t *testing.T) {
 	t.Parallel()
 
@@ -291,6 +300,9 @@ func TestClient_ReplaceTopologyError(t *testing.T) {
 	err = c.Ping(ctx, nil)
 	require.Equal(t, err, ErrClientDisconnected)
 
+	_, err = c.PingAll(ctx)
+	require.Equal(t, err, ErrClientDisconnected)
+
 	err = c.Disconnect(ctx)
 	require.Equal(t, err, ErrClientDisconnected)
 
@@ -522,3 +534,255 @@ func TestClient_Ping_InvalidHost(t *testing.T) {
 	err = c.Ping(ctx, nil)
 	require.NotNil(t, err)
 }
+
+func TestClient_PingAll(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	cs := testutil.ConnString(t)
+	c, err := NewClient(cs.String())
+	require.NoError(t, err)
+	require.NoError(t, c.Connect(ctx))
+	defer func() { _ = c.Disconnect(ctx) }()
+	require.NoError(t, c.Ping(ctx, nil))
+
+	results, err := c.PingAll(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	for _, res := range results {
+		require.NoError(t, res.Err, "ping of %s failed", res.Addr)
+		require.NotEmpty(t, res.Addr)
+		require.True(t, res.RTT > 0)
+	}
+}
+
+func TestClient_PingAll_InvalidHost(t *testing.T) {
+	c, err := NewClientWithOptions("mongodb://nohost:27017", options.Client().SetConnectTimeout(time.Second))
+	require.NoError(t, err)
+
+	require.NoError(t, c.Connect(ctx))
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	results, err := c.PingAll(pingCtx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "nohost:27017", results[0].Addr)
+	require.Equal(t, "Unknown", results[0].Kind)
+	require.Error(t, results[0].Err)
+	require.Equal(t, time.Duration(0), results[0].RTT)
+}
+
+func TestClient_ServerInfo(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	cs := testutil.ConnString(t)
+	c, err := NewClient(cs.String())
+	require.NoError(t, err)
+	require.NoError(t, c.Connect(ctx))
+	defer func() { _ = c.Disconnect(ctx) }()
+
+	info, err := c.ServerInfo(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, info.Addr)
+	require.True(t, info.MaxWireVersion > 0)
+	require.NotEqual(t, "Unknown", info.TopologyType)
+
+	version, err := getServerVersion(c.Database("admin"))
+	require.NoError(t, err)
+	require.Equal(t, version, info.Version)
+
+	// a second call is answered from the cache
+	c.serverInfo.Lock()
+	cached := c.serverInfo.infos[info.Addr]
+	cached.Version = "cached"
+	c.serverInfo.infos[info.Addr] = cached
+	c.serverInfo.Unlock()
+
+	info, err = c.ServerInfo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "cached", info.Version)
+}
+
+func TestClient_PoolStats(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	cs := testutil.ConnString(t)
+	c, err := NewClient(cs.String())
+	require.NoError(t, err)
+	require.Empty(t, c.PoolStats(), "a client that is not connected has no pools")
+
+	require.NoError(t, c.Connect(ctx))
+	defer func() { _ = c.Disconnect(ctx) }()
+	require.NoError(t, c.Ping(ctx, nil))
+	_, err = c.ListDatabaseNames(ctx, bsonx.Doc{})
+	require.NoError(t, err)
+
+	stats := c.PoolStats()
+	require.NotEmpty(t, stats)
+	var total int
+	for addr, stat := range stats {
+		require.NotEmpty(t, addr)
+		require.Equal(t, stat.TotalConnections, stat.AvailableConnections+stat.CheckedOut, "stats for %s", addr)
+		total += stat.TotalConnections
+	}
+	require.True(t, total > 0, "expected the connection used by ListDatabaseNames to be pooled")
+}
+
+func TestClient_LoadBalancedUnsupported(t *testing.T) {
+	_, err := NewClient("mongodb://localhost/?loadBalanced=true")
+	require.Equal(t, topology.ErrLoadBalancedUnsupported, err)
+
+	_, err = NewClient("mongodb://localhost/?loadBalanced=false")
+	require.NoError(t, err)
+}
+
+func TestClient_Timeout(t *testing.T) {
+	t.Run("context without deadline", func(t *testing.T) {
+		c := &Client{timeout: time.Minute}
+		opCtx, cancel := c.contextWithTimeout(context.Background())
+		defer cancel()
+
+		deadline, ok := opCtx.Deadline()
+		require.True(t, ok)
+		require.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+	})
+	t.Run("context deadline takes precedence", func(t *testing.T) {
+		c := &Client{timeout: time.Minute}
+		ctx, ctxCancel := context.WithTimeout(context.Background(), time.Hour)
+		defer ctxCancel()
+
+		opCtx, cancel := c.contextWithTimeout(ctx)
+		defer cancel()
+		require.Equal(t, ctx, opCtx)
+	})
+	t.Run("no timeout", func(t *testing.T) {
+		c := &Client{}
+		opCtx, cancel := c.contextWithTimeout(nil)
+		defer cancel()
+
+		_, ok := opCtx.Deadline()
+		require.False(t, ok)
+	})
+	t.Run("session is preserved", func(t *testing.T) {
+		c := &Client{timeout: time.Minute}
+		sess := &sessionImpl{Client: &session.Client{}}
+		opCtx, cancel := c.contextWithTimeout(contextWithSession(context.Background(), sess))
+		defer cancel()
+		require.Equal(t, sess.Client, sessionFromContext(opCtx))
+	})
+	t.Run("bounds server selection", func(t *testing.T) {
+		// Nothing listens on the address, so server selection blocks until the operation times out.
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := l.Addr().String()
+		require.NoError(t, l.Close())
+
+		c, err := NewClientWithOptions("mongodb://"+addr,
+			options.Client().SetServerSelectionTimeout(time.Minute).SetTimeout(100*time.Millisecond))
+		require.NoError(t, err)
+		require.NoError(t, c.Connect(context.Background()))
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			_ = c.Disconnect(ctx)
+		}()
+
+		start := time.Now()
+		_, err = c.Database("test").Collection("test").Find(context.Background(), bsonx.Doc{})
+		require.Error(t, err)
+		require.True(t, time.Since(start) < 10*time.Second, "expected the find to abort at the timeout, took %v", time.Since(start))
+	})
+	t.Run("bounds retries", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping integration test in short mode")
+		}
+		if os.Getenv("TOPOLOGY") != "replica_set" {
+			t.Skip("retryable writes are only tested against replica sets")
+		}
+		version, err := getServerVersion(createTestDatabase(t, nil))
+		require.NoError(t, err)
+		if compareVersions(t, version, "4.4") < 0 {
+			t.Skip("blocking fail points require MongoDB 4.4 or later")
+		}
+
+		var inserts int
+		monitor := &event.CommandMonitor{
+			Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+				if cse.CommandName == "insert" {
+					inserts++
+				}
+			},
+		}
+		cs := testutil.ConnString(t)
+		c, err := NewClientWithOptions(cs.String(),
+			options.Client().SetMonitor(monitor).SetRetryWrites(true).SetTimeout(700*time.Millisecond))
+		require.NoError(t, err)
+		require.NoError(t, c.Connect(ctx))
+		defer func() { _ = c.Disconnect(ctx) }()
+
+		coll := c.Database("ClientTimeoutTest").Collection("retry")
+		require.NoError(t, coll.Drop(ctx))
+
+		// both attempts block for 500ms and then fail with a retryable error, so the retry is still blocked when
+		// the 700ms timeout expires
+		admin := c.Database("admin")
+		err = admin.RunCommand(ctx, bsonx.Doc{
+			{"configureFailPoint", bsonx.String("failCommand")},
+			{"mode", bsonx.Document(bsonx.Doc{{"times", bsonx.Int32(2)}})},
+			{"data", bsonx.Document(bsonx.Doc{
+				{"failCommands", bsonx.Array(bsonx.Arr{bsonx.String("insert")})},
+				{"errorCode", bsonx.Int32(10107)},
+				{"blockConnection", bsonx.Boolean(true)},
+				{"blockTimeMS", bsonx.Int32(500)},
+			})},
+		}).Err()
+		require.NoError(t, err)
+		defer func() {
+			_ = admin.RunCommand(ctx, bsonx.Doc{
+				{"configureFailPoint", bsonx.String("failCommand")},
+				{"mode", bsonx.String("off")},
+			})
+		}()
+
+		start := time.Now()
+		_, err = coll.InsertOne(context.Background(), bsonx.Doc{{"x", bsonx.Int32(1)}})
+		elapsed := time.Since(start)
+		require.Error(t, err)
+		require.Equal(t, 2, inserts, "expected the insert to be retried once")
+		require.True(t, elapsed < time.Second, "expected the retry to abort at the timeout, took %v", elapsed)
+	})
+}
+
+func TestClient_ServerLatencies(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	cs := testutil.ConnString(t)
+	c, err := NewClient(cs.String())
+	require.NoError(t, err)
+	require.Empty(t, c.ServerLatencies(), "a client that is not connected has no server monitors")
+
+	require.NoError(t, c.Connect(ctx))
+	defer func() { _ = c.Disconnect(ctx) }()
+	require.NoError(t, c.Ping(ctx, nil))
+
+	latencies := c.ServerLatencies()
+	require.NotEmpty(t, latencies)
+	for addr, rtt := range latencies {
+		require.NotEmpty(t, addr)
+		require.True(t, rtt > 0, "expected a measured round trip time for %s, got %v", addr, rtt)
+	}
+}
@@ -9,6 +9,7 @@ package mongo
 import (
 	"context"
 	"net"
+	"sync"
 	"sync/atomic"
 	"testing"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/topology"
 	"github.com/mongodb/mongo-go-driver/x/network/connstring"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
 	"github.com/stretchr/testify/require"
 )
 
@@ -99,7 +101,7 @@ func TestClientOptions_chainAll(t *testing.T) {
 		"mongodb://localhost:27018",
 		"mongodb://localhost:27019",
 	}).SetLocalThreshold(time.Second).SetMaxConnIdleTime(30 * time.Second).SetMaxPoolSize(150).
-		SetReadConcern(rc).SetReadPreference(rp).SetReplicaSet("foo").
+		SetMaxConnecting(4).SetWarmupConnections(10).SetReadConcern(rc).SetReadPreference(rp).SetReplicaSet("foo").
 		SetRetryWrites(retryWrites).SetServerSelectionTimeout(time.Second).
 		SetSingle(false).SetSocketTimeout(2 * time.Second).SetSSL(&options.SSLOpt{
 		Enabled:                      true,
@@ -107,7 +109,7 @@ func TestClientOptions_chainAll(t *testing.T) {
 		ClientCertificateKeyPassword: nil,
 		Insecure:                     false,
 		CaFile:                       "ca.pem",
-	}).SetWriteConcern(wc)
+	}).SetTimeout(5 * time.Second).SetWriteConcern(wc)
 
 	expectedClient := &options.ClientOptions{
 		TopologyOptions: []topology.Option{},
@@ -128,10 +130,14 @@ func TestClientOptions_chainAll(t *testing.T) {
 			},
 			LocalThresholdSet:                  true,
 			LocalThreshold:                     time.Second,
+			MaxConnecting:                      4,
+			MaxConnectingSet:                   true,
 			MaxConnIdleTime:                    30 * time.Second,
 			MaxConnIdleTimeSet:                 true,
 			MaxPoolSize:                        150,
 			MaxPoolSizeSet:                     true,
+			MinPoolSize:                        10,
+			MinPoolSizeSet:                     true,
 			ReplicaSet:                         "foo",
 			ServerSelectionTimeoutSet:          true,
 			ServerSelectionTimeout:             time.Second,
@@ -149,6 +155,9 @@ func TestClientOptions_chainAll(t *testing.T) {
 			SSLInsecureSet:                     true,
 			SSLCaFile:                          "ca.pem",
 			SSLCaFileSet:                       true,
+			SSLGetClientCertificateSet:         true,
+			Timeout:                            5 * time.Second,
+			TimeoutSet:                         true,
 		},
 		ReadConcern:    rc,
 		ReadPreference: rp,
@@ -183,3 +192,63 @@ func (td *testDialer) DialContext(ctx context.Context, network, address string)
 	atomic.AddInt32(&td.called, 1)
 	return td.d.DialContext(ctx, network, address)
 }
+
+func TestClientOptions_heartbeatInterval(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClientWithOptions("mongodb://localhost", options.Client().SetHeartbeatInterval(500*time.Millisecond))
+	require.NoError(t, err)
+	require.Equal(t, 500*time.Millisecond, client.connString.HeartbeatInterval)
+
+	_, err = NewClientWithOptions("mongodb://localhost", options.Client().SetHeartbeatInterval(100*time.Millisecond))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "heartbeat interval must be at least 500ms")
+
+	_, err = NewClient("mongodb://localhost/?heartbeatFrequencyMS=499")
+	require.Error(t, err)
+
+	client, err = NewClient("mongodb://localhost")
+	require.NoError(t, err)
+	require.False(t, client.connString.HeartbeatIntervalSet, "the default heartbeat interval should be used")
+}
+
+type logSink struct {
+	sync.Mutex
+	msgs []string
+}
+
+func (s *logSink) Log(_ options.LogLevel, _ options.LogComponent, msg string, _ ...interface{}) {
+	s.Lock()
+	defer s.Unlock()
+	s.msgs = append(s.msgs, msg)
+}
+
+func (s *logSink) messages() []string {
+	s.Lock()
+	defer s.Unlock()
+	return append([]string(nil), s.msgs...)
+}
+
+func TestClientOptions_logger(t *testing.T) {
+	t.Parallel()
+
+	sink := &logSink{}
+	lo := options.Logger().SetSink(sink).SetComponentLevel(options.LogComponentAll, options.LogLevelDebug)
+	merged := options.MergeClientOptions(connstring.ConnString{}, options.Client().SetLoggerOptions(options.Logger()),
+		options.Client().SetLoggerOptions(lo), options.Client())
+	require.True(t, merged.LoggerOptions == lo, "expected the last logger options to win")
+
+	client, err := NewClientWithOptions("mongodb://localhost:1",
+		options.Client().SetLoggerOptions(lo).SetServerSelectionTimeout(100*time.Millisecond))
+	require.NoError(t, err)
+	require.NoError(t, client.Connect(context.Background()))
+	defer func() { _ = client.Disconnect(context.Background()) }()
+
+	_, err = client.topology.SelectServer(context.Background(), description.WriteSelector())
+	require.Error(t, err)
+
+	msgs := sink.messages()
+	for _, msg := range []string{"Starting topology monitoring", "Server selection started", "Server selection failed"} {
+		require.Contains(t, msgs, msg)
+	}
+}
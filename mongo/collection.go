@@ -9,9 +9,14 @@ package mongo
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
+	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/mongo/options"
 	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
@@ -21,9 +26,15 @@ import (
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/session"
 	"github.com/mongodb/mongo-go-driver/x/network/command"
 	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/mongodb/mongo-go-driver/x/network/result"
 )
 
 // Collection performs operations on a given collection.
+//
+// The read concern, write concern and read preference of a Collection are resolved when it is created. Each one
+// is taken from the CollectionOptions if set there, and otherwise inherited from the Database, which in turn
+// inherits it from the Client. Options of the transaction an operation runs in take precedence over those of
+// the Collection.
 type Collection struct {
 	client         *Client
 	db             *Database
@@ -98,7 +109,8 @@ func (coll *Collection) copy() *Collection {
 	}
 }
 
-// Clone creates a copy of this collection with updated options, if any are given.
+// Clone creates a copy of this collection with updated options, if any are given. Options that are not set keep
+// the values of the original collection.
 func (coll *Collection) Clone(opts ...*options.CollectionOptions) (*Collection, error) {
 	copyColl := coll.copy()
 	optsColl := options.MergeCollectionOptions(opts...)
@@ -144,6 +156,11 @@ func (coll *Collection) Database() *Database {
 
 // BulkWrite performs a bulk write operation.
 //
+// If some of the operations fail, the returned error is a BulkWriteException and the returned
+// result describes the operations that succeeded, keyed by their index in models. For an
+// unordered bulk write, the failed operations need not be contiguous; use the Index of each
+// write error, or BulkWriteException.FailedIndexes, to find them.
+//
 // See https://docs.mongodb.com/manual/core/bulk-write-operations/.
 func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 	opts ...*options.BulkWriteOptions) (*BulkWriteResult, error) {
@@ -155,6 +172,8 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	sess := sessionFromContext(ctx)
 
@@ -184,9 +203,18 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 		opts...,
 	)
 
+	result := &BulkWriteResult{
+		InsertedCount: res.InsertedCount,
+		MatchedCount:  res.MatchedCount,
+		ModifiedCount: res.ModifiedCount,
+		DeletedCount:  res.DeletedCount,
+		UpsertedCount: res.UpsertedCount,
+		UpsertedIDs:   res.UpsertedIDs,
+	}
+
 	if err != nil {
 		if conv, ok := err.(driver.BulkWriteException); ok {
-			return &BulkWriteResult{}, BulkWriteException{
+			return result, BulkWriteException{
 				WriteConcernError: convertWriteConcernError(conv.WriteConcernError),
 				WriteErrors:       convertBulkWriteErrors(conv.WriteErrors),
 			}
@@ -195,14 +223,7 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 		return &BulkWriteResult{}, replaceTopologyErr(err)
 	}
 
-	return &BulkWriteResult{
-		InsertedCount: res.InsertedCount,
-		MatchedCount:  res.MatchedCount,
-		ModifiedCount: res.ModifiedCount,
-		DeletedCount:  res.DeletedCount,
-		UpsertedCount: res.UpsertedCount,
-		UpsertedIDs:   res.UpsertedIDs,
-	}, nil
+	return result, nil
 }
 
 // InsertOne inserts a single document into the collection.
@@ -212,8 +233,10 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
-	doc, insertedID, err := transformAndEnsureID(coll.registry, document)
+	doc, insertedID, err := transformAndEnsureID(coll.registry, document, !coll.client.bypassIDGen)
 	if err != nil {
 		return nil, err
 	}
@@ -260,22 +283,27 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 		return nil, err
 	}
 
-	return &InsertOneResult{InsertedID: insertedID}, err
+	return &InsertOneResult{InsertedID: insertedID, OperationTime: res.OperationTime}, err
 }
 
 // InsertMany inserts the provided documents.
+//
+// If some of the documents fail to insert, the returned error is a BulkWriteException, whose FailedIndexes are
+// the indexes of those documents, and the InsertedIDs of the result include only the documents that were inserted.
 func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 	opts ...*options.InsertManyOptions) (*InsertManyResult, error) {
 
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	result := make([]interface{}, len(documents))
 	docs := make([]bsonx.Doc, len(documents))
 
 	for i, doc := range documents {
-		bdoc, insertedID, err := transformAndEnsureID(coll.registry, doc)
+		bdoc, insertedID, err := transformAndEnsureID(coll.registry, doc, !coll.client.bypassIDGen)
 		if err != nil {
 			return nil, err
 		}
@@ -296,6 +324,11 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 		wc = nil
 	}
 
+	ordered := true
+	if imo := options.MergeInsertManyOptions(opts...); imo.Ordered != nil {
+		ordered = *imo.Ordered
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Insert{
 		NS:           command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
@@ -339,9 +372,31 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 			WriteErrors:       bwErrors,
 			WriteConcernError: convertWriteConcernError(res.WriteConcernError),
 		}
+		result = insertedIDs(result, err.(BulkWriteException).FailedIndexes(), ordered)
 	}
 
-	return &InsertManyResult{InsertedIDs: result}, err
+	return &InsertManyResult{InsertedIDs: result, OperationTime: res.OperationTime}, err
+}
+
+// insertedIDs returns the IDs of the documents that were inserted, given the indexes of the documents that failed.
+// An ordered insert stops at its first failure, so none of the documents from that point on were inserted.
+func insertedIDs(ids []interface{}, failed []int, ordered bool) []interface{} {
+	if len(failed) == 0 {
+		return ids
+	}
+	if ordered {
+		return ids[:failed[0]]
+	}
+
+	inserted := make([]interface{}, 0, len(ids)-len(failed))
+	for i, id := range ids {
+		if len(failed) > 0 && failed[0] == i {
+			failed = failed[1:]
+			continue
+		}
+		inserted = append(inserted, id)
+	}
+	return inserted
 }
 
 // DeleteOne deletes a single document from the collection.
@@ -351,6 +406,8 @@ func (coll *Collection) DeleteOne(ctx context.Context, filter interface{},
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
@@ -398,7 +455,7 @@ func (coll *Collection) DeleteOne(ctx context.Context, filter interface{},
 	if rr&rrOne == 0 {
 		return nil, err
 	}
-	return &DeleteResult{DeletedCount: int64(res.N)}, err
+	return &DeleteResult{DeletedCount: int64(res.N), OperationTime: res.OperationTime}, err
 }
 
 // DeleteMany deletes multiple documents from the collection.
@@ -408,6 +465,8 @@ func (coll *Collection) DeleteMany(ctx context.Context, filter interface{},
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
@@ -450,11 +509,13 @@ func (coll *Collection) DeleteMany(ctx context.Context, filter interface{},
 	if rr&rrMany == 0 {
 		return nil, err
 	}
-	return &DeleteResult{DeletedCount: int64(res.N)}, err
+	return &DeleteResult{DeletedCount: int64(res.N), OperationTime: res.OperationTime}, err
 }
 
+// updateOrReplaceOne runs a single update and reports whether it inserted a new document. The
+// upserted _id alone can't tell, since an upsert may insert a document with a null _id.
 func (coll *Collection) updateOrReplaceOne(ctx context.Context, filter,
-	update bsonx.Doc, sess *session.Client, opts ...*options.UpdateOptions) (*UpdateResult, error) {
+	update bsonx.Doc, sess *session.Client, opts ...*options.UpdateOptions) (*UpdateResult, bool, error) {
 
 	// TODO: should session be taken from ctx or left as argument?
 	if ctx == nil {
@@ -493,52 +554,83 @@ func (coll *Collection) updateOrReplaceOne(ctx context.Context, filter,
 		opts...,
 	)
 	if err != nil && err != command.ErrUnacknowledgedWrite {
-		return nil, replaceTopologyErr(err)
+		return nil, false, replaceTopologyErr(err)
 	}
 
 	res := &UpdateResult{
 		MatchedCount:  r.MatchedCount,
 		ModifiedCount: r.ModifiedCount,
+		OperationTime: r.OperationTime,
 	}
-	if len(r.Upserted) > 0 {
+	upserted := len(r.Upserted) > 0
+	if upserted {
 		res.UpsertedID = r.Upserted[0].ID
 		res.MatchedCount--
 	}
 
 	rr, err := processWriteError(r.WriteConcernError, r.WriteErrors, err)
 	if rr&rrOne == 0 {
-		return nil, err
+		return nil, false, err
 	}
-	return res, err
+	return res, upserted, err
 }
 
 // UpdateOne updates a single document in the collection.
 func (coll *Collection) UpdateOne(ctx context.Context, filter interface{}, update interface{},
 	opts ...*options.UpdateOptions) (*UpdateResult, error) {
 
+	res, _, err := coll.updateOne(ctx, filter, update, opts...)
+	return res, err
+}
+
+// UpsertOne updates a single document in the collection, inserting a new document if none matches the
+// filter. It is UpdateOne with the Upsert option set to true, and returns an UpsertResult that reports
+// whether a document was inserted. Setting Upsert to false in opts has no effect.
+func (coll *Collection) UpsertOne(ctx context.Context, filter interface{}, update interface{},
+	opts ...*options.UpdateOptions) (*UpsertResult, error) {
+
+	opts = append(opts, options.Update().SetUpsert(true))
+	res, upserted, err := coll.updateOne(ctx, filter, update, opts...)
+	if res == nil {
+		return nil, err
+	}
+	return &UpsertResult{
+		MatchedCount:  res.MatchedCount,
+		ModifiedCount: res.ModifiedCount,
+		UpsertedID:    res.UpsertedID,
+		WasInsert:     upserted,
+		OperationTime: res.OperationTime,
+	}, err
+}
+
+func (coll *Collection) updateOne(ctx context.Context, filter interface{}, update interface{},
+	opts ...*options.UpdateOptions) (*UpdateResult, bool, error) {
+
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	u, err := transformDocument(coll.registry, update)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	if err := ensureDollarKey(u); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	sess := sessionFromContext(ctx)
 
 	err = coll.client.ValidSession(sess)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	return coll.updateOrReplaceOne(ctx, f, u, sess, opts...)
@@ -551,6 +643,8 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
@@ -610,6 +704,7 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 	res := &UpdateResult{
 		MatchedCount:  r.MatchedCount,
 		ModifiedCount: r.ModifiedCount,
+		OperationTime: r.OperationTime,
 	}
 	// TODO(skriptble): Is this correct? Do we only return the first upserted ID for an UpdateMany?
 	if len(r.Upserted) > 0 {
@@ -631,6 +726,8 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
@@ -662,11 +759,15 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 		updateOptions = append(updateOptions, uOpts)
 	}
 
-	return coll.updateOrReplaceOne(ctx, f, r, sess, updateOptions...)
+	res, _, err := coll.updateOrReplaceOne(ctx, f, r, sess, updateOptions...)
+	return res, err
 }
 
 // Aggregate runs an aggregation framework pipeline.
 //
+// A pipeline that ends with a $out or $merge stage writes to a collection, so it always runs on the primary with
+// the collection's write concern, regardless of the collection's read preference.
+//
 // See https://docs.mongodb.com/manual/aggregation/.
 func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 	opts ...*options.AggregateOptions) (Cursor, error) {
@@ -674,6 +775,8 @@ func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	pipelineArr, err := transformAggregatePipeline(coll.registry, pipeline)
 	if err != nil {
@@ -681,6 +784,9 @@ func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 	}
 
 	aggOpts := options.MergeAggregateOptions(opts...)
+	if coll.client.ignoresMaxTime() {
+		aggOpts.MaxTime = nil
+	}
 
 	sess := sessionFromContext(ctx)
 
@@ -710,6 +816,8 @@ func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 		Clock:        coll.client.clock,
 	}
 
+	cmd.ReadConcern = readConcernAfter(cmd.ReadConcern, sess, aggOpts.ReadAfter)
+
 	cursor, err := driver.Aggregate(
 		ctx, cmd,
 		coll.client.topology,
@@ -724,6 +832,73 @@ func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 	return cursor, replaceTopologyErr(err)
 }
 
+// AggregateExplain runs the aggregation pipeline with the explain command and returns the server's
+// description of how it executed the pipeline instead of its results. The explain uses the
+// executionStats verbosity, so the query stages of the pipeline are run to collect statistics
+// such as the number of index keys examined. The returned result always holds the raw explain
+// document, since its format differs between server versions and topologies, along with the
+// fields that could be parsed from it.
+//
+// The BatchSize, BypassDocumentValidation, and MaxAwaitTime options do not apply to an explain
+// and are ignored.
+func (coll *Collection) AggregateExplain(ctx context.Context, pipeline interface{},
+	opts ...*options.AggregateOptions) (*AggregateExplainResult, error) {
+
+	pipelineArr, err := transformAggregatePipeline(coll.registry, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	agg := bsonx.Doc{
+		{"aggregate", bsonx.String(coll.name)},
+		{"pipeline", bsonx.Array(pipelineArr)},
+		{"cursor", bsonx.Document(bsonx.Doc{})},
+	}
+	cmd := bsonx.Doc{{"verbosity", bsonx.String("executionStats")}}
+
+	aggOpts := options.MergeAggregateOptions(opts...)
+	if coll.client.ignoresMaxTime() {
+		aggOpts.MaxTime = nil
+	}
+	if aggOpts.AllowDiskUse != nil {
+		agg = append(agg, bsonx.Elem{"allowDiskUse", bsonx.Boolean(*aggOpts.AllowDiskUse)})
+	}
+	if aggOpts.Collation != nil {
+		agg = append(agg, bsonx.Elem{"collation", bsonx.Document(aggOpts.Collation.ToDocument())})
+	}
+	if aggOpts.MaxTime != nil {
+		cmd = append(cmd, bsonx.Elem{"maxTimeMS", bsonx.Int64(int64(*aggOpts.MaxTime / time.Millisecond))})
+	}
+	if aggOpts.Comment != nil {
+		comment, err := transformValue(coll.registry, aggOpts.Comment)
+		if err != nil {
+			return nil, err
+		}
+		agg = append(agg, bsonx.Elem{"comment", comment})
+	}
+	if aggOpts.Hint != nil {
+		switch hint := aggOpts.Hint.(type) {
+		case string:
+			agg = append(agg, bsonx.Elem{"hint", bsonx.String(hint)})
+		default:
+			hintDoc, err := transformDocument(coll.registry, hint)
+			if err != nil {
+				return nil, err
+			}
+			agg = append(agg, bsonx.Elem{"hint", bsonx.Document(hintDoc)})
+		}
+	}
+
+	cmd = cmd.Prepend("explain", bsonx.Document(agg))
+
+	raw, err := coll.db.RunCommand(ctx, cmd, options.RunCmd().SetReadPreference(coll.readPreference)).DecodeBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return newAggregateExplainResult(raw), nil
+}
+
 // Count gets the number of documents matching the filter.
 func (coll *Collection) Count(ctx context.Context, filter interface{},
 	opts ...*options.CountOptions) (int64, error) {
@@ -731,6 +906,8 @@ func (coll *Collection) Count(ctx context.Context, filter interface{},
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
@@ -759,6 +936,13 @@ func (coll *Collection) Count(ctx context.Context, filter interface{},
 		Clock:       coll.client.clock,
 	}
 
+	countOpts := options.MergeCountOptions(opts...)
+	if coll.client.ignoresMaxTime() {
+		countOpts.MaxTime = nil
+	}
+
+	cmd.ReadConcern = readConcernAfter(cmd.ReadConcern, sess, countOpts.ReadAfter)
+
 	count, err := driver.Count(
 		ctx, cmd,
 		coll.client.topology,
@@ -766,7 +950,7 @@ func (coll *Collection) Count(ctx context.Context, filter interface{},
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.registry,
-		opts...,
+		countOpts,
 	)
 
 	return count, replaceTopologyErr(err)
@@ -779,8 +963,13 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	countOpts := options.MergeCountOptions(opts...)
+	if coll.client.ignoresMaxTime() {
+		countOpts.MaxTime = nil
+	}
 
 	pipelineArr, err := countDocumentsAggregatePipeline(coll.registry, filter, countOpts)
 	if err != nil {
@@ -809,6 +998,8 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 		Clock:       coll.client.clock,
 	}
 
+	cmd.ReadConcern = readConcernAfter(cmd.ReadConcern, sess, countOpts.ReadAfter)
+
 	count, err := driver.CountDocuments(
 		ctx, cmd,
 		coll.client.topology,
@@ -823,12 +1014,15 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 }
 
 // EstimatedDocumentCount gets an estimate of the count of documents in a collection using collection metadata.
+// It returns ErrEstimatedCountInTransaction if the session in ctx has a transaction running.
 func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	opts ...*options.EstimatedDocumentCountOptions) (int64, error) {
 
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	sess := sessionFromContext(ctx)
 
@@ -836,6 +1030,9 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	if err != nil {
 		return 0, err
 	}
+	if sess != nil && sess.TransactionRunning() {
+		return 0, ErrEstimatedCountInTransaction
+	}
 
 	rc := coll.readConcern
 	if sess != nil && (sess.TransactionInProgress()) {
@@ -853,8 +1050,8 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	}
 
 	countOpts := options.Count()
-	if len(opts) >= 1 {
-		countOpts = countOpts.SetMaxTime(*opts[len(opts)-1].MaxTime)
+	if maxTime := options.MergeEstimatedDocumentCountOptions(opts...).MaxTime; maxTime != nil && !coll.client.ignoresMaxTime() {
+		countOpts = countOpts.SetMaxTime(*maxTime)
 	}
 
 	count, err := driver.Count(
@@ -878,6 +1075,8 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	var f bsonx.Doc
 	var err error
@@ -911,13 +1110,20 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 		Clock:       coll.client.clock,
 	}
 
+	distinctOpts := options.MergeDistinctOptions(opts...)
+	if coll.client.ignoresMaxTime() {
+		distinctOpts.MaxTime = nil
+	}
+
+	cmd.ReadConcern = readConcernAfter(cmd.ReadConcern, sess, distinctOpts.ReadAfter)
+
 	res, err := driver.Distinct(
 		ctx, cmd,
 		coll.client.topology,
 		coll.readSelector,
 		coll.client.id,
 		coll.client.topology.SessionPool,
-		opts...,
+		distinctOpts,
 	)
 	if err != nil {
 		return nil, replaceTopologyErr(err)
@@ -933,6 +1139,8 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	var f bsonx.Doc
 	var err error
@@ -943,6 +1151,12 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 		}
 	}
 
+	if coll.client.connString.LoadBalanced {
+		if fo := options.MergeFindOptions(opts...); fo.Exhaust != nil && *fo.Exhaust {
+			return nil, ErrExhaustLoadBalanced
+		}
+	}
+
 	sess := sessionFromContext(ctx)
 
 	err = coll.client.ValidSession(sess)
@@ -965,6 +1179,13 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 		Clock:       coll.client.clock,
 	}
 
+	findOpts := options.MergeFindOptions(opts...)
+	if coll.client.ignoresMaxTime() {
+		findOpts.MaxTime = nil
+	}
+
+	cmd.ReadConcern = readConcernAfter(cmd.ReadConcern, sess, findOpts.ReadAfter)
+
 	cursor, err := driver.Find(
 		ctx, cmd,
 		coll.client.topology,
@@ -972,7 +1193,7 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.registry,
-		opts...,
+		findOpts,
 	)
 
 	return cursor, replaceTopologyErr(err)
@@ -985,6 +1206,8 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	var f bsonx.Doc
 	var err error
@@ -1028,6 +1251,7 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 			Hint:                opt.Hint,
 			Max:                 opt.Max,
 			MaxAwaitTime:        opt.MaxAwaitTime,
+			MaxTime:             opt.MaxTime,
 			Min:                 opt.Min,
 			NoCursorTimeout:     opt.NoCursorTimeout,
 			OplogReplay:         opt.OplogReplay,
@@ -1038,8 +1262,13 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 			Snapshot:            opt.Snapshot,
 			Sort:                opt.Sort,
 		}
+		if coll.client.ignoresMaxTime() {
+			findOpts[i].MaxTime = nil
+		}
 	}
 
+	cmd.ReadConcern = readConcernAfter(cmd.ReadConcern, sess, options.MergeFindOneOptions(opts...).ReadAfter)
+
 	cursor, err := driver.Find(
 		ctx, cmd,
 		coll.client.topology,
@@ -1064,6 +1293,8 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	var f bsonx.Doc
 	var err error
@@ -1095,6 +1326,11 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 		Clock:        coll.client.clock,
 	}
 
+	fo := options.MergeFindOneAndDeleteOptions(opts...)
+	if coll.client.ignoresMaxTime() {
+		fo.MaxTime = nil
+	}
+
 	res, err := driver.FindOneAndDelete(
 		ctx, cmd,
 		coll.client.topology,
@@ -1103,7 +1339,7 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 		coll.client.topology.SessionPool,
 		coll.client.retryWrites,
 		coll.registry,
-		opts...,
+		fo,
 	)
 	if err != nil {
 		return &SingleResult{err: replaceTopologyErr(err)}
@@ -1120,6 +1356,8 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
@@ -1157,6 +1395,11 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 		Clock:        coll.client.clock,
 	}
 
+	fo := options.MergeFindOneAndReplaceOptions(opts...)
+	if coll.client.ignoresMaxTime() {
+		fo.MaxTime = nil
+	}
+
 	res, err := driver.FindOneAndReplace(
 		ctx, cmd,
 		coll.client.topology,
@@ -1165,7 +1408,7 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 		coll.client.topology.SessionPool,
 		coll.client.retryWrites,
 		coll.registry,
-		opts...,
+		fo,
 	)
 	if err != nil {
 		return &SingleResult{err: replaceTopologyErr(err)}
@@ -1182,26 +1425,133 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
-	f, err := transformDocument(coll.registry, filter)
+	res, err := coll.findOneAndUpdate(ctx, filter, update, opts...)
 	if err != nil {
 		return &SingleResult{err: err}
 	}
 
+	return &SingleResult{rdr: res.Value, reg: coll.registry}
+}
+
+// FindOneAndUpdateImages finds a single document and updates it like FindOneAndUpdate, returning both the
+// original and the updated document. The ReturnDocument option is ignored. If a projection is given it is
+// applied to both documents and must include the _id field, otherwise ErrProjectionExcludesID is returned
+// without running the update.
+//
+// The update is atomic and the original document is exactly the document it was applied to, but MongoDB returns
+// only one document from a findAndModify command, so the updated document is read by _id with a second command. Outside of a
+// transaction another write can occur between the two commands, in which case the updated document will
+// reflect that write as well, or the result will return ErrNoDocuments for it if the document was deleted.
+// When ctx is a SessionContext with a transaction in progress both commands run in the transaction, so the
+// documents are exactly the state before and after the update.
+func (coll *Collection) FindOneAndUpdateImages(ctx context.Context, filter interface{},
+	update interface{}, opts ...*options.FindOneAndUpdateOptions) *UpdateImagesResult {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
+
+	fo := options.MergeFindOneAndUpdateOptions(opts...)
+	fo.SetReturnDocument(options.Before)
+
+	// the updated document is found by _id, so reject projections without it before anything is modified
+	if fo.Projection != nil {
+		proj, err := transformDocument(coll.registry, fo.Projection)
+		if err != nil {
+			return &UpdateImagesResult{err: err}
+		}
+		if !projectionIncludesID(proj) {
+			return &UpdateImagesResult{err: ErrProjectionExcludesID}
+		}
+	}
+
+	res, err := coll.findOneAndUpdate(ctx, filter, update, fo)
+	if err != nil {
+		return &UpdateImagesResult{err: err}
+	}
+
+	var id interface{}
+	switch {
+	case res.Value != nil:
+		idVal, err := res.Value.LookupErr("_id")
+		if err != nil {
+			return &UpdateImagesResult{err: ErrProjectionExcludesID}
+		}
+		id = idVal
+	case res.LastErrorObject.Upserted != nil:
+		id = res.LastErrorObject.Upserted
+	default:
+		return &UpdateImagesResult{err: ErrNoDocuments}
+	}
+
+	// the updated document must be read from the primary that applied the update
+	primary, err := coll.Clone(options.Collection().SetReadPreference(readpref.Primary()))
+	if err != nil {
+		return &UpdateImagesResult{err: err}
+	}
+	findOpts := options.FindOne()
+	if fo.Projection != nil {
+		findOpts.SetProjection(fo.Projection)
+	}
+	after, err := primary.FindOne(ctx, bson.D{{"_id", id}}, findOpts).DecodeBytes()
+	if err != nil && err != ErrNoDocuments {
+		return &UpdateImagesResult{err: err}
+	}
+
+	return &UpdateImagesResult{before: res.Value, after: after, reg: coll.registry}
+}
+
+// projectionIncludesID reports whether a projection returns the _id field. _id is returned unless the projection
+// excludes it or replaces it with an expression.
+func projectionIncludesID(proj bsonx.Doc) bool {
+	val, err := proj.LookupErr("_id")
+	if err != nil {
+		return true
+	}
+
+	switch val.Type() {
+	case bsontype.Boolean:
+		return val.Boolean()
+	case bsontype.Int32:
+		return val.Int32() != 0
+	case bsontype.Int64:
+		return val.Int64() != 0
+	case bsontype.Double:
+		return val.Double() != 0
+	default:
+		return false
+	}
+}
+
+// findOneAndUpdate runs a findAndModify command that updates a single document. The caller is responsible for
+// applying the client timeout to ctx.
+func (coll *Collection) findOneAndUpdate(ctx context.Context, filter interface{},
+	update interface{}, opts ...*options.FindOneAndUpdateOptions) (result.FindAndModify, error) {
+
+	f, err := transformDocument(coll.registry, filter)
+	if err != nil {
+		return result.FindAndModify{}, err
+	}
+
 	u, err := transformDocument(coll.registry, update)
 	if err != nil {
-		return &SingleResult{err: err}
+		return result.FindAndModify{}, err
 	}
 
 	if len(u) > 0 && !strings.HasPrefix(u[0].Key, "$") {
-		return &SingleResult{err: errors.New("update document must contain key beginning with '$")}
+		return result.FindAndModify{}, errors.New("update document must contain key beginning with '$")
 	}
 
 	sess := sessionFromContext(ctx)
 
 	err = coll.client.ValidSession(sess)
 	if err != nil {
-		return &SingleResult{err: err}
+		return result.FindAndModify{}, err
 	}
 
 	wc := coll.writeConcern
@@ -1219,6 +1569,11 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 		Clock:        coll.client.clock,
 	}
 
+	fo := options.MergeFindOneAndUpdateOptions(opts...)
+	if coll.client.ignoresMaxTime() {
+		fo.MaxTime = nil
+	}
+
 	res, err := driver.FindOneAndUpdate(
 		ctx, cmd,
 		coll.client.topology,
@@ -1227,13 +1582,9 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 		coll.client.topology.SessionPool,
 		coll.client.retryWrites,
 		coll.registry,
-		opts...,
+		fo,
 	)
-	if err != nil {
-		return &SingleResult{err: replaceTopologyErr(err)}
-	}
-
-	return &SingleResult{rdr: res.Value, reg: coll.registry}
+	return res, replaceTopologyErr(err)
 }
 
 // Watch returns a change stream cursor used to receive notifications of changes to the collection.
@@ -1242,7 +1593,7 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 // supports resumability in the case of some errors. The collection must have read concern majority or no read concern
 // for a change stream to be created successfully.
 func (coll *Collection) Watch(ctx context.Context, pipeline interface{},
-	opts ...*options.ChangeStreamOptions) (Cursor, error) {
+	opts ...*options.ChangeStreamOptions) (ChangeStream, error) {
 	return newChangeStream(ctx, coll, pipeline, opts...)
 }
 
@@ -1256,6 +1607,8 @@ func (coll *Collection) Drop(ctx context.Context) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	sess := sessionFromContext(ctx)
 
@@ -1288,3 +1641,85 @@ func (coll *Collection) Drop(ctx context.Context) error {
 	}
 	return nil
 }
+
+// Rename renames the collection to newName in the same database with the renameCollection command, and returns a
+// Collection for newName with the options of this one. If a collection named newName already exists, the server
+// returns a command.Error with code 48 (NamespaceExists) unless the DropTarget option is set, in which case it is
+// dropped first.
+func (coll *Collection) Rename(ctx context.Context, newName string, opts ...*options.RenameOptions) (*Collection, error) {
+	if err := validateCollectionName(newName); err != nil {
+		return nil, err
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
+
+	sess := sessionFromContext(ctx)
+
+	err := coll.client.ValidSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	wc := coll.writeConcern
+	if sess != nil && sess.TransactionRunning() {
+		wc = nil
+	}
+
+	renameOpts := options.MergeRenameOptions(opts...)
+	cmd := bsonx.Doc{
+		{"renameCollection", bsonx.String(coll.db.name + "." + coll.name)},
+		{"to", bsonx.String(coll.db.name + "." + newName)},
+	}
+	if renameOpts.DropTarget != nil {
+		cmd = append(cmd, bsonx.Elem{"dropTarget", bsonx.Boolean(*renameOpts.DropTarget)})
+	}
+
+	_, err = driver.Write(
+		ctx,
+		command.Write{
+			DB:           "admin",
+			Command:      cmd,
+			WriteConcern: wc,
+			Session:      sess,
+			Clock:        coll.client.clock,
+		},
+		coll.client.topology,
+		coll.writeSelector,
+		coll.client.id,
+		coll.client.topology.SessionPool,
+	)
+	if err != nil {
+		return nil, replaceTopologyErr(err)
+	}
+
+	renamed := coll.copy()
+	renamed.name = newName
+	return renamed, nil
+}
+
+// validateCollectionName reports names the server would reject as a collection name.
+func validateCollectionName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("collection name cannot be empty")
+	case strings.ContainsAny(name, "$\x00"):
+		return fmt.Errorf("collection name %q cannot contain '$' or a null byte", name)
+	case strings.HasPrefix(name, "system."):
+		return fmt.Errorf("collection name %q cannot begin with 'system.'", name)
+	}
+	return nil
+}
+
+// readConcernAfter returns rc with its afterClusterTime set to opTime, so the read observes the write that returned
+// that operation time. rc is returned unchanged if opTime is nil or the read is part of a transaction, whose read
+// concern is fixed when the transaction starts.
+func readConcernAfter(rc *readconcern.ReadConcern, sess *session.Client, opTime *primitive.Timestamp) *readconcern.ReadConcern {
+	if opTime == nil || sess != nil && sess.TransactionInProgress() {
+		return rc
+	}
+	return rc.WithOptions(readconcern.AfterClusterTime(*opTime))
+}
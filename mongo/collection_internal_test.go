@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/mongodb/mongo-go-driver/mongo/options"
@@ -18,12 +19,15 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/mongodb/mongo-go-driver/event"
 	"github.com/mongodb/mongo-go-driver/internal/testutil"
 	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver"
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/session"
 	"github.com/mongodb/mongo-go-driver/x/network/command"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
 	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -142,6 +146,106 @@ func TestCollection_InheritOptions(t *testing.T) {
 	}
 }
 
+func TestCollection_OptionsPrecedence(t *testing.T) {
+	// each level is identified by its read concern, write concern and read preference
+	rcs := []*readconcern.ReadConcern{readconcern.Local(), readconcern.Majority(), readconcern.Linearizable(),
+		readconcern.Available()}
+	wcs := []*writeconcern.WriteConcern{writeconcern.New(writeconcern.W(1)), writeconcern.New(writeconcern.W(2)),
+		writeconcern.New(writeconcern.W(3)), writeconcern.New(writeconcern.W(4))}
+	rps := []*readpref.ReadPref{readpref.Primary(), readpref.PrimaryPreferred(), readpref.Secondary(),
+		readpref.Nearest()}
+	const clientLevel, dbLevel, collLevel, cloneLevel = 0, 1, 2, 3
+
+	client, err := NewClientWithOptions("mongodb://localhost", options.Client().
+		SetReadConcern(rcs[clientLevel]).SetWriteConcern(wcs[clientLevel]).SetReadPreference(rps[clientLevel]))
+	require.NoError(t, err)
+
+	// every combination of the database, collection and clone levels setting or not setting their options
+	for set := 0; set < 8; set++ {
+		setDb, setColl, setClone := set&1 != 0, set&2 != 0, set&4 != 0
+		expected := clientLevel
+		dbOpts := options.Database()
+		if setDb {
+			expected = dbLevel
+			dbOpts.SetReadConcern(rcs[dbLevel]).SetWriteConcern(wcs[dbLevel]).SetReadPreference(rps[dbLevel])
+		}
+		collOpts := options.Collection()
+		if setColl {
+			expected = collLevel
+			collOpts.SetReadConcern(rcs[collLevel]).SetWriteConcern(wcs[collLevel]).SetReadPreference(rps[collLevel])
+		}
+		cloneOpts := options.Collection()
+		if setClone {
+			expected = cloneLevel
+			cloneOpts.SetReadConcern(rcs[cloneLevel]).SetWriteConcern(wcs[cloneLevel]).SetReadPreference(rps[cloneLevel])
+		}
+
+		name := fmt.Sprintf("database %v collection %v clone %v", setDb, setColl, setClone)
+		t.Run(name, func(t *testing.T) {
+			coll, err := client.Database("foo", dbOpts).Collection("bar", collOpts).Clone(cloneOpts)
+			require.NoError(t, err)
+			require.True(t, coll.readConcern == rcs[expected], "expected read concern %#v, got %#v",
+				rcs[expected], coll.readConcern)
+			require.True(t, coll.writeConcern == wcs[expected], "expected write concern %#v, got %#v",
+				wcs[expected], coll.writeConcern)
+			require.True(t, coll.readPreference == rps[expected], "expected read preference %#v, got %#v",
+				rps[expected], coll.readPreference)
+		})
+	}
+}
+
+func TestCollection_TransactionReadConcernPrecedence(t *testing.T) {
+	client, err := NewClientWithOptions("mongodb://localhost", options.Client().SetReadConcern(readconcern.Local()))
+	require.NoError(t, err)
+	client.topology.SessionPool = session.NewPool(nil)
+	coll := client.Database("foo").Collection("bar", options.Collection().SetReadConcern(readconcern.Majority()))
+
+	desc := description.SelectedServer{
+		Server: description.Server{Kind: description.RSPrimary, WireVersion: &description.VersionRange{Max: 7}},
+		Kind:   description.ReplicaSetWithPrimary,
+	}
+
+	testCases := []struct {
+		name     string
+		sessOpts *options.SessionOptions
+		txnOpts  *options.TransactionOptions
+		expected string
+	}{
+		{"no transaction", nil, nil, "majority"},
+		{"client default", options.Session(), options.Transaction(), "local"},
+		{"session default", options.Session().SetDefaultReadConcern(readconcern.Snapshot()), options.Transaction(),
+			"snapshot"},
+		{"transaction", options.Session().SetDefaultReadConcern(readconcern.Snapshot()),
+			options.Transaction().SetReadConcern(readconcern.Available()), "available"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sess, err := client.StartSession(tc.sessOpts)
+			require.NoError(t, err)
+			defer sess.EndSession(context.Background())
+			if tc.txnOpts != nil {
+				require.NoError(t, sess.StartTransaction(tc.txnOpts))
+			}
+
+			// operations send the read concern of the collection and leave it to the command to apply the
+			// read concern of the transaction
+			cmd := command.Read{
+				DB:          "foo",
+				Command:     bsonx.Doc{{"find", bsonx.String("bar")}},
+				ReadConcern: coll.readConcern,
+				Session:     sess.(*sessionImpl).Client,
+			}
+			wm, err := cmd.Encode(desc)
+			require.NoError(t, err)
+			msg := wm.(wiremessage.Msg)
+			doc, err := msg.GetMainDocument()
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, doc.Lookup("readConcern", "level").StringValue())
+		})
+	}
+}
+
 func TestCollection_ReplaceTopologyError(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -275,6 +379,59 @@ func TestCollection_InsertOne(t *testing.T) {
 
 }
 
+func TestCollection_Insert_bypassClientIDGeneration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	var inserted []bsonx.Doc
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			if cse.CommandName != "insert" {
+				return
+			}
+			docs, err := cse.Command.LookupErr("documents")
+			if err != nil {
+				return
+			}
+			for _, val := range docs.Array() {
+				inserted = append(inserted, val.Document())
+			}
+		},
+	}
+	cs := testutil.ConnString(t)
+	client, err := NewClientWithOptions(cs.String(),
+		options.Client().SetMonitor(monitor).SetBypassClientIDGeneration(true))
+	require.NoError(t, err)
+	require.NoError(t, client.Connect(ctx))
+	defer func() { _ = client.Disconnect(ctx) }()
+
+	coll := client.Database(testutil.DBName(t)).Collection(testutil.ColName(t))
+	defer func() { _ = coll.Drop(ctx) }()
+
+	oneRes, err := coll.InsertOne(ctx, bsonx.Doc{{"x", bsonx.Int32(1)}})
+	require.NoError(t, err)
+	require.Nil(t, oneRes.InsertedID)
+
+	manyRes, err := coll.InsertMany(ctx, []interface{}{
+		bsonx.Doc{{"x", bsonx.Int32(2)}},
+		bsonx.Doc{{"_id", bsonx.Int32(3)}, {"x", bsonx.Int32(3)}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{nil, int32(3)}, manyRes.InsertedIDs)
+
+	require.Len(t, inserted, 3)
+	for _, doc := range inserted[:2] {
+		_, err := doc.LookupErr("_id")
+		require.Error(t, err, "expected no _id to be sent but got %v", doc)
+	}
+
+	// the server generates the _id instead
+	count, err := coll.CountDocuments(ctx, bsonx.Doc{{"_id", bsonx.Document(bsonx.Doc{{"$exists", bsonx.Boolean(true)}})}})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
+
 func TestCollection_InsertOne_WriteError(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -475,6 +632,64 @@ func TestCollection_InsertMany_ErrorCases(t *testing.T) {
 
 }
 
+func TestCollection_InsertMany_unorderedDuplicateKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	coll := createTestCollection(t, nil, nil)
+	_, err := coll.InsertOne(context.Background(), bsonx.Doc{{"_id", bsonx.Int32(1)}})
+	require.NoError(t, err)
+
+	docs := []interface{}{
+		bsonx.Doc{{"x", bsonx.Int32(1)}},
+		bsonx.Doc{{"x", bsonx.Int32(2)}},
+		bsonx.Doc{{"_id", bsonx.Int32(1)}},
+		bsonx.Doc{{"x", bsonx.Int32(3)}},
+	}
+
+	res, err := coll.InsertMany(context.Background(), docs, options.InsertMany().SetOrdered(false))
+	bwe, ok := err.(BulkWriteException)
+	require.True(t, ok, "expected a BulkWriteException but got %T: %v", err, err)
+	require.Equal(t, []int{2}, bwe.FailedIndexes())
+	require.Equal(t, 11000, bwe.WriteErrors[0].Code)
+
+	require.Len(t, res.InsertedIDs, 3)
+	for _, id := range res.InsertedIDs {
+		_, isObjectID := id.(primitive.ObjectID)
+		require.True(t, isObjectID, "expected a generated ObjectID but got %v", id)
+	}
+	count, err := coll.CountDocuments(context.Background(), bsonx.Doc{{"_id", bsonx.Document(bsonx.Doc{
+		{"$in", bsonx.Array(bsonx.Arr{
+			bsonx.ObjectID(res.InsertedIDs[0].(primitive.ObjectID)),
+			bsonx.ObjectID(res.InsertedIDs[1].(primitive.ObjectID)),
+			bsonx.ObjectID(res.InsertedIDs[2].(primitive.ObjectID)),
+		})},
+	})}})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	// An ordered insert stops at the duplicate.
+	docs[0] = bsonx.Doc{{"x", bsonx.Int32(4)}}
+	docs[1] = bsonx.Doc{{"x", bsonx.Int32(5)}}
+	docs[3] = bsonx.Doc{{"x", bsonx.Int32(6)}}
+	res, err = coll.InsertMany(context.Background(), docs)
+	bwe, ok = err.(BulkWriteException)
+	require.True(t, ok, "expected a BulkWriteException but got %T: %v", err, err)
+	require.Equal(t, []int{2}, bwe.FailedIndexes())
+	require.Len(t, res.InsertedIDs, 2)
+}
+
+func TestInsertedIDs(t *testing.T) {
+	ids := []interface{}{0, 1, 2, 3, 4}
+
+	require.Equal(t, ids, insertedIDs(ids, nil, false))
+	require.Equal(t, []interface{}{0, 2, 4}, insertedIDs(ids, []int{1, 3}, false))
+	require.Equal(t, []interface{}{1, 2, 3}, insertedIDs(ids, []int{0, 4}, false))
+	require.Equal(t, []interface{}{0}, insertedIDs(ids, []int{1}, true))
+	require.Empty(t, insertedIDs(ids, []int{0}, true))
+}
+
 func TestCollection_InsertMany_WriteConcernError(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -503,6 +718,72 @@ func TestCollection_InsertMany_WriteConcernError(t *testing.T) {
 	}
 }
 
+func TestCollection_BulkWrite_unorderedWriteErrors(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	coll := createTestCollection(t, nil, nil)
+	_, err := coll.InsertOne(context.Background(), bsonx.Doc{{"_id", bsonx.Int32(1)}})
+	require.NoError(t, err)
+
+	models := []WriteModel{
+		NewInsertOneModel().Document(bsonx.Doc{{"_id", bsonx.Int32(1)}}),
+		NewUpdateOneModel().Filter(bsonx.Doc{{"_id", bsonx.Int32(2)}}).
+			Update(bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(1)}})}}).Upsert(true),
+		NewInsertOneModel().Document(bsonx.Doc{{"_id", bsonx.Int32(3)}}),
+		NewInsertOneModel().Document(bsonx.Doc{{"_id", bsonx.Int32(1)}}),
+	}
+
+	res, err := coll.BulkWrite(context.Background(), models, options.BulkWrite().SetOrdered(false))
+	bwe, ok := err.(BulkWriteException)
+	if !ok {
+		t.Fatalf("Did not receive correct type of error. got %T; want %T", err, BulkWriteException{})
+	}
+	require.Equal(t, []int{0, 3}, bwe.FailedIndexes())
+	require.Equal(t, models[3], bwe.WriteErrors[1].Request)
+	require.Equal(t, int64(1), res.InsertedCount)
+	require.Equal(t, int64(1), res.UpsertedCount)
+	require.Contains(t, res.UpsertedIDs, int64(1))
+}
+
+func TestCollection_BulkWrite_mixedCollations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	coll := createTestCollection(t, nil, nil)
+	skipIfBelow34(t, coll.db)
+	_, err := coll.InsertMany(context.Background(), []interface{}{
+		bsonx.Doc{{"name", bsonx.String("ALICE")}, {"n", bsonx.Int32(1)}},
+		bsonx.Doc{{"name", bsonx.String("BOB")}, {"n", bsonx.Int32(2)}},
+		bsonx.Doc{{"name", bsonx.String("CAROL")}, {"n", bsonx.Int32(3)}},
+	})
+	require.NoError(t, err)
+
+	caseInsensitive := &options.Collation{Locale: "en", Strength: 2}
+	set := bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"seen", bsonx.Boolean(true)}})}}
+	models := []WriteModel{
+		NewUpdateOneModel().Filter(bsonx.Doc{{"name", bsonx.String("alice")}}).Update(set).Collation(caseInsensitive),
+		NewUpdateOneModel().Filter(bsonx.Doc{{"name", bsonx.String("bob")}}).Update(set),
+		NewDeleteOneModel().Filter(bsonx.Doc{{"name", bsonx.String("carol")}}).Collation(caseInsensitive),
+		NewDeleteManyModel().Filter(bsonx.Doc{{"name", bsonx.String("bob")}}),
+	}
+
+	res, err := coll.BulkWrite(context.Background(), models)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), res.MatchedCount)
+	require.Equal(t, int64(1), res.ModifiedCount)
+	require.Equal(t, int64(1), res.DeletedCount)
+
+	count, err := coll.CountDocuments(context.Background(), bsonx.Doc{{"seen", bsonx.Boolean(true)}})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+	count, err = coll.CountDocuments(context.Background(), bsonx.Doc{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
 func TestCollection_DeleteOne_found(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -785,6 +1066,50 @@ func TestCollection_UpdateOne_upsert(t *testing.T) {
 
 }
 
+func TestCollection_UpsertOne_update(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+
+	filter := bsonx.Doc{{"x", bsonx.Int32(1)}}
+	update := bsonx.Doc{{"$inc", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(1)}})}}
+
+	result, err := coll.UpsertOne(context.Background(), filter, update)
+	require.Nil(t, err)
+	require.Equal(t, &UpsertResult{MatchedCount: 1, ModifiedCount: 1}, result)
+}
+
+func TestCollection_UpsertOne_insert(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+
+	filter := bsonx.Doc{{"x", bsonx.Int32(0)}}
+	update := bsonx.Doc{{"$inc", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(1)}})}}
+
+	// Upsert set to false is overridden
+	result, err := coll.UpsertOne(context.Background(), filter, update, options.Update().SetUpsert(false))
+	require.Nil(t, err)
+	require.Equal(t, int64(0), result.MatchedCount)
+	require.Equal(t, int64(0), result.ModifiedCount)
+	require.True(t, result.WasInsert)
+	_, ok := result.UpsertedID.(primitive.ObjectID)
+	require.True(t, ok, "expected an ObjectID, got %T", result.UpsertedID)
+
+	// an upsert inserting a null _id is still reported as an insert
+	filter = bsonx.Doc{{"_id", bsonx.Null()}}
+	result, err = coll.UpsertOne(context.Background(), filter, update)
+	require.Nil(t, err)
+	require.True(t, result.WasInsert)
+	require.Nil(t, result.UpsertedID)
+}
+
 func TestCollection_UpdateOne_WriteError(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -1087,6 +1412,8 @@ func TestCollection_Aggregate(t *testing.T) {
 
 	coll := createTestCollection(t, nil, nil)
 	initCollection(t, coll)
+	_, err := coll.Indexes().CreateOne(context.Background(), IndexModel{Keys: bsonx.Doc{{"x", bsonx.Int32(1)}}})
+	require.NoError(t, err)
 
 	pipeline := bsonx.Arr{
 		bsonx.Document(
@@ -1182,6 +1509,32 @@ func testAggregateWithOptions(t *testing.T, createIndex bool, opts *options.Aggr
 	return nil
 }
 
+func TestCollection_AggregateExplain(t *testing.T) {
+	skipIfBelow36(t)
+
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+
+	pipeline := bsonx.Arr{
+		bsonx.Document(
+			bsonx.Doc{{"$match", bsonx.Document(bsonx.Doc{{"x", bsonx.Document(bsonx.Doc{{"$gte", bsonx.Int32(2)}})}})}},
+		),
+		bsonx.Document(
+			bsonx.Doc{{"$group", bsonx.Document(bsonx.Doc{{"_id", bsonx.Null()}, {"n", bsonx.Document(bsonx.Doc{{"$sum", bsonx.Int32(1)}})}})}},
+		),
+	}
+
+	res, err := coll.AggregateExplain(context.Background(), pipeline, options.Aggregate().SetComment("explain"))
+	require.NoError(t, err)
+	require.NotNil(t, res.Raw)
+	require.Contains(t, res.WinningPlanStages, "IXSCAN")
+	require.True(t, res.KeysExamined > 0, "expected index keys to be examined")
+}
+
 func TestCollection_Aggregate_IndexHint(t *testing.T) {
 	skipIfBelow36(t)
 
@@ -1207,6 +1560,60 @@ func TestCollection_Aggregate_withOptions(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCollection_Aggregate_mergeUsesPrimary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	if os.Getenv("TOPOLOGY") != "replica_set" {
+		t.Skip("read preferences are only tested against replica sets")
+	}
+	serverVersion, err := getServerVersion(createTestDatabase(t, nil))
+	require.NoError(t, err)
+	if compareVersions(t, serverVersion, "4.2") < 0 {
+		t.Skip("$merge requires MongoDB 4.2 or later")
+	}
+
+	var started []*event.CommandStartedEvent
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			if cse.CommandName == "aggregate" {
+				started = append(started, cse)
+			}
+		},
+	}
+	client := createSessionsMonitoredClient(t, monitor)
+	defer func() { _ = client.Disconnect(ctx) }()
+
+	db := client.Database("TestCollectionAggregateMerge")
+	require.NoError(t, db.Drop(ctx))
+	defer func() { _ = db.Drop(ctx) }()
+	initCollection(t, db.Collection("source", options.Collection().SetWriteConcern(wcMajority)))
+
+	coll := db.Collection("source",
+		options.Collection().SetReadPreference(readpref.SecondaryPreferred()).SetWriteConcern(wcMajority))
+	pipeline := bsonx.Arr{bsonx.Document(bsonx.Doc{{"$merge", bsonx.Document(bsonx.Doc{{"into", bsonx.String("merged")}})}})}
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	require.NoError(t, err)
+	require.NoError(t, cursor.Close(ctx))
+
+	require.Len(t, started, 1)
+	var primary string
+	for _, server := range client.topology.Description().Servers {
+		if server.Kind == description.RSPrimary {
+			primary = server.Addr.String()
+		}
+	}
+	require.NotEmpty(t, primary)
+	require.True(t, strings.HasPrefix(started[0].ConnectionID, primary+"["),
+		"expected the aggregate to run on the primary %s but it ran on %s", primary, started[0].ConnectionID)
+	_, err = started[0].Command.LookupErr("writeConcern")
+	require.NoError(t, err)
+
+	count, err := db.Collection("merged").CountDocuments(ctx, bsonx.Doc{})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+}
+
 func TestCollection_Count(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -1303,6 +1710,61 @@ func TestCollection_CountDocuments_withSkipOptions(t *testing.T) {
 	require.Equal(t, count, int64(2))
 }
 
+func TestCollection_CountDocuments_withHint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	skipIfBelow36(t)
+
+	var started []*event.CommandStartedEvent
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			if cse.CommandName == "aggregate" {
+				started = append(started, cse)
+			}
+		},
+	}
+	client := createSessionsMonitoredClient(t, monitor)
+	defer func() { _ = client.Disconnect(ctx) }()
+
+	coll := client.Database(testutil.DBName(t)).Collection(testutil.ColName(t))
+	defer func() { _ = coll.Drop(ctx) }()
+	initCollection(t, coll)
+
+	_, err := coll.Indexes().CreateOne(ctx, IndexModel{Keys: bsonx.Doc{{"x", bsonx.Int32(1)}}})
+	require.NoError(t, err)
+
+	filter := bsonx.Doc{{"x", bsonx.Document(bsonx.Doc{{"$gt", bsonx.Int32(2)}})}}
+
+	t.Run("index name", func(t *testing.T) {
+		started = nil
+		count, err := coll.CountDocuments(ctx, filter, options.Count().SetHint("x_1"))
+		require.NoError(t, err)
+		require.Equal(t, int64(3), count)
+
+		require.Len(t, started, 1)
+		require.Equal(t, "x_1", started[0].Command.Lookup("hint").StringValue())
+	})
+
+	t.Run("index keys", func(t *testing.T) {
+		started = nil
+		count, err := coll.CountDocuments(ctx, filter, options.Count().SetHint(bson.D{{"x", int32(1)}}))
+		require.NoError(t, err)
+		require.Equal(t, int64(3), count)
+
+		require.Len(t, started, 1)
+		keys := started[0].Command.Lookup("hint").Document()
+		require.Equal(t, int32(1), keys.Lookup("x").Int32())
+	})
+
+	t.Run("no matching index", func(t *testing.T) {
+		_, err := coll.CountDocuments(ctx, filter, options.Count().SetHint("y_1"))
+		require.Error(t, err)
+		_, ok := err.(command.Error)
+		require.True(t, ok, "expected a command.Error but got %T: %v", err, err)
+	})
+}
+
 func TestCollection_EstimatedDocumentCount(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -1744,3 +2206,184 @@ func TestCollection_FindOneAndUpdate_notFound_ignoreResult(t *testing.T) {
 	err := coll.FindOneAndUpdate(context.Background(), filter, update).Decode(nil)
 	require.Equal(t, err, ErrNoDocuments)
 }
+
+func TestCollection_FindOneAndUpdateImages_found(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+
+	filter := bsonx.Doc{{"x", bsonx.Int32(3)}}
+	update := bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(6)}})}}
+
+	var before, after bsonx.Doc
+	err := coll.FindOneAndUpdateImages(context.Background(), filter, update).Decode(&before, &after)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), before.Lookup("x").Int32())
+	require.Equal(t, int32(6), after.Lookup("x").Int32())
+	require.Equal(t, before.Lookup("_id"), after.Lookup("_id"))
+}
+
+func TestCollection_FindOneAndUpdateImages_upsert(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+
+	filter := bsonx.Doc{{"_id", bsonx.String("new")}}
+	update := bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(7)}})}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	before, after, err := coll.FindOneAndUpdateImages(context.Background(), filter, update, opts).DecodeBytes()
+	require.NoError(t, err)
+	require.Nil(t, before)
+	require.Equal(t, int32(7), after.Lookup("x").Int32())
+}
+
+func TestCollection_FindOneAndUpdateImages_notFound(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+
+	filter := bsonx.Doc{{"x", bsonx.Int32(6)}}
+	update := bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(6)}})}}
+
+	err := coll.FindOneAndUpdateImages(context.Background(), filter, update).Decode(nil, nil)
+	require.Equal(t, ErrNoDocuments, err)
+}
+
+func TestCollection_FindOneAndUpdateImages_projectionExcludesID(t *testing.T) {
+	// the projection is rejected before any command is sent, so no server is needed
+	coll := &Collection{client: &Client{}, registry: bson.DefaultRegistry}
+
+	filter := bsonx.Doc{{"x", bsonx.Int32(3)}}
+	update := bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(6)}})}}
+	opts := options.FindOneAndUpdate().SetProjection(bsonx.Doc{{"_id", bsonx.Int32(0)}, {"x", bsonx.Int32(1)}})
+
+	err := coll.FindOneAndUpdateImages(context.Background(), filter, update, opts).Decode(nil, nil)
+	require.Equal(t, ErrProjectionExcludesID, err)
+}
+
+func TestProjectionIncludesID(t *testing.T) {
+	testCases := []struct {
+		name string
+		proj bsonx.Doc
+		want bool
+	}{
+		{"empty", bsonx.Doc{}, true},
+		{"inclusion without _id", bsonx.Doc{{"x", bsonx.Int32(1)}}, true},
+		{"exclusion without _id", bsonx.Doc{{"x", bsonx.Int32(0)}}, true},
+		{"_id true", bsonx.Doc{{"_id", bsonx.Boolean(true)}}, true},
+		{"_id 1", bsonx.Doc{{"_id", bsonx.Int64(1)}}, true},
+		{"_id false", bsonx.Doc{{"_id", bsonx.Boolean(false)}}, false},
+		{"_id 0", bsonx.Doc{{"x", bsonx.Int32(1)}, {"_id", bsonx.Int32(0)}}, false},
+		{"_id 0.0", bsonx.Doc{{"_id", bsonx.Double(0)}}, false},
+		{"_id expression", bsonx.Doc{{"_id", bsonx.String("$x")}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, projectionIncludesID(tc.proj))
+		})
+	}
+}
+
+func TestUpdateImagesResult_Decode(t *testing.T) {
+	before, err := bson.Marshal(bson.D{{"_id", 1}, {"x", 3}})
+	require.NoError(t, err)
+	after, err := bson.Marshal(bson.D{{"_id", 1}, {"x", 6}})
+	require.NoError(t, err)
+
+	type doc struct {
+		ID int32 `bson:"_id"`
+		X  int32 `bson:"x"`
+	}
+
+	t.Run("both", func(t *testing.T) {
+		var b, a doc
+		res := &UpdateImagesResult{before: before, after: after, reg: bson.DefaultRegistry}
+		require.NoError(t, res.Decode(&b, &a))
+		require.Equal(t, doc{1, 3}, b)
+		require.Equal(t, doc{1, 6}, a)
+	})
+	t.Run("only after", func(t *testing.T) {
+		var a doc
+		res := &UpdateImagesResult{before: before, after: after, reg: bson.DefaultRegistry}
+		require.NoError(t, res.Decode(nil, &a))
+		require.Equal(t, doc{1, 6}, a)
+	})
+	t.Run("upserted", func(t *testing.T) {
+		b := doc{ID: 42}
+		var a doc
+		res := &UpdateImagesResult{after: after, reg: bson.DefaultRegistry}
+		require.NoError(t, res.Decode(&b, &a))
+		require.Equal(t, doc{ID: 42}, b)
+		require.Equal(t, doc{1, 6}, a)
+	})
+	t.Run("updated document missing", func(t *testing.T) {
+		var b doc
+		res := &UpdateImagesResult{before: before, reg: bson.DefaultRegistry}
+		require.Equal(t, ErrNoDocuments, res.Decode(&b, &doc{}))
+		require.Equal(t, doc{1, 3}, b)
+	})
+	t.Run("error", func(t *testing.T) {
+		res := &UpdateImagesResult{err: ErrClientDisconnected}
+		require.Equal(t, ErrClientDisconnected, res.Decode(&doc{}, &doc{}))
+		_, _, err := res.DecodeBytes()
+		require.Equal(t, ErrClientDisconnected, err)
+	})
+}
+
+func TestCollection_Rename(t *testing.T) {
+	t.Run("invalid names", func(t *testing.T) {
+		coll := &Collection{}
+		for _, name := range []string{"", "a$b", "a\x00b", "system.users"} {
+			_, err := coll.Rename(ctx, name)
+			require.Error(t, err, "expected an error for %q", name)
+		}
+	})
+
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	coll := createTestCollection(t, nil, nil)
+	defer func() { _ = coll.db.Drop(ctx) }()
+	initCollection(t, coll)
+
+	renamed, err := coll.Rename(ctx, coll.Name()+"_renamed")
+	require.NoError(t, err)
+	require.Equal(t, coll.Name()+"_renamed", renamed.Name())
+	require.Equal(t, coll.db, renamed.Database())
+	count, err := renamed.CountDocuments(ctx, bsonx.Doc{})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+	count, err = coll.CountDocuments(ctx, bsonx.Doc{})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+
+	t.Run("target exists", func(t *testing.T) {
+		_, err := coll.InsertOne(ctx, bsonx.Doc{{"x", bsonx.Int32(6)}})
+		require.NoError(t, err)
+
+		_, err = renamed.Rename(ctx, coll.Name())
+		cerr, ok := err.(command.Error)
+		require.True(t, ok, "expected a command.Error but got %T: %v", err, err)
+		require.Equal(t, int32(namespaceExistsCode), cerr.Code)
+	})
+
+	t.Run("drop target", func(t *testing.T) {
+		back, err := renamed.Rename(ctx, coll.Name(), options.Rename().SetDropTarget(true))
+		require.NoError(t, err)
+		count, err := back.CountDocuments(ctx, bsonx.Doc{})
+		require.NoError(t, err)
+		require.Equal(t, int64(5), count)
+	})
+}
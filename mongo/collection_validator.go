@@ -0,0 +1,155 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
+)
+
+// ErrCollectionNotFound is returned by Collection.Validator when the collection does not exist.
+var ErrCollectionNotFound = errors.New("collection not found")
+
+// namespaceExistsCode is returned by the create command when the collection already exists.
+const namespaceExistsCode = 48
+
+// ValidationLevel determines which writes document validation applies to.
+type ValidationLevel string
+
+// These constants are the possible validation levels. An empty ValidationLevel leaves the choice to the server,
+// which defaults to ValidationLevelStrict.
+const (
+	// ValidationLevelOff disables validation.
+	ValidationLevelOff ValidationLevel = "off"
+	// ValidationLevelStrict validates every insert and update.
+	ValidationLevelStrict ValidationLevel = "strict"
+	// ValidationLevelModerate validates inserts and updates to documents that already pass validation.
+	ValidationLevelModerate ValidationLevel = "moderate"
+)
+
+// ValidationAction determines what happens to writes that fail document validation.
+type ValidationAction string
+
+// These constants are the possible validation actions. An empty ValidationAction leaves the choice to the
+// server, which defaults to ValidationActionError.
+const (
+	// ValidationActionError rejects invalid writes.
+	ValidationActionError ValidationAction = "error"
+	// ValidationActionWarn allows invalid writes and records a warning in the server log.
+	ValidationActionWarn ValidationAction = "warn"
+)
+
+// CollectionValidator is the document validation configured on a collection. Validator is the complete
+// validator document and Schema is its $jsonSchema, or nil if the validator uses query operators instead.
+type CollectionValidator struct {
+	Validator bson.Raw
+	Schema    bson.M
+	Level     ValidationLevel
+	Action    ValidationAction
+}
+
+// SetValidator installs schema as the $jsonSchema validator of the collection, creating the collection if it
+// does not exist. A nil schema removes the collection's validator. Empty level and action values are not sent,
+// so the server keeps the collection's current settings or applies its defaults.
+//
+// The server does not check the documents already in the collection when a validator is installed. Existing
+// documents that do not match schema are left in place, and with ValidationLevelStrict and
+// ValidationActionError every later update to them fails with a DocumentValidationFailure (code 121) command
+// error until the update makes the document valid. Use ValidationLevelModerate to exempt them, or query for
+// them with {$nor: [{$jsonSchema: schema}]} before switching to strict validation.
+func (coll *Collection) SetValidator(ctx context.Context, schema bson.M, level ValidationLevel,
+	action ValidationAction) error {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := coll.client.contextWithTimeout(ctx)
+	defer cancel()
+
+	validator := bsonx.Doc{}
+	if schema != nil {
+		schemaDoc, err := transformDocument(coll.registry, schema)
+		if err != nil {
+			return err
+		}
+		validator = bsonx.Doc{{"$jsonSchema", bsonx.Document(schemaDoc)}}
+	}
+
+	opts := bsonx.Doc{{"validator", bsonx.Document(validator)}}
+	if level != "" {
+		opts = append(opts, bsonx.Elem{"validationLevel", bsonx.String(string(level))})
+	}
+	if action != "" {
+		opts = append(opts, bsonx.Elem{"validationAction", bsonx.String(string(action))})
+	}
+
+	collMod := append(bsonx.Doc{{"collMod", bsonx.String(coll.name)}}, opts...)
+	err := coll.db.runWriteCommand(ctx, collMod)
+	if !command.IsNotFound(err) {
+		return replaceTopologyErr(err)
+	}
+
+	err = coll.db.runWriteCommand(ctx, append(bsonx.Doc{{"create", bsonx.String(coll.name)}}, opts...))
+	if cerr, ok := err.(command.Error); ok && cerr.Code == namespaceExistsCode {
+		// The collection was created concurrently, so modify it instead.
+		err = coll.db.runWriteCommand(ctx, collMod)
+	}
+	return replaceTopologyErr(err)
+}
+
+// Validator returns the document validation configured on the collection. If the collection exists but has no
+// validator, the returned CollectionValidator has a nil Validator. ErrCollectionNotFound is returned if the
+// collection does not exist.
+func (coll *Collection) Validator(ctx context.Context) (*CollectionValidator, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cursor, err := coll.db.ListCollections(ctx, bsonx.Doc{{"name", bsonx.String(coll.name)}})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	if !cursor.Next(ctx) {
+		if err = cursor.Err(); err != nil {
+			return nil, err
+		}
+		return nil, ErrCollectionNotFound
+	}
+
+	var info struct {
+		Options struct {
+			Validator        bson.Raw `bson:"validator"`
+			ValidationLevel  string   `bson:"validationLevel"`
+			ValidationAction string   `bson:"validationAction"`
+		} `bson:"options"`
+	}
+	if err = cursor.Decode(&info); err != nil {
+		return nil, err
+	}
+
+	cv := &CollectionValidator{
+		Level:  ValidationLevel(info.Options.ValidationLevel),
+		Action: ValidationAction(info.Options.ValidationAction),
+	}
+	if len(info.Options.Validator) == 0 {
+		return cv, nil
+	}
+
+	cv.Validator = info.Options.Validator
+	if schema, err := cv.Validator.LookupErr("$jsonSchema"); err == nil {
+		if err = schema.UnmarshalWithRegistry(coll.registry, &cv.Schema); err != nil {
+			return nil, err
+		}
+	}
+	return cv, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollection_Validator(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	skipIfBelow36(t)
+
+	schema := bson.M{
+		"bsonType": "object",
+		"required": bson.A{"name"},
+		"properties": bson.M{
+			"name": bson.M{"bsonType": "string"},
+		},
+	}
+
+	db := createTestDatabase(t, nil)
+	coll := db.Collection("validator")
+	require.NoError(t, coll.Drop(ctx))
+	defer func() { _ = coll.Drop(ctx) }()
+
+	_, err := coll.Validator(ctx)
+	require.Equal(t, ErrCollectionNotFound, err)
+
+	t.Run("creates missing collections", func(t *testing.T) {
+		require.NoError(t, coll.SetValidator(ctx, schema, ValidationLevelModerate, ValidationActionWarn))
+
+		cv, err := coll.Validator(ctx)
+		require.NoError(t, err)
+		require.Equal(t, ValidationLevelModerate, cv.Level)
+		require.Equal(t, ValidationActionWarn, cv.Action)
+		require.Equal(t, "object", cv.Schema["bsonType"])
+	})
+
+	t.Run("existing documents are not validated", func(t *testing.T) {
+		require.NoError(t, coll.SetValidator(ctx, nil, "", ""))
+		cv, err := coll.Validator(ctx)
+		require.NoError(t, err)
+		require.Nil(t, cv.Schema)
+
+		_, err = coll.InsertOne(ctx, bsonx.Doc{{"_id", bsonx.Int32(1)}, {"name", bsonx.Int32(42)}})
+		require.NoError(t, err)
+
+		require.NoError(t, coll.SetValidator(ctx, schema, ValidationLevelStrict, ValidationActionError))
+		cv, err = coll.Validator(ctx)
+		require.NoError(t, err)
+		require.Equal(t, ValidationLevelStrict, cv.Level)
+		require.Equal(t, ValidationActionError, cv.Action)
+		require.NotNil(t, cv.Schema)
+
+		count, err := coll.CountDocuments(ctx, bsonx.Doc{})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+
+		_, err = coll.UpdateOne(ctx,
+			bsonx.Doc{{"_id", bsonx.Int32(1)}},
+			bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"other", bsonx.Int32(1)}})}},
+		)
+		requireValidationFailure(t, err)
+
+		_, err = coll.InsertOne(ctx, bsonx.Doc{{"_id", bsonx.Int32(2)}})
+		requireValidationFailure(t, err)
+
+		_, err = coll.UpdateOne(ctx,
+			bsonx.Doc{{"_id", bsonx.Int32(1)}},
+			bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"name", bsonx.String("fixed")}})}},
+		)
+		require.NoError(t, err)
+	})
+}
+
+// requireValidationFailure checks that err is a write error for a DocumentValidationFailure.
+func requireValidationFailure(t *testing.T, err error) {
+	t.Helper()
+	require.Error(t, err)
+	switch e := err.(type) {
+	case WriteErrors:
+		require.Len(t, e, 1)
+		require.Equal(t, 121, e[0].Code)
+	case command.Error:
+		require.Equal(t, int32(121), e.Code)
+	default:
+		t.Fatalf("expected a document validation failure but got %T: %v", err, err)
+	}
+}
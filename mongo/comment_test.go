@@ -0,0 +1,121 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/event"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationComments(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	var started []*event.CommandStartedEvent
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			started = append(started, cse)
+		},
+	}
+	client := createSessionsMonitoredClient(t, monitor)
+	defer func() { _ = client.Disconnect(ctx) }()
+
+	db := client.Database("TestOperationComments")
+	require.NoError(t, db.Drop(ctx))
+	defer func() { _ = db.Drop(ctx) }()
+	coll := db.Collection("comments")
+	_, err := coll.InsertOne(ctx, bsonx.Doc{{"x", bsonx.Int32(1)}})
+	require.NoError(t, err)
+
+	serverVersion, err := getServerVersion(db)
+	require.NoError(t, err)
+
+	filter := bsonx.Doc{{"x", bsonx.Int32(1)}}
+	update := bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"y", bsonx.Int32(1)}})}}
+
+	operations := []struct {
+		name    string
+		command string
+		run     func(comment interface{}) error
+	}{
+		{"Find", "find", func(comment interface{}) error {
+			cursor, err := coll.Find(ctx, filter, options.Find().SetComment(comment))
+			if err == nil {
+				err = cursor.Close(ctx)
+			}
+			return err
+		}},
+		{"Aggregate", "aggregate", func(comment interface{}) error {
+			cursor, err := coll.Aggregate(ctx, Pipeline{}, options.Aggregate().SetComment(comment))
+			if err == nil {
+				err = cursor.Close(ctx)
+			}
+			return err
+		}},
+		{"UpdateOne", "update", func(comment interface{}) error {
+			_, err := coll.UpdateOne(ctx, filter, update, options.Update().SetComment(comment))
+			return err
+		}},
+		{"DeleteOne", "delete", func(comment interface{}) error {
+			_, err := coll.DeleteOne(ctx, bsonx.Doc{{"x", bsonx.Int32(2)}}, options.Delete().SetComment(comment))
+			return err
+		}},
+		{"CountDocuments", "aggregate", func(comment interface{}) error {
+			_, err := coll.CountDocuments(ctx, filter, options.Count().SetComment(comment))
+			return err
+		}},
+		{"Distinct", "distinct", func(comment interface{}) error {
+			_, err := coll.Distinct(ctx, "x", filter, options.Distinct().SetComment(comment))
+			return err
+		}},
+	}
+
+	comments := []struct {
+		name       string
+		comment    interface{}
+		expected   bsonx.Val
+		minVersion string
+	}{
+		{"string", "hello", bsonx.String("hello"), ""},
+		{"document", bson.D{{"foo", "bar"}}, bsonx.Document(bsonx.Doc{{"foo", bsonx.String("bar")}}), "4.4"},
+	}
+
+	for _, c := range comments {
+		t.Run(c.name, func(t *testing.T) {
+			if c.minVersion != "" && compareVersions(t, serverVersion, c.minVersion) < 0 {
+				t.Skipf("skipping for server version < %s", c.minVersion)
+			}
+
+			for _, op := range operations {
+				t.Run(op.name, func(t *testing.T) {
+					started = started[:0]
+					require.NoError(t, op.run(c.comment))
+
+					var cmd bsonx.Doc
+					for _, cse := range started {
+						if cse.CommandName == op.command {
+							cmd = cse.Command
+							break
+						}
+					}
+					require.NotNil(t, cmd, "no %s command was sent", op.command)
+
+					got, err := cmd.LookupErr("comment")
+					require.NoError(t, err)
+					require.True(t, c.expected.Equal(got), "expected comment %v, got %v", c.expected, got)
+				})
+			}
+		})
+	}
+}
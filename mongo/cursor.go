@@ -37,9 +37,15 @@ import (
 type Cursor interface {
 	// NOTE: Whenever ops.Cursor changes, this must be changed to match it.
 
-	// Get the ID of the cursor.
+	// Get the ID of the cursor. This is 0 once the server side cursor has
+	// been exhausted or closed.
 	ID() int64
 
+	// Get the number of documents left in the current batch. Once these have
+	// been iterated, the next call to Next will request another batch from
+	// the server.
+	RemainingBatchLength() int
+
 	// Get the next result from the cursor.
 	// Returns true if there were no errors and there is a next result.
 	Next(context.Context) bool
@@ -51,6 +57,13 @@ type Cursor interface {
 	// Returns the error status of the cursor
 	Err() error
 
+	// Call the function with each remaining document, stopping early if it
+	// returns an error. Unlike decoding every document into a slice, this
+	// only holds one batch of documents in memory at a time. The cursor is
+	// closed when ForEach returns, and the document passed to the function
+	// must be copied to be retained after it returns.
+	ForEach(context.Context, func(bson.Raw) error) error
+
 	// Close the cursor.
 	Close(context.Context) error
 }
@@ -8,6 +8,7 @@ package mongo
 
 import (
 	"context"
+	"errors"
 
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
 	"github.com/mongodb/mongo-go-driver/mongo/options"
@@ -50,13 +51,18 @@ func newDatabase(client *Client, name string, opts ...*options.DatabaseOptions)
 		wc = dbOpt.WriteConcern
 	}
 
+	reg := client.registry
+	if dbOpt.Registry != nil {
+		reg = dbOpt.Registry
+	}
+
 	db := &Database{
 		client:         client,
 		name:           name,
 		readPreference: rp,
 		readConcern:    rc,
 		writeConcern:   wc,
-		registry:       client.registry,
+		registry:       reg,
 	}
 
 	db.readSelector = description.CompositeSelector([]description.ServerSelector{
@@ -82,7 +88,8 @@ func (db *Database) Name() string {
 	return db.name
 }
 
-// Collection gets a handle for a given collection in the database.
+// Collection gets a handle for a given collection in the database. The read concern, write concern, read preference
+// and registry of the collection default to those of the database.
 func (db *Database) Collection(name string, opts ...*options.CollectionOptions) *Collection {
 	return newCollection(db, name, opts...)
 }
@@ -132,10 +139,15 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{}, opts
 
 // RunCommand runs a command on the database. A user can supply a custom
 // context to this method, or nil to default to context.Background().
+//
+// The command is run with the read preference given in the RunCmdOptions, or the read preference of the
+// transaction it runs in. Otherwise it is run on the primary; the read preference of the database is not used.
 func (db *Database) RunCommand(ctx context.Context, runCommand interface{}, opts ...*options.RunCmdOptions) *SingleResult {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := db.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	readCmd, readSelect, err := db.processRunCommand(ctx, runCommand, opts...)
 	if err != nil {
@@ -153,12 +165,20 @@ func (db *Database) RunCommand(ctx context.Context, runCommand interface{}, opts
 	return &SingleResult{err: replaceTopologyErr(err), rdr: doc, reg: db.registry}
 }
 
-// RunCommandCursor runs a command on the database and returns a cursor over the resulting reader. A user can supply
-// a custom context to this method, or nil to default to context.Background().
+// RunCommandCursor runs a command that returns a cursor, such as listCollections or aggregate, and returns a Cursor
+// over its results. The cursor.firstBatch, cursor.ns and cursor.id fields of the response are used to build the
+// Cursor, which issues getMores as it is iterated. A user can supply a custom context to this method, or nil to
+// default to context.Background().
+//
+// The read preference is chosen as for RunCommand. The getMores are sent to the server the command ran on, so a
+// secondary read preference applies to the whole cursor. If ctx holds a session, the command and its getMores run
+// in that session and in its transaction, if one is running.
 func (db *Database) RunCommandCursor(ctx context.Context, runCommand interface{}, opts ...*options.RunCmdOptions) (Cursor, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := db.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	readCmd, readSelect, err := db.processRunCommand(ctx, runCommand, opts...)
 	if err != nil {
@@ -177,11 +197,112 @@ func (db *Database) RunCommandCursor(ctx context.Context, runCommand interface{}
 	return result, replaceTopologyErr(err)
 }
 
+// Aggregate runs an aggregation framework pipeline against the database rather than a collection. It is used for
+// pipelines whose first stage produces documents itself, such as $documents, $currentOp (run against the admin
+// database), or $listLocalSessions.
+//
+// See https://docs.mongodb.com/manual/reference/command/aggregate/.
+func (db *Database) Aggregate(ctx context.Context, pipeline interface{},
+	opts ...*options.AggregateOptions) (Cursor, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := db.client.contextWithTimeout(ctx)
+	defer cancel()
+
+	pipelineArr, err := transformAggregatePipeline(db.registry, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	aggOpts := options.MergeAggregateOptions(opts...)
+	if db.client.ignoresMaxTime() {
+		aggOpts.MaxTime = nil
+	}
+
+	sess := sessionFromContext(ctx)
+
+	err = db.client.ValidSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	wc := db.writeConcern
+	if sess != nil && sess.TransactionRunning() {
+		wc = nil
+	}
+
+	rc := db.readConcern
+	if sess != nil && (sess.TransactionInProgress()) {
+		rc = nil
+	}
+
+	cmd := command.Aggregate{
+		NS:           command.Namespace{DB: db.name},
+		Pipeline:     pipelineArr,
+		ReadPref:     db.readPreference,
+		WriteConcern: wc,
+		ReadConcern:  rc,
+		Session:      sess,
+		Clock:        db.client.clock,
+	}
+
+	cmd.ReadConcern = readConcernAfter(cmd.ReadConcern, sess, aggOpts.ReadAfter)
+
+	cursor, err := driver.Aggregate(
+		ctx, cmd,
+		db.client.topology,
+		db.readSelector,
+		db.writeSelector,
+		db.client.id,
+		db.client.topology.SessionPool,
+		db.registry,
+		aggOpts,
+	)
+
+	return cursor, replaceTopologyErr(err)
+}
+
+// CreateCollection explicitly creates a collection named name with the given options. Collections are otherwise
+// created implicitly by the first write to them, but collections such as time series collections must be created
+// explicitly. The server returns a command.Error with code 48 (NamespaceExists) if the collection already exists.
+func (db *Database) CreateCollection(ctx context.Context, name string, opts ...*options.CreateCollectionOptions) error {
+	if err := validateCollectionName(name); err != nil {
+		return err
+	}
+
+	createOpts := options.MergeCreateCollectionOptions(opts...)
+	cmd := bsonx.Doc{{"create", bsonx.String(name)}}
+	if ts := createOpts.TimeSeriesOptions; ts != nil {
+		switch {
+		case ts.TimeField == "":
+			return errors.New("the timeField of a time series collection is required")
+		case (ts.BucketMaxSpan == 0) != (ts.BucketRounding == 0):
+			return errors.New("bucketMaxSpanSeconds and bucketRoundingSeconds must be set together")
+		}
+		cmd = append(cmd, bsonx.Elem{"timeseries", bsonx.Document(ts.ToDocument())})
+	}
+	if createOpts.ExpireAfterSeconds != nil {
+		cmd = append(cmd, bsonx.Elem{"expireAfterSeconds", bsonx.Int64(*createOpts.ExpireAfterSeconds)})
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := db.client.contextWithTimeout(ctx)
+	defer cancel()
+
+	return replaceTopologyErr(db.runWriteCommand(ctx, cmd))
+}
+
 // Drop drops this database from mongodb.
 func (db *Database) Drop(ctx context.Context) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := db.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	sess := sessionFromContext(ctx)
 
@@ -213,6 +334,8 @@ func (db *Database) ListCollections(ctx context.Context, filter interface{}, opt
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := db.client.contextWithTimeout(ctx)
+	defer cancel()
 
 	sess := sessionFromContext(ctx)
 
@@ -272,7 +395,7 @@ func (db *Database) WriteConcern() *writeconcern.WriteConcern {
 // to running a raw aggregation with a $changeStream stage because it supports resumability in the case of some errors.
 // The database must have read concern majority or no read concern for a change stream to be created successfully.
 func (db *Database) Watch(ctx context.Context, pipeline interface{},
-	opts ...*options.ChangeStreamOptions) (Cursor, error) {
+	opts ...*options.ChangeStreamOptions) (ChangeStream, error) {
 
 	return newDbChangeStream(ctx, db, pipeline, opts...)
 }
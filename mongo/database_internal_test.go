@@ -12,6 +12,7 @@ import (
 
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/primitive"
@@ -21,6 +22,8 @@ import (
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/session"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
 	"github.com/mongodb/mongo-go-driver/x/network/connstring"
 	"github.com/mongodb/mongo-go-driver/x/network/description"
 	"github.com/stretchr/testify/require"
@@ -105,6 +108,55 @@ func TestDatabase_InheritOptions(t *testing.T) {
 	}
 }
 
+func TestDatabase_InheritRegistry(t *testing.T) {
+	client, err := NewClient("mongodb://localhost")
+	require.NoError(t, err)
+	reg := bson.NewRegistryBuilder().Build()
+
+	db := client.Database("foo", options.Database().SetRegistry(reg))
+	require.True(t, db.registry == reg, "expected the database registry to be used")
+	require.True(t, db.Collection("bar").registry == reg, "expected the collection to inherit the database registry")
+	require.True(t, client.Database("foo").registry == client.registry, "expected the client registry to be used")
+}
+
+func TestDatabase_RunCommandReadPreference(t *testing.T) {
+	client, err := NewClient("mongodb://localhost")
+	require.NoError(t, err)
+	client.topology.SessionPool = session.NewPool(nil)
+	db := client.Database("foo", options.Database().SetReadPreference(readpref.Secondary()))
+
+	testCases := []struct {
+		name     string
+		txnOpts  *options.TransactionOptions
+		opts     *options.RunCmdOptions
+		expected readpref.Mode
+	}{
+		{"default", nil, nil, readpref.PrimaryMode},
+		{"option", nil, options.RunCmd().SetReadPreference(readpref.Nearest()), readpref.NearestMode},
+		{"transaction", options.Transaction().SetReadPreference(readpref.PrimaryPreferred()), nil,
+			readpref.PrimaryPreferredMode},
+		{"option in transaction", options.Transaction().SetReadPreference(readpref.PrimaryPreferred()),
+			options.RunCmd().SetReadPreference(readpref.Nearest()), readpref.NearestMode},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.txnOpts != nil {
+				sess, err := client.StartSession()
+				require.NoError(t, err)
+				defer sess.EndSession(ctx)
+				require.NoError(t, sess.StartTransaction(tc.txnOpts))
+				ctx = contextWithSession(ctx, sess)
+			}
+
+			cmd, _, err := db.processRunCommand(ctx, bson.D{{"ping", 1}}, tc.opts)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, cmd.ReadPref.Mode())
+		})
+	}
+}
+
 func TestDatabase_ReplaceTopologyError(t *testing.T) {
 	t.Parallel()
 
@@ -165,6 +217,100 @@ func TestDatabase_RunCommand_DecodeStruct(t *testing.T) {
 	require.Equal(t, result.Ok, 1.0)
 }
 
+func TestDatabase_RunCommandCursor(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	listCollectionNames := func(t *testing.T, ctx context.Context, db *Database, opts ...*options.RunCmdOptions) []string {
+		cursor, err := db.RunCommandCursor(ctx, bsonx.Doc{
+			{"listCollections", bsonx.Int32(1)},
+			{"cursor", bsonx.Document(bsonx.Doc{{"batchSize", bsonx.Int32(1)}})},
+		}, opts...)
+		require.NoError(t, err)
+		defer func() { _ = cursor.Close(ctx) }()
+
+		var names []string
+		for cursor.Next(ctx) {
+			var coll struct {
+				Name string `bson:"name"`
+			}
+			require.NoError(t, cursor.Decode(&coll))
+			names = append(names, coll.Name)
+		}
+		require.NoError(t, cursor.Err())
+		return names
+	}
+
+	dbName := "TestDatabase_RunCommandCursor"
+	db := createTestDatabase(t, &dbName, options.Database().SetWriteConcern(wcMajority))
+	defer func() { _ = db.Drop(ctx) }()
+	uncappedName, cappedName, err := setupListCollectionsDb(db)
+	require.NoError(t, err)
+
+	t.Run("listCollections", func(t *testing.T) {
+		// a batch size of one means the second collection is returned by a getMore
+		names := listCollectionNames(t, ctx, db)
+		require.Contains(t, names, uncappedName)
+		require.Contains(t, names, cappedName)
+	})
+
+	t.Run("secondary read preference", func(t *testing.T) {
+		if os.Getenv("TOPOLOGY") != "replica_set" {
+			t.Skip("skipping for non-replica set topology")
+		}
+
+		names := listCollectionNames(t, ctx, db, options.RunCmd().SetReadPreference(readpref.SecondaryPreferred()))
+		require.Contains(t, names, uncappedName)
+		require.Contains(t, names, cappedName)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		if os.Getenv("TOPOLOGY") != "replica_set" {
+			t.Skip("skipping for non-replica set topology")
+		}
+		version, err := getServerVersion(db)
+		require.NoError(t, err)
+		if compareVersions(t, version, "4.0") < 0 {
+			t.Skip("skipping for server version < 4.0")
+		}
+
+		sess, err := db.Client().StartSession()
+		require.NoError(t, err)
+		defer sess.EndSession(ctx)
+
+		err = WithSession(ctx, sess, func(sc SessionContext) error {
+			require.NoError(t, sess.StartTransaction())
+			defer func() { _ = sess.AbortTransaction(sc) }()
+
+			_, err := db.Collection(uncappedName).InsertOne(sc, bsonx.Doc{{"x", bsonx.Int32(2)}})
+			require.NoError(t, err)
+
+			aggregate := bsonx.Doc{
+				{"aggregate", bsonx.String(uncappedName)},
+				{"pipeline", bsonx.Array(bsonx.Arr{})},
+				{"cursor", bsonx.Document(bsonx.Doc{{"batchSize", bsonx.Int32(1)}})},
+			}
+			_, err = db.RunCommandCursor(sc, aggregate, options.RunCmd().SetReadPreference(readpref.Secondary()))
+			require.Equal(t, command.ErrNonPrimaryRP, err)
+
+			cursor, err := db.RunCommandCursor(sc, aggregate)
+			require.NoError(t, err)
+			var n int
+			for cursor.Next(sc) {
+				n++
+			}
+			require.NoError(t, cursor.Err())
+			require.NoError(t, cursor.Close(sc))
+
+			// the document inserted in the transaction is only visible inside it
+			require.Equal(t, 2, n)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
+
 func TestDatabase_Drop(t *testing.T) {
 	t.Parallel()
 
@@ -365,3 +511,145 @@ func TestDatabase_ListCollections(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabase_Aggregate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	db := createTestDatabase(t, nil)
+	version, err := getServerVersion(db)
+	require.NoError(t, err)
+	if compareVersions(t, version, "6.0") < 0 {
+		t.Skip("$documents and $unionWith with $documents require MongoDB 6.0")
+	}
+	defer func() { _ = db.Drop(ctx) }()
+
+	coll := db.Collection(testutil.ColName(t))
+	_, err = coll.InsertMany(ctx, []interface{}{bson.D{{"x", int32(1)}}, bson.D{{"x", int32(2)}}})
+	require.NoError(t, err)
+
+	readX := func(t *testing.T, cursor Cursor) []int32 {
+		defer func() { _ = cursor.Close(ctx) }()
+		var xs []int32
+		for cursor.Next(ctx) {
+			var doc struct{ X int32 }
+			require.NoError(t, cursor.Decode(&doc))
+			xs = append(xs, doc.X)
+		}
+		require.NoError(t, cursor.Err())
+		return xs
+	}
+
+	t.Run("documents", func(t *testing.T) {
+		pipeline, err := NewPipelineBuilder().
+			Documents([]bson.D{{{"x", int32(10)}}, {{"x", int32(20)}}}).
+			Sort(bson.D{{"x", -1}}).
+			Build()
+		require.NoError(t, err)
+
+		cursor, err := db.Aggregate(ctx, pipeline)
+		require.NoError(t, err)
+		require.Equal(t, []int32{20, 10}, readX(t, cursor))
+	})
+
+	t.Run("union with", func(t *testing.T) {
+		nested, err := NewPipelineBuilder().Documents([]bson.D{{{"x", int32(3)}}}).Build()
+		require.NoError(t, err)
+		pipeline, err := NewPipelineBuilder().
+			Project(bson.D{{"_id", 0}, {"x", 1}}).
+			UnionWith(coll.Name(), Pipeline{{{"$match", bson.D{{"x", int32(2)}}}}}).
+			UnionWith("", nested).
+			Sort(bson.D{{"x", 1}}).
+			Build()
+		require.NoError(t, err)
+
+		cursor, err := coll.Aggregate(ctx, pipeline)
+		require.NoError(t, err)
+		require.Equal(t, []int32{1, 2, 2, 3}, readX(t, cursor))
+	})
+}
+
+func TestDatabase_CreateCollection(t *testing.T) {
+	t.Run("invalid options", func(t *testing.T) {
+		db := &Database{}
+		testCases := []struct {
+			name string
+			opts *options.CreateCollectionOptions
+		}{
+			{"missing time field", options.CreateCollection().SetTimeSeriesOptions(options.TimeSeriesOptions{MetaField: "m"})},
+			{"bucket max span without rounding", options.CreateCollection().SetTimeSeriesOptions(options.TimeSeriesOptions{
+				TimeField: "ts", BucketMaxSpan: time.Hour,
+			})},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				require.Error(t, db.CreateCollection(ctx, "coll", tc.opts))
+			})
+		}
+	})
+
+	t.Run("time series document", func(t *testing.T) {
+		tso := options.TimeSeriesOptions{
+			TimeField:      "ts",
+			MetaField:      "sensor",
+			BucketMaxSpan:  time.Hour,
+			BucketRounding: time.Hour,
+		}
+		require.Equal(t, bsonx.Doc{
+			{"timeField", bsonx.String("ts")},
+			{"metaField", bsonx.String("sensor")},
+			{"bucketMaxSpanSeconds", bsonx.Int64(3600)},
+			{"bucketRoundingSeconds", bsonx.Int64(3600)},
+		}, tso.ToDocument())
+	})
+
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	db := createTestDatabase(t, nil)
+	version, err := getServerVersion(db)
+	require.NoError(t, err)
+	if compareVersions(t, version, "5.0") < 0 {
+		t.Skip("time series collections require MongoDB 5.0")
+	}
+	require.NoError(t, db.Drop(ctx))
+	defer func() { _ = db.Drop(ctx) }()
+
+	name := testutil.ColName(t)
+	opts := options.CreateCollection().
+		SetTimeSeriesOptions(options.TimeSeriesOptions{TimeField: "ts", MetaField: "sensor", Granularity: "minutes"}).
+		SetExpireAfterSeconds(86400)
+	require.NoError(t, db.CreateCollection(ctx, name, opts))
+
+	_, err = db.Collection(name).InsertOne(ctx, bson.D{{"ts", time.Now()}, {"sensor", "a"}, {"temp", 21.5}})
+	require.NoError(t, err)
+
+	cursor, err := db.ListCollections(ctx, bsonx.Doc{{"name", bsonx.String(name)}})
+	require.NoError(t, err)
+	defer func() { _ = cursor.Close(ctx) }()
+	require.True(t, cursor.Next(ctx))
+	var info struct {
+		Type    string `bson:"type"`
+		Options struct {
+			TimeSeries struct {
+				TimeField   string `bson:"timeField"`
+				MetaField   string `bson:"metaField"`
+				Granularity string `bson:"granularity"`
+			} `bson:"timeseries"`
+			ExpireAfterSeconds int64 `bson:"expireAfterSeconds"`
+		} `bson:"options"`
+	}
+	require.NoError(t, cursor.Decode(&info))
+	require.Equal(t, "timeseries", info.Type)
+	require.Equal(t, "ts", info.Options.TimeSeries.TimeField)
+	require.Equal(t, "sensor", info.Options.TimeSeries.MetaField)
+	require.Equal(t, "minutes", info.Options.TimeSeries.Granularity)
+	require.Equal(t, int64(86400), info.Options.ExpireAfterSeconds)
+
+	err = db.CreateCollection(ctx, name, opts)
+	cerr, ok := err.(command.Error)
+	require.True(t, ok, "expected a command.Error but got %T: %v", err, err)
+	require.Equal(t, int32(namespaceExistsCode), cerr.Code)
+}
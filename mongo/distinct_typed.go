@@ -0,0 +1,59 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build go1.18
+// +build go1.18
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+)
+
+// DistinctTyped runs Distinct on coll and decodes each distinct value into a T using the
+// collection's registry. An error is returned if any value cannot be decoded into a T, for
+// example if T is string and one of the values is a number.
+//
+// DistinctTyped requires Go 1.18 or later.
+func DistinctTyped[T any](ctx context.Context, coll *Collection, fieldName string, filter interface{},
+	opts ...*options.DistinctOptions) ([]T, error) {
+
+	values, err := coll.Distinct(ctx, fieldName, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertDistinctValues[T](coll.registry, values)
+}
+
+func convertDistinctValues[T any](reg *bsoncodec.Registry, values []interface{}) ([]T, error) {
+	typed := make([]T, 0, len(values))
+	for i, val := range values {
+		if t, ok := val.(T); ok {
+			typed = append(typed, t)
+			continue
+		}
+
+		// Round trip the value through BSON so the registry's decoders decide which
+		// conversions are allowed.
+		var t T
+		doc, err := bson.MarshalWithRegistry(reg, bson.D{{"v", val}})
+		if err == nil {
+			err = bson.Raw(doc).Lookup("v").UnmarshalWithRegistry(reg, &t)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode distinct value %d of type %T into %T: %v", i, val, t, err)
+		}
+		typed = append(typed, t)
+	}
+
+	return typed, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build go1.18
+// +build go1.18
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertDistinctValues(t *testing.T) {
+	t.Run("strings", func(t *testing.T) {
+		got, err := convertDistinctValues[string](bson.DefaultRegistry, []interface{}{"a", "b"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, got)
+	})
+
+	t.Run("ints", func(t *testing.T) {
+		got, err := convertDistinctValues[int](bson.DefaultRegistry, []interface{}{int32(1), int64(2)})
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, got)
+	})
+
+	t.Run("structs", func(t *testing.T) {
+		type point struct {
+			X int32
+			Y int32
+		}
+		got, err := convertDistinctValues[point](bson.DefaultRegistry, []interface{}{
+			primitive.D{{"x", int32(1)}, {"y", int32(2)}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []point{{X: 1, Y: 2}}, got)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		got, err := convertDistinctValues[string](bson.DefaultRegistry, nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{}, got)
+	})
+
+	t.Run("not assignable", func(t *testing.T) {
+		got, err := convertDistinctValues[string](bson.DefaultRegistry, []interface{}{"a", int32(1)})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "distinct value 1 of type int32 into string")
+		require.Nil(t, got)
+	})
+}
+
+func TestDistinctTyped(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	coll := createTestCollection(t, nil, nil)
+	defer func() { _ = coll.Drop(ctx) }()
+
+	_, err := coll.InsertMany(ctx, []interface{}{
+		bsonx.Doc{{"x", bsonx.Int32(1)}, {"name", bsonx.String("a")}},
+		bsonx.Doc{{"x", bsonx.Int32(2)}, {"name", bsonx.String("b")}},
+		bsonx.Doc{{"x", bsonx.Int32(2)}, {"name", bsonx.String("b")}},
+	})
+	require.NoError(t, err)
+
+	names, err := DistinctTyped[string](ctx, coll, "name", bsonx.Doc{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, names)
+
+	xs, err := DistinctTyped[int64](ctx, coll, "x", bsonx.Doc{{"x", bsonx.Document(bsonx.Doc{{"$gt", bsonx.Int32(1)}})}})
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, xs)
+
+	_, err = DistinctTyped[string](ctx, coll, "x", nil)
+	require.Error(t, err)
+}
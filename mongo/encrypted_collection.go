@@ -0,0 +1,284 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
+)
+
+// ErrEncryptedFieldsKeyID is returned by CreateEncryptedCollection when a field of the encrypted fields has a
+// missing or null keyId and no DataKeyCreator was given to create one.
+var ErrEncryptedFieldsKeyID = errors.New("every encrypted field must have a keyId")
+
+// ErrEncryptedFieldsFields is returned by CreateEncryptedCollection when the encrypted fields do not contain a
+// fields array of documents.
+var ErrEncryptedFieldsFields = errors.New("encrypted fields must contain a fields array of documents")
+
+// EncryptedCollectionError is returned when creating or dropping a queryable encryption collection fails part
+// way through. Collection is the collection whose create, drop or index build failed. Orphaned lists the
+// collections that may have been left behind: state collections that could not be cleaned up after a failed
+// create, or collections that had not been dropped yet when a drop failed.
+type EncryptedCollectionError struct {
+	Collection string
+	Orphaned   []string
+	Wrapped    error
+}
+
+func (e EncryptedCollectionError) Error() string {
+	msg := fmt.Sprintf("queryable encryption collection %s: %s", e.Collection, e.Wrapped)
+	if len(e.Orphaned) > 0 {
+		msg += fmt.Sprintf(" (collections left behind: %s)", strings.Join(e.Orphaned, ", "))
+	}
+	return msg
+}
+
+// CreateEncryptedCollection creates a queryable encryption collection named name. encryptedFields is the
+// encryptedFields document the collection is created with. When the DataKeys option is set, its DataKeyCreator
+// creates a data key for every field with a missing or null keyId; otherwise every field must give a keyId. The
+// encrypted fields the collection was created with, including the new key IDs, are returned so they can be stored
+// and passed to DropEncryptedCollection later. They are also returned with an error, so that keys created before
+// the failure can be reused or removed.
+//
+// The ESC and ECOC state collections are created first, named by the escCollection and ecocCollection fields of
+// encryptedFields or enxcol_.<name>.esc and enxcol_.<name>.ecoc by default. An ECC state collection is only created
+// when encryptedFields names one with eccCollection, which MongoDB 6.x requires. The data collection is then
+// created with the encrypted fields and indexed on __safeContent__.
+//
+// The collections are not created atomically. If any step fails, every collection created so far is dropped and
+// an EncryptedCollectionError is returned; its Orphaned field lists the collections that could not be dropped.
+func (db *Database) CreateEncryptedCollection(ctx context.Context, name string, encryptedFields interface{},
+	opts ...*options.CreateEncryptedCollectionOptions) (bson.Raw, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := db.client.contextWithTimeout(ctx)
+	defer cancel()
+
+	efDoc, err := transformDocument(db.registry, encryptedFields)
+	if err != nil {
+		return nil, err
+	}
+
+	eco := options.MergeCreateEncryptedCollectionOptions(opts...)
+	if eco.DataKeys != nil {
+		efDoc, err = createDataKeys(ctx, efDoc, eco.DataKeys)
+		if err != nil {
+			return marshalEncryptedFields(efDoc), err
+		}
+	}
+	if err = validateEncryptedFields(efDoc); err != nil {
+		return nil, err
+	}
+
+	var created []string
+	fail := func(coll string, err error) (bson.Raw, error) {
+		return marshalEncryptedFields(efDoc), EncryptedCollectionError{
+			Collection: coll,
+			Orphaned:   db.dropCollections(ctx, created),
+			Wrapped:    replaceTopologyErr(err),
+		}
+	}
+
+	for _, state := range encryptedStateCollections(name, efDoc) {
+		err = db.runWriteCommand(ctx, bsonx.Doc{
+			{"create", bsonx.String(state)},
+			{"clusteredIndex", bsonx.Document(bsonx.Doc{
+				{"key", bsonx.Document(bsonx.Doc{{"_id", bsonx.Int32(1)}})},
+				{"unique", bsonx.Boolean(true)},
+			})},
+		})
+		if err != nil {
+			return fail(state, err)
+		}
+		created = append(created, state)
+	}
+
+	err = db.runWriteCommand(ctx, bsonx.Doc{
+		{"create", bsonx.String(name)},
+		{"encryptedFields", bsonx.Document(efDoc)},
+	})
+	if err != nil {
+		return fail(name, err)
+	}
+	created = append(created, name)
+
+	err = db.runWriteCommand(ctx, bsonx.Doc{
+		{"createIndexes", bsonx.String(name)},
+		{"indexes", bsonx.Array(bsonx.Arr{
+			bsonx.Document(bsonx.Doc{
+				{"key", bsonx.Document(bsonx.Doc{{"__safeContent__", bsonx.Int32(1)}})},
+				{"name", bsonx.String("__safeContent___1")},
+			}),
+		})},
+	})
+	if err != nil {
+		return fail(name, err)
+	}
+
+	return marshalEncryptedFields(efDoc), nil
+}
+
+// DropEncryptedCollection drops the queryable encryption collection named name along with the state collections
+// named by encryptedFields, which is the encryptedFields document the collection was created with. Collections
+// that do not exist are ignored.
+//
+// The state collections are dropped before the data collection. If a drop fails, an EncryptedCollectionError is
+// returned and its Orphaned field lists the collections that were not dropped, so the call can be retried.
+func (db *Database) DropEncryptedCollection(ctx context.Context, name string, encryptedFields interface{}) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := db.client.contextWithTimeout(ctx)
+	defer cancel()
+
+	efDoc, err := transformDocument(db.registry, encryptedFields)
+	if err != nil {
+		return err
+	}
+
+	colls := append(encryptedStateCollections(name, efDoc), name)
+	for i, coll := range colls {
+		if err = db.Collection(coll).Drop(ctx); err != nil {
+			return EncryptedCollectionError{
+				Collection: coll,
+				Orphaned:   colls[i:],
+				Wrapped:    err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// encryptedStateCollections returns the names of the state collections for the queryable encryption collection
+// named name.
+func encryptedStateCollections(name string, efDoc bsonx.Doc) []string {
+	stateName := func(key, suffix string) string {
+		if val, err := efDoc.LookupErr(key); err == nil && val.Type() == bsontype.String {
+			return val.StringValue()
+		}
+		return "enxcol_." + name + "." + suffix
+	}
+
+	colls := []string{stateName("escCollection", "esc")}
+	if _, err := efDoc.LookupErr("eccCollection"); err == nil {
+		colls = append(colls, stateName("eccCollection", "ecc"))
+	}
+	return append(colls, stateName("ecocCollection", "ecoc"))
+}
+
+// createDataKeys returns a copy of efDoc in which every field with a missing or null keyId has the ID of a new data
+// key created with dk. If a key cannot be created, the returned document holds the keys created so far. Fields
+// that are not documents are left for validateEncryptedFields to reject.
+func createDataKeys(ctx context.Context, efDoc bsonx.Doc, dk options.DataKeyCreator) (bsonx.Doc, error) {
+	fieldsVal, err := efDoc.LookupErr("fields")
+	if err != nil || fieldsVal.Type() != bsontype.Array {
+		return efDoc, nil
+	}
+
+	fields := make(bsonx.Arr, 0, len(fieldsVal.Array()))
+	var keyErr error
+	for _, fieldVal := range fieldsVal.Array() {
+		if fieldVal.Type() != bsontype.EmbeddedDocument || keyErr != nil {
+			fields = append(fields, fieldVal)
+			continue
+		}
+		field := fieldVal.Document()
+		if keyID, err := field.LookupErr("keyId"); err == nil && keyID.Type() != bsontype.Null {
+			fields = append(fields, fieldVal)
+			continue
+		}
+
+		key, err := dk.CreateDataKey(ctx)
+		if err != nil {
+			keyErr = err
+			fields = append(fields, fieldVal)
+			continue
+		}
+		fields = append(fields, bsonx.Document(field.Copy().Set("keyId", bsonx.Binary(key.Subtype, key.Data))))
+	}
+
+	return efDoc.Copy().Set("fields", bsonx.Array(fields)), keyErr
+}
+
+// marshalEncryptedFields returns efDoc as raw BSON, or nil if it cannot be marshaled.
+func marshalEncryptedFields(efDoc bsonx.Doc) bson.Raw {
+	b, err := efDoc.MarshalBSON()
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func validateEncryptedFields(efDoc bsonx.Doc) error {
+	fieldsVal, err := efDoc.LookupErr("fields")
+	if err != nil || fieldsVal.Type() != bsontype.Array {
+		return ErrEncryptedFieldsFields
+	}
+
+	for _, fieldVal := range fieldsVal.Array() {
+		if fieldVal.Type() != bsontype.EmbeddedDocument {
+			return ErrEncryptedFieldsFields
+		}
+		keyID, err := fieldVal.Document().LookupErr("keyId")
+		if err != nil || keyID.Type() == bsontype.Null {
+			return ErrEncryptedFieldsKeyID
+		}
+	}
+	return nil
+}
+
+// runWriteCommand runs cmd against the primary with the database's write concern.
+func (db *Database) runWriteCommand(ctx context.Context, cmd bsonx.Doc) error {
+	sess := sessionFromContext(ctx)
+	if err := db.client.ValidSession(sess); err != nil {
+		return err
+	}
+
+	wc := db.writeConcern
+	if sess != nil && sess.TransactionRunning() {
+		wc = nil
+	}
+
+	_, err := driver.Write(
+		ctx,
+		command.Write{
+			DB:           db.name,
+			Command:      cmd,
+			WriteConcern: wc,
+			Session:      sess,
+			Clock:        db.client.clock,
+		},
+		db.client.topology,
+		db.writeSelector,
+		db.client.id,
+		db.client.topology.SessionPool,
+	)
+	return err
+}
+
+// dropCollections drops colls in reverse order and returns the names of those that could not be dropped.
+func (db *Database) dropCollections(ctx context.Context, colls []string) []string {
+	var failed []string
+	for i := len(colls) - 1; i >= 0; i-- {
+		if err := db.Collection(colls[i]).Drop(ctx); err != nil {
+			failed = append(failed, colls[i])
+		}
+	}
+	return failed
+}
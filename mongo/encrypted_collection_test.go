@@ -0,0 +1,203 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func encryptedFieldsDoc(keyID bsonx.Val) bsonx.Doc {
+	return bsonx.Doc{
+		{"fields", bsonx.Array(bsonx.Arr{
+			bsonx.Document(bsonx.Doc{
+				{"path", bsonx.String("ssn")},
+				{"bsonType", bsonx.String("string")},
+				{"keyId", keyID},
+			}),
+		})},
+	}
+}
+
+func TestEncryptedStateCollections(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		names := encryptedStateCollections("coll", bsonx.Doc{})
+		require.Equal(t, []string{"enxcol_.coll.esc", "enxcol_.coll.ecoc"}, names)
+	})
+
+	t.Run("named", func(t *testing.T) {
+		names := encryptedStateCollections("coll", bsonx.Doc{
+			{"escCollection", bsonx.String("esc")},
+			{"eccCollection", bsonx.String("ecc")},
+			{"ecocCollection", bsonx.String("ecoc")},
+		})
+		require.Equal(t, []string{"esc", "ecc", "ecoc"}, names)
+	})
+}
+
+func TestValidateEncryptedFields(t *testing.T) {
+	keyID := bsonx.Binary(0x04, make([]byte, 16))
+
+	testCases := []struct {
+		name string
+		ef   bsonx.Doc
+		err  error
+	}{
+		{"valid", encryptedFieldsDoc(keyID), nil},
+		{"null keyId", encryptedFieldsDoc(bsonx.Null()), ErrEncryptedFieldsKeyID},
+		{"missing keyId", bsonx.Doc{{"fields", bsonx.Array(bsonx.Arr{
+			bsonx.Document(bsonx.Doc{{"path", bsonx.String("ssn")}}),
+		})}}, ErrEncryptedFieldsKeyID},
+		{"missing fields", bsonx.Doc{}, ErrEncryptedFieldsFields},
+		{"non-document field", bsonx.Doc{{"fields", bsonx.Array(bsonx.Arr{bsonx.String("ssn")})}}, ErrEncryptedFieldsFields},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.err, validateEncryptedFields(tc.ef))
+		})
+	}
+}
+
+// dataKeys creates data keys with sequential IDs. If err is set, it is returned once failAfter keys were created.
+type dataKeys struct {
+	created   int
+	failAfter int
+	err       error
+}
+
+func (dk *dataKeys) CreateDataKey(ctx context.Context) (primitive.Binary, error) {
+	if dk.err != nil && dk.created == dk.failAfter {
+		return primitive.Binary{}, dk.err
+	}
+	dk.created++
+	id := make([]byte, 16)
+	id[15] = byte(dk.created)
+	return primitive.Binary{Subtype: 0x04, Data: id}, nil
+}
+
+func TestCreateDataKeys(t *testing.T) {
+	keyID := func(n byte) bsonx.Val {
+		id := make([]byte, 16)
+		id[15] = n
+		return bsonx.Binary(0x04, id)
+	}
+	field := func(path string, keyID *bsonx.Val) bsonx.Val {
+		doc := bsonx.Doc{{"path", bsonx.String(path)}}
+		if keyID != nil {
+			doc = append(doc, bsonx.Elem{"keyId", *keyID})
+		}
+		return bsonx.Document(doc)
+	}
+	existing := bsonx.Binary(0x04, []byte("0123456789abcdef"))
+	null := bsonx.Null()
+	ef := bsonx.Doc{
+		{"escCollection", bsonx.String("esc")},
+		{"fields", bsonx.Array(bsonx.Arr{field("a", &existing), field("b", &null), field("c", nil)})},
+	}
+
+	t.Run("creates missing keys", func(t *testing.T) {
+		first, second := keyID(1), keyID(2)
+		got, err := createDataKeys(context.Background(), ef, &dataKeys{})
+		require.NoError(t, err)
+		require.Equal(t, bsonx.Doc{
+			{"escCollection", bsonx.String("esc")},
+			{"fields", bsonx.Array(bsonx.Arr{field("a", &existing), field("b", &first), field("c", &second)})},
+		}, got)
+		require.NoError(t, validateEncryptedFields(got))
+		require.Equal(t, null, ef.Lookup("fields").Array()[1].Document().Lookup("keyId"), "input was modified")
+	})
+
+	t.Run("returns the keys created before an error", func(t *testing.T) {
+		first := keyID(1)
+		dk := &dataKeys{failAfter: 1, err: errors.New("key vault unavailable")}
+		got, err := createDataKeys(context.Background(), ef, dk)
+		require.Equal(t, dk.err, err)
+		require.Equal(t, bsonx.Array(bsonx.Arr{field("a", &existing), field("b", &first), field("c", nil)}), got.Lookup("fields"))
+	})
+}
+
+func TestDatabase_CreateEncryptedCollection_keyID(t *testing.T) {
+	// the encrypted fields are rejected before any command is sent, so no server is needed
+	db := &Database{client: &Client{}, registry: bson.DefaultRegistry}
+	ef := encryptedFieldsDoc(bsonx.Null())
+
+	_, err := db.CreateEncryptedCollection(context.Background(), "coll", ef)
+	require.Equal(t, ErrEncryptedFieldsKeyID, err)
+
+	dk := &dataKeys{err: errors.New("key vault unavailable")}
+	raw, err := db.CreateEncryptedCollection(context.Background(), "coll", ef,
+		options.CreateEncryptedCollection().SetDataKeys(dk))
+	require.Equal(t, dk.err, err)
+	require.Equal(t, bson.TypeNull, raw.Lookup("fields", "0", "keyId").Type)
+}
+
+func TestDatabase_EncryptedCollection(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	if os.Getenv("TOPOLOGY") != "replica_set" {
+		t.Skip("queryable encryption collections require a replica set")
+	}
+
+	db := createTestDatabase(t, nil)
+	version, err := getServerVersion(db)
+	require.NoError(t, err)
+	if compareVersions(t, version, "7.0") < 0 {
+		t.Skip("skipping for server version < 7.0")
+	}
+
+	name := "encrypted"
+	ef := encryptedFieldsDoc(bsonx.Binary(0x04, []byte("0123456789abcdef")))
+	defer func() { _ = db.DropEncryptedCollection(ctx, name, ef) }()
+
+	collectionNames := func() map[string]bool {
+		cursor, err := db.ListCollections(ctx, bsonx.Doc{})
+		require.NoError(t, err)
+		names := make(map[string]bool)
+		for cursor.Next(ctx) {
+			var coll struct {
+				Name string `bson:"name"`
+			}
+			require.NoError(t, cursor.Decode(&coll))
+			names[coll.Name] = true
+		}
+		require.NoError(t, cursor.Close(ctx))
+		return names
+	}
+
+	created, err := db.CreateEncryptedCollection(ctx, name, ef)
+	require.NoError(t, err)
+	expected, err := ef.MarshalBSON()
+	require.NoError(t, err)
+	require.Equal(t, bson.Raw(expected), created)
+	names := collectionNames()
+	for _, coll := range []string{name, "enxcol_.encrypted.esc", "enxcol_.encrypted.ecoc"} {
+		require.True(t, names[coll], "expected collection %s to exist", coll)
+	}
+
+	_, err = db.CreateEncryptedCollection(ctx, name, ef)
+	require.Error(t, err)
+	ecErr, ok := err.(EncryptedCollectionError)
+	require.True(t, ok, "expected an EncryptedCollectionError but got %T", err)
+	require.Equal(t, "enxcol_.encrypted.esc", ecErr.Collection)
+	require.Empty(t, ecErr.Orphaned)
+
+	require.NoError(t, db.DropEncryptedCollection(ctx, name, ef))
+	names = collectionNames()
+	for _, coll := range []string{name, "enxcol_.encrypted.esc", "enxcol_.encrypted.ecoc"} {
+		require.False(t, names[coll], "expected collection %s to be dropped", coll)
+	}
+}
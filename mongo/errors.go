@@ -10,11 +10,13 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/topology"
 	"github.com/mongodb/mongo-go-driver/x/network/command"
+	"github.com/mongodb/mongo-go-driver/x/network/connection"
 	"github.com/mongodb/mongo-go-driver/x/network/result"
 )
 
@@ -26,10 +28,33 @@ var ErrUnacknowledgedWrite = errors.New("unacknowledged write")
 // disconnected client
 var ErrClientDisconnected = errors.New("client is disconnected")
 
+// ErrExhaustLoadBalanced is returned when an exhaust cursor is requested from a client connected to a load
+// balanced deployment.
+var ErrExhaustLoadBalanced = errors.New("exhaust cursors are not supported with load balanced deployments")
+
+// ErrEstimatedCountInTransaction is returned when EstimatedDocumentCount is called with a session that has a
+// transaction running. The count command it uses is not allowed in transactions; use CountDocuments instead.
+var ErrEstimatedCountInTransaction = errors.New("estimatedDocumentCount is not supported in transactions")
+
+// DisconnectError is returned from Client.Disconnect when the context expires
+// before every in flight operation has finished. The connections used by those
+// operations have been closed, so the operations fail.
+type DisconnectError struct {
+	InFlight int
+	Wrapped  error
+}
+
+func (de DisconnectError) Error() string {
+	return fmt.Sprintf("client disconnected with %d operation(s) still in flight: %s", de.InFlight, de.Wrapped)
+}
+
 func replaceTopologyErr(err error) error {
 	if err == topology.ErrTopologyClosed {
 		return ErrClientDisconnected
 	}
+	if de, ok := err.(connection.DisconnectError); ok {
+		return DisconnectError{InFlight: de.InFlight, Wrapped: de.Wrapped}
+	}
 	return err
 }
 
@@ -102,7 +127,8 @@ func convertWriteConcernError(wce *result.WriteConcernError) *WriteConcernError
 	return &WriteConcernError{Code: wce.Code, Message: wce.ErrMsg, Details: wce.ErrInfo}
 }
 
-// BulkWriteError is an error for one operation in a bulk write.
+// BulkWriteError is an error for one operation in a bulk write. The Index of the embedded
+// WriteError is the index of the failed operation in the models passed to BulkWrite.
 type BulkWriteError struct {
 	WriteError
 	Request WriteModel
@@ -128,6 +154,18 @@ func (bwe BulkWriteException) Error() string {
 	return buf.String()
 }
 
+// FailedIndexes returns the indexes, in ascending order, of the operations that failed with a
+// write error. Operations that are not listed either succeeded or, for an ordered bulk write,
+// were not attempted.
+func (bwe BulkWriteException) FailedIndexes() []int {
+	indexes := make([]int, 0, len(bwe.WriteErrors))
+	for _, we := range bwe.WriteErrors {
+		indexes = append(indexes, we.Index)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
 // returnResult is used to determine if a function calling processWriteError should return
 // the result or return nil. Since the processWriteError function is used by many different
 // methods, both *One and *Many, we need a way to differentiate if the method should return
@@ -0,0 +1,63 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build go1.18
+// +build go1.18
+
+package mongo
+
+import (
+	"context"
+
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+)
+
+// FindOne runs coll.FindOne and decodes the document it returns into a T using the collection's
+// registry. If no document matches the filter, the zero value of T and ErrNoDocuments are returned.
+//
+// FindOne requires Go 1.18 or later.
+func FindOne[T any](ctx context.Context, coll *Collection, filter interface{},
+	opts ...*options.FindOneOptions) (T, error) {
+
+	var t T
+	if err := coll.FindOne(ctx, filter, opts...).Decode(&t); err != nil {
+		var zero T
+		return zero, err
+	}
+	return t, nil
+}
+
+// Find runs coll.Find, decodes every document it returns into a T using the collection's registry, and
+// closes the cursor. An empty slice is returned if no documents match the filter.
+//
+// Find requires Go 1.18 or later.
+func Find[T any](ctx context.Context, coll *Collection, filter interface{},
+	opts ...*options.FindOptions) ([]T, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cursor, err := coll.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	docs := make([]T, 0, cursor.RemainingBatchLength())
+	for cursor.Next(ctx) {
+		var t T
+		if err = cursor.Decode(&t); err != nil {
+			return nil, err
+		}
+		docs = append(docs, t)
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build go1.18
+// +build go1.18
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+type findTypedDoc struct {
+	X    int32  `bson:"x"`
+	Name string `bson:"name"`
+}
+
+func TestFindTyped(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	coll := createTestCollection(t, nil, nil)
+	defer func() { _ = coll.Drop(ctx) }()
+
+	_, err := coll.InsertMany(ctx, []interface{}{
+		bsonx.Doc{{"x", bsonx.Int32(1)}, {"name", bsonx.String("a")}},
+		bsonx.Doc{{"x", bsonx.Int32(2)}, {"name", bsonx.String("b")}},
+		bsonx.Doc{{"x", bsonx.Int32(3)}, {"name", bsonx.String("c")}},
+	})
+	require.NoError(t, err)
+
+	t.Run("FindOne", func(t *testing.T) {
+		doc, err := FindOne[findTypedDoc](ctx, coll, bsonx.Doc{{"x", bsonx.Int32(2)}})
+		require.NoError(t, err)
+		require.Equal(t, findTypedDoc{X: 2, Name: "b"}, doc)
+
+		doc, err = FindOne[findTypedDoc](ctx, coll, bsonx.Doc{},
+			options.FindOne().SetSort(bsonx.Doc{{"x", bsonx.Int32(-1)}}).SetSkip(1))
+		require.NoError(t, err)
+		require.Equal(t, findTypedDoc{X: 2, Name: "b"}, doc)
+
+		doc, err = FindOne[findTypedDoc](ctx, coll, bsonx.Doc{{"x", bsonx.Int32(4)}})
+		require.Equal(t, ErrNoDocuments, err)
+		require.Equal(t, findTypedDoc{}, doc)
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		docs, err := Find[findTypedDoc](ctx, coll, bsonx.Doc{},
+			options.Find().SetSort(bsonx.Doc{{"x", bsonx.Int32(1)}}).SetBatchSize(1))
+		require.NoError(t, err)
+		require.Equal(t, []findTypedDoc{{1, "a"}, {2, "b"}, {3, "c"}}, docs)
+
+		names, err := Find[bsonx.Doc](ctx, coll, bsonx.Doc{{"x", bsonx.Int32(3)}},
+			options.Find().SetProjection(bsonx.Doc{{"_id", bsonx.Int32(0)}, {"name", bsonx.Int32(1)}}))
+		require.NoError(t, err)
+		require.Equal(t, []bsonx.Doc{{{"name", bsonx.String("c")}}}, names)
+
+		docs, err = Find[findTypedDoc](ctx, coll, bsonx.Doc{{"x", bsonx.Int32(4)}})
+		require.NoError(t, err)
+		require.Equal(t, []findTypedDoc{}, docs)
+	})
+}
@@ -32,17 +32,22 @@ const DefaultChunkSize int32 = 255 * 1000 // 255 KB
 // ErrFileNotFound occurs if a user asks to download a file with a file ID that isn't found in the files collection.
 var ErrFileNotFound = errors.New("file with given parameters not found")
 
+// ErrUploadComplete occurs if a user asks to resume or clean up an upload whose files collection document has
+// already been inserted.
+var ErrUploadComplete = errors.New("upload is already complete")
+
 // Bucket represents a GridFS bucket.
 type Bucket struct {
 	db         *mongo.Database
 	chunksColl *mongo.Collection // collection to store file chunks
 	filesColl  *mongo.Collection // collection to store file metadata
 
-	name      string
-	chunkSize int32
-	wc        *writeconcern.WriteConcern
-	rc        *readconcern.ReadConcern
-	rp        *readpref.ReadPref
+	name               string
+	chunkSize          int32
+	maxDownloadRetries int
+	wc                 *writeconcern.WriteConcern
+	rc                 *readconcern.ReadConcern
+	rp                 *readpref.ReadPref
 
 	firstWriteDone bool
 	readBuf        []byte
@@ -76,6 +81,9 @@ func NewBucket(db *mongo.Database, opts ...*options.BucketOptions) (*Bucket, err
 	if bo.ChunkSizeBytes != nil {
 		b.chunkSize = *bo.ChunkSizeBytes
 	}
+	if bo.MaxDownloadRetries != nil {
+		b.maxDownloadRetries = *bo.MaxDownloadRetries
+	}
 	if bo.WriteConcern != nil {
 		b.wc = bo.WriteConcern
 	}
@@ -174,6 +182,108 @@ func (b *Bucket) UploadFromStreamWithID(fileID primitive.ObjectID, filename stri
 	return us.Close()
 }
 
+// ResumeUploadStream reopens an interrupted upload of the file with the given ID so that more data can be appended
+// to it. The chunks stored so far are kept up to the first missing or short chunk, and any later chunks are deleted.
+// The returned stream's Offset is the number of bytes kept, and the caller must continue writing the file from
+// that offset. The upload options, in particular the chunk size, must match those of the interrupted upload.
+// ErrUploadComplete is returned if the file's files collection document already exists.
+func (b *Bucket) ResumeUploadStream(fileID primitive.ObjectID, filename string, opts ...*options.UploadOptions) (*UploadStream, error) {
+	ctx, cancel := deadlineContext(b.writeDeadline)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	if err := b.checkFirstWrite(ctx); err != nil {
+		return nil, err
+	}
+
+	upload, err := b.parseUploadOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = b.checkIncomplete(ctx, fileID); err != nil {
+		return nil, err
+	}
+
+	chunksCursor, err := b.findChunks(ctx, fileID, 0)
+	if err != nil {
+		return nil, err
+	}
+	next, err := resumePoint(ctx, chunksCursor, upload.chunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = b.chunksColl.DeleteMany(ctx, bsonx.Doc{
+		{"files_id", bsonx.ObjectID(fileID)},
+		{"n", bsonx.Document(bsonx.Doc{{"$gte", bsonx.Int32(next)}})},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	us := newUploadStream(upload, fileID, filename, b.chunksColl, b.filesColl)
+	us.chunkIndex = int(next)
+	us.fileLen = int64(next) * int64(upload.chunkSize)
+	return us, nil
+}
+
+// ResumeUploadFromStream resumes an interrupted upload of the file with the given ID, seeking source to the end
+// of the data already stored and uploading the rest of it. Unlike UploadFromStream, an error reading source
+// leaves the chunks written so far in place so the upload can be resumed again.
+func (b *Bucket) ResumeUploadFromStream(fileID primitive.ObjectID, filename string, source io.ReadSeeker, opts ...*options.UploadOptions) error {
+	us, err := b.ResumeUploadStream(fileID, filename, opts...)
+	if err != nil {
+		return err
+	}
+
+	if _, err = source.Seek(us.Offset(), io.SeekStart); err != nil {
+		return err
+	}
+
+	err = us.SetWriteDeadline(b.writeDeadline)
+	if err != nil {
+		return err
+	}
+
+	for {
+		n, err := source.Read(b.readBuf)
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		if n > 0 {
+			_, err := us.Write(b.readBuf[:n])
+			if err != nil {
+				return err
+			}
+		}
+
+		if n == 0 || err == io.EOF {
+			break
+		}
+	}
+
+	return us.Close()
+}
+
+// CleanupIncompleteUpload deletes the chunks of an interrupted upload of the file with the given ID.
+// ErrUploadComplete is returned, and nothing is deleted, if the file's files collection document exists. The
+// caller must ensure the upload is not still in progress elsewhere.
+func (b *Bucket) CleanupIncompleteUpload(fileID primitive.ObjectID) error {
+	ctx, cancel := deadlineContext(b.writeDeadline)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	if err := b.checkIncomplete(ctx, fileID); err != nil {
+		return err
+	}
+
+	return b.deleteChunks(ctx, fileID)
+}
+
 // OpenDownloadStream creates a stream from which the contents of the file can be read.
 func (b *Bucket) OpenDownloadStream(fileID primitive.ObjectID) (*DownloadStream, error) {
 	return b.openDownloadStream(bsonx.Doc{
@@ -341,11 +451,18 @@ func (b *Bucket) openDownloadStream(filter interface{}, opts ...*options.FindOpt
 		return newDownloadStream(nil, b.chunkSize, 0), nil
 	}
 
-	chunksCursor, err := b.findChunks(ctx, fileIDElem.ObjectID())
+	fileID := fileIDElem.ObjectID()
+	chunksCursor, err := b.findChunks(ctx, fileID, 0)
 	if err != nil {
 		return nil, err
 	}
-	return newDownloadStream(chunksCursor, b.chunkSize, int64(fileLen)), nil
+
+	ds := newDownloadStream(chunksCursor, b.chunkSize, int64(fileLen))
+	ds.maxRetries = b.maxDownloadRetries
+	ds.findChunks = func(ctx context.Context, from int32) (mongo.Cursor, error) {
+		return b.findChunks(ctx, fileID, from)
+	}
+	return ds, nil
 }
 
 func deadlineContext(deadline time.Time) (context.Context, context.CancelFunc) {
@@ -377,6 +494,52 @@ func (b *Bucket) deleteChunks(ctx context.Context, fileID primitive.ObjectID) er
 	return err
 }
 
+// checkIncomplete returns ErrUploadComplete if the files collection has a document for the file.
+func (b *Bucket) checkIncomplete(ctx context.Context, fileID primitive.ObjectID) error {
+	// read from the primary so a document inserted just before the upload was interrupted is found
+	cloned, err := b.filesColl.Clone(options.Collection().SetReadPreference(readpref.Primary()))
+	if err != nil {
+		return err
+	}
+
+	err = cloned.FindOne(ctx, bsonx.Doc{{"_id", bsonx.ObjectID(fileID)}},
+		options.FindOne().SetProjection(bsonx.Doc{{"_id", bsonx.Int32(1)}})).Err()
+	switch err {
+	case nil:
+		return ErrUploadComplete
+	case mongo.ErrNoDocuments:
+		return nil
+	default:
+		return err
+	}
+}
+
+// resumePoint returns the index of the first chunk of an interrupted upload that is missing or shorter than
+// chunkSize. The chunks before it hold the first index*chunkSize bytes of the file. cursor must return the chunks
+// sorted by index and is closed.
+func resumePoint(ctx context.Context, cursor mongo.Cursor, chunkSize int32) (int32, error) {
+	defer func() {
+		_ = cursor.Close(ctx)
+	}()
+
+	var next int32
+	for cursor.Next(ctx) {
+		var chunk struct {
+			N    int32  `bson:"n"`
+			Data []byte `bson:"data"`
+		}
+		if err := cursor.Decode(&chunk); err != nil {
+			return 0, err
+		}
+		if chunk.N != next || len(chunk.Data) != int(chunkSize) {
+			break
+		}
+		next++
+	}
+
+	return next, cursor.Err()
+}
+
 func (b *Bucket) findFile(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (mongo.Cursor, error) {
 	cursor, err := b.filesColl.Find(ctx, filter, opts...)
 	if err != nil {
@@ -391,9 +554,14 @@ func (b *Bucket) findFile(ctx context.Context, filter interface{}, opts ...*opti
 	return cursor, nil
 }
 
-func (b *Bucket) findChunks(ctx context.Context, fileID primitive.ObjectID) (mongo.Cursor, error) {
-	chunksCursor, err := b.chunksColl.Find(ctx,
-		bsonx.Doc{{"files_id", bsonx.ObjectID(fileID)}},
+// findChunks finds the chunks of a file starting at the chunk with index from.
+func (b *Bucket) findChunks(ctx context.Context, fileID primitive.ObjectID, from int32) (mongo.Cursor, error) {
+	filter := bsonx.Doc{{"files_id", bsonx.ObjectID(fileID)}}
+	if from > 0 {
+		filter = append(filter, bsonx.Elem{"n", bsonx.Document(bsonx.Doc{{"$gte", bsonx.Int32(from)}})})
+	}
+
+	chunksCursor, err := b.chunksColl.Find(ctx, filter,
 		options.Find().SetSort(bsonx.Doc{{"n", bsonx.Int32(1)}})) // sort by chunk index
 	if err != nil {
 		return nil, err
@@ -17,9 +17,12 @@ import (
 	"math"
 
 	"github.com/mongodb/mongo-go-driver/mongo"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
+	"github.com/mongodb/mongo-go-driver/x/network/connection"
 )
 
-// ErrWrongIndex is used when the chunk retrieved from the server does not have the expected index.
+// ErrWrongIndex is used when the chunk retrieved from the server does not have the expected index, for example because
+// a chunk is missing or duplicated.
 var ErrWrongIndex = errors.New("chunk index does not match expected index")
 
 // ErrWrongSize is used when the chunk retrieved from the server does not have the expected size.
@@ -36,9 +39,15 @@ type DownloadStream struct {
 	closed        bool
 	buffer        []byte // store up to 1 chunk if the user provided buffer isn't big enough
 	bufferStart   int
+	bufferEnd     int
 	expectedChunk int32 // index of next expected chunk
 	readDeadline  time.Time
 	fileLen       int64
+
+	// findChunks reopens the chunks cursor at the given chunk index to resume after a retryable error.
+	findChunks func(ctx context.Context, from int32) (mongo.Cursor, error)
+	maxRetries int
+	retries    int
 }
 
 func newDownloadStream(cursor mongo.Cursor, chunkSize int32, fileLen int64) *DownloadStream {
@@ -93,7 +102,7 @@ func (ds *DownloadStream) Read(p []byte) (int, error) {
 	var err error
 
 	for bytesCopied < len(p) {
-		if ds.bufferStart == 0 {
+		if ds.bufferStart == ds.bufferEnd {
 			// buffer empty
 			err = ds.fillBuffer(ctx)
 			if err != nil {
@@ -105,9 +114,9 @@ func (ds *DownloadStream) Read(p []byte) (int, error) {
 			}
 		}
 
-		copied := copy(p, ds.buffer[ds.bufferStart:])
+		copied := copy(p[bytesCopied:], ds.buffer[ds.bufferStart:ds.bufferEnd])
 		bytesCopied += copied
-		ds.bufferStart = (ds.bufferStart + copied) % int(ds.chunkSize)
+		ds.bufferStart += copied
 	}
 
 	return len(p), nil
@@ -132,7 +141,7 @@ func (ds *DownloadStream) Skip(skip int64) (int64, error) {
 	var err error
 
 	for skipped < skip {
-		if ds.bufferStart == 0 {
+		if ds.bufferStart == ds.bufferEnd {
 			err = ds.fillBuffer(ctx)
 			if err != nil {
 				if err == errNoMoreChunks {
@@ -143,29 +152,38 @@ func (ds *DownloadStream) Skip(skip int64) (int64, error) {
 			}
 		}
 
-		// try to skip whole chunk if possible
-		toSkip := 0
-		if skip-skipped < int64(len(ds.buffer)) {
-			// can skip whole chunk
-			toSkip = len(ds.buffer)
-		} else {
+		// skip the rest of the buffered chunk if possible
+		toSkip := ds.bufferEnd - ds.bufferStart
+		if skip-skipped < int64(toSkip) {
 			// can only skip part of buffer
 			toSkip = int(skip - skipped)
 		}
 
 		skipped += int64(toSkip)
-		ds.bufferStart = (ds.bufferStart + toSkip) % (int(ds.chunkSize))
+		ds.bufferStart += toSkip
 	}
 
 	return skip, nil
 }
 
 func (ds *DownloadStream) fillBuffer(ctx context.Context) error {
-	if !ds.cursor.Next(ctx) {
+	if ds.expectedChunk == ds.numChunks {
 		ds.done = true
 		return errNoMoreChunks
 	}
 
+	for !ds.cursor.Next(ctx) {
+		err := ds.cursor.Err()
+		if err == nil {
+			// the cursor ran out of chunks before the final chunk
+			return ErrWrongIndex
+		}
+
+		if err = ds.resume(ctx, err); err != nil {
+			return err
+		}
+	}
+
 	nextChunk, err := ds.cursor.DecodeBytes()
 	if err != nil {
 		return err
@@ -176,7 +194,8 @@ func (ds *DownloadStream) fillBuffer(ctx context.Context) error {
 		return err
 	}
 
-	if chunkIndex.Int32() != ds.expectedChunk {
+	// Resuming restarts the cursor at the expected chunk, so a gap or duplicate left by a resume is caught here too.
+	if n, ok := chunkIndex.Int32OK(); !ok || n != ds.expectedChunk {
 		return ErrWrongIndex
 	}
 
@@ -204,5 +223,42 @@ func (ds *DownloadStream) fillBuffer(ctx context.Context) error {
 
 	copy(ds.buffer, dataBytes)
 	ds.bufferStart = 0
+	ds.bufferEnd = len(dataBytes)
 	return nil
 }
+
+// resume reopens the chunks cursor at the next expected chunk if err is retryable and retries remain. It returns the
+// error that ended the download, or nil if the download can continue.
+func (ds *DownloadStream) resume(ctx context.Context, err error) error {
+	if ds.findChunks == nil || ds.retries >= ds.maxRetries || !isRetryableReadError(err) {
+		return err
+	}
+
+	_ = ds.cursor.Close(ctx)
+	for ds.retries < ds.maxRetries {
+		ds.retries++
+
+		var cursor mongo.Cursor
+		cursor, err = ds.findChunks(ctx, ds.expectedChunk)
+		if err == nil {
+			ds.cursor = cursor
+			return nil
+		}
+		if !isRetryableReadError(err) {
+			break
+		}
+	}
+
+	return err
+}
+
+func isRetryableReadError(err error) bool {
+	switch e := err.(type) {
+	case command.Error:
+		return e.Retryable()
+	case connection.NetworkError:
+		return true
+	default:
+		return false
+	}
+}
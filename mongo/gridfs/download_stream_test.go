@@ -0,0 +1,158 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package gridfs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
+	"github.com/mongodb/mongo-go-driver/x/network/connection"
+	"github.com/stretchr/testify/require"
+)
+
+// chunkCursor is a mongo.Cursor over chunk documents that fails with err once the documents run out.
+type chunkCursor struct {
+	docs    []bson.Raw
+	current int
+	err     error
+	closed  bool
+}
+
+func newChunkCursor(t *testing.T, chunkSize int, err error, indexes ...int32) *chunkCursor {
+	cursor := &chunkCursor{current: -1, err: err}
+	for _, n := range indexes {
+		doc, marshalErr := bsonx.Doc{
+			{"n", bsonx.Int32(n)},
+			{"data", bsonx.Binary(0x00, bytes.Repeat([]byte{byte(n)}, chunkSize))},
+		}.MarshalBSON()
+		require.NoError(t, marshalErr)
+		cursor.docs = append(cursor.docs, doc)
+	}
+	return cursor
+}
+
+func (cc *chunkCursor) ID() int64 { return 0 }
+
+func (cc *chunkCursor) RemainingBatchLength() int { return len(cc.docs) - cc.current - 1 }
+
+func (cc *chunkCursor) Next(context.Context) bool {
+	if cc.current+1 >= len(cc.docs) {
+		return false
+	}
+	cc.current++
+	return true
+}
+
+func (cc *chunkCursor) Decode(v interface{}) error { return bson.Unmarshal(cc.docs[cc.current], v) }
+
+func (cc *chunkCursor) DecodeBytes() (bson.Raw, error) { return cc.docs[cc.current], nil }
+
+func (cc *chunkCursor) Err() error {
+	if cc.current+1 >= len(cc.docs) {
+		return cc.err
+	}
+	return nil
+}
+
+func (cc *chunkCursor) ForEach(ctx context.Context, fn func(bson.Raw) error) error {
+	return command.ForEach(ctx, cc, fn)
+}
+
+func (cc *chunkCursor) Close(context.Context) error {
+	cc.closed = true
+	return nil
+}
+
+func TestDownloadStreamResume(t *testing.T) {
+	const chunkSize = 4
+	const fileLen = 3*chunkSize + 2 // four chunks, the last one short
+
+	networkErr := connection.NetworkError{ConnectionID: "test", Wrapped: errors.New("connection reset")}
+	expected := append(bytes.Repeat([]byte{0}, chunkSize), bytes.Repeat([]byte{1}, chunkSize)...)
+	expected = append(expected, bytes.Repeat([]byte{2}, chunkSize)...)
+	expected = append(expected, 3, 3)
+
+	shortLast := func(t *testing.T, err error, indexes ...int32) *chunkCursor {
+		c := newChunkCursor(t, chunkSize, err, indexes...)
+		for i, n := range indexes {
+			if n == 3 {
+				doc, marshalErr := bsonx.Doc{
+					{"n", bsonx.Int32(n)},
+					{"data", bsonx.Binary(0x00, []byte{3, 3})},
+				}.MarshalBSON()
+				require.NoError(t, marshalErr)
+				c.docs[i] = doc
+			}
+		}
+		return c
+	}
+
+	testCases := []struct {
+		name       string
+		first      *chunkCursor
+		resumed    *chunkCursor
+		resumeErr  error
+		maxRetries int
+		wantFrom   int32
+		wantErr    error
+	}{
+		{"resumes at next chunk", shortLast(t, networkErr, 0, 1), shortLast(t, nil, 2, 3), nil, 1, 2, nil},
+		{"duplicate chunk after resume", shortLast(t, networkErr, 0, 1), shortLast(t, nil, 1, 2, 3), nil, 1, 2, ErrWrongIndex},
+		{"gap after resume", shortLast(t, networkErr, 0, 1), shortLast(t, nil, 3), nil, 1, 2, ErrWrongIndex},
+		{"truncated after resume", shortLast(t, networkErr, 0, 1), shortLast(t, nil, 2), nil, 1, 2, ErrWrongIndex},
+		{"retries exhausted", shortLast(t, networkErr, 0, 1), nil, nil, 0, -1, networkErr},
+		{"retries exhausted by failed resume", shortLast(t, networkErr, 0, 1), nil, networkErr, 1, 2, networkErr},
+		{"non-retryable error", shortLast(t, command.Error{Code: 2}, 0, 1), nil, nil, 1, -1, command.Error{Code: 2}},
+		{"missing final chunk", shortLast(t, nil, 0, 1, 2), nil, nil, 1, -1, ErrWrongIndex},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := newDownloadStream(tc.first, chunkSize, fileLen)
+			ds.maxRetries = tc.maxRetries
+			from := int32(-1)
+			ds.findChunks = func(ctx context.Context, n int32) (mongo.Cursor, error) {
+				from = n
+				if tc.resumeErr != nil {
+					return nil, tc.resumeErr
+				}
+				return tc.resumed, nil
+			}
+
+			got, err := ioutil.ReadAll(ds)
+			require.Equal(t, tc.wantErr, err)
+			require.Equal(t, tc.wantFrom, from)
+			if tc.wantErr == nil {
+				require.Equal(t, expected, got)
+				require.True(t, tc.first.closed, "expected the failed cursor to be closed")
+			}
+		})
+	}
+
+	t.Run("skip across resume", func(t *testing.T) {
+		ds := newDownloadStream(shortLast(t, networkErr, 0, 1), chunkSize, fileLen)
+		ds.maxRetries = 1
+		ds.findChunks = func(ctx context.Context, n int32) (mongo.Cursor, error) {
+			return shortLast(t, nil, 2, 3), nil
+		}
+
+		skipped, err := ds.Skip(chunkSize + 2)
+		require.NoError(t, err)
+		require.Equal(t, int64(chunkSize+2), skipped)
+
+		got, err := ioutil.ReadAll(ds)
+		require.NoError(t, err)
+		require.Equal(t, expected[chunkSize+2:], got)
+	})
+}
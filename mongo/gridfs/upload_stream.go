@@ -27,6 +27,11 @@ const UploadBufferSize = 16 * 1000000 // 16 MB
 var ErrStreamClosed = errors.New("stream is closed or aborted")
 
 // UploadStream is used to upload files in chunks.
+//
+// Chunks are written to the chunks collection each time UploadBufferSize bytes have been buffered, and the file
+// becomes visible only when Close inserts its files collection document. If an upload is interrupted before then,
+// the chunks already written are orphaned: they can be appended to with Bucket.ResumeUploadStream or removed with
+// Bucket.CleanupIncompleteUpload.
 type UploadStream struct {
 	*Upload // chunk size and metadata
 	FileID  primitive.ObjectID
@@ -44,6 +49,12 @@ type UploadStream struct {
 
 // NewUploadStream creates a new upload stream.
 func newUploadStream(upload *Upload, fileID primitive.ObjectID, filename string, chunks *mongo.Collection, files *mongo.Collection) *UploadStream {
+	// hold a whole number of chunks so every chunk but the last is full size
+	bufferSize := UploadBufferSize - UploadBufferSize%int(upload.chunkSize)
+	if bufferSize == 0 {
+		bufferSize = int(upload.chunkSize)
+	}
+
 	return &UploadStream{
 		Upload: upload,
 		FileID: fileID,
@@ -51,11 +62,12 @@ func newUploadStream(upload *Upload, fileID primitive.ObjectID, filename string,
 		chunksColl: chunks,
 		filename:   filename,
 		filesColl:  files,
-		buffer:     make([]byte, UploadBufferSize),
+		buffer:     make([]byte, bufferSize),
 	}
 }
 
-// Close closes this upload stream.
+// Close uploads any buffered data and inserts the files collection document, which makes the file visible to
+// readers. An error before that insert leaves the chunks written so far in place to be resumed or cleaned up.
 func (us *UploadStream) Close() error {
 	if us.closed {
 		return ErrStreamClosed
@@ -106,7 +118,7 @@ func (us *UploadStream) Write(p []byte) (int, error) {
 
 	origLen := len(p)
 	for {
-		if us.bufferIndex == UploadBufferSize {
+		if us.bufferIndex == len(us.buffer) {
 			err := us.uploadChunks(ctx)
 			if err != nil {
 				return 0, err
@@ -121,12 +133,17 @@ func (us *UploadStream) Write(p []byte) (int, error) {
 		n := copy(us.buffer[us.bufferIndex:], p) // copy as much as possible
 		p = p[n:]
 		us.bufferIndex += n
-		break
 	}
 
 	return origLen, nil
 }
 
+// Offset returns the number of bytes written to this stream, including those stored before it was resumed with
+// Bucket.ResumeUploadStream.
+func (us *UploadStream) Offset() int64 {
+	return us.fileLen + int64(us.bufferIndex)
+}
+
 // Abort closes the stream and deletes all file chunks that have already been written.
 func (us *UploadStream) Abort() error {
 	if us.closed {
@@ -152,23 +169,19 @@ func (us *UploadStream) uploadChunks(ctx context.Context) error {
 
 	docs := make([]interface{}, int(numChunks))
 
-	for i := 0; i < us.bufferIndex; i += int(us.chunkSize) {
-		var chunkData []byte
-		if us.bufferIndex-i < int(us.chunkSize) {
-			chunkData = us.buffer[i:us.bufferIndex]
-		} else {
-			chunkData = us.buffer[i : i+int(us.chunkSize)]
+	for i := range docs {
+		start := i * int(us.chunkSize)
+		end := start + int(us.chunkSize)
+		if end > us.bufferIndex {
+			end = us.bufferIndex
 		}
 
-		docs[us.chunkIndex] = bsonx.Doc{
+		docs[i] = bsonx.Doc{
 			{"_id", bsonx.ObjectID(primitive.NewObjectID())},
 			{"files_id", bsonx.ObjectID(us.FileID)},
-			{"n", bsonx.Int32(int32(us.chunkIndex))},
-			{"data", bsonx.Binary(0x00, chunkData)},
+			{"n", bsonx.Int32(int32(us.chunkIndex + i))},
+			{"data", bsonx.Binary(0x00, us.buffer[start:end])},
 		}
-
-		us.chunkIndex++
-		us.fileLen += int64(len(chunkData))
 	}
 
 	_, err := us.chunksColl.InsertMany(ctx, docs)
@@ -176,6 +189,8 @@ func (us *UploadStream) uploadChunks(ctx context.Context) error {
 		return err
 	}
 
+	us.chunkIndex += len(docs)
+	us.fileLen += int64(us.bufferIndex)
 	return nil
 }
 
@@ -0,0 +1,133 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package gridfs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/mongodb/mongo-go-driver/internal/testutil"
+	"github.com/mongodb/mongo-go-driver/mongo"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumePoint(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name      string
+		cursor    *chunkCursor
+		chunkSize int32
+		next      int32
+	}{
+		{"no chunks", newChunkCursor(t, 4, nil), 4, 0},
+		{"contiguous chunks", newChunkCursor(t, 4, nil, 0, 1, 2), 4, 3},
+		{"missing chunk", newChunkCursor(t, 4, nil, 0, 1, 3, 4), 4, 2},
+		{"missing first chunk", newChunkCursor(t, 4, nil, 1, 2), 4, 0},
+		{"short chunks", newChunkCursor(t, 3, nil, 0, 1), 4, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, err := resumePoint(ctx, tc.cursor, tc.chunkSize)
+			require.NoError(t, err)
+			require.Equal(t, tc.next, next)
+			require.True(t, tc.cursor.closed)
+		})
+	}
+
+	t.Run("short last chunk", func(t *testing.T) {
+		cursor := newChunkCursor(t, 4, nil, 0, 1)
+		short, err := bsonx.Doc{{"n", bsonx.Int32(2)}, {"data", bsonx.Binary(0x00, []byte{1})}}.MarshalBSON()
+		require.NoError(t, err)
+		cursor.docs = append(cursor.docs, short)
+
+		next, err := resumePoint(ctx, cursor, 4)
+		require.NoError(t, err)
+		require.Equal(t, int32(2), next)
+	})
+
+	t.Run("cursor error", func(t *testing.T) {
+		cursorErr := errors.New("cursor failed")
+		_, err := resumePoint(ctx, newChunkCursor(t, 4, cursorErr, 0), 4)
+		require.Equal(t, cursorErr, err)
+	})
+}
+
+func TestBucket_ResumeUpload(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	cs := testutil.ConnString(t)
+	client, err := mongo.NewClient(cs.String())
+	require.NoError(t, err)
+	require.NoError(t, client.Connect(ctx))
+	defer func() { _ = client.Disconnect(ctx) }()
+
+	db := client.Database("gridFSResumeTestDB")
+	require.NoError(t, db.Drop(ctx))
+	defer func() { _ = db.Drop(ctx) }()
+
+	const chunkSize = 4
+	bucket, err := NewBucket(db, options.GridFSBucket().SetChunkSizeBytes(chunkSize))
+	require.NoError(t, err)
+
+	data := []byte("abcdefghijklmnopqrstuvwxyz")
+
+	// simulate an upload interrupted after two full chunks and a short one were written
+	fileID := primitive.NewObjectID()
+	var chunkDocs []interface{}
+	for n, chunk := range [][]byte{data[0:4], data[4:8], data[8:10]} {
+		chunkDocs = append(chunkDocs, bsonx.Doc{
+			{"_id", bsonx.ObjectID(primitive.NewObjectID())},
+			{"files_id", bsonx.ObjectID(fileID)},
+			{"n", bsonx.Int32(int32(n))},
+			{"data", bsonx.Binary(0x00, chunk)},
+		})
+	}
+	_, err = db.Collection("fs.chunks").InsertMany(ctx, chunkDocs)
+	require.NoError(t, err)
+
+	us, err := bucket.ResumeUploadStream(fileID, "resumed")
+	require.NoError(t, err)
+	require.Equal(t, int64(8), us.Offset())
+
+	require.NoError(t, bucket.ResumeUploadFromStream(fileID, "resumed", bytes.NewReader(data)))
+
+	ds, err := bucket.OpenDownloadStream(fileID)
+	require.NoError(t, err)
+	downloaded, err := ioutil.ReadAll(ds)
+	require.NoError(t, err)
+	require.Equal(t, data, downloaded)
+
+	_, err = bucket.ResumeUploadStream(fileID, "resumed")
+	require.Equal(t, ErrUploadComplete, err)
+	require.Equal(t, ErrUploadComplete, bucket.CleanupIncompleteUpload(fileID))
+
+	t.Run("cleanup", func(t *testing.T) {
+		orphanID := primitive.NewObjectID()
+		_, err := db.Collection("fs.chunks").InsertOne(ctx, bsonx.Doc{
+			{"files_id", bsonx.ObjectID(orphanID)},
+			{"n", bsonx.Int32(0)},
+			{"data", bsonx.Binary(0x00, data[:chunkSize])},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, bucket.CleanupIncompleteUpload(orphanID))
+		count, err := db.Collection("fs.chunks").Count(ctx, bsonx.Doc{{"files_id", bsonx.ObjectID(orphanID)}})
+		require.NoError(t, err)
+		require.Equal(t, int64(0), count)
+	})
+}
@@ -118,7 +118,8 @@ func (iob *IndexOptionsBuilder) BucketSize(bucketSize int32) *IndexOptionsBuilde
 	return iob
 }
 
-// PartialFilterExpression sets the partialFilterExpression option
+// PartialFilterExpression sets the partialFilterExpression option. IndexView.CreateMany rejects expressions that use
+// operators partial indexes do not support unless CreateIndexesOptions.SetSkipPartialFilterValidation is used.
 func (iob *IndexOptionsBuilder) PartialFilterExpression(partialFilterExpression bsonx.Doc) *IndexOptionsBuilder {
 	iob.document = append(iob.document, bsonx.Elem{"partialFilterExpression", bsonx.Document(partialFilterExpression)})
 	return iob
@@ -130,6 +131,13 @@ func (iob *IndexOptionsBuilder) Collation(collation bsonx.Doc) *IndexOptionsBuil
 	return iob
 }
 
+// WildcardProjection sets the wildcardProjection option, which selects the fields covered by a wildcard index
+// on "$**". It cannot be used with any other keys.
+func (iob *IndexOptionsBuilder) WildcardProjection(wildcardProjection bsonx.Doc) *IndexOptionsBuilder {
+	iob.document = append(iob.document, bsonx.Elem{"wildcardProjection", bsonx.Document(wildcardProjection)})
+	return iob
+}
+
 // Build returns the BSON document from the builder
 func (iob *IndexOptionsBuilder) Build() bsonx.Doc {
 	return iob.document
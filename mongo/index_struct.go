@@ -0,0 +1,202 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+// structIndex accumulates the keys and options of a single index while the struct is being walked.
+type structIndex struct {
+	name   string
+	keys   bsonx.Doc
+	unique bool
+	sparse bool
+	ttl    *time.Duration
+}
+
+// IndexViewFromStruct generates the IndexModels described by the index struct tags of v, which must be a
+// struct or a pointer to a struct. The result can be passed to IndexView.CreateMany.
+//
+// The index key for a field is the key the field is marshaled to, taken from its bson struct tag. The index
+// tag is a comma separated list of the following options, any of which may be omitted:
+//
+//	unique          Create a unique index.
+//	sparse          Create a sparse index.
+//	desc            Index the field in descending order. Fields are indexed in ascending order by default.
+//	ttl=<duration>  Create a TTL index that expires documents after the given duration, which is parsed
+//	                with time.ParseDuration and must be a whole number of seconds.
+//	name=<name>     Set the name of the index. Fields that share a name are combined, in field order,
+//	                into a single compound index.
+//
+// An example:
+//
+//	type User struct {
+//	    Email     string    `bson:"email" index:"unique"`
+//	    Last      string    `bson:"last" index:"name=full_name"`
+//	    First     string    `bson:"first" index:"name=full_name"`
+//	    CreatedAt time.Time `bson:"createdAt" index:"ttl=24h"`
+//	}
+//
+// Fields of struct and pointer to struct type that do not have an index tag themselves are searched for
+// index tags. Their keys are prefixed with the key of the field, unless the field is inlined.
+func IndexViewFromStruct(v interface{}) ([]IndexModel, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot generate indexes from %T, only structs and pointers to structs are supported", v)
+	}
+
+	var indexes []*structIndex
+	named := make(map[string]*structIndex)
+	if err := collectStructIndexes(t, "", map[reflect.Type]bool{}, &indexes, named); err != nil {
+		return nil, err
+	}
+
+	models := make([]IndexModel, 0, len(indexes))
+	for _, idx := range indexes {
+		opts := NewIndexOptionsBuilder()
+		if idx.name != "" {
+			opts.Name(idx.name)
+		}
+		if idx.unique {
+			opts.Unique(true)
+		}
+		if idx.sparse {
+			opts.Sparse(true)
+		}
+		if idx.ttl != nil {
+			if len(idx.keys) > 1 {
+				return nil, fmt.Errorf("index %s is a compound index and cannot have a ttl", idx.name)
+			}
+			opts.ExpireAfterSeconds(int32(*idx.ttl / time.Second))
+		}
+
+		model := IndexModel{Keys: idx.keys}
+		if doc := opts.Build(); len(doc) > 0 {
+			model.Options = doc
+		}
+		models = append(models, model)
+	}
+
+	return models, nil
+}
+
+// collectStructIndexes walks the fields of t, appending the indexes described by their index tags to indexes.
+// Indexes with a name are also recorded in named so fields that share the name are added to the same index.
+// The visiting map holds the struct types currently being walked so recursive types terminate.
+func collectStructIndexes(t reflect.Type, prefix string, visiting map[reflect.Type]bool,
+	indexes *[]*structIndex, named map[string]*structIndex) error {
+
+	if visiting[t] {
+		return nil
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			// unexported, ignore
+			continue
+		}
+
+		stags, err := bsoncodec.DefaultStructTagParser(sf)
+		if err != nil {
+			return err
+		}
+		if stags.Skip {
+			continue
+		}
+
+		key := prefix + stags.Name
+		tag, ok := sf.Tag.Lookup("index")
+		if !ok {
+			ft := sf.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() != reflect.Struct {
+				continue
+			}
+
+			nested := key + "."
+			if stags.Inline {
+				nested = prefix
+			}
+			if err = collectStructIndexes(ft, nested, visiting, indexes, named); err != nil {
+				return err
+			}
+			continue
+		}
+		if tag == "-" {
+			continue
+		}
+
+		var name string
+		var unique, sparse bool
+		var ttl *time.Duration
+		direction := int32(1)
+		for _, opt := range strings.Split(tag, ",") {
+			opt = strings.TrimSpace(opt)
+			switch {
+			case opt == "":
+			case opt == "unique":
+				unique = true
+			case opt == "sparse":
+				sparse = true
+			case opt == "desc":
+				direction = -1
+			case strings.HasPrefix(opt, "name="):
+				name = strings.TrimPrefix(opt, "name=")
+				if name == "" {
+					return fmt.Errorf("(struct %s) field %s has an empty index name", t.String(), sf.Name)
+				}
+			case strings.HasPrefix(opt, "ttl="):
+				d, err := time.ParseDuration(strings.TrimPrefix(opt, "ttl="))
+				if err != nil {
+					return fmt.Errorf("(struct %s) field %s has an invalid ttl: %v", t.String(), sf.Name, err)
+				}
+				if d < 0 || d%time.Second != 0 {
+					return fmt.Errorf("(struct %s) field %s ttl must be a non-negative whole number of seconds, got %v",
+						t.String(), sf.Name, d)
+				}
+				ttl = &d
+			default:
+				return fmt.Errorf("(struct %s) field %s has unknown index option %q", t.String(), sf.Name, opt)
+			}
+		}
+
+		idx, exists := named[name]
+		if name == "" || !exists {
+			idx = &structIndex{name: name}
+			*indexes = append(*indexes, idx)
+			if name != "" {
+				named[name] = idx
+			}
+		}
+		if _, err = idx.keys.LookupErr(key); err == nil {
+			return fmt.Errorf("(struct %s) duplicated key %s in index %s", t.String(), key, name)
+		}
+		idx.keys = append(idx.keys, bsonx.Elem{key, bsonx.Int32(direction)})
+		idx.unique = idx.unique || unique
+		idx.sparse = idx.sparse || sparse
+		if ttl != nil {
+			idx.ttl = ttl
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+type indexedAddress struct {
+	City string `bson:"city" index:""`
+	Zip  string `bson:"zip" index:"sparse"`
+}
+
+type IndexedAudit struct {
+	CreatedAt time.Time `bson:"createdAt" index:"ttl=24h"`
+	CreatedBy string    `bson:"createdBy"`
+}
+
+type indexedUser struct {
+	IndexedAudit `bson:",inline"`
+	Email        string          `bson:"email" index:"unique"`
+	Last         string          `bson:"last" index:"name=full_name,unique"`
+	First        string          `bson:"first" index:"name=full_name,desc"`
+	Address      *indexedAddress `bson:"address"`
+	Previous     indexedAddress
+	Manager      *indexedUser `bson:"manager"`
+	Ignored      string       `bson:"-" index:"unique"`
+	Untagged     string
+	secret       string `index:"unique"`
+}
+
+func TestIndexViewFromStruct(t *testing.T) {
+	t.Run("generates indexes", func(t *testing.T) {
+		expected := []IndexModel{
+			{
+				Keys:    bsonx.Doc{{"createdAt", bsonx.Int32(1)}},
+				Options: bsonx.Doc{{"expireAfterSeconds", bsonx.Int32(86400)}},
+			},
+			{
+				Keys:    bsonx.Doc{{"email", bsonx.Int32(1)}},
+				Options: bsonx.Doc{{"unique", bsonx.Boolean(true)}},
+			},
+			{
+				Keys:    bsonx.Doc{{"last", bsonx.Int32(1)}, {"first", bsonx.Int32(-1)}},
+				Options: bsonx.Doc{{"name", bsonx.String("full_name")}, {"unique", bsonx.Boolean(true)}},
+			},
+			{Keys: bsonx.Doc{{"address.city", bsonx.Int32(1)}}},
+			{
+				Keys:    bsonx.Doc{{"address.zip", bsonx.Int32(1)}},
+				Options: bsonx.Doc{{"sparse", bsonx.Boolean(true)}},
+			},
+			{Keys: bsonx.Doc{{"previous.city", bsonx.Int32(1)}}},
+			{
+				Keys:    bsonx.Doc{{"previous.zip", bsonx.Int32(1)}},
+				Options: bsonx.Doc{{"sparse", bsonx.Boolean(true)}},
+			},
+		}
+
+		got, err := IndexViewFromStruct(indexedUser{})
+		require.NoError(t, err)
+		require.Equal(t, expected, got)
+
+		got, err = IndexViewFromStruct(&indexedUser{})
+		require.NoError(t, err)
+		require.Equal(t, expected, got)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			v    interface{}
+		}{
+			{"not a struct", 42},
+			{"nil", nil},
+			{"unknown option", struct {
+				A int `index:"clustered"`
+			}{}},
+			{"invalid ttl", struct {
+				A time.Time `index:"ttl=soon"`
+			}{}},
+			{"fractional ttl", struct {
+				A time.Time `index:"ttl=1500ms"`
+			}{}},
+			{"compound ttl", struct {
+				A time.Time `index:"name=a_b,ttl=1h"`
+				B int       `index:"name=a_b"`
+			}{}},
+			{"duplicated key", struct {
+				A int `index:"name=a"`
+				B int `bson:"a" index:"name=a"`
+			}{}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := IndexViewFromStruct(tc.v)
+				require.Error(t, err)
+			})
+		}
+	})
+}
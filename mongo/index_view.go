@@ -26,6 +26,10 @@ var ErrInvalidIndexValue = errors.New("invalid index value")
 // ErrNonStringIndexName indicates that the index name specified in the options is not a string.
 var ErrNonStringIndexName = errors.New("index name must be a string")
 
+// ErrInvalidWildcardProjection indicates that the wildcardProjection index option was used with keys other than a
+// single "$**" key.
+var ErrInvalidWildcardProjection = errors.New(`the wildcardProjection option requires an index on the single key "$**"`)
+
 // ErrMultipleIndexDrop indicates that multiple indexes would be dropped from a call to IndexView.DropOne.
 var ErrMultipleIndexDrop = errors.New("multiple indexes would be dropped")
 
@@ -42,6 +46,9 @@ type IndexModel struct {
 
 // List returns a cursor iterating over all the indexes in the collection.
 func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOptions) (Cursor, error) {
+	ctx, cancel := iv.coll.client.contextWithTimeout(ctx)
+	defer cancel()
+
 	sess := sessionFromContext(ctx)
 
 	err := iv.coll.client.ValidSession(sess)
@@ -55,13 +62,18 @@ func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOption
 		Clock:   iv.coll.client.clock,
 	}
 
+	listOpts := options.MergeListIndexesOptions(opts...)
+	if iv.coll.client.ignoresMaxTime() {
+		listOpts.MaxTime = nil
+	}
+
 	return driver.ListIndexes(
 		ctx, listCmd,
 		iv.coll.client.topology,
 		iv.coll.writeSelector,
 		iv.coll.client.id,
 		iv.coll.client.topology.SessionPool,
-		opts...,
+		listOpts,
 	)
 }
 
@@ -78,27 +90,30 @@ func (iv IndexView) CreateOne(ctx context.Context, model IndexModel, opts ...*op
 // CreateMany creates multiple indexes in the collection specified by the models. The names of the
 // creates indexes are returned.
 func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
+	ctx, cancel := iv.coll.client.contextWithTimeout(ctx)
+	defer cancel()
+
+	createOpts := options.MergeCreateIndexesOptions(opts...)
+	validatePartial := createOpts.SkipPartialFilterValidation == nil || !*createOpts.SkipPartialFilterValidation
+
 	names := make([]string, 0, len(models))
 	indexes := bsonx.Arr{}
 
 	for _, model := range models {
-		if model.Keys == nil {
-			return nil, fmt.Errorf("index model keys cannot be nil")
-		}
-
-		name, err := getOrGenerateIndexName(model)
+		index, name, err := indexDocument(model)
 		if err != nil {
 			return nil, err
 		}
 
-		names = append(names, name)
-
-		index := bsonx.Doc{{"key", bsonx.Document(model.Keys)}}
-		if model.Options != nil {
-			index = append(index, model.Options...)
+		if validatePartial {
+			if filter, err := model.Options.LookupErr("partialFilterExpression"); err == nil {
+				if err = validatePartialFilter(filter); err != nil {
+					return nil, err
+				}
+			}
 		}
-		index = index.Set("name", bsonx.String(name))
 
+		names = append(names, name)
 		indexes = append(indexes, bsonx.Document(index))
 	}
 
@@ -116,13 +131,17 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 		Clock:   iv.coll.client.clock,
 	}
 
+	if iv.coll.client.ignoresMaxTime() {
+		createOpts.MaxTime = nil
+	}
+
 	_, err = driver.CreateIndexes(
 		ctx, cmd,
 		iv.coll.client.topology,
 		iv.coll.writeSelector,
 		iv.coll.client.id,
 		iv.coll.client.topology.SessionPool,
-		opts...,
+		createOpts,
 	)
 	if err != nil {
 		return nil, err
@@ -133,6 +152,9 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 
 // DropOne drops the index with the given name from the collection.
 func (iv IndexView) DropOne(ctx context.Context, name string, opts ...*options.DropIndexesOptions) (bson.Raw, error) {
+	ctx, cancel := iv.coll.client.contextWithTimeout(ctx)
+	defer cancel()
+
 	if name == "*" {
 		return nil, ErrMultipleIndexDrop
 	}
@@ -151,18 +173,26 @@ func (iv IndexView) DropOne(ctx context.Context, name string, opts ...*options.D
 		Clock:   iv.coll.client.clock,
 	}
 
+	dropOpts := options.MergeDropIndexesOptions(opts...)
+	if iv.coll.client.ignoresMaxTime() {
+		dropOpts.MaxTime = nil
+	}
+
 	return driver.DropIndexes(
 		ctx, cmd,
 		iv.coll.client.topology,
 		iv.coll.writeSelector,
 		iv.coll.client.id,
 		iv.coll.client.topology.SessionPool,
-		opts...,
+		dropOpts,
 	)
 }
 
 // DropAll drops all indexes in the collection.
 func (iv IndexView) DropAll(ctx context.Context, opts ...*options.DropIndexesOptions) (bson.Raw, error) {
+	ctx, cancel := iv.coll.client.contextWithTimeout(ctx)
+	defer cancel()
+
 	sess := sessionFromContext(ctx)
 
 	err := iv.coll.client.ValidSession(sess)
@@ -177,16 +207,129 @@ func (iv IndexView) DropAll(ctx context.Context, opts ...*options.DropIndexesOpt
 		Clock:   iv.coll.client.clock,
 	}
 
+	dropOpts := options.MergeDropIndexesOptions(opts...)
+	if iv.coll.client.ignoresMaxTime() {
+		dropOpts.MaxTime = nil
+	}
+
 	return driver.DropIndexes(
 		ctx, cmd,
 		iv.coll.client.topology,
 		iv.coll.writeSelector,
 		iv.coll.client.id,
 		iv.coll.client.topology.SessionPool,
-		opts...,
+		dropOpts,
 	)
 }
 
+// indexDocument returns the createIndexes specification of model and the name of the index.
+func indexDocument(model IndexModel) (bsonx.Doc, string, error) {
+	if model.Keys == nil {
+		return nil, "", fmt.Errorf("index model keys cannot be nil")
+	}
+
+	if _, err := model.Options.LookupErr("wildcardProjection"); err == nil {
+		// The server only accepts a projection for an index on every field, not for one on a subtree.
+		if len(model.Keys) != 1 || model.Keys[0].Key != "$**" {
+			return nil, "", ErrInvalidWildcardProjection
+		}
+	}
+
+	name, err := getOrGenerateIndexName(model)
+	if err != nil {
+		return nil, "", err
+	}
+
+	index := bsonx.Doc{{"key", bsonx.Document(model.Keys)}}
+	if model.Options != nil {
+		index = append(index, model.Options...)
+	}
+	return index.Set("name", bsonx.String(name)), name, nil
+}
+
+// partialFilterOperators are the query operators the server accepts in the partialFilterExpression of an index.
+var partialFilterOperators = map[string]bool{
+	"$eq":     true,
+	"$exists": true,
+	"$gt":     true,
+	"$gte":    true,
+	"$lt":     true,
+	"$lte":    true,
+	"$type":   true,
+}
+
+// validatePartialFilter returns an error if filter uses operators that are not allowed in a partial index, which
+// are everything but equality, comparisons, $exists: true and $type, combined with $and and $or.
+func validatePartialFilter(filter bsonx.Val) error {
+	doc, ok := filter.DocumentOK()
+	if !ok {
+		return fmt.Errorf("partial filter expression must be a document, but got %s", filter.Type())
+	}
+
+	for _, elem := range doc {
+		switch elem.Key {
+		case "$and", "$or":
+			clauses, ok := elem.Value.ArrayOK()
+			if !ok || len(clauses) == 0 {
+				return fmt.Errorf("%s in a partial filter expression must be a non-empty array", elem.Key)
+			}
+			for _, clause := range clauses {
+				if err := validatePartialFilter(clause); err != nil {
+					return err
+				}
+			}
+		default:
+			if len(elem.Key) > 0 && elem.Key[0] == '$' {
+				return fmt.Errorf("partial filter expressions do not support %s", elem.Key)
+			}
+			if err := validatePartialFilterField(elem.Key, elem.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validatePartialFilterField(field string, val bsonx.Val) error {
+	if val.Type() == bsontype.Regex {
+		return fmt.Errorf("partial filter expressions do not support regular expressions, but %s is one", field)
+	}
+
+	ops, ok := val.DocumentOK()
+	if !ok || len(ops) == 0 || len(ops[0].Key) == 0 || ops[0].Key[0] != '$' {
+		// Anything else is an equality match on the field.
+		return nil
+	}
+
+	for _, op := range ops {
+		if !partialFilterOperators[op.Key] {
+			return fmt.Errorf("partial filter expressions do not support %s, which is used on %s", op.Key, field)
+		}
+		if op.Key == "$exists" && !truthy(op.Value) {
+			return fmt.Errorf("partial filter expressions only support $exists: true, but %s uses false", field)
+		}
+	}
+	return nil
+}
+
+// truthy reports whether the server treats val as true.
+func truthy(val bsonx.Val) bool {
+	switch val.Type() {
+	case bsontype.Boolean:
+		return val.Boolean()
+	case bsontype.Int32:
+		return val.Int32() != 0
+	case bsontype.Int64:
+		return val.Int64() != 0
+	case bsontype.Double:
+		return val.Double() != 0
+	case bsontype.Null, bsontype.Undefined:
+		return false
+	default:
+		return true
+	}
+}
+
 func getOrGenerateIndexName(model IndexModel) (string, error) {
 	if model.Options != nil {
 		nameVal, err := model.Options.LookupErr("name")
@@ -17,6 +17,7 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/mongo/options"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
 	"github.com/stretchr/testify/require"
 )
 
@@ -310,6 +311,156 @@ func TestIndexView_CreateMany(t *testing.T) {
 	require.True(t, barBazFound)
 }
 
+func TestIndexDocument(t *testing.T) {
+	t.Run("wildcard with projection", func(t *testing.T) {
+		projection := bsonx.Doc{{"a", bsonx.Int32(1)}, {"b.c", bsonx.Int32(1)}}
+		index, name, err := indexDocument(IndexModel{
+			Keys:    bsonx.Doc{{"$**", bsonx.Int32(1)}},
+			Options: NewIndexOptionsBuilder().WildcardProjection(projection).Build(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "$**_1", name)
+		require.Equal(t, bsonx.Doc{
+			{"key", bsonx.Document(bsonx.Doc{{"$**", bsonx.Int32(1)}})},
+			{"wildcardProjection", bsonx.Document(projection)},
+			{"name", bsonx.String("$**_1")},
+		}, index)
+	})
+
+	t.Run("hashed", func(t *testing.T) {
+		index, name, err := indexDocument(IndexModel{Keys: bsonx.Doc{{"a", bsonx.String("hashed")}}})
+		require.NoError(t, err)
+		require.Equal(t, "a_hashed", name)
+		require.Equal(t, bsonx.Doc{
+			{"key", bsonx.Document(bsonx.Doc{{"a", bsonx.String("hashed")}})},
+			{"name", bsonx.String("a_hashed")},
+		}, index)
+	})
+
+	t.Run("projection requires a wildcard key", func(t *testing.T) {
+		opts := NewIndexOptionsBuilder().WildcardProjection(bsonx.Doc{{"a", bsonx.Int32(1)}}).Build()
+		for _, keys := range []bsonx.Doc{
+			{{"a", bsonx.Int32(1)}},
+			{{"a.$**", bsonx.Int32(1)}},
+			{{"$**", bsonx.Int32(1)}, {"b", bsonx.Int32(1)}},
+		} {
+			_, _, err := indexDocument(IndexModel{Keys: keys, Options: opts})
+			require.Equal(t, ErrInvalidWildcardProjection, err)
+		}
+	})
+}
+
+func TestValidatePartialFilter(t *testing.T) {
+	doc := func(elems ...bsonx.Elem) bsonx.Val { return bsonx.Document(bsonx.Doc(elems)) }
+	op := func(key string, val bsonx.Val) bsonx.Val { return doc(bsonx.Elem{key, val}) }
+
+	valid := []bsonx.Val{
+		doc(bsonx.Elem{"a", bsonx.Int32(1)}),
+		doc(bsonx.Elem{"a", doc(bsonx.Elem{"b", bsonx.Int32(1)})}),
+		doc(bsonx.Elem{"a", doc(bsonx.Elem{"$gt", bsonx.Int32(1)}, bsonx.Elem{"$lte", bsonx.Int32(5)})}),
+		doc(bsonx.Elem{"a", op("$exists", bsonx.Boolean(true))}, bsonx.Elem{"b", op("$type", bsonx.String("string"))}),
+		doc(bsonx.Elem{"$and", bsonx.Array(bsonx.Arr{
+			op("a", op("$eq", bsonx.Int32(1))),
+			op("$or", bsonx.Array(bsonx.Arr{op("b", op("$lt", bsonx.Int32(2))), op("c", op("$gte", bsonx.Int32(3)))})),
+		})}),
+	}
+	for _, filter := range valid {
+		require.NoError(t, validatePartialFilter(filter), "%v", filter)
+	}
+
+	invalid := []bsonx.Val{
+		bsonx.Int32(1),
+		doc(bsonx.Elem{"a", op("$ne", bsonx.Int32(1))}),
+		doc(bsonx.Elem{"a", op("$in", bsonx.Array(bsonx.Arr{bsonx.Int32(1)}))}),
+		doc(bsonx.Elem{"a", op("$exists", bsonx.Boolean(false))}),
+		doc(bsonx.Elem{"a", bsonx.Regex("^x", "")}),
+		doc(bsonx.Elem{"$nor", bsonx.Array(bsonx.Arr{op("a", bsonx.Int32(1))})}),
+		doc(bsonx.Elem{"$and", bsonx.Array(bsonx.Arr{})}),
+		doc(bsonx.Elem{"$and", bsonx.Array(bsonx.Arr{op("a", op("$not", op("$gt", bsonx.Int32(1))))})}),
+		doc(bsonx.Elem{"$expr", op("$gt", bsonx.Array(bsonx.Arr{bsonx.String("$a"), bsonx.Int32(1)}))}),
+	}
+	for _, filter := range invalid {
+		require.Error(t, validatePartialFilter(filter), "%v", filter)
+	}
+}
+
+func TestIndexView_CreateWithInvalidPartialFilter(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	_, coll := getIndexableCollection(t)
+	indexView := coll.Indexes()
+	model := IndexModel{
+		Keys: bsonx.Doc{{"a", bsonx.Int32(1)}},
+		Options: NewIndexOptionsBuilder().
+			PartialFilterExpression(bsonx.Doc{{"a", bsonx.Document(bsonx.Doc{{"$ne", bsonx.Int32(1)}})}}).
+			Build(),
+	}
+
+	_, err := indexView.CreateOne(context.Background(), model)
+	require.Error(t, err)
+	_, ok := err.(command.Error)
+	require.False(t, ok, "expected a client-side error but got %v", err)
+
+	// Without validation the server rejects the expression instead.
+	_, err = indexView.CreateOne(context.Background(), model, options.CreateIndexes().SetSkipPartialFilterValidation(true))
+	require.Error(t, err)
+	_, ok = err.(command.Error)
+	require.True(t, ok, "expected a command.Error but got %T: %v", err, err)
+}
+
+func TestIndexView_CreateWildcardAndHashed(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	_, coll := getIndexableCollection(t)
+	version, err := getServerVersion(coll.db)
+	require.NoError(t, err)
+	if compareVersions(t, version, "4.2") < 0 {
+		t.Skip("skipping wildcard index test for server version < 4.2")
+	}
+	indexView := coll.Indexes()
+
+	names, err := indexView.CreateMany(
+		context.Background(),
+		[]IndexModel{
+			{
+				Keys:    bsonx.Doc{{"$**", bsonx.Int32(1)}},
+				Options: NewIndexOptionsBuilder().WildcardProjection(bsonx.Doc{{"a", bsonx.Int32(1)}}).Build(),
+			},
+			{
+				Keys: bsonx.Doc{{"b", bsonx.String("hashed")}},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"$**_1", "b_hashed"}, names)
+
+	cursor, err := indexView.List(context.Background())
+	require.NoError(t, err)
+	defer func() { _ = cursor.Close(context.Background()) }()
+
+	found := make(map[string]bsonx.Doc)
+	for cursor.Next(context.Background()) {
+		var idx bsonx.Doc
+		require.NoError(t, cursor.Decode(&idx))
+		found[idx.Lookup("name").StringValue()] = idx
+	}
+	require.NoError(t, cursor.Err())
+
+	require.Contains(t, found, "$**_1")
+	projection := found["$**_1"].Lookup("wildcardProjection").Document()
+	require.Equal(t, int32(1), projection.Lookup("a").Int32())
+	require.Contains(t, found, "b_hashed")
+	require.Equal(t, "hashed", found["b_hashed"].Lookup("key", "b").StringValue())
+}
+
 func TestIndexView_DropOne(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,111 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/event"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationMaxTime(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	var started []*event.CommandStartedEvent
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			if cse.CommandName == "find" {
+				started = append(started, cse)
+			}
+		},
+	}
+	client := createSessionsMonitoredClient(t, monitor)
+	defer func() { _ = client.Disconnect(ctx) }()
+
+	db := client.Database("TestOperationMaxTime")
+	require.NoError(t, db.Drop(ctx))
+	defer func() { _ = db.Drop(ctx) }()
+	coll := db.Collection("maxtime")
+	_, err := coll.InsertMany(ctx, []interface{}{
+		bsonx.Doc{{"x", bsonx.Int32(1)}},
+		bsonx.Doc{{"x", bsonx.Int32(2)}},
+	})
+	require.NoError(t, err)
+
+	sentMaxTime := func(t *testing.T) (int64, bool) {
+		require.Len(t, started, 1)
+		val, err := started[0].Command.LookupErr("maxTimeMS")
+		if err != nil {
+			return 0, false
+		}
+		return val.Int64(), true
+	}
+
+	t.Run("without a context deadline", func(t *testing.T) {
+		started = nil
+		cursor, err := coll.Find(ctx, bsonx.Doc{}, options.Find().SetMaxTime(5*time.Second))
+		require.NoError(t, err)
+		require.NoError(t, cursor.Close(ctx))
+
+		maxTime, ok := sentMaxTime(t)
+		require.True(t, ok)
+		require.Equal(t, int64(5000), maxTime)
+	})
+
+	t.Run("with a context deadline", func(t *testing.T) {
+		deadlineCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+
+		started = nil
+		res := coll.FindOne(deadlineCtx, bsonx.Doc{}, options.FindOne().SetMaxTime(2*time.Second))
+		require.NoError(t, res.Err())
+
+		maxTime, ok := sentMaxTime(t)
+		require.True(t, ok)
+		require.Equal(t, int64(2000), maxTime)
+	})
+
+	t.Run("is enforced by the server", func(t *testing.T) {
+		deadlineCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+
+		slow := bsonx.Doc{{"$where", bsonx.String("sleep(500) || true")}}
+		cursor, err := coll.Find(deadlineCtx, slow, options.Find().SetMaxTime(time.Millisecond))
+		if err == nil {
+			for cursor.Next(deadlineCtx) {
+			}
+			err = cursor.Err()
+			_ = cursor.Close(ctx)
+		}
+		require.Error(t, err)
+		cerr, ok := err.(command.Error)
+		require.True(t, ok, "expected a command.Error but got %T: %v", err, err)
+		require.Equal(t, int32(50), cerr.Code) // MaxTimeMSExpired
+	})
+
+	t.Run("ignored with a client timeout", func(t *testing.T) {
+		timeoutClient := *client
+		timeoutClient.timeout = time.Minute
+		timeoutColl := timeoutClient.Database(db.Name()).Collection(coll.Name())
+
+		started = nil
+		cursor, err := timeoutColl.Find(ctx, bsonx.Doc{}, options.Find().SetMaxTime(5*time.Second))
+		require.NoError(t, err)
+		require.NoError(t, cursor.Close(ctx))
+
+		_, ok := sentMaxTime(t)
+		require.False(t, ok)
+	})
+}
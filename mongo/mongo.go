@@ -71,8 +71,9 @@ func (me MarshalError) Error() string {
 type Pipeline []bson.D
 
 // transformAndEnsureID is a hack that makes it easy to get a RawValue as the _id value. This will
-// be removed when we switch from using bsonx to bsoncore for the driver package.
-func transformAndEnsureID(registry *bsoncodec.Registry, val interface{}) (bsonx.Doc, interface{}, error) {
+// be removed when we switch from using bsonx to bsoncore for the driver package. If generateID is false, a
+// document without an _id is left as is and the returned ID is nil.
+func transformAndEnsureID(registry *bsoncodec.Registry, val interface{}, generateID bool) (bsonx.Doc, interface{}, error) {
 	// TODO: performance is going to be pretty bad for bsonx.Doc here since we turn it into a []byte
 	// only to turn it back into a bsonx.Doc. We can fix this post beta1 when we refactor the driver
 	// package to use bsoncore.Document instead of bsonx.Doc.
@@ -110,6 +111,9 @@ func transformAndEnsureID(registry *bsoncodec.Registry, val interface{}) (bsonx.
 			return nil, nil, err
 		}
 	default:
+		if !generateID {
+			break
+		}
 		oid := primitive.NewObjectID()
 		d = append(d, bsonx.Elem{"_id", bsonx.ObjectID(oid)})
 		id = oid
@@ -141,6 +145,26 @@ func transformDocument(registry *bsoncodec.Registry, val interface{}) (bsonx.Doc
 	return bsonx.ReadDoc(b)
 }
 
+// transformValue converts val into a single BSON value using the registry.
+func transformValue(registry *bsoncodec.Registry, val interface{}) (bsonx.Val, error) {
+	if registry == nil {
+		registry = bson.NewRegistryBuilder().Build()
+	}
+	if str, ok := val.(string); ok {
+		return bsonx.String(str), nil
+	}
+
+	b, err := bson.MarshalWithRegistry(registry, bson.D{{"v", val}})
+	if err != nil {
+		return bsonx.Val{}, MarshalError{Value: val, Err: err}
+	}
+	doc, err := bsonx.ReadDoc(b)
+	if err != nil {
+		return bsonx.Val{}, err
+	}
+	return doc[0].Value, nil
+}
+
 func ensureID(d bsonx.Doc) (bsonx.Doc, interface{}) {
 	var id interface{}
 
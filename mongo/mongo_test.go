@@ -75,6 +75,46 @@ func TestTransformDocument(t *testing.T) {
 	}
 }
 
+func TestTransformAndEnsureID(t *testing.T) {
+	registry := bson.NewRegistryBuilder().Build()
+
+	t.Run("generates an _id", func(t *testing.T) {
+		doc, id, err := transformAndEnsureID(registry, bsonx.Doc{{"x", bsonx.Int32(1)}}, true)
+		noerr(t, err)
+		oid, ok := id.(primitive.ObjectID)
+		if !ok {
+			t.Fatalf("expected a generated ObjectID but got %T", id)
+		}
+		if got := doc.Lookup("_id"); !got.Equal(bsonx.ObjectID(oid)) {
+			t.Errorf("_id does not match the returned ID. got %v; want %v", got, oid)
+		}
+	})
+
+	t.Run("keeps an existing _id", func(t *testing.T) {
+		for _, generateID := range []bool{true, false} {
+			doc, id, err := transformAndEnsureID(registry, bsonx.Doc{{"_id", bsonx.Int32(7)}}, generateID)
+			noerr(t, err)
+			if id != int32(7) {
+				t.Errorf("returned ID does not match. got %v; want 7", id)
+			}
+			if len(doc) != 1 {
+				t.Errorf("expected the document to be unchanged but got %v", doc)
+			}
+		}
+	})
+
+	t.Run("bypasses generation", func(t *testing.T) {
+		doc, id, err := transformAndEnsureID(registry, bsonx.Doc{{"x", bsonx.Int32(1)}}, false)
+		noerr(t, err)
+		if id != nil {
+			t.Errorf("expected a nil ID but got %v", id)
+		}
+		if _, err := doc.LookupErr("_id"); err == nil {
+			t.Errorf("expected no _id to be added but got %v", doc)
+		}
+	})
+}
+
 func TestTransformAggregatePipeline(t *testing.T) {
 	index, arr := bsoncore.AppendArrayStart(nil)
 	dindex, arr := bsoncore.AppendDocumentElementStart(arr, "0")
@@ -6,18 +6,25 @@
 
 package options
 
-import "time"
+import (
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+)
 
 // AggregateOptions represents all possible options to the aggregate() function
 type AggregateOptions struct {
-	AllowDiskUse             *bool          // Enables writing to temporary files. When set to true, aggregation stages can write data to the _tmp subdirectory in the dbPath directory
-	BatchSize                *int32         // The number of documents to return per batch
-	BypassDocumentValidation *bool          // If true, allows the write to opt-out of document level validation. This only applies when the $out stage is specified
-	Collation                *Collation     // Specifies a collation
-	MaxTime                  *time.Duration // The maximum amount of time to allow the query to run
-	MaxAwaitTime             *time.Duration // The maximum amount of time for the server to wait on new documents to satisfy a tailable cursor query
-	Comment                  *string        // Enables users to specify an arbitrary string to help trace the operation through the database profiler, currentOp and logs.
-	Hint                     interface{}    // The index to use for the aggregation. The hint does not apply to $lookup and $graphLookup stages
+	AllowDiskUse             *bool                // Enables writing to temporary files. When set to true, aggregation stages can write data to the _tmp subdirectory in the dbPath directory
+	BatchSize                *int32               // The number of documents to return per batch
+	BypassDocumentValidation *bool                // If true, allows the write to opt-out of document level validation. This only applies when the $out stage is specified
+	Collation                *Collation           // Specifies a collation
+	MaxTime                  *time.Duration       // The maximum amount of time to allow the query to run
+	MaxAwaitTime             *time.Duration       // The maximum amount of time for the server to wait on new documents to satisfy a tailable cursor query
+	ReadAfter                *primitive.Timestamp // Makes the read wait until the server has applied the write with this operation time.
+	Comment                  interface{}          // Enables users to specify an arbitrary value to help trace the operation through the database profiler, currentOp and logs.
+	Hint                     interface{}          // The index to use for the aggregation. The hint does not apply to $lookup and $graphLookup stages
+	CausalConsistency        *bool                // If false, opts the operation out of the causal consistency of its session.
+	Prefetch                 *bool                // If true, the cursor fetches its next batch in the background.
 }
 
 // Aggregate returns a pointer to a new AggregateOptions
@@ -60,6 +67,13 @@ func (ao *AggregateOptions) SetMaxTime(d time.Duration) *AggregateOptions {
 	return ao
 }
 
+// SetReadAfter makes the read wait until the server has applied the write with the given operation time. See
+// FindOptions.SetReadAfter.
+func (ao *AggregateOptions) SetReadAfter(t *primitive.Timestamp) *AggregateOptions {
+	ao.ReadAfter = t
+	return ao
+}
+
 // SetMaxAwaitTime specifies the maximum amount of time for the server to
 // wait on new documents to satisfy a tailable cursor query
 // For servers < 3.2, this option is ignored
@@ -68,10 +82,11 @@ func (ao *AggregateOptions) SetMaxAwaitTime(d time.Duration) *AggregateOptions {
 	return ao
 }
 
-// SetComment enables users to specify an arbitrary string to help trace the
+// SetComment enables users to specify an arbitrary value to help trace the
 // operation through the database profiler, currentOp and logs.
-func (ao *AggregateOptions) SetComment(s string) *AggregateOptions {
-	ao.Comment = &s
+// Comments other than strings are valid for server versions >= 4.4.
+func (ao *AggregateOptions) SetComment(comment interface{}) *AggregateOptions {
+	ao.Comment = comment
 	return ao
 }
 
@@ -82,6 +97,21 @@ func (ao *AggregateOptions) SetHint(h interface{}) *AggregateOptions {
 	return ao
 }
 
+// SetCausalConsistency specifies whether the aggregation is causally consistent
+// with the other operations in its session. See
+// FindOptions.SetCausalConsistency.
+func (ao *AggregateOptions) SetCausalConsistency(b bool) *AggregateOptions {
+	ao.CausalConsistency = &b
+	return ao
+}
+
+// SetPrefetch specifies whether the cursor should fetch its next batch in the background while the current batch
+// is iterated. See FindOptions.SetPrefetch.
+func (ao *AggregateOptions) SetPrefetch(b bool) *AggregateOptions {
+	ao.Prefetch = &b
+	return ao
+}
+
 // MergeAggregateOptions combines the argued AggregateOptions into a single AggregateOptions in a last-one-wins fashion
 func MergeAggregateOptions(opts ...*AggregateOptions) *AggregateOptions {
 	aggOpts := Aggregate()
@@ -104,15 +134,24 @@ func MergeAggregateOptions(opts ...*AggregateOptions) *AggregateOptions {
 		if ao.MaxTime != nil {
 			aggOpts.MaxTime = ao.MaxTime
 		}
+		if ao.ReadAfter != nil {
+			aggOpts.ReadAfter = ao.ReadAfter
+		}
 		if ao.MaxAwaitTime != nil {
 			aggOpts.MaxAwaitTime = ao.MaxAwaitTime
 		}
 		if ao.Comment != nil {
 			aggOpts.Comment = ao.Comment
 		}
+		if ao.Prefetch != nil {
+			aggOpts.Prefetch = ao.Prefetch
+		}
 		if ao.Hint != nil {
 			aggOpts.Hint = ao.Hint
 		}
+		if ao.CausalConsistency != nil {
+			aggOpts.CausalConsistency = ao.CausalConsistency
+		}
 	}
 
 	return aggOpts
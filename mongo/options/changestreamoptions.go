@@ -13,12 +13,13 @@ import (
 
 // ChangeStreamOptions represents all possible options to a change stream
 type ChangeStreamOptions struct {
-	BatchSize            *int32               // The number of documents to return per batch
-	Collation            *Collation           // Specifies a collation
-	FullDocument         *FullDocument        // When set to ‘updateLookup’, the change notification for partial updates will include both a delta describing the changes to the document, as well as a copy of the entire document that was changed from some time after the change occurred.
-	MaxAwaitTime         *time.Duration       // The maximum amount of time for the server to wait on new documents to satisfy a change stream query
-	ResumeAfter          interface{}          // Specifies the logical starting point for the new change stream
-	StartAtOperationTime *primitive.Timestamp // Ensures that a change stream will only provide changes that occurred after a timestamp.
+	BatchSize                *int32               // The number of documents to return per batch
+	Collation                *Collation           // Specifies a collation
+	FullDocument             *FullDocument        // When set to ‘updateLookup’, the change notification for partial updates will include both a delta describing the changes to the document, as well as a copy of the entire document that was changed from some time after the change occurred.
+	FullDocumentBeforeChange *FullDocument        // Specifies whether change notifications include a copy of the document from before the change.
+	MaxAwaitTime             *time.Duration       // The maximum amount of time for the server to wait on new documents to satisfy a change stream query
+	ResumeAfter              interface{}          // Specifies the logical starting point for the new change stream
+	StartAtOperationTime     *primitive.Timestamp // Ensures that a change stream will only provide changes that occurred after a timestamp.
 }
 
 // ChangeStream returns a pointer to a new ChangeStreamOptions
@@ -48,6 +49,15 @@ func (cso *ChangeStreamOptions) SetFullDocument(fd FullDocument) *ChangeStreamOp
 	return cso
 }
 
+// SetFullDocumentBeforeChange specifies the fullDocumentBeforeChange option.
+// When set to ‘whenAvailable’ or ‘required’, the change notification for
+// updates, replacements and deletes will include a copy of the document from
+// before the change. This requires pre-images to be enabled on the collection.
+func (cso *ChangeStreamOptions) SetFullDocumentBeforeChange(fd FullDocument) *ChangeStreamOptions {
+	cso.FullDocumentBeforeChange = &fd
+	return cso
+}
+
 // SetMaxAwaitTime specifies the maximum amount of time for the server to wait on new documents to satisfy a change stream query
 func (cso *ChangeStreamOptions) SetMaxAwaitTime(d time.Duration) *ChangeStreamOptions {
 	cso.MaxAwaitTime = &d
@@ -82,6 +92,9 @@ func MergeChangeStreamOptions(opts ...*ChangeStreamOptions) *ChangeStreamOptions
 		if cso.FullDocument != nil {
 			csOpts.FullDocument = cso.FullDocument
 		}
+		if cso.FullDocumentBeforeChange != nil {
+			csOpts.FullDocumentBeforeChange = cso.FullDocumentBeforeChange
+		}
 		if cso.MaxAwaitTime != nil {
 			csOpts.MaxAwaitTime = cso.MaxAwaitTime
 		}
@@ -8,6 +8,7 @@ package options
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"time"
 
@@ -39,18 +40,26 @@ type ContextDialer interface {
 // Insecure indicates whether to skip the verification of the server certificate and hostname.
 //
 // CaFile specifies the file containing the certificate authority used for SSL connections.
+//
+// GetClientCertificate provides a callback that returns the client certificate to present to the
+// server. It is called during the TLS handshake of every new connection, so certificates that are
+// rotated by the callback are picked up without reconnecting the client. Connections that are
+// already pooled keep using the certificate they were established with until they are closed. If
+// set, it takes precedence over ClientCertificateKeyFile for the TLS handshake.
 type SSLOpt struct {
 	Enabled                      bool
 	ClientCertificateKeyFile     string
 	ClientCertificateKeyPassword func() string
 	Insecure                     bool
 	CaFile                       string
+	GetClientCertificate         func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
 }
 
 // Credential holds auth options.
 //
 // AuthMechanism indicates the mechanism to use for authentication.
-// Supported values include "SCRAM-SHA-256", "SCRAM-SHA-1", "MONGODB-CR", "PLAIN", "GSSAPI", and "MONGODB-X509".
+// Supported values include "SCRAM-SHA-256", "SCRAM-SHA-1", "MONGODB-CR", "PLAIN", "GSSAPI", "MONGODB-X509",
+// "MONGODB-AWS", and "MONGODB-OIDC".
 //
 // AuthMechanismProperties specifies additional configuration options which may be used by certain
 // authentication mechanisms.
@@ -60,12 +69,40 @@ type SSLOpt struct {
 // Username specifies the username that will be authenticated.
 //
 // Password specifies the password used for authentication.
+//
+// PasswordProvider, if set, is called to obtain the password each time a new connection is authenticated and takes
+// precedence over Password. It is used by the SCRAM-SHA-1, SCRAM-SHA-256, MONGODB-CR and PLAIN mechanisms.
+//
+// OIDCMachineCallback supplies access tokens to the MONGODB-OIDC mechanism and is required by it.
 type Credential struct {
 	AuthMechanism           string
 	AuthMechanismProperties map[string]string
 	AuthSource              string
 	Username                string
 	Password                string
+	PasswordProvider        func(ctx context.Context) (string, error)
+	OIDCMachineCallback     OIDCCallback
+}
+
+// OIDCCallback obtains an access token for the MONGODB-OIDC mechanism from an identity provider, such as a
+// workload identity endpoint or a service account token file.
+type OIDCCallback = connstring.OIDCCallback
+
+// OIDCArgs are the arguments passed to an OIDCCallback: the callback API version, the configured username, and
+// the address of the server being authenticated to.
+type OIDCArgs = connstring.OIDCArgs
+
+// OIDCCredential is the access token returned by an OIDCCallback and, if known, when it expires.
+type OIDCCredential = connstring.OIDCCredential
+
+// SetOIDCMachineCallback specifies the callback used by the MONGODB-OIDC mechanism to obtain access tokens without
+// user interaction. Tokens are cached by the client and shared by its connections; the callback is called again
+// shortly before the cached token expires, or when the server rejects it or requires connections to be
+// reauthenticated. Calls are serialized, and the callback's context is limited to one minute.
+func (c *Credential) SetOIDCMachineCallback(cb OIDCCallback) *Credential {
+	c.OIDCMachineCallback = cb
+
+	return c
 }
 
 // ClientOptions represents all possbile options to configure a client.
@@ -77,6 +114,9 @@ type ClientOptions struct {
 	ReadConcern     *readconcern.ReadConcern
 	WriteConcern    *writeconcern.WriteConcern
 	Registry        *bsoncodec.Registry
+	LoggerOptions   *LoggerOptions
+
+	BypassClientIDGeneration *bool
 }
 
 // Client creates a new ClientOptions instance.
@@ -101,6 +141,29 @@ func (c *ClientOptions) SetAuth(auth Credential) *ClientOptions {
 	c.ConnString.AuthSource = auth.AuthSource
 	c.ConnString.Username = auth.Username
 	c.ConnString.Password = auth.Password
+	c.ConnString.PasswordProvider = auth.PasswordProvider
+	c.ConnString.OIDCMachineCallback = auth.OIDCMachineCallback
+
+	return c
+}
+
+// SetPasswordProvider specifies a function that returns the password used for authentication. The function is
+// called every time a new connection is authenticated, including when connections are re-established after a
+// network error, so a password read from a secrets manager can be rotated without recreating the client. The
+// function may be called concurrently from multiple goroutines and must be safe for concurrent use. It should
+// honor the context's deadline, since connection establishment blocks until it returns.
+func (c *ClientOptions) SetPasswordProvider(fn func(ctx context.Context) (string, error)) *ClientOptions {
+	c.ConnString.PasswordProvider = fn
+
+	return c
+}
+
+// SetBypassClientIDGeneration specifies whether InsertOne and InsertMany send documents without an _id field
+// as they are. By default the driver adds a generated ObjectID _id to such documents so it can report it in the
+// result. When bypassed, the server generates the _id, or rejects the document if the collection requires one,
+// and the inserted ID reported for the document is nil.
+func (c *ClientOptions) SetBypassClientIDGeneration(b bool) *ClientOptions {
+	c.BypassClientIDGeneration = &b
 
 	return c
 }
@@ -155,12 +218,31 @@ func (c *ClientOptions) SetMonitor(m *event.CommandMonitor) *ClientOptions {
 				}),
 			)
 		}),
+		topology.WithCommandMonitor(func(*event.CommandMonitor) *event.CommandMonitor { return m }),
 	)
 
 	return c
 }
 
-// SetHeartbeatInterval specifies the interval to wait between server monitoring checks.
+// SetTopologyMonitor specifies a monitor that is notified when the client's topology becomes available, having
+// at least one data-bearing server, or unavailable, having none. This is useful for flushing or re-warming
+// caches when a cluster that was unreachable recovers.
+func (c *ClientOptions) SetTopologyMonitor(m *event.TopologyMonitor) *ClientOptions {
+	c.TopologyOptions = append(
+		c.TopologyOptions,
+		topology.WithTopologyMonitor(func(*event.TopologyMonitor) *event.TopologyMonitor { return m }),
+	)
+
+	return c
+}
+
+// SetHeartbeatInterval specifies the interval to wait between server monitoring checks. The default is 10
+// seconds and the minimum is 500 milliseconds; creating a Client with a smaller interval returns an error.
+//
+// The heartbeat interval does not limit how long server selection waits. When no suitable server is known,
+// server selection requests an immediate check of every server, at most once every 500 milliseconds, and
+// keeps waiting for up to the server selection timeout. The interval instead controls how quickly the driver
+// notices changes, such as a new primary, while a suitable server is still known.
 func (c *ClientOptions) SetHeartbeatInterval(d time.Duration) *ClientOptions {
 	c.ConnString.HeartbeatInterval = d
 	c.ConnString.HeartbeatIntervalSet = true
@@ -175,6 +257,14 @@ func (c *ClientOptions) SetHosts(s []string) *ClientOptions {
 	return c
 }
 
+// SetKeepAlivePeriod specifies the period between TCP keepalive probes sent on idle connections. The default is
+// 5 minutes, and a period of zero disables keepalive. Environments with load balancers or firewalls that drop idle
+// connections may need a shorter period. If a custom Dialer is used, this option is ignored and keepalive must be
+// configured on the dialer.
+func (c *ClientOptions) SetKeepAlivePeriod(d time.Duration) *ClientOptions {
+	return c.appendConnectionOption(connection.WithKeepAlive(func(time.Duration) time.Duration { return d }))
+}
+
 // SetLocalThreshold specifies how far to distribute queries, beyond the server with the fastest
 // round-trip time. If a server's roundtrip time is more than LocalThreshold slower than the
 // the fastest, the driver will not send queries to that server.
@@ -185,6 +275,38 @@ func (c *ClientOptions) SetLocalThreshold(d time.Duration) *ClientOptions {
 	return c
 }
 
+// SetLoggerOptions specifies how the client logs what it is doing internally, such as the commands it runs,
+// changes to the topology, the connections it opens and closes, and the outcome of server selection. Without
+// logger options, logging is configured by the MONGODB_LOG_* environment variables and is off if none are set.
+func (c *ClientOptions) SetLoggerOptions(lo *LoggerOptions) *ClientOptions {
+	c.LoggerOptions = lo
+
+	return c
+}
+
+// SetMaxConnecting specifies the maximum number of connections a server's connection pool may be
+// establishing at the same time. If max is 0, then there is no limit. The default is 2.
+func (c *ClientOptions) SetMaxConnecting(u uint64) *ClientOptions {
+	c.ConnString.MaxConnecting = u
+	c.ConnString.MaxConnectingSet = true
+
+	return c
+}
+
+// SetMaxSessionPoolSize specifies the maximum number of idle server sessions the client keeps for reuse by
+// implicit and explicit sessions. When a session ends while the pool is full, the least recently used pooled
+// session is discarded and left for the server to remove once it has been idle for the server's
+// logicalSessionTimeoutMinutes. Pooled sessions are also pruned when they come within a minute of that timeout.
+// The default is 0, which means no limit.
+func (c *ClientOptions) SetMaxSessionPoolSize(u uint64) *ClientOptions {
+	c.TopologyOptions = append(
+		c.TopologyOptions,
+		topology.WithMaxSessionPoolSize(func(uint64) uint64 { return u }),
+	)
+
+	return c
+}
+
 // SetMaxConnIdleTime specifies the maximum number of milliseconds that a connection can remain idle
 // in a connection pool before being removed and closed.
 func (c *ClientOptions) SetMaxConnIdleTime(d time.Duration) *ClientOptions {
@@ -202,6 +324,16 @@ func (c *ClientOptions) SetMaxPoolSize(u uint16) *ClientOptions {
 	return c
 }
 
+// SetWarmupConnections specifies the number of connections each server's connection pool will
+// establish in the background when the client connects, so that they are ready before the first
+// operation. This corresponds to the minPoolSize URI option and is limited by the max pool size.
+func (c *ClientOptions) SetWarmupConnections(u uint64) *ClientOptions {
+	c.ConnString.MinPoolSize = u
+	c.ConnString.MinPoolSizeSet = true
+
+	return c
+}
+
 // SetReadConcern specifies the read concern.
 func (c *ClientOptions) SetReadConcern(rc *readconcern.ReadConcern) *ClientOptions {
 	c.ReadConcern = rc
@@ -250,7 +382,9 @@ func (c *ClientOptions) SetRetryWrites(b bool) *ClientOptions {
 	return c
 }
 
-// SetServerSelectionTimeout specifies a timeout in milliseconds to block for server selection.
+// SetServerSelectionTimeout specifies a timeout in milliseconds to block for server selection. While
+// waiting, servers are checked more often than the heartbeat interval, so the timeout may be shorter than
+// the heartbeat interval.
 func (c *ClientOptions) SetServerSelectionTimeout(d time.Duration) *ClientOptions {
 	c.ConnString.ServerSelectionTimeout = d
 	c.ConnString.ServerSelectionTimeoutSet = true
@@ -297,6 +431,30 @@ func (c *ClientOptions) SetSSL(ssl *SSLOpt) *ClientOptions {
 	c.ConnString.SSLCaFile = ssl.CaFile
 	c.ConnString.SSLCaFileSet = true
 
+	c.ConnString.SSLGetClientCertificate = ssl.GetClientCertificate
+	c.ConnString.SSLGetClientCertificateSet = true
+
+	return c
+}
+
+// SetTCPNoDelay specifies whether TCP_NODELAY is set on connections, which disables Nagle's algorithm so that
+// small messages are sent without delay. The default is true. It also applies to connections made by a custom
+// Dialer.
+func (c *ClientOptions) SetTCPNoDelay(b bool) *ClientOptions {
+	return c.appendConnectionOption(connection.WithTCPNoDelay(func(bool) bool { return b }))
+}
+
+// SetTimeout specifies the amount of time that a single operation run on the client may take,
+// including server selection, sending and receiving on sockets, and any retries. The timeout is
+// applied to operations whose context has no deadline; a context deadline takes precedence.
+// When a timeout is set, SocketTimeout is ignored and ServerSelectionTimeout only applies if it
+// is shorter than the time remaining in the operation. Operation-level MaxTime options, such as
+// FindOptions.SetMaxTime, are also ignored and no maxTimeMS is sent for them. A timeout of zero
+// means no timeout.
+func (c *ClientOptions) SetTimeout(d time.Duration) *ClientOptions {
+	c.ConnString.Timeout = d
+	c.ConnString.TimeoutSet = true
+
 	return c
 }
 
@@ -307,6 +465,23 @@ func (c *ClientOptions) SetWriteConcern(wc *writeconcern.WriteConcern) *ClientOp
 	return c
 }
 
+// appendConnectionOption adds opt to the options of every connection made by the client.
+func (c *ClientOptions) appendConnectionOption(opt connection.Option) *ClientOptions {
+	c.TopologyOptions = append(
+		c.TopologyOptions,
+		topology.WithServerOptions(func(opts ...topology.ServerOption) []topology.ServerOption {
+			return append(
+				opts,
+				topology.WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+					return append(opts, opt)
+				}),
+			)
+		}),
+	)
+
+	return c
+}
+
 // MergeClientOptions combines the given connstring and *ClientOptions into a single *ClientOptions in a last one wins
 // fashion. The given connstring will be used for the default options, which can be overwritten using the given
 // *ClientOptions.
@@ -338,6 +513,12 @@ func MergeClientOptions(cs connstring.ConnString, opts ...*ClientOptions) *Clien
 		if p := opt.ConnString.Password; len(p) != 0 {
 			c.ConnString.Password = p
 		}
+		if pp := opt.ConnString.PasswordProvider; pp != nil {
+			c.ConnString.PasswordProvider = pp
+		}
+		if cb := opt.ConnString.OIDCMachineCallback; cb != nil {
+			c.ConnString.OIDCMachineCallback = cb
+		}
 		if opt.ConnString.ConnectTimeoutSet {
 			c.ConnString.ConnectTimeoutSet = true
 			c.ConnString.ConnectTimeout = opt.ConnString.ConnectTimeout
@@ -353,6 +534,10 @@ func MergeClientOptions(cs connstring.ConnString, opts ...*ClientOptions) *Clien
 			c.ConnString.LocalThresholdSet = true
 			c.ConnString.LocalThreshold = opt.ConnString.LocalThreshold
 		}
+		if opt.ConnString.MaxConnectingSet {
+			c.ConnString.MaxConnectingSet = true
+			c.ConnString.MaxConnecting = opt.ConnString.MaxConnecting
+		}
 		if opt.ConnString.MaxConnIdleTimeSet {
 			c.ConnString.MaxConnIdleTimeSet = true
 			c.ConnString.MaxConnIdleTime = opt.ConnString.MaxConnIdleTime
@@ -361,6 +546,10 @@ func MergeClientOptions(cs connstring.ConnString, opts ...*ClientOptions) *Clien
 			c.ConnString.MaxPoolSizeSet = true
 			c.ConnString.MaxPoolSize = opt.ConnString.MaxPoolSize
 		}
+		if opt.ConnString.MinPoolSizeSet {
+			c.ConnString.MinPoolSizeSet = true
+			c.ConnString.MinPoolSize = opt.ConnString.MinPoolSize
+		}
 		if opt.ReadConcern != nil {
 			c.ReadConcern = opt.ReadConcern
 		}
@@ -370,12 +559,18 @@ func MergeClientOptions(cs connstring.ConnString, opts ...*ClientOptions) *Clien
 		if opt.Registry != nil {
 			c.Registry = opt.Registry
 		}
+		if opt.LoggerOptions != nil {
+			c.LoggerOptions = opt.LoggerOptions
+		}
 		if rs := opt.ConnString.ReplicaSet; rs != "" {
 			c.ConnString.ReplicaSet = rs
 		}
 		if opt.RetryWrites != nil {
 			c.RetryWrites = opt.RetryWrites
 		}
+		if opt.BypassClientIDGeneration != nil {
+			c.BypassClientIDGeneration = opt.BypassClientIDGeneration
+		}
 		if opt.ConnString.ServerSelectionTimeoutSet {
 			c.ConnString.ServerSelectionTimeoutSet = true
 			c.ConnString.ServerSelectionTimeout = opt.ConnString.ServerSelectionTimeout
@@ -408,6 +603,14 @@ func MergeClientOptions(cs connstring.ConnString, opts ...*ClientOptions) *Clien
 			c.ConnString.SSLCaFileSet = true
 			c.ConnString.SSLCaFile = opt.ConnString.SSLCaFile
 		}
+		if opt.ConnString.SSLGetClientCertificateSet {
+			c.ConnString.SSLGetClientCertificateSet = true
+			c.ConnString.SSLGetClientCertificate = opt.ConnString.SSLGetClientCertificate
+		}
+		if opt.ConnString.TimeoutSet {
+			c.ConnString.TimeoutSet = true
+			c.ConnString.Timeout = opt.ConnString.Timeout
+		}
 		if opt.WriteConcern != nil {
 			c.WriteConcern = opt.WriteConcern
 		}
@@ -13,7 +13,8 @@ import (
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
 )
 
-// CollectionOptions represent all possible options to configure a Collection.
+// CollectionOptions represent all possible options to configure a Collection. Options that are not set are
+// inherited from the Database.
 type CollectionOptions struct {
 	ReadConcern    *readconcern.ReadConcern   // The read concern for operations in the collection.
 	WriteConcern   *writeconcern.WriteConcern // The write concern for operations in the collection.
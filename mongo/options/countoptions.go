@@ -6,13 +6,17 @@
 
 package options
 
+import "github.com/mongodb/mongo-go-driver/bson/primitive"
+
 // CountOptions represents all possible options to the count() function
 type CountOptions struct {
-	Collation *Collation  // Specifies a collation
-	Hint      interface{} // The index to use
-	Limit     *int64      // The maximum number of documents to count
-	MaxTime   *int64      // The maximum amount of time to allow the operation to run
-	Skip      *int64      // The number of documents to skip before counting
+	Collation *Collation           // Specifies a collation
+	Comment   interface{}          // Specifies a value to help trace the operation through the database
+	Hint      interface{}          // The index to use
+	Limit     *int64               // The maximum number of documents to count
+	MaxTime   *int64               // The maximum amount of time to allow the operation to run
+	ReadAfter *primitive.Timestamp // Makes the read wait until the server has applied the write with this operation time.
+	Skip      *int64               // The number of documents to skip before counting
 }
 
 // Count returns a pointer to a new CountOptions
@@ -27,7 +31,17 @@ func (co *CountOptions) SetCollation(c *Collation) *CountOptions {
 	return co
 }
 
-// SetHint specifies the index to use
+// SetComment specifies a value to help trace the operation through the database profiler, currentOp and logs.
+// Valid for server versions >= 4.4.
+func (co *CountOptions) SetComment(comment interface{}) *CountOptions {
+	co.Comment = comment
+	return co
+}
+
+// SetHint specifies the index to use, either as an index name string or as the index's key document. The hint is
+// sent with the aggregation run by CountDocuments and with the count command run by Count. If no index matches the
+// hint, the server returns an error.
+// Valid for server versions >= 3.6 when used with CountDocuments.
 func (co *CountOptions) SetHint(h interface{}) *CountOptions {
 	co.Hint = h
 	return co
@@ -45,6 +59,13 @@ func (co *CountOptions) SetMaxTime(i int64) *CountOptions {
 	return co
 }
 
+// SetReadAfter makes the read wait until the server has applied the write with the given operation time. See
+// FindOptions.SetReadAfter.
+func (co *CountOptions) SetReadAfter(t *primitive.Timestamp) *CountOptions {
+	co.ReadAfter = t
+	return co
+}
+
 // SetSkip specifies the number of documents to skip before counting
 func (co *CountOptions) SetSkip(i int64) *CountOptions {
 	co.Skip = &i
@@ -61,6 +82,9 @@ func MergeCountOptions(opts ...*CountOptions) *CountOptions {
 		if co.Collation != nil {
 			countOpts.Collation = co.Collation
 		}
+		if co.Comment != nil {
+			countOpts.Comment = co.Comment
+		}
 		if co.Hint != nil {
 			countOpts.Hint = co.Hint
 		}
@@ -70,6 +94,9 @@ func MergeCountOptions(opts ...*CountOptions) *CountOptions {
 		if co.MaxTime != nil {
 			countOpts.MaxTime = co.MaxTime
 		}
+		if co.ReadAfter != nil {
+			countOpts.ReadAfter = co.ReadAfter
+		}
 		if co.Skip != nil {
 			countOpts.Skip = co.Skip
 		}
@@ -0,0 +1,88 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import (
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+// TimeSeriesOptions specifies how the measurements of a time series collection are stored. TimeField is
+// required. Granularity ("seconds", "minutes" or "hours") and the pair of BucketMaxSpan and BucketRounding are
+// alternative ways of sizing the buckets measurements are grouped into; the bucket durations require MongoDB 6.3
+// and are sent as whole seconds.
+type TimeSeriesOptions struct {
+	TimeField      string        // The field holding the date of each measurement
+	MetaField      string        // The field holding the metadata identifying the series of each measurement
+	Granularity    string        // The expected interval between measurements of a series
+	BucketMaxSpan  time.Duration // The maximum time span of the measurements in a bucket
+	BucketRounding time.Duration // The interval the start times of buckets are rounded down to
+}
+
+// ToDocument converts the TimeSeriesOptions to a bsonx.Doc
+func (tso *TimeSeriesOptions) ToDocument() bsonx.Doc {
+	doc := bsonx.Doc{{"timeField", bsonx.String(tso.TimeField)}}
+	if tso.MetaField != "" {
+		doc = append(doc, bsonx.Elem{"metaField", bsonx.String(tso.MetaField)})
+	}
+	if tso.Granularity != "" {
+		doc = append(doc, bsonx.Elem{"granularity", bsonx.String(tso.Granularity)})
+	}
+	if tso.BucketMaxSpan != 0 {
+		doc = append(doc, bsonx.Elem{"bucketMaxSpanSeconds", bsonx.Int64(int64(tso.BucketMaxSpan / time.Second))})
+	}
+	if tso.BucketRounding != 0 {
+		doc = append(doc, bsonx.Elem{"bucketRoundingSeconds", bsonx.Int64(int64(tso.BucketRounding / time.Second))})
+	}
+	return doc
+}
+
+// CreateCollectionOptions represents all possible options to the CreateCollection() function
+type CreateCollectionOptions struct {
+	TimeSeriesOptions  *TimeSeriesOptions // Creates a time series collection
+	ExpireAfterSeconds *int64             // The age after which documents of a time series collection are deleted
+}
+
+// CreateCollection returns a pointer to a new CreateCollectionOptions
+func CreateCollection() *CreateCollectionOptions {
+	return &CreateCollectionOptions{}
+}
+
+// SetTimeSeriesOptions specifies that a time series collection is created and how its measurements are stored.
+// Requires MongoDB 5.0 or later.
+func (cco *CreateCollectionOptions) SetTimeSeriesOptions(tso TimeSeriesOptions) *CreateCollectionOptions {
+	cco.TimeSeriesOptions = &tso
+	return cco
+}
+
+// SetExpireAfterSeconds specifies the number of seconds after which the documents of a time series collection are
+// deleted, based on the value of their time field.
+func (cco *CreateCollectionOptions) SetExpireAfterSeconds(i int64) *CreateCollectionOptions {
+	cco.ExpireAfterSeconds = &i
+	return cco
+}
+
+// MergeCreateCollectionOptions combines the given *CreateCollectionOptions into a single *CreateCollectionOptions
+// in a last one wins fashion.
+func MergeCreateCollectionOptions(opts ...*CreateCollectionOptions) *CreateCollectionOptions {
+	c := CreateCollection()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.TimeSeriesOptions != nil {
+			c.TimeSeriesOptions = opt.TimeSeriesOptions
+		}
+		if opt.ExpireAfterSeconds != nil {
+			c.ExpireAfterSeconds = opt.ExpireAfterSeconds
+		}
+	}
+
+	return c
+}
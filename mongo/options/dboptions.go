@@ -13,7 +13,8 @@ import (
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
 )
 
-// DatabaseOptions represent all possible options to configure a Database.
+// DatabaseOptions represent all possible options to configure a Database. Options that are not set are
+// inherited from the Client.
 type DatabaseOptions struct {
 	ReadConcern    *readconcern.ReadConcern   // The read concern for operations in the database.
 	WriteConcern   *writeconcern.WriteConcern // The write concern for operations in the database.
@@ -8,7 +8,8 @@ package options
 
 // DeleteOptions represents all possible options to the deleteOne() and deleteMany() functions
 type DeleteOptions struct {
-	Collation *Collation // Specifies a collation
+	Collation *Collation  // Specifies a collation
+	Comment   interface{} // Specifies a value to help trace the operation through the database
 }
 
 // Delete returns a pointer to a new DeleteOptions
@@ -23,6 +24,13 @@ func (do *DeleteOptions) SetCollation(c *Collation) *DeleteOptions {
 	return do
 }
 
+// SetComment specifies a value to help trace the operation through the database profiler, currentOp and logs.
+// Valid for server versions >= 4.4.
+func (do *DeleteOptions) SetComment(comment interface{}) *DeleteOptions {
+	do.Comment = comment
+	return do
+}
+
 // MergeDeleteOptions combines the argued DeleteOptions into a single DeleteOptions in a last-one-wins fashion
 func MergeDeleteOptions(opts ...*DeleteOptions) *DeleteOptions {
 	dOpts := Delete()
@@ -33,6 +41,9 @@ func MergeDeleteOptions(opts ...*DeleteOptions) *DeleteOptions {
 		if do.Collation != nil {
 			dOpts.Collation = do.Collation
 		}
+		if do.Comment != nil {
+			dOpts.Comment = do.Comment
+		}
 	}
 
 	return dOpts
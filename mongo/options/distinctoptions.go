@@ -6,10 +6,14 @@
 
 package options
 
+import "github.com/mongodb/mongo-go-driver/bson/primitive"
+
 // DistinctOptions represents all possible options to the distinct() function
 type DistinctOptions struct {
-	Collation *Collation // Specifies a collation
-	MaxTime   *int64     // The maximum amount of time to allow the operation to run
+	Collation *Collation           // Specifies a collation
+	Comment   interface{}          // Specifies a value to help trace the operation through the database
+	MaxTime   *int64               // The maximum amount of time to allow the operation to run
+	ReadAfter *primitive.Timestamp // Makes the read wait until the server has applied the write with this operation time.
 }
 
 // Distinct returns a pointer to a new DistinctOptions
@@ -24,12 +28,26 @@ func (do *DistinctOptions) SetCollation(c *Collation) *DistinctOptions {
 	return do
 }
 
+// SetComment specifies a value to help trace the operation through the database profiler, currentOp and logs.
+// Valid for server versions >= 4.4.
+func (do *DistinctOptions) SetComment(comment interface{}) *DistinctOptions {
+	do.Comment = comment
+	return do
+}
+
 // SetMaxTime specifies the maximum amount of time to allow the operation to run
 func (do *DistinctOptions) SetMaxTime(i int64) *DistinctOptions {
 	do.MaxTime = &i
 	return do
 }
 
+// SetReadAfter makes the read wait until the server has applied the write with the given operation time. See
+// FindOptions.SetReadAfter.
+func (do *DistinctOptions) SetReadAfter(t *primitive.Timestamp) *DistinctOptions {
+	do.ReadAfter = t
+	return do
+}
+
 // MergeDistinctOptions combines the argued DistinctOptions into a single DistinctOptions in a last-one-wins fashion
 func MergeDistinctOptions(opts ...*DistinctOptions) *DistinctOptions {
 	distinctOpts := Distinct()
@@ -40,9 +58,15 @@ func MergeDistinctOptions(opts ...*DistinctOptions) *DistinctOptions {
 		if do.Collation != nil {
 			distinctOpts.Collation = do.Collation
 		}
+		if do.Comment != nil {
+			distinctOpts.Comment = do.Comment
+		}
 		if do.MaxTime != nil {
 			distinctOpts.MaxTime = do.MaxTime
 		}
+		if do.ReadAfter != nil {
+			distinctOpts.ReadAfter = do.ReadAfter
+		}
 	}
 
 	return distinctOpts
@@ -0,0 +1,52 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import (
+	"context"
+
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+)
+
+// DataKeyCreator creates data keys for queryable encryption. The driver does not provide an implementation; callers
+// supply their own, which decides where and how each new data key is stored.
+type DataKeyCreator interface {
+	// CreateDataKey creates a data key and returns its UUID.
+	CreateDataKey(ctx context.Context) (primitive.Binary, error)
+}
+
+// CreateEncryptedCollectionOptions represents all possible options for creating a queryable encryption collection.
+type CreateEncryptedCollectionOptions struct {
+	DataKeys DataKeyCreator // Creates a data key for every encrypted field with a missing or null keyId.
+}
+
+// CreateEncryptedCollection creates a new *CreateEncryptedCollectionOptions
+func CreateEncryptedCollection() *CreateEncryptedCollectionOptions {
+	return &CreateEncryptedCollectionOptions{}
+}
+
+// SetDataKeys sets the DataKeyCreator used to create data keys for encrypted fields without a keyId.
+func (ec *CreateEncryptedCollectionOptions) SetDataKeys(dk DataKeyCreator) *CreateEncryptedCollectionOptions {
+	ec.DataKeys = dk
+	return ec
+}
+
+// MergeCreateEncryptedCollectionOptions combines the given *CreateEncryptedCollectionOptions into one
+// *CreateEncryptedCollectionOptions in a last one wins fashion.
+func MergeCreateEncryptedCollectionOptions(opts ...*CreateEncryptedCollectionOptions) *CreateEncryptedCollectionOptions {
+	ec := CreateEncryptedCollection()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.DataKeys != nil {
+			ec.DataKeys = opt.DataKeys
+		}
+	}
+
+	return ec
+}
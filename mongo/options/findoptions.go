@@ -8,29 +8,35 @@ package options
 
 import (
 	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
 )
 
 // FindOptions represent all possible options to the find() function.
 type FindOptions struct {
-	AllowPartialResults *bool          // If true, allows partial results to be returned if some shards are down.
-	BatchSize           *int32         // Specifies the number of documents to return in every batch.
-	Collation           *Collation     // Specifies a collation to be used
-	Comment             *string        // Specifies a string to help trace the operation through the database.
-	CursorType          *CursorType    // Specifies the type of cursor to use
-	Hint                interface{}    // Specifies the index to use.
-	Limit               *int64         // Sets a limit on the number of results to return.
-	Max                 interface{}    // Sets an exclusive upper bound for a specific index
-	MaxAwaitTime        *time.Duration // Specifies the maximum amount of time for the server to wait on new documents.
-	MaxTime             *time.Duration // Specifies the maximum amount of time to allow the query to run.
-	Min                 interface{}    // Specifies the inclusive lower bound for a specific index.
-	NoCursorTimeout     *bool          // If true, prevents cursors from timing out after an inactivity period.
-	OplogReplay         *bool          // Adds an option for internal use only and should not be set.
-	Projection          interface{}    // Limits the fields returned for all documents.
-	ReturnKey           *bool          // If true, only returns index keys for all result documents.
-	ShowRecordID        *bool          // If true, a $recordId field with the record identifier will be added to the returned documents.
-	Skip                *int64         // Specifies the number of documents to skip before returning
-	Snapshot            *bool          // If true, prevents the cursor from returning a document more than once because of an intervening write operation.
-	Sort                interface{}    // Specifies the order in which to return results.
+	AllowPartialResults *bool                // If true, allows partial results to be returned if some shards are down.
+	BatchSize           *int32               // Specifies the number of documents to return in every batch.
+	CausalConsistency   *bool                // If false, opts the operation out of the causal consistency of its session.
+	Collation           *Collation           // Specifies a collation to be used
+	Comment             interface{}          // Specifies a value to help trace the operation through the database.
+	CursorType          *CursorType          // Specifies the type of cursor to use
+	Exhaust             *bool                // If true, the server streams every batch after the first over one connection.
+	Hint                interface{}          // Specifies the index to use.
+	Limit               *int64               // Sets a limit on the number of results to return.
+	Max                 interface{}          // Sets an exclusive upper bound for a specific index
+	MaxAwaitTime        *time.Duration       // Specifies the maximum amount of time for the server to wait on new documents.
+	MaxTime             *time.Duration       // Specifies the maximum amount of time to allow the query to run.
+	Min                 interface{}          // Specifies the inclusive lower bound for a specific index.
+	NoCursorTimeout     *bool                // If true, prevents cursors from timing out after an inactivity period.
+	OplogReplay         *bool                // Adds an option for internal use only and should not be set.
+	Prefetch            *bool                // If true, the cursor fetches its next batch in the background.
+	Projection          interface{}          // Limits the fields returned for all documents.
+	ReadAfter           *primitive.Timestamp // Makes the read wait until the server has applied the write with this operation time.
+	ReturnKey           *bool                // If true, only returns index keys for all result documents.
+	ShowRecordID        *bool                // If true, a $recordId field with the record identifier will be added to the returned documents.
+	Skip                *int64               // Specifies the number of documents to skip before returning
+	Snapshot            *bool                // If true, prevents the cursor from returning a document more than once because of an intervening write operation.
+	Sort                interface{}          // Specifies the order in which to return results.
 }
 
 // Find creates a new FindOptions instance.
@@ -51,6 +57,16 @@ func (f *FindOptions) SetBatchSize(i int32) *FindOptions {
 	return f
 }
 
+// SetCausalConsistency specifies whether the operation is causally consistent with the other operations in its
+// session. Setting it to false skips sending afterClusterTime for this operation only, so it may not observe the
+// session's preceding writes; the session's operation time still advances with the response. It has no effect
+// outside of a causally consistent session or on the first operation of a transaction, whose read concern applies to
+// the whole transaction.
+func (f *FindOptions) SetCausalConsistency(b bool) *FindOptions {
+	f.CausalConsistency = &b
+	return f
+}
+
 // SetCollation specifies a Collation to use for the Find operation.
 // Valid for server versions >= 3.4
 func (f *FindOptions) SetCollation(collation *Collation) *FindOptions {
@@ -58,9 +74,10 @@ func (f *FindOptions) SetCollation(collation *Collation) *FindOptions {
 	return f
 }
 
-// SetComment specifies a string to help trace the operation through the database.
-func (f *FindOptions) SetComment(comment string) *FindOptions {
-	f.Comment = &comment
+// SetComment specifies a value to help trace the operation through the database.
+// Comments other than strings are valid for server versions >= 4.4.
+func (f *FindOptions) SetComment(comment interface{}) *FindOptions {
+	f.Comment = comment
 	return f
 }
 
@@ -70,6 +87,17 @@ func (f *FindOptions) SetCursorType(ct CursorType) *FindOptions {
 	return f
 }
 
+// SetExhaust specifies whether the cursor should use exhaust mode. An exhaust cursor keeps the connection it
+// first runs getMore on checked out and the server streams the remaining batches on it without waiting for
+// further getMore commands, which saves a round trip per batch. The connection is returned to the pool once
+// the cursor is exhausted; closing the cursor early closes the connection instead.
+// Valid for server versions >= 4.2. Exhaust is not available against load balanced deployments, and older
+// servers or mongos fall back to a regular cursor.
+func (f *FindOptions) SetExhaust(b bool) *FindOptions {
+	f.Exhaust = &b
+	return f
+}
+
 // SetHint specifies the index to use.
 func (f *FindOptions) SetHint(hint interface{}) *FindOptions {
 	f.Hint = hint
@@ -97,7 +125,9 @@ func (f *FindOptions) SetMaxAwaitTime(d time.Duration) *FindOptions {
 	return f
 }
 
-// SetMaxTime specifies the max time to allow the query to run.
+// SetMaxTime specifies the max time to allow the query to run. It is sent to the server as maxTimeMS and is
+// independent of the context deadline, which bounds how long the client waits. It is ignored if the client has a
+// timeout set with ClientOptions.SetTimeout.
 func (f *FindOptions) SetMaxTime(d time.Duration) *FindOptions {
 	f.MaxTime = &d
 	return f
@@ -123,12 +153,33 @@ func (f *FindOptions) SetOplogReplay(b bool) *FindOptions {
 	return f
 }
 
+// SetPrefetch specifies whether the cursor should prefetch. A prefetching cursor sends each getMore as soon as
+// the previous batch arrives and runs it in the background, so the next batch is usually ready by the time the
+// application has processed the current one and sequential scans do not wait on the network between batches.
+// Errors from a background getMore are returned when Next reaches the end of the current batch. A prefetching
+// cursor holds a connection while a getMore is in flight and must be closed if it is not fully iterated. Cursors
+// on an explicit session never prefetch, so the session is not used concurrently, and Exhaust takes precedence.
+func (f *FindOptions) SetPrefetch(b bool) *FindOptions {
+	f.Prefetch = &b
+	return f
+}
+
 // SetProjection adds an option to limit the fields returned for all documents.
 func (f *FindOptions) SetProjection(projection interface{}) *FindOptions {
 	f.Projection = projection
 	return f
 }
 
+// SetReadAfter makes the read wait until the server has applied the write with the given operation time, as
+// reported by the OperationTime of a write result, which gives read-your-writes consistency without a session.
+// It is sent as the afterClusterTime of the read concern, which only guarantees that the write will not be rolled
+// back if the read concern level is "majority". It requires MongoDB 3.6 or later and a replica set or sharded
+// cluster, and is ignored in a transaction.
+func (f *FindOptions) SetReadAfter(t *primitive.Timestamp) *FindOptions {
+	f.ReadAfter = t
+	return f
+}
+
 // SetReturnKey adds an option to only return index keys for all result documents.
 func (f *FindOptions) SetReturnKey(b bool) *FindOptions {
 	f.ReturnKey = &b
@@ -174,6 +225,9 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.BatchSize != nil {
 			fo.BatchSize = opt.BatchSize
 		}
+		if opt.CausalConsistency != nil {
+			fo.CausalConsistency = opt.CausalConsistency
+		}
 		if opt.Collation != nil {
 			fo.Collation = opt.Collation
 		}
@@ -183,6 +237,9 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.CursorType != nil {
 			fo.CursorType = opt.CursorType
 		}
+		if opt.Exhaust != nil {
+			fo.Exhaust = opt.Exhaust
+		}
 		if opt.Hint != nil {
 			fo.Hint = opt.Hint
 		}
@@ -207,9 +264,15 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.OplogReplay != nil {
 			fo.OplogReplay = opt.OplogReplay
 		}
+		if opt.Prefetch != nil {
+			fo.Prefetch = opt.Prefetch
+		}
 		if opt.Projection != nil {
 			fo.Projection = opt.Projection
 		}
+		if opt.ReadAfter != nil {
+			fo.ReadAfter = opt.ReadAfter
+		}
 		if opt.ReturnKey != nil {
 			fo.ReturnKey = opt.ReturnKey
 		}
@@ -232,24 +295,25 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 
 // FindOneOptions represent all possible options to the findOne() function.
 type FindOneOptions struct {
-	AllowPartialResults *bool          // If true, allows partial results to be returned if some shards are down.
-	BatchSize           *int32         // Specifies the number of documents to return in every batch.
-	Collation           *Collation     // Specifies a collation to be used
-	Comment             *string        // Specifies a string to help trace the operation through the database.
-	CursorType          *CursorType    // Specifies the type of cursor to use
-	Hint                interface{}    // Specifies the index to use.
-	Max                 interface{}    // Sets an exclusive upper bound for a specific index
-	MaxAwaitTime        *time.Duration // Specifies the maximum amount of time for the server to wait on new documents.
-	MaxTime             *time.Duration // Specifies the maximum amount of time to allow the query to run.
-	Min                 interface{}    // Specifies the inclusive lower bound for a specific index.
-	NoCursorTimeout     *bool          // If true, prevents cursors from timing out after an inactivity period.
-	OplogReplay         *bool          // Adds an option for internal use only and should not be set.
-	Projection          interface{}    // Limits the fields returned for all documents.
-	ReturnKey           *bool          // If true, only returns index keys for all result documents.
-	ShowRecordID        *bool          // If true, a $recordId field with the record identifier will be added to the returned documents.
-	Skip                *int64         // Specifies the number of documents to skip before returning
-	Snapshot            *bool          // If true, prevents the cursor from returning a document more than once because of an intervening write operation.
-	Sort                interface{}    // Specifies the order in which to return results.
+	AllowPartialResults *bool                // If true, allows partial results to be returned if some shards are down.
+	BatchSize           *int32               // Specifies the number of documents to return in every batch.
+	Collation           *Collation           // Specifies a collation to be used
+	Comment             interface{}          // Specifies a value to help trace the operation through the database.
+	CursorType          *CursorType          // Specifies the type of cursor to use
+	Hint                interface{}          // Specifies the index to use.
+	Max                 interface{}          // Sets an exclusive upper bound for a specific index
+	MaxAwaitTime        *time.Duration       // Specifies the maximum amount of time for the server to wait on new documents.
+	MaxTime             *time.Duration       // Specifies the maximum amount of time to allow the query to run.
+	Min                 interface{}          // Specifies the inclusive lower bound for a specific index.
+	NoCursorTimeout     *bool                // If true, prevents cursors from timing out after an inactivity period.
+	OplogReplay         *bool                // Adds an option for internal use only and should not be set.
+	Projection          interface{}          // Limits the fields returned for all documents.
+	ReadAfter           *primitive.Timestamp // Makes the read wait until the server has applied the write with this operation time.
+	ReturnKey           *bool                // If true, only returns index keys for all result documents.
+	ShowRecordID        *bool                // If true, a $recordId field with the record identifier will be added to the returned documents.
+	Skip                *int64               // Specifies the number of documents to skip before returning
+	Snapshot            *bool                // If true, prevents the cursor from returning a document more than once because of an intervening write operation.
+	Sort                interface{}          // Specifies the order in which to return results.
 }
 
 // FindOne creates a new FindOneOptions instance.
@@ -275,9 +339,10 @@ func (f *FindOneOptions) SetCollation(collation *Collation) *FindOneOptions {
 	return f
 }
 
-// SetComment specifies a string to help trace the operation through the database.
-func (f *FindOneOptions) SetComment(comment string) *FindOneOptions {
-	f.Comment = &comment
+// SetComment specifies a value to help trace the operation through the database.
+// Comments other than strings are valid for server versions >= 4.4.
+func (f *FindOneOptions) SetComment(comment interface{}) *FindOneOptions {
+	f.Comment = comment
 	return f
 }
 
@@ -336,6 +401,13 @@ func (f *FindOneOptions) SetProjection(projection interface{}) *FindOneOptions {
 	return f
 }
 
+// SetReadAfter makes the read wait until the server has applied the write with the given operation time. See
+// FindOptions.SetReadAfter.
+func (f *FindOneOptions) SetReadAfter(t *primitive.Timestamp) *FindOneOptions {
+	f.ReadAfter = t
+	return f
+}
+
 // SetReturnKey adds an option to only return index keys for all result documents.
 func (f *FindOneOptions) SetReturnKey(b bool) *FindOneOptions {
 	f.ReturnKey = &b
@@ -413,6 +485,9 @@ func MergeFindOneOptions(opts ...*FindOneOptions) *FindOneOptions {
 		if opt.Projection != nil {
 			fo.Projection = opt.Projection
 		}
+		if opt.ReadAfter != nil {
+			fo.ReadAfter = opt.ReadAfter
+		}
 		if opt.ReturnKey != nil {
 			fo.ReturnKey = opt.ReturnKey
 		}
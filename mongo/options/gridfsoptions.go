@@ -24,6 +24,9 @@ var DefaultChunkSize int32 = 255 * 1000
 // DefaultRevision is the default revision number for a download by name operation.
 var DefaultRevision int32 = -1
 
+// DefaultMaxDownloadRetries is the default number of times a download resumes after a retryable error.
+var DefaultMaxDownloadRetries = 1
+
 // BucketOptions represents all possible options to configure a GridFS bucket.
 type BucketOptions struct {
 	Name           *string                    // The bucket name. Defaults to "fs".
@@ -31,13 +34,18 @@ type BucketOptions struct {
 	WriteConcern   *writeconcern.WriteConcern // The write concern for the bucket. Defaults to the write concern of the database.
 	ReadConcern    *readconcern.ReadConcern   // The read concern for the bucket. Defaults to the read concern of the database.
 	ReadPreference *readpref.ReadPref         // The read preference for the bucket. Defaults to the read preference of the database.
+
+	// The maximum number of times a download resumes reading chunks after a retryable error, such as a network error.
+	// Defaults to 1. Setting it to 0 disables resuming.
+	MaxDownloadRetries *int
 }
 
 // GridFSBucket creates a new *BucketOptions
 func GridFSBucket() *BucketOptions {
 	return &BucketOptions{
-		Name:           &DefaultName,
-		ChunkSizeBytes: &DefaultChunkSize,
+		Name:               &DefaultName,
+		ChunkSizeBytes:     &DefaultChunkSize,
+		MaxDownloadRetries: &DefaultMaxDownloadRetries,
 	}
 }
 
@@ -71,9 +79,16 @@ func (b *BucketOptions) SetReadPreference(rp *readpref.ReadPref) *BucketOptions
 	return b
 }
 
+// SetMaxDownloadRetries sets the maximum number of times a download resumes reading chunks after a retryable error.
+// Defaults to 1 if not set.
+func (b *BucketOptions) SetMaxDownloadRetries(n int) *BucketOptions {
+	b.MaxDownloadRetries = &n
+	return b
+}
+
 // MergeBucketOptions combines the given *BucketOptions into a single *BucketOptions.
-// If the name or chunk size is not set in any of the given *BucketOptions, the resulting *BucketOptions will have
-// name "fs" and chunk size 255KB.
+// If the name, chunk size, or maximum download retries are not set in any of the given *BucketOptions, the resulting
+// *BucketOptions will have name "fs", chunk size 255KB, and maximum download retries 1.
 func MergeBucketOptions(opts ...*BucketOptions) *BucketOptions {
 	b := GridFSBucket()
 
@@ -96,6 +111,9 @@ func MergeBucketOptions(opts ...*BucketOptions) *BucketOptions {
 		if opt.ReadPreference != nil {
 			b.ReadPreference = opt.ReadPreference
 		}
+		if opt.MaxDownloadRetries != nil {
+			b.MaxDownloadRetries = opt.MaxDownloadRetries
+		}
 	}
 
 	return b
@@ -10,7 +10,8 @@ import "time"
 
 // CreateIndexesOptions represents all possible options for the create() function.
 type CreateIndexesOptions struct {
-	MaxTime *time.Duration // The maximum amount of time to allow the query to run.
+	MaxTime                     *time.Duration // The maximum amount of time to allow the query to run.
+	SkipPartialFilterValidation *bool          // Send partial filter expressions to the server without checking them.
 }
 
 // CreateIndexes creates a new CreateIndexesOptions instance.
@@ -24,6 +25,15 @@ func (c *CreateIndexesOptions) SetMaxTime(d time.Duration) *CreateIndexesOptions
 	return c
 }
 
+// SetSkipPartialFilterValidation specifies whether the partialFilterExpression option of each index is sent to the
+// server without being checked first. By default, expressions that use operators other than those supported in
+// partial indexes are rejected with an error before the command is sent. Skipping the check allows operators added
+// by newer servers to be used.
+func (c *CreateIndexesOptions) SetSkipPartialFilterValidation(b bool) *CreateIndexesOptions {
+	c.SkipPartialFilterValidation = &b
+	return c
+}
+
 // MergeCreateIndexesOptions combines the given *CreateIndexesOptions into a single *CreateIndexesOptions in a last one
 // wins fashion.
 func MergeCreateIndexesOptions(opts ...*CreateIndexesOptions) *CreateIndexesOptions {
@@ -35,6 +45,9 @@ func MergeCreateIndexesOptions(opts ...*CreateIndexesOptions) *CreateIndexesOpti
 		if opt.MaxTime != nil {
 			c.MaxTime = opt.MaxTime
 		}
+		if opt.SkipPartialFilterValidation != nil {
+			c.SkipPartialFilterValidation = opt.SkipPartialFilterValidation
+		}
 	}
 
 	return c
@@ -103,6 +116,9 @@ func MergeListIndexesOptions(opts ...*ListIndexesOptions) *ListIndexesOptions {
 		if opt == nil {
 			continue
 		}
+		if opt.BatchSize != nil {
+			c.BatchSize = opt.BatchSize
+		}
 		if opt.MaxTime != nil {
 			c.MaxTime = opt.MaxTime
 		}
@@ -0,0 +1,84 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import "github.com/mongodb/mongo-go-driver/x/mongo/driver/logger"
+
+// LogLevel is the severity of a driver log message. A component configured with a level logs the messages of
+// that level and of every more severe level.
+type LogLevel = logger.Level
+
+// These constants are the levels a log component can be configured with, from least to most verbose.
+const (
+	LogLevelOff   = logger.LevelOff
+	LogLevelError = logger.LevelError
+	LogLevelWarn  = logger.LevelWarn
+	LogLevelInfo  = logger.LevelInfo
+	LogLevelDebug = logger.LevelDebug
+)
+
+// LogComponent is the part of the driver a log message is about.
+type LogComponent = logger.Component
+
+// These constants are the components of the driver that log messages. LogComponentAll configures the level of
+// every component at once.
+const (
+	LogComponentAll             = logger.ComponentAll
+	LogComponentCommand         = logger.ComponentCommand
+	LogComponentTopology        = logger.ComponentTopology
+	LogComponentServerSelection = logger.ComponentServerSelection
+	LogComponentConnection      = logger.ComponentConnection
+)
+
+// LogSink receives the messages logged by the driver. logger.NewSlogSink adapts a *slog.Logger.
+type LogSink = logger.Sink
+
+// LoggerOptions represents all possible options to configure the logging of a client.
+//
+// ComponentLevels sets the level of each component. Components without a level use the level of
+// LogComponentAll, if set, or of the MONGODB_LOG_ALL and MONGODB_LOG_<COMPONENT> environment variables.
+//
+// Sink receives the messages. If nil, messages are written as JSON to standard error.
+//
+// MaxDocumentLength is the length the extended JSON of commands and replies is truncated to. If zero, the
+// MONGODB_LOG_MAX_DOCUMENT_LENGTH environment variable is used, or 1000 if it is not set.
+type LoggerOptions struct {
+	ComponentLevels   map[LogComponent]LogLevel
+	Sink              LogSink
+	MaxDocumentLength uint
+}
+
+// Logger creates a new LoggerOptions instance.
+func Logger() *LoggerOptions {
+	return &LoggerOptions{}
+}
+
+// SetComponentLevel specifies the level of messages logged for the component. Setting the level of
+// LogComponentAll applies to every component whose level is not set.
+func (lo *LoggerOptions) SetComponentLevel(component LogComponent, level LogLevel) *LoggerOptions {
+	if lo.ComponentLevels == nil {
+		lo.ComponentLevels = make(map[LogComponent]LogLevel)
+	}
+	lo.ComponentLevels[component] = level
+
+	return lo
+}
+
+// SetSink specifies the sink that receives the messages logged by the driver.
+func (lo *LoggerOptions) SetSink(sink LogSink) *LoggerOptions {
+	lo.Sink = sink
+
+	return lo
+}
+
+// SetMaxDocumentLength specifies the length, in bytes, that the extended JSON of commands and replies included
+// in messages is truncated to.
+func (lo *LoggerOptions) SetMaxDocumentLength(n uint) *LoggerOptions {
+	lo.MaxDocumentLength = n
+
+	return lo
+}
@@ -93,6 +93,14 @@ const (
 	// UpdateLookup includes a delta describing the changes to the document and a copy of the entire document that
 	// was changed
 	UpdateLookup FullDocument = "updateLookup"
+	// FullDocumentOff does not include a copy of the document from before the change. It is only used with
+	// fullDocumentBeforeChange.
+	FullDocumentOff FullDocument = "off"
+	// FullDocumentWhenAvailable includes a copy of the document if one is available
+	FullDocumentWhenAvailable FullDocument = "whenAvailable"
+	// FullDocumentRequired includes a copy of the document and causes the server to return an error if one is not
+	// available
+	FullDocumentRequired FullDocument = "required"
 )
 
 // ArrayFilters is used to hold filters for the array filters CRUD option. If a registry is nil, bson.DefaultRegistry
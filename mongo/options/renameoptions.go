@@ -0,0 +1,40 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// RenameOptions represents all possible options to the Rename() function
+type RenameOptions struct {
+	DropTarget *bool // If true, an existing collection with the new name is dropped before the rename
+}
+
+// Rename returns a pointer to a new RenameOptions
+func Rename() *RenameOptions {
+	return &RenameOptions{}
+}
+
+// SetDropTarget specifies whether an existing collection with the new name is dropped before the rename. If
+// false, the rename fails when the target collection exists.
+func (ro *RenameOptions) SetDropTarget(b bool) *RenameOptions {
+	ro.DropTarget = &b
+	return ro
+}
+
+// MergeRenameOptions combines the given *RenameOptions into a single *RenameOptions in a last one wins fashion.
+func MergeRenameOptions(opts ...*RenameOptions) *RenameOptions {
+	r := Rename()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.DropTarget != nil {
+			r.DropTarget = opt.DropTarget
+		}
+	}
+
+	return r
+}
@@ -21,6 +21,7 @@ type SessionOptions struct {
 	DefaultReadConcern    *readconcern.ReadConcern   // The default read concern for transactions started in the session.
 	DefaultReadPreference *readpref.ReadPref         // The default read preference for transactions started in the session.
 	DefaultWriteConcern   *writeconcern.WriteConcern // The default write concern for transactions started in the session.
+	Snapshot              *bool                      // Specifies if reads should read from a single snapshot. Defaults to false.
 }
 
 // Session creates a new *SessionOptions
@@ -54,6 +55,14 @@ func (s *SessionOptions) SetDefaultWriteConcern(wc *writeconcern.WriteConcern) *
 	return s
 }
 
+// SetSnapshot specifies if reads in a session should read from a single point in time. The first find,
+// aggregate or distinct in the session chooses the point in time and later reads reuse it. Snapshot
+// sessions are not causally consistent, do not support transactions and require MongoDB 5.0 or later.
+func (s *SessionOptions) SetSnapshot(b bool) *SessionOptions {
+	s.Snapshot = &b
+	return s
+}
+
 // MergeSessionOptions combines the given *SessionOptions into a single *SessionOptions in a last one wins fashion.
 func MergeSessionOptions(opts ...*SessionOptions) *SessionOptions {
 	s := Session()
@@ -73,6 +82,9 @@ func MergeSessionOptions(opts ...*SessionOptions) *SessionOptions {
 		if opt.DefaultWriteConcern != nil {
 			s.DefaultWriteConcern = opt.DefaultWriteConcern
 		}
+		if opt.Snapshot != nil {
+			s.Snapshot = opt.Snapshot
+		}
 	}
 
 	return s
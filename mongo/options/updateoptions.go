@@ -11,6 +11,7 @@ type UpdateOptions struct {
 	ArrayFilters             *ArrayFilters // A set of filters specifying to which array elements an update should apply
 	BypassDocumentValidation *bool         // If true, allows the write to opt-out of document level validation
 	Collation                *Collation    // Specifies a collation
+	Comment                  interface{}   // Specifies a value to help trace the operation through the database
 	Upsert                   *bool         // When true, creates a new document if no document matches the query
 }
 
@@ -40,6 +41,13 @@ func (uo *UpdateOptions) SetCollation(c *Collation) *UpdateOptions {
 	return uo
 }
 
+// SetComment specifies a value to help trace the operation through the database profiler, currentOp and logs.
+// Valid for server versions >= 4.4.
+func (uo *UpdateOptions) SetComment(comment interface{}) *UpdateOptions {
+	uo.Comment = comment
+	return uo
+}
+
 // SetUpsert allows the creation of a new document if not document matches the query
 func (uo *UpdateOptions) SetUpsert(b bool) *UpdateOptions {
 	uo.Upsert = &b
@@ -62,6 +70,9 @@ func MergeUpdateOptions(opts ...*UpdateOptions) *UpdateOptions {
 		if uo.Collation != nil {
 			uOpts.Collation = uo.Collation
 		}
+		if uo.Comment != nil {
+			uOpts.Comment = uo.Comment
+		}
 		if uo.Upsert != nil {
 			uOpts.Upsert = uo.Upsert
 		}
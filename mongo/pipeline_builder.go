@@ -0,0 +1,318 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+)
+
+// PipelineBuilderError is returned by PipelineBuilder.Build when one or more stages are invalid. It contains an
+// error for every invalid stage, in the order the stages were added.
+type PipelineBuilderError struct {
+	Errors []error
+}
+
+// Error implements the error interface.
+func (pbe PipelineBuilderError) Error() string {
+	msgs := make([]string, 0, len(pbe.Errors))
+	for _, err := range pbe.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return "invalid pipeline: " + strings.Join(msgs, "; ")
+}
+
+// PipelineBuilder constructs a Pipeline one stage at a time, validating each stage as it is added. Errors are
+// accumulated rather than returned by each method, so calls can be chained and all of the errors are reported
+// together by Build.
+//
+// Example usage:
+//
+//	pipeline, err := mongo.NewPipelineBuilder().
+//		Match(bson.D{{"state", bson.D{{"$in", bson.A{"NY", "CA"}}}}}).
+//		Group("$state", bson.D{{"totalPop", bson.D{{"$sum", "$pop"}}}}).
+//		Sort(bson.D{{"totalPop", -1}}).
+//		Build()
+type PipelineBuilder struct {
+	pipeline Pipeline
+	errs     []error
+}
+
+// NewPipelineBuilder creates a new instance of PipelineBuilder
+func NewPipelineBuilder() *PipelineBuilder {
+	return &PipelineBuilder{}
+}
+
+// Match adds a $match stage that filters documents with the given query. The $where operator is not allowed.
+func (pb *PipelineBuilder) Match(filter bson.D) *PipelineBuilder {
+	if filter == nil {
+		filter = bson.D{}
+	}
+	for _, elem := range filter {
+		if elem.Key == "$where" {
+			pb.addError("$match", "the $where operator cannot be used in an aggregation pipeline")
+			return pb
+		}
+	}
+	return pb.Stage("$match", filter)
+}
+
+// Group adds a $group stage that groups documents by the id expression. A nil id groups all documents together.
+// Each accumulator must be a field name mapped to a document with a single accumulator operator, such as
+// {"total", bson.D{{"$sum", "$amount"}}}.
+func (pb *PipelineBuilder) Group(id interface{}, accumulators bson.D) *PipelineBuilder {
+	group := bson.D{{"_id", id}}
+	for _, acc := range accumulators {
+		switch {
+		case acc.Key == "":
+			pb.addError("$group", "accumulator field names cannot be empty")
+			return pb
+		case acc.Key == "_id":
+			pb.addError("$group", "the _id field is set by the id parameter and cannot be an accumulator")
+			return pb
+		case strings.Contains(acc.Key, "."):
+			pb.addError("$group", "accumulator field %q cannot contain a '.'", acc.Key)
+			return pb
+		}
+
+		var op string
+		switch expr := acc.Value.(type) {
+		case bson.D:
+			if len(expr) == 1 {
+				op = expr[0].Key
+			}
+		case bson.M:
+			if len(expr) == 1 {
+				for key := range expr {
+					op = key
+				}
+			}
+		}
+		if !strings.HasPrefix(op, "$") {
+			pb.addError("$group", "accumulator field %q must be a document with a single accumulator operator", acc.Key)
+			return pb
+		}
+		group = append(group, acc)
+	}
+	return pb.Stage("$group", group)
+}
+
+// Sort adds a $sort stage. Each key must be sorted by 1 for ascending order, -1 for descending order, or a
+// {"$meta": "textScore"} document.
+func (pb *PipelineBuilder) Sort(keys bson.D) *PipelineBuilder {
+	if len(keys) == 0 {
+		pb.addError("$sort", "at least one sort key is required")
+		return pb
+	}
+	for _, key := range keys {
+		if !validSortOrder(key.Value) {
+			pb.addError("$sort", "sort order for %q must be 1, -1, or {$meta: \"textScore\"}, got %v", key.Key, key.Value)
+			return pb
+		}
+	}
+	return pb.Stage("$sort", keys)
+}
+
+// Lookup adds a $lookup stage that performs an equality match between localField and the foreignField of the
+// documents in the from collection, storing the matches in the as field.
+func (pb *PipelineBuilder) Lookup(from, localField, foreignField, as string) *PipelineBuilder {
+	for _, field := range []struct{ name, value string }{
+		{"from", from}, {"localField", localField}, {"foreignField", foreignField}, {"as", as},
+	} {
+		if field.value == "" {
+			pb.addError("$lookup", "the %s field is required", field.name)
+			return pb
+		}
+	}
+	return pb.Stage("$lookup", bson.D{
+		{"from", from},
+		{"localField", localField},
+		{"foreignField", foreignField},
+		{"as", as},
+	})
+}
+
+// Project adds a $project stage with the given specification, which cannot be empty.
+func (pb *PipelineBuilder) Project(projection bson.D) *PipelineBuilder {
+	if len(projection) == 0 {
+		pb.addError("$project", "the projection must specify at least one field")
+		return pb
+	}
+	return pb.Stage("$project", projection)
+}
+
+// Unwind adds an $unwind stage for the array at the given field path, which must begin with '$'.
+func (pb *PipelineBuilder) Unwind(path string) *PipelineBuilder {
+	if len(path) < 2 || path[0] != '$' {
+		pb.addError("$unwind", "the path %q must be a field path beginning with '$'", path)
+		return pb
+	}
+	return pb.Stage("$unwind", path)
+}
+
+// Skip adds a $skip stage. The number of documents to skip cannot be negative.
+func (pb *PipelineBuilder) Skip(n int64) *PipelineBuilder {
+	if n < 0 {
+		pb.addError("$skip", "the number of documents to skip cannot be negative, got %d", n)
+		return pb
+	}
+	return pb.Stage("$skip", n)
+}
+
+// Limit adds a $limit stage. The limit must be positive.
+func (pb *PipelineBuilder) Limit(n int64) *PipelineBuilder {
+	if n <= 0 {
+		pb.addError("$limit", "the limit must be positive, got %d", n)
+		return pb
+	}
+	return pb.Stage("$limit", n)
+}
+
+// Documents adds a $documents stage, which uses docs instead of the documents of a collection as the input of the
+// pipeline. It must be the first stage of the pipeline, and the pipeline must be run with Database.Aggregate or
+// nested in a UnionWith stage. Requires MongoDB 5.1 or later.
+func (pb *PipelineBuilder) Documents(docs []bson.D) *PipelineBuilder {
+	if len(pb.pipeline)+len(pb.errs) > 0 {
+		pb.addError("$documents", "the $documents stage must be the first stage of the pipeline")
+		return pb
+	}
+	if docs == nil {
+		docs = []bson.D{}
+	}
+	return pb.Stage("$documents", docs)
+}
+
+// UnionWith adds a $unionWith stage that appends the results of pipeline, run against the coll collection, to the
+// documents of the pipeline being built. The pipeline may be nil to append every document of coll. coll may only be
+// empty if pipeline begins with a $documents stage, and pipeline cannot write its results with $out or $merge.
+// Requires MongoDB 4.4 or later.
+func (pb *PipelineBuilder) UnionWith(coll string, pipeline Pipeline) *PipelineBuilder {
+	if coll == "" && (len(pipeline) == 0 || len(pipeline[0]) == 0 || pipeline[0][0].Key != "$documents") {
+		pb.addError("$unionWith", "the coll field is required unless the pipeline begins with $documents")
+		return pb
+	}
+	for _, stage := range pipeline {
+		for _, elem := range stage {
+			if elem.Key == "$out" || elem.Key == "$merge" {
+				pb.addError("$unionWith", "the pipeline cannot contain a %s stage", elem.Key)
+				return pb
+			}
+		}
+	}
+
+	var union bson.D
+	if coll != "" {
+		union = append(union, bson.E{"coll", coll})
+	}
+	if pipeline != nil {
+		union = append(union, bson.E{"pipeline", pipeline})
+	}
+	return pb.Stage("$unionWith", union)
+}
+
+// maxVectorSearchCandidates is the largest numCandidates accepted by $vectorSearch.
+const maxVectorSearchCandidates = 10000
+
+// VectorSearch is the specification of an Atlas Vector Search $vectorSearch stage, which returns the Limit
+// documents whose vectors at Path are nearest to QueryVector according to the vector search index named Index.
+// NumCandidates is the number of nearest neighbors considered before the closest Limit are returned, so raising it
+// improves accuracy at the cost of latency. Filter is an optional query on fields indexed as filter fields, which
+// is applied before the nearest neighbors are found.
+type VectorSearch struct {
+	Index         string
+	Path          string
+	QueryVector   []float64
+	NumCandidates int64
+	Limit         int64
+	Filter        bson.D
+}
+
+// VectorSearch adds a $vectorSearch stage, which must be the first stage of the pipeline. NumCandidates must be
+// at least Limit and at most 10000.
+func (pb *PipelineBuilder) VectorSearch(vs VectorSearch) *PipelineBuilder {
+	switch {
+	case len(pb.pipeline)+len(pb.errs) > 0:
+		pb.addError("$vectorSearch", "the $vectorSearch stage must be the first stage of the pipeline")
+		return pb
+	case vs.Index == "":
+		pb.addError("$vectorSearch", "the index field is required")
+		return pb
+	case vs.Path == "":
+		pb.addError("$vectorSearch", "the path field is required")
+		return pb
+	case len(vs.QueryVector) == 0:
+		pb.addError("$vectorSearch", "the queryVector field is required")
+		return pb
+	case vs.Limit <= 0:
+		pb.addError("$vectorSearch", "the limit must be positive, got %d", vs.Limit)
+		return pb
+	case vs.NumCandidates < vs.Limit:
+		pb.addError("$vectorSearch", "numCandidates (%d) must be at least the limit (%d)", vs.NumCandidates, vs.Limit)
+		return pb
+	case vs.NumCandidates > maxVectorSearchCandidates:
+		pb.addError("$vectorSearch", "numCandidates cannot exceed %d, got %d", maxVectorSearchCandidates, vs.NumCandidates)
+		return pb
+	}
+
+	stage := bson.D{
+		{"index", vs.Index},
+		{"path", vs.Path},
+		{"queryVector", vs.QueryVector},
+		{"numCandidates", vs.NumCandidates},
+		{"limit", vs.Limit},
+	}
+	if len(vs.Filter) > 0 {
+		stage = append(stage, bson.E{"filter", vs.Filter})
+	}
+	return pb.Stage("$vectorSearch", stage)
+}
+
+// Stage adds a stage that does not have a dedicated method. The name must begin with '$'. The stage is not
+// otherwise validated.
+func (pb *PipelineBuilder) Stage(name string, value interface{}) *PipelineBuilder {
+	if !strings.HasPrefix(name, "$") {
+		pb.addError(name, "stage names must begin with '$'")
+		return pb
+	}
+	pb.pipeline = append(pb.pipeline, bson.D{{name, value}})
+	return pb
+}
+
+// Build returns the pipeline, or a PipelineBuilderError describing every invalid stage.
+func (pb *PipelineBuilder) Build() (Pipeline, error) {
+	if len(pb.errs) > 0 {
+		return nil, PipelineBuilderError{Errors: pb.errs}
+	}
+	return pb.pipeline, nil
+}
+
+// addError records an error for the stage that would have been added next, so the reported index matches the
+// position of the stage in the calls made to the builder.
+func (pb *PipelineBuilder) addError(stage, format string, args ...interface{}) {
+	idx := len(pb.pipeline) + len(pb.errs)
+	pb.errs = append(pb.errs, fmt.Errorf("stage %d (%s): %s", idx, stage, fmt.Sprintf(format, args...)))
+}
+
+func validSortOrder(order interface{}) bool {
+	switch o := order.(type) {
+	case int:
+		return o == 1 || o == -1
+	case int32:
+		return o == 1 || o == -1
+	case int64:
+		return o == 1 || o == -1
+	case float64:
+		return o == 1 || o == -1
+	case bson.D:
+		return len(o) == 1 && o[0].Key == "$meta" && o[0].Value == "textScore"
+	case bson.M:
+		return len(o) == 1 && o["$meta"] == "textScore"
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineBuilder(t *testing.T) {
+	t.Run("builds stages in order", func(t *testing.T) {
+		pipeline, err := NewPipelineBuilder().
+			Match(bson.D{{"state", "NY"}}).
+			Lookup("orders", "_id", "customer", "orders").
+			Unwind("$orders").
+			Group("$city", bson.D{{"total", bson.D{{"$sum", "$orders.amount"}}}}).
+			Sort(bson.D{{"total", -1}, {"_id", 1}}).
+			Project(bson.D{{"total", 1}}).
+			Skip(10).
+			Limit(5).
+			Stage("$count", "cities").
+			Build()
+		require.NoError(t, err)
+
+		expected := Pipeline{
+			{{"$match", bson.D{{"state", "NY"}}}},
+			{{"$lookup", bson.D{{"from", "orders"}, {"localField", "_id"}, {"foreignField", "customer"}, {"as", "orders"}}}},
+			{{"$unwind", "$orders"}},
+			{{"$group", bson.D{{"_id", "$city"}, {"total", bson.D{{"$sum", "$orders.amount"}}}}}},
+			{{"$sort", bson.D{{"total", -1}, {"_id", 1}}}},
+			{{"$project", bson.D{{"total", 1}}}},
+			{{"$skip", int64(10)}},
+			{{"$limit", int64(5)}},
+			{{"$count", "cities"}},
+		}
+		require.Equal(t, expected, pipeline)
+
+		arr, err := transformAggregatePipeline(bson.DefaultRegistry, pipeline)
+		require.NoError(t, err)
+		require.Len(t, arr, len(expected))
+		group := arr[3].Document().Lookup("$group").Document()
+		require.Equal(t, bsonx.Doc{{"_id", bsonx.String("$city")},
+			{"total", bsonx.Document(bsonx.Doc{{"$sum", bsonx.String("$orders.amount")}})}}, group)
+	})
+
+	t.Run("group by null", func(t *testing.T) {
+		pipeline, err := NewPipelineBuilder().Group(nil, bson.D{{"count", bson.M{"$sum": 1}}}).Build()
+		require.NoError(t, err)
+		require.Equal(t, Pipeline{{{"$group", bson.D{{"_id", nil}, {"count", bson.M{"$sum": 1}}}}}}, pipeline)
+	})
+
+	t.Run("empty match", func(t *testing.T) {
+		pipeline, err := NewPipelineBuilder().Match(nil).Build()
+		require.NoError(t, err)
+		require.Equal(t, Pipeline{{{"$match", bson.D{}}}}, pipeline)
+	})
+
+	t.Run("vector search", func(t *testing.T) {
+		pipeline, err := NewPipelineBuilder().
+			VectorSearch(VectorSearch{
+				Index:         "embeddings",
+				Path:          "plot_embedding",
+				QueryVector:   []float64{0.1, -0.2, 0.3},
+				NumCandidates: 100,
+				Limit:         10,
+				Filter:        bson.D{{"year", bson.D{{"$gt", 1990}}}},
+			}).
+			Project(bson.D{{"title", 1}, {"score", bson.D{{"$meta", "vectorSearchScore"}}}}).
+			Build()
+		require.NoError(t, err)
+
+		expected := Pipeline{
+			{{"$vectorSearch", bson.D{
+				{"index", "embeddings"},
+				{"path", "plot_embedding"},
+				{"queryVector", []float64{0.1, -0.2, 0.3}},
+				{"numCandidates", int64(100)},
+				{"limit", int64(10)},
+				{"filter", bson.D{{"year", bson.D{{"$gt", 1990}}}}},
+			}}},
+			{{"$project", bson.D{{"title", 1}, {"score", bson.D{{"$meta", "vectorSearchScore"}}}}}},
+		}
+		require.Equal(t, expected, pipeline)
+
+		arr, err := transformAggregatePipeline(bson.DefaultRegistry, pipeline)
+		require.NoError(t, err)
+		stage := arr[0].Document().Lookup("$vectorSearch").Document()
+		require.Len(t, stage.Lookup("queryVector").Array(), 3)
+		require.Equal(t, int64(100), stage.Lookup("numCandidates").Int64())
+
+		// The filter is optional.
+		pipeline, err = NewPipelineBuilder().
+			VectorSearch(VectorSearch{Index: "i", Path: "p", QueryVector: []float64{1}, NumCandidates: 5, Limit: 5}).
+			Build()
+		require.NoError(t, err)
+		for _, elem := range pipeline[0][0].Value.(bson.D) {
+			require.NotEqual(t, "filter", elem.Key, "an empty filter should be omitted")
+		}
+	})
+
+	t.Run("documents and union with", func(t *testing.T) {
+		nested := Pipeline{{{"$documents", []bson.D{{{"x", 3}}}}}}
+		pipeline, err := NewPipelineBuilder().
+			Documents([]bson.D{{{"x", 1}}, {{"x", 2}}}).
+			UnionWith("other", nil).
+			UnionWith("other", Pipeline{{{"$match", bson.D{{"x", 1}}}}}).
+			UnionWith("", nested).
+			Build()
+		require.NoError(t, err)
+
+		expected := Pipeline{
+			{{"$documents", []bson.D{{{"x", 1}}, {{"x", 2}}}}},
+			{{"$unionWith", bson.D{{"coll", "other"}}}},
+			{{"$unionWith", bson.D{{"coll", "other"}, {"pipeline", Pipeline{{{"$match", bson.D{{"x", 1}}}}}}}}},
+			{{"$unionWith", bson.D{{"pipeline", nested}}}},
+		}
+		require.Equal(t, expected, pipeline)
+
+		arr, err := transformAggregatePipeline(bson.DefaultRegistry, pipeline)
+		require.NoError(t, err)
+		docs := arr[0].Document().Lookup("$documents").Array()
+		require.Len(t, docs, 2)
+		union := arr[3].Document().Lookup("$unionWith").Document()
+		require.Equal(t, bsonx.Int64(3), union.Lookup("pipeline").Array()[0].Document().Lookup("$documents").Array()[0].Document().Lookup("x"))
+	})
+
+	t.Run("empty documents", func(t *testing.T) {
+		pipeline, err := NewPipelineBuilder().Documents(nil).Build()
+		require.NoError(t, err)
+		arr, err := transformAggregatePipeline(bson.DefaultRegistry, pipeline)
+		require.NoError(t, err)
+		require.Len(t, arr[0].Document().Lookup("$documents").Array(), 0)
+	})
+
+	t.Run("invalid stages", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			build func(*PipelineBuilder) *PipelineBuilder
+		}{
+			{"match with $where", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.Match(bson.D{{"$where", "this.a > 1"}})
+			}},
+			{"group _id accumulator", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.Group("$a", bson.D{{"_id", bson.D{{"$first", "$b"}}}})
+			}},
+			{"group dotted accumulator", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.Group("$a", bson.D{{"a.b", bson.D{{"$sum", 1}}}})
+			}},
+			{"group non-operator accumulator", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.Group("$a", bson.D{{"total", "$b"}})
+			}},
+			{"group multiple operators", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.Group("$a", bson.D{{"total", bson.D{{"$sum", 1}, {"$avg", "$b"}}}})
+			}},
+			{"empty sort", func(pb *PipelineBuilder) *PipelineBuilder { return pb.Sort(nil) }},
+			{"invalid sort order", func(pb *PipelineBuilder) *PipelineBuilder { return pb.Sort(bson.D{{"a", 2}}) }},
+			{"invalid sort meta", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.Sort(bson.D{{"a", bson.D{{"$meta", "randVal"}}}})
+			}},
+			{"lookup missing as", func(pb *PipelineBuilder) *PipelineBuilder { return pb.Lookup("b", "a", "a", "") }},
+			{"empty projection", func(pb *PipelineBuilder) *PipelineBuilder { return pb.Project(bson.D{}) }},
+			{"unwind without $", func(pb *PipelineBuilder) *PipelineBuilder { return pb.Unwind("tags") }},
+			{"negative skip", func(pb *PipelineBuilder) *PipelineBuilder { return pb.Skip(-1) }},
+			{"zero limit", func(pb *PipelineBuilder) *PipelineBuilder { return pb.Limit(0) }},
+			{"stage without $", func(pb *PipelineBuilder) *PipelineBuilder { return pb.Stage("count", "n") }},
+			{"vector search without index", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.VectorSearch(VectorSearch{Path: "p", QueryVector: []float64{1}, NumCandidates: 10, Limit: 1})
+			}},
+			{"vector search without path", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.VectorSearch(VectorSearch{Index: "i", QueryVector: []float64{1}, NumCandidates: 10, Limit: 1})
+			}},
+			{"vector search without query vector", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.VectorSearch(VectorSearch{Index: "i", Path: "p", NumCandidates: 10, Limit: 1})
+			}},
+			{"vector search without limit", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.VectorSearch(VectorSearch{Index: "i", Path: "p", QueryVector: []float64{1}, NumCandidates: 10})
+			}},
+			{"vector search with fewer candidates than the limit", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.VectorSearch(VectorSearch{Index: "i", Path: "p", QueryVector: []float64{1}, NumCandidates: 5, Limit: 10})
+			}},
+			{"vector search with too many candidates", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.VectorSearch(VectorSearch{Index: "i", Path: "p", QueryVector: []float64{1}, NumCandidates: 10001, Limit: 10})
+			}},
+			{"vector search after another stage", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.Stage("$match", bson.D{}).
+					VectorSearch(VectorSearch{Index: "i", Path: "p", QueryVector: []float64{1}, NumCandidates: 10, Limit: 1})
+			}},
+			{"documents after another stage", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.Stage("$match", bson.D{}).Documents([]bson.D{{{"x", 1}}})
+			}},
+			{"union with without coll", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.UnionWith("", Pipeline{{{"$match", bson.D{}}}})
+			}},
+			{"union with without coll or pipeline", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.UnionWith("", nil)
+			}},
+			{"union with $out", func(pb *PipelineBuilder) *PipelineBuilder {
+				return pb.UnionWith("other", Pipeline{{{"$out", "out"}}})
+			}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				pipeline, err := tc.build(NewPipelineBuilder()).Build()
+				require.Nil(t, pipeline)
+				pbe, ok := err.(PipelineBuilderError)
+				require.True(t, ok, "expected a PipelineBuilderError, got %v", err)
+				require.Len(t, pbe.Errors, 1)
+			})
+		}
+	})
+
+	t.Run("accumulates errors", func(t *testing.T) {
+		_, err := NewPipelineBuilder().
+			Match(bson.D{{"a", 1}}).
+			Sort(nil).
+			Limit(1).
+			Unwind("tags").
+			Build()
+		pbe, ok := err.(PipelineBuilderError)
+		require.True(t, ok, "expected a PipelineBuilderError, got %v", err)
+		require.Len(t, pbe.Errors, 2)
+		require.Contains(t, pbe.Errors[0].Error(), "stage 1 ($sort)")
+		require.Contains(t, pbe.Errors[1].Error(), "stage 3 ($unwind)")
+		require.Contains(t, err.Error(), "invalid pipeline: ")
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+)
+
+// ProjectionBuilderError is returned by ProjectionBuilder.Build when the projection is invalid. It contains an error
+// for every invalid field, in the order the fields were added.
+type ProjectionBuilderError struct {
+	Errors []error
+}
+
+// Error implements the error interface.
+func (pbe ProjectionBuilderError) Error() string {
+	msgs := make([]string, 0, len(pbe.Errors))
+	for _, err := range pbe.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return "invalid projection: " + strings.Join(msgs, "; ")
+}
+
+// ProjectionBuilder constructs a projection document for options such as FindOptions.SetProjection. A projection
+// either includes or excludes fields; Build reports an error if both are used, except for _id, which can be
+// excluded from an inclusion projection or included in an exclusion projection. As with PipelineBuilder, errors are
+// accumulated and reported together by Build.
+//
+// Example usage:
+//
+//	projection, err := mongo.NewProjectionBuilder().
+//		Include("name", "address.city").
+//		Exclude("_id").
+//		Slice("comments", 5).
+//		Build()
+type ProjectionBuilder struct {
+	projection bson.D
+	include    []string
+	exclude    []string
+	errs       []error
+}
+
+// NewProjectionBuilder creates a new instance of ProjectionBuilder
+func NewProjectionBuilder() *ProjectionBuilder {
+	return &ProjectionBuilder{}
+}
+
+// Include adds the given fields to the projection so they are returned.
+func (pb *ProjectionBuilder) Include(fields ...string) *ProjectionBuilder {
+	for _, field := range fields {
+		if pb.add(field, int32(1)) && field != "_id" {
+			pb.include = append(pb.include, field)
+		}
+	}
+	return pb
+}
+
+// Exclude adds the given fields to the projection so they are not returned.
+func (pb *ProjectionBuilder) Exclude(fields ...string) *ProjectionBuilder {
+	for _, field := range fields {
+		if pb.add(field, int32(0)) && field != "_id" {
+			pb.exclude = append(pb.exclude, field)
+		}
+	}
+	return pb
+}
+
+// Slice limits the array in field to its first n elements, or its last -n elements if n is negative. It can be used
+// with either an inclusion or an exclusion projection.
+func (pb *ProjectionBuilder) Slice(field string, n int32) *ProjectionBuilder {
+	pb.add(field, bson.D{{"$slice", n}})
+	return pb
+}
+
+// Build returns the projection, or a ProjectionBuilderError if a field is invalid or inclusion and exclusion are
+// combined.
+func (pb *ProjectionBuilder) Build() (bson.D, error) {
+	errs := pb.errs
+	if len(pb.include) > 0 && len(pb.exclude) > 0 {
+		errs = append(errs, fmt.Errorf("cannot exclude %s in an inclusion projection of %s; only _id can be "+
+			"both included and excluded", strings.Join(pb.exclude, ", "), strings.Join(pb.include, ", ")))
+	}
+	if len(errs) > 0 {
+		return nil, ProjectionBuilderError{Errors: errs}
+	}
+
+	if pb.projection == nil {
+		return bson.D{}, nil
+	}
+	return pb.projection, nil
+}
+
+// add appends field to the projection and reports whether it was valid.
+func (pb *ProjectionBuilder) add(field string, value interface{}) bool {
+	switch {
+	case field == "":
+		pb.errs = append(pb.errs, fmt.Errorf("field names cannot be empty"))
+		return false
+	case strings.HasPrefix(field, "$"):
+		pb.errs = append(pb.errs, fmt.Errorf("field %q cannot begin with '$'", field))
+		return false
+	}
+	for _, elem := range pb.projection {
+		if elem.Key == field {
+			pb.errs = append(pb.errs, fmt.Errorf("field %q is projected more than once", field))
+			return false
+		}
+	}
+
+	pb.projection = append(pb.projection, bson.E{field, value})
+	return true
+}
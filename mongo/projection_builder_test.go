@@ -0,0 +1,83 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectionBuilder(t *testing.T) {
+	t.Run("inclusion", func(t *testing.T) {
+		projection, err := NewProjectionBuilder().
+			Include("name", "address.city").
+			Exclude("_id").
+			Slice("comments", -5).
+			Build()
+		require.NoError(t, err)
+		require.Equal(t, bson.D{
+			{"name", int32(1)},
+			{"address.city", int32(1)},
+			{"_id", int32(0)},
+			{"comments", bson.D{{"$slice", int32(-5)}}},
+		}, projection)
+
+		doc, err := transformDocument(bson.DefaultRegistry, projection)
+		require.NoError(t, err)
+		require.Equal(t, bsonx.Doc{
+			{"name", bsonx.Int32(1)},
+			{"address.city", bsonx.Int32(1)},
+			{"_id", bsonx.Int32(0)},
+			{"comments", bsonx.Document(bsonx.Doc{{"$slice", bsonx.Int32(-5)}})},
+		}, doc)
+	})
+
+	t.Run("exclusion with _id", func(t *testing.T) {
+		projection, err := NewProjectionBuilder().Exclude("password").Include("_id").Build()
+		require.NoError(t, err)
+		require.Equal(t, bson.D{{"password", int32(0)}, {"_id", int32(1)}}, projection)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		projection, err := NewProjectionBuilder().Build()
+		require.NoError(t, err)
+		require.Equal(t, bson.D{}, projection)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			builder *ProjectionBuilder
+			msgs    []string
+		}{
+			{"mixed", NewProjectionBuilder().Include("a", "b").Exclude("c"),
+				[]string{"cannot exclude c in an inclusion projection of a, b"}},
+			{"empty field", NewProjectionBuilder().Include(""), []string{"field names cannot be empty"}},
+			{"operator field", NewProjectionBuilder().Exclude("$a"), []string{`field "$a" cannot begin with '$'`}},
+			{"duplicate", NewProjectionBuilder().Include("a").Slice("a", 1),
+				[]string{`field "a" is projected more than once`}},
+			{"multiple", NewProjectionBuilder().Include("a", "").Exclude("b"),
+				[]string{"field names cannot be empty", "cannot exclude b in an inclusion projection of a"}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				projection, err := tc.builder.Build()
+				require.Nil(t, projection)
+				pbe, ok := err.(ProjectionBuilderError)
+				require.True(t, ok, "expected a ProjectionBuilderError but got %T", err)
+				require.Len(t, pbe.Errors, len(tc.msgs))
+				for i, msg := range tc.msgs {
+					require.Contains(t, pbe.Errors[i].Error(), msg)
+				}
+			})
+		}
+	})
+}
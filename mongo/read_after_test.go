@@ -0,0 +1,91 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/event"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAfter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	if os.Getenv("TOPOLOGY") == "server" {
+		t.Skip("standalone servers do not report operation times")
+	}
+	skipIfBelow36(t)
+
+	var started []*event.CommandStartedEvent
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			started = append(started, cse)
+		},
+	}
+	client := createSessionsMonitoredClient(t, monitor)
+	defer func() { _ = client.Disconnect(ctx) }()
+
+	db := client.Database("TestReadAfter")
+	require.NoError(t, db.Drop(ctx))
+	defer func() { _ = db.Drop(ctx) }()
+	coll, err := db.Collection("readafter").Clone(options.Collection().SetReadConcern(readconcern.Majority()))
+	require.NoError(t, err)
+
+	insertRes, err := coll.InsertOne(ctx, bsonx.Doc{{"x", bsonx.Int32(1)}})
+	require.NoError(t, err)
+	require.NotNil(t, insertRes.OperationTime)
+
+	updateRes, err := coll.UpdateOne(ctx, bsonx.Doc{{"x", bsonx.Int32(1)}},
+		bsonx.Doc{{"$inc", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(1)}})}})
+	require.NoError(t, err)
+	require.NotNil(t, updateRes.OperationTime)
+	require.False(t, updateRes.OperationTime.T < insertRes.OperationTime.T)
+
+	// sentReadConcern returns the read concern of the only command that was started.
+	sentReadConcern := func(t *testing.T) bsonx.Doc {
+		require.Len(t, started, 1)
+		rc, err := started[0].Command.LookupErr("readConcern")
+		require.NoError(t, err)
+		return rc.Document()
+	}
+	want := bsonx.Doc{
+		{"level", bsonx.String("majority")},
+		{"afterClusterTime", bsonx.Timestamp(updateRes.OperationTime.T, updateRes.OperationTime.I)},
+	}
+
+	t.Run("find", func(t *testing.T) {
+		started = nil
+		res := coll.FindOne(ctx, bsonx.Doc{}, options.FindOne().SetReadAfter(updateRes.OperationTime))
+		var doc struct{ X int32 }
+		require.NoError(t, res.Decode(&doc))
+		require.Equal(t, int32(2), doc.X)
+		require.True(t, sentReadConcern(t).Equal(want), "got %v; want %v", sentReadConcern(t), want)
+	})
+
+	t.Run("count", func(t *testing.T) {
+		started = nil
+		n, err := coll.CountDocuments(ctx, bsonx.Doc{}, options.Count().SetReadAfter(updateRes.OperationTime))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), n)
+		require.True(t, sentReadConcern(t).Equal(want), "got %v; want %v", sentReadConcern(t), want)
+	})
+
+	t.Run("without the option", func(t *testing.T) {
+		started = nil
+		_, err := coll.Distinct(ctx, "x", bsonx.Doc{})
+		require.NoError(t, err)
+		_, err = sentReadConcern(t).LookupErr("afterClusterTime")
+		require.Error(t, err)
+	})
+}
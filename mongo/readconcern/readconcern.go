@@ -7,12 +7,15 @@
 package readconcern
 
 import (
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 )
 
 // ReadConcern for replica sets and replica set shards determines which data to return from a query.
 type ReadConcern struct {
-	level string
+	level            string
+	atClusterTime    *primitive.Timestamp
+	afterClusterTime *primitive.Timestamp
 }
 
 // Option is an option to provide when creating a ReadConcern.
@@ -25,6 +28,24 @@ func Level(level string) Option {
 	}
 }
 
+// AtClusterTime creates an option that sets the cluster time a snapshot read concern reads from. It
+// is only valid with the "snapshot" level outside of a transaction and requires MongoDB 5.0 or later.
+func AtClusterTime(ts primitive.Timestamp) Option {
+	return func(concern *ReadConcern) {
+		concern.atClusterTime = &ts
+	}
+}
+
+// AfterClusterTime creates an option that makes reads with a ReadConcern wait until the server has applied the
+// operation at the given operation time, so that a read on any member sees the effects of a write that returned
+// that time. It is only meaningful with the "majority" level, because the effects of a write that has not been
+// majority committed can be rolled back.
+func AfterClusterTime(ts primitive.Timestamp) Option {
+	return func(concern *ReadConcern) {
+		concern.afterClusterTime = &ts
+	}
+}
+
 // Local specifies that the query should return the instance’s most recent data.
 func Local() *ReadConcern {
 	return New(Level("local"))
@@ -48,7 +69,9 @@ func Available() *ReadConcern {
 	return New(Level("available"))
 }
 
-// Snapshot is only available for operations within multi-document transactions.
+// Snapshot specifies that the query should return data from a single point in time. It is available for
+// operations within multi-document transactions and, on MongoDB 5.0 or later, for find, aggregate and
+// distinct outside of a transaction.
 func Snapshot() *ReadConcern {
 	return New(Level("snapshot"))
 }
@@ -64,6 +87,21 @@ func New(options ...Option) *ReadConcern {
 	return concern
 }
 
+// WithOptions returns a copy of the ReadConcern with the given options applied. It can be called on a nil
+// ReadConcern, which is equivalent to calling New.
+func (rc *ReadConcern) WithOptions(options ...Option) *ReadConcern {
+	concern := &ReadConcern{}
+	if rc != nil {
+		*concern = *rc
+	}
+
+	for _, option := range options {
+		option(concern)
+	}
+
+	return concern
+}
+
 // MarshalBSONElement implements the bsonx.ElementMarshaler interface.
 func (rc *ReadConcern) MarshalBSONElement() (bsonx.Elem, error) {
 	doc := bsonx.Doc{}
@@ -71,6 +109,12 @@ func (rc *ReadConcern) MarshalBSONElement() (bsonx.Elem, error) {
 	if len(rc.level) > 0 {
 		doc = doc.Append("level", bsonx.String(rc.level))
 	}
+	if rc.atClusterTime != nil {
+		doc = doc.Append("atClusterTime", bsonx.Timestamp(rc.atClusterTime.T, rc.atClusterTime.I))
+	}
+	if rc.afterClusterTime != nil {
+		doc = doc.Append("afterClusterTime", bsonx.Timestamp(rc.afterClusterTime.T, rc.afterClusterTime.I))
+	}
 
 	return bsonx.Elem{"readConcern", bsonx.Document(doc)}, nil
 }
@@ -29,6 +29,17 @@ func WithMaxStaleness(ms time.Duration) Option {
 	}
 }
 
+// WithHedgeEnabled enables or disables hedged reads, where a mongos sends
+// the read to two members of each shard and returns the first response.
+// Hedged reads are only sent to mongos instances that support them and
+// are ignored otherwise.
+func WithHedgeEnabled(enabled bool) Option {
+	return func(rp *ReadPref) error {
+		rp.hedgeEnabled = &enabled
+		return nil
+	}
+}
+
 // WithTags sets a single tag set used to match
 // a server. The last call to WithTags or WithTagSets
 // overrides all previous calls to either method.
@@ -50,7 +61,11 @@ func WithTags(tags ...string) Option {
 }
 
 // WithTagSets sets the tag sets used to match
-// a server. The last call to WithTags or WithTagSets
+// a server. The tag sets are tried in order and servers
+// are selected from the first one that matches any
+// eligible server. An empty tag set matches every server,
+// so it can be passed last to fall back to any server.
+// The last call to WithTags or WithTagSets
 // overrides all previous calls to either method.
 func WithTagSets(tagSets ...tag.Set) Option {
 	return func(rp *ReadPref) error {
@@ -74,12 +74,22 @@ func New(mode Mode, opts ...Option) (*ReadPref, error) {
 
 // ReadPref determines which servers are considered suitable for read operations.
 type ReadPref struct {
+	hedgeEnabled    *bool
 	maxStaleness    time.Duration
 	maxStalenessSet bool
 	mode            Mode
 	tagSets         []tag.Set
 }
 
+// HedgeEnabled indicates whether hedged reads are enabled. The
+// second return value indicates if this value has been set.
+func (r *ReadPref) HedgeEnabled() (bool, bool) {
+	if r.hedgeEnabled == nil {
+		return false, false
+	}
+	return *r.hedgeEnabled, true
+}
+
 // MaxStaleness is the maximum amount of time to allow
 // a server to be considered eligible for selection. The
 // second return value indicates if this value has been set.
@@ -120,3 +120,21 @@ func TestNearest_with_options(t *testing.T) {
 	require.Equal(time.Duration(10), ms)
 	require.Equal([]tag.Set{{tag.Tag{Name: "a", Value: "1"}, tag.Tag{Name: "b", Value: "2"}}}, subject.TagSets())
 }
+
+func TestHedgeEnabled(t *testing.T) {
+	require := require.New(t)
+
+	_, set := Nearest().HedgeEnabled()
+	require.False(set)
+
+	enabled, set := Nearest(WithHedgeEnabled(true)).HedgeEnabled()
+	require.True(set)
+	require.True(enabled)
+
+	enabled, set = SecondaryPreferred(WithHedgeEnabled(false)).HedgeEnabled()
+	require.True(set)
+	require.False(enabled)
+
+	_, err := New(PrimaryMode, WithHedgeEnabled(true))
+	require.Error(err)
+}
@@ -8,8 +8,10 @@ package mongo
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/x/network/result"
 )
 
@@ -25,22 +27,83 @@ type BulkWriteResult struct {
 
 // InsertOneResult is a result of an InsertOne operation.
 //
-// InsertedID will be a Go type that corresponds to a BSON type.
+// InsertedID will be a Go type that corresponds to a BSON type, or nil if the document had no _id and the client
+// was created with options.ClientOptions.SetBypassClientIDGeneration(true).
 type InsertOneResult struct {
 	// The identifier that was inserted.
 	InsertedID interface{}
+	// The operation time of the write, which can be passed to the ReadAfter option of a read to make it observe
+	// the write. It is nil if the server did not report one, as standalone servers and unacknowledged writes do not.
+	OperationTime *primitive.Timestamp
 }
 
 // InsertManyResult is a result of an InsertMany operation.
 type InsertManyResult struct {
-	// Maps the indexes of inserted documents to their _id fields.
+	// The _id fields of the inserted documents, in the order the documents were passed to InsertMany. Documents
+	// that failed to insert are omitted. The ID of a document without an _id is nil if the client bypasses _id
+	// generation.
 	InsertedIDs []interface{}
+	// The operation time of the write, or nil if the server did not report one. See InsertOneResult.
+	OperationTime *primitive.Timestamp
 }
 
 // DeleteResult is a result of an DeleteOne operation.
 type DeleteResult struct {
 	// The number of documents that were deleted.
 	DeletedCount int64 `bson:"n"`
+	// The operation time of the write, or nil if the server did not report one. See InsertOneResult.
+	OperationTime *primitive.Timestamp `bson:"-"`
+}
+
+// ServerPingResult is the result of pinging a single server as part of a PingAll operation.
+type ServerPingResult struct {
+	// The address of the server.
+	Addr string
+	// The measured round trip time of the ping. This is zero if the ping failed.
+	RTT time.Duration
+	// The type of the server as last reported by the topology, e.g. RSPrimary, RSSecondary, or Mongos.
+	Kind string
+	// The error that occurred while pinging the server, if any.
+	Err error
+}
+
+// PoolStat is a snapshot of the connection pool for a single server, as returned by Client.PoolStats.
+type PoolStat struct {
+	// The number of established connections, whether idle or in use.
+	TotalConnections int
+	// The number of idle connections that can be checked out without establishing a new one.
+	AvailableConnections int
+	// The number of connections that are being established.
+	PendingConnections int
+	// The number of connections checked out by operations.
+	CheckedOut int
+}
+
+// SessionPoolStat is a snapshot of a client's pool of server sessions.
+type SessionPoolStat struct {
+	// The number of idle sessions that can be reused.
+	Idle int
+	// The number of sessions in use by explicit sessions and in-progress operations.
+	CheckedOut int
+	// The number of pooled sessions removed because they were idle for close to the server's session timeout.
+	Expired uint64
+	// The number of pooled sessions removed because the pool was at its maximum size.
+	Discarded uint64
+}
+
+// ServerInfo describes the server a client sends primary-preferred reads to, as returned by Client.ServerInfo.
+type ServerInfo struct {
+	// The address of the server.
+	Addr string
+	// The server version, e.g. "4.0.5".
+	Version string
+	// The highest wire protocol version the server supports, as reported in the connection handshake.
+	MaxWireVersion int32
+	// The name of the storage engine, e.g. "wiredTiger". It is empty for mongos and if the user is not
+	// authorized to run serverStatus.
+	StorageEngine string
+	// The type of the topology, e.g. ReplicaSetWithPrimary, Sharded, or Single.
+	TopologyType string
 }
 
 // ListDatabasesResult is a result of a ListDatabases operation. Each specification
@@ -80,6 +143,8 @@ type UpdateResult struct {
 	ModifiedCount int64
 	// The identifier of the inserted document if an upsert took place.
 	UpsertedID interface{}
+	// The operation time of the write, or nil if the server did not report one. See InsertOneResult.
+	OperationTime *primitive.Timestamp
 }
 
 // UnmarshalBSON implements the bson.Unmarshaler interface.
@@ -135,3 +200,107 @@ func (result *UpdateResult) UnmarshalBSON(b []byte) error {
 
 	return nil
 }
+
+// UpsertResult is the result of an UpsertOne operation.
+//
+// UpsertedID will be a Go type that corresponds to a BSON type.
+type UpsertResult struct {
+	// The number of documents that matched the filter.
+	MatchedCount int64
+	// The number of documents that were modified.
+	ModifiedCount int64
+	// The identifier of the inserted document if a new document was inserted, or nil otherwise.
+	UpsertedID interface{}
+	// True if no document matched the filter and a new document was inserted. This is false for an
+	// unacknowledged write.
+	WasInsert bool
+	// The operation time of the write, or nil if the server did not report one. See InsertOneResult.
+	OperationTime *primitive.Timestamp
+}
+
+// AggregateExplainResult is the result of an AggregateExplain operation.
+type AggregateExplainResult struct {
+	// The explain document returned by the server.
+	Raw bson.Raw
+	// The names of the stages of each winning query plan, such as IXSCAN or COLLSCAN, with each
+	// stage listed before its input stages. A sharded explain lists the plans of every shard.
+	WinningPlanStages []string
+	// The total number of index keys examined by the query stages of the pipeline, summed across
+	// shards for a sharded collection.
+	KeysExamined int64
+}
+
+func newAggregateExplainResult(raw bson.Raw) *AggregateExplainResult {
+	res := &AggregateExplainResult{Raw: raw}
+	res.parse(raw)
+	return res
+}
+
+// parse collects the fields of an explain document. The query planner output is at the top
+// level when the whole pipeline can be run by the query system, in the $cursor stage of the
+// stages array otherwise, and in a document per shard for a sharded collection.
+func (aer *AggregateExplainResult) parse(doc bson.Raw) {
+	if planner, ok := doc.Lookup("queryPlanner").DocumentOK(); ok {
+		plan, _ := planner.Lookup("winningPlan").DocumentOK()
+		if queryPlan, ok := plan.Lookup("queryPlan").DocumentOK(); ok {
+			// Servers using the slot-based execution engine nest the plan one level deeper.
+			plan = queryPlan
+		}
+		aer.parsePlan(plan)
+	}
+	if stats, ok := doc.Lookup("executionStats").DocumentOK(); ok {
+		aer.KeysExamined += explainNumber(stats.Lookup("totalKeysExamined"))
+	}
+
+	if stages, ok := doc.Lookup("stages").ArrayOK(); ok {
+		vals, _ := stages.Values()
+		for _, val := range vals {
+			stage, _ := val.DocumentOK()
+			if cursorStage, ok := stage.Lookup("$cursor").DocumentOK(); ok {
+				aer.parse(cursorStage)
+			}
+		}
+	}
+
+	if shards, ok := doc.Lookup("shards").DocumentOK(); ok {
+		elems, _ := shards.Elements()
+		for _, elem := range elems {
+			if shard, ok := elem.Value().DocumentOK(); ok {
+				aer.parse(shard)
+			}
+		}
+	}
+}
+
+func (aer *AggregateExplainResult) parsePlan(plan bson.Raw) {
+	if len(plan) == 0 {
+		return
+	}
+
+	if stage, ok := plan.Lookup("stage").StringValueOK(); ok {
+		aer.WinningPlanStages = append(aer.WinningPlanStages, stage)
+	}
+	if input, ok := plan.Lookup("inputStage").DocumentOK(); ok {
+		aer.parsePlan(input)
+	}
+	if inputs, ok := plan.Lookup("inputStages").ArrayOK(); ok {
+		vals, _ := inputs.Values()
+		for _, val := range vals {
+			input, _ := val.DocumentOK()
+			aer.parsePlan(input)
+		}
+	}
+}
+
+func explainNumber(val bson.RawValue) int64 {
+	switch val.Type {
+	case bson.TypeInt32:
+		return int64(val.Int32())
+	case bson.TypeInt64:
+		return val.Int64()
+	case bson.TypeDouble:
+		return int64(val.Double())
+	default:
+		return 0
+	}
+}
@@ -72,3 +72,94 @@ func TestUpdateOneResult_unmarshalInto(t *testing.T) {
 	require.Equal(t, result.ModifiedCount, int64(2))
 	require.Equal(t, int(result.UpsertedID.(int32)), 3)
 }
+
+func TestAggregateExplainResult(t *testing.T) {
+	t.Parallel()
+
+	ixscan := bsonx.Doc{{"stage", bsonx.String("IXSCAN")}, {"keyPattern", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(1)}})}}
+	fetch := bsonx.Doc{{"stage", bsonx.String("FETCH")}, {"inputStage", bsonx.Document(ixscan)}}
+	planner := func(plan bsonx.Doc) bsonx.Doc {
+		return bsonx.Doc{{"queryPlanner", bsonx.Document(bsonx.Doc{{"winningPlan", bsonx.Document(plan)}})}}
+	}
+	cursorStage := func(doc bsonx.Doc) bsonx.Doc {
+		return doc.Copy().Prepend("query", bsonx.Document(bsonx.Doc{}))
+	}
+
+	testCases := []struct {
+		name         string
+		doc          bsonx.Doc
+		stages       []string
+		keysExamined int64
+	}{
+		{
+			"stages",
+			bsonx.Doc{
+				{"stages", bsonx.Array(bsonx.Arr{
+					bsonx.Document(bsonx.Doc{{"$cursor", bsonx.Document(cursorStage(planner(fetch)))}}),
+					bsonx.Document(bsonx.Doc{{"$group", bsonx.Document(bsonx.Doc{{"_id", bsonx.Null()}})}}),
+				})},
+				{"ok", bsonx.Double(1)},
+			},
+			[]string{"FETCH", "IXSCAN"}, 0,
+		},
+		{
+			"top level",
+			append(planner(bsonx.Doc{{"stage", bsonx.String("COLLSCAN")}}),
+				bsonx.Elem{"executionStats", bsonx.Document(bsonx.Doc{{"totalKeysExamined", bsonx.Int32(0)}})}),
+			[]string{"COLLSCAN"}, 0,
+		},
+		{
+			"execution stats",
+			append(planner(fetch),
+				bsonx.Elem{"executionStats", bsonx.Document(bsonx.Doc{{"totalKeysExamined", bsonx.Int64(42)}})}),
+			[]string{"FETCH", "IXSCAN"}, 42,
+		},
+		{
+			"slot based engine",
+			planner(bsonx.Doc{{"queryPlan", bsonx.Document(fetch)}, {"slotBasedPlan", bsonx.Document(bsonx.Doc{})}}),
+			[]string{"FETCH", "IXSCAN"}, 0,
+		},
+		{
+			"input stages",
+			planner(bsonx.Doc{
+				{"stage", bsonx.String("OR")},
+				{"inputStages", bsonx.Array(bsonx.Arr{bsonx.Document(fetch), bsonx.Document(ixscan)})},
+			}),
+			[]string{"OR", "FETCH", "IXSCAN", "IXSCAN"}, 0,
+		},
+		{
+			"sharded",
+			bsonx.Doc{{"shards", bsonx.Document(bsonx.Doc{
+				{"shard0", bsonx.Document(append(planner(fetch),
+					bsonx.Elem{"executionStats", bsonx.Document(bsonx.Doc{{"totalKeysExamined", bsonx.Int32(3)}})}))},
+				{"shard1", bsonx.Document(bsonx.Doc{{"stages", bsonx.Array(bsonx.Arr{
+					bsonx.Document(bsonx.Doc{{"$cursor", bsonx.Document(append(planner(ixscan),
+						bsonx.Elem{"executionStats", bsonx.Document(bsonx.Doc{{"totalKeysExamined", bsonx.Double(4)}})}))}}),
+				})}})},
+			})}},
+			[]string{"FETCH", "IXSCAN", "IXSCAN"}, 7,
+		},
+		{
+			"unknown format",
+			bsonx.Doc{{"ok", bsonx.Double(1)}, {"queryPlanner", bsonx.String("unexpected")}},
+			nil, 0,
+		},
+		{
+			"missing winning plan",
+			bsonx.Doc{{"queryPlanner", bsonx.Document(bsonx.Doc{})}},
+			nil, 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := tc.doc.MarshalBSON()
+			require.NoError(t, err)
+
+			res := newAggregateExplainResult(raw)
+			require.Equal(t, bson.Raw(raw), res.Raw)
+			require.Equal(t, tc.stages, res.WinningPlanStages)
+			require.Equal(t, tc.keysExamined, res.KeysExamined)
+		})
+	}
+}
@@ -397,3 +397,70 @@ func shouldSkipRetryTest(t *testing.T, serverVersion string) bool {
 	return compareVersions(t, serverVersion, "3.6") < 0 ||
 		os.Getenv("TOPOLOGY") == "server"
 }
+
+func TestRetryableWrites_RetryEvent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	if os.Getenv("TOPOLOGY") != "replica_set" {
+		t.Skip("retryable writes are only tested against replica sets")
+	}
+	serverVersion, err := getServerVersion(createTestDatabase(t, nil))
+	require.NoError(t, err)
+	if compareVersions(t, serverVersion, "4.0") < 0 {
+		t.Skip("the failCommand fail point requires MongoDB 4.0 or later")
+	}
+
+	var mu sync.Mutex
+	var seen []string
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			if cse.CommandName == "insert" {
+				mu.Lock()
+				seen = append(seen, "started")
+				mu.Unlock()
+			}
+		},
+		Retried: func(ctx context.Context, e *event.CommandRetryEvent) {
+			require.Equal(t, "insert", e.CommandName)
+			require.Equal(t, 2, e.Attempt)
+			require.True(t, e.Write)
+			require.NotEmpty(t, e.Failure)
+			mu.Lock()
+			seen = append(seen, "retried")
+			mu.Unlock()
+		},
+	}
+	cs := testutil.ConnString(t)
+	client, err := NewClientWithOptions(cs.String(),
+		options.Client().SetMonitor(monitor).SetRetryWrites(true))
+	require.NoError(t, err)
+	require.NoError(t, client.Connect(ctx))
+	defer func() { _ = client.Disconnect(ctx) }()
+
+	admin := client.Database("admin")
+	err = admin.RunCommand(ctx, bsonx.Doc{
+		{"configureFailPoint", bsonx.String("failCommand")},
+		{"mode", bsonx.Document(bsonx.Doc{{"times", bsonx.Int32(1)}})},
+		{"data", bsonx.Document(bsonx.Doc{
+			{"failCommands", bsonx.Array(bsonx.Arr{bsonx.String("insert")})},
+			{"errorCode", bsonx.Int32(91)}, // ShutdownInProgress
+		})},
+	}).Err()
+	require.NoError(t, err)
+	defer func() {
+		_ = admin.RunCommand(ctx, bsonx.Doc{
+			{"configureFailPoint", bsonx.String("failCommand")},
+			{"mode", bsonx.String("off")},
+		})
+	}()
+
+	coll := client.Database("RetryableWritesRetryEvent").Collection("retry")
+	defer func() { _ = coll.Drop(ctx) }()
+	_, err = coll.InsertOne(ctx, bsonx.Doc{{"x", bsonx.Int32(1)}})
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"started", "retried", "started"}, seen)
+}
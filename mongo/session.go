@@ -9,6 +9,7 @@ package mongo
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/mongo/options"
@@ -20,6 +21,17 @@ import (
 	"github.com/mongodb/mongo-go-driver/x/network/description"
 )
 
+// commitRetryTimeout bounds how long after StartTransaction CommitTransaction keeps retrying a commit whose
+// result is unknown.
+const commitRetryTimeout = 120 * time.Second
+
+// commitRetryBackoff is how long CommitTransaction waits before the first retry of a commit whose result is
+// unknown. The wait doubles after each attempt, up to maxCommitRetryBackoff.
+const (
+	commitRetryBackoff    = 10 * time.Millisecond
+	maxCommitRetryBackoff = time.Second
+)
+
 // ErrWrongClient is returned when a user attempts to pass in a session created by a different client than
 // the method call is using.
 var ErrWrongClient = errors.New("session was not created by this client")
@@ -59,7 +71,8 @@ type Session interface {
 type sessionImpl struct {
 	*session.Client
 	topo                *topology.Topology
-	didCommitAfterStart bool // true if commit was called after start with no other operations
+	didCommitAfterStart bool      // true if commit was called after start with no other operations
+	txnStarted          time.Time // when StartTransaction was last called
 }
 
 // EndSession ends the session.
@@ -79,6 +92,7 @@ func (s *sessionImpl) StartTransaction(opts ...*options.TransactionOptions) erro
 	}
 
 	s.didCommitAfterStart = false
+	s.txnStarted = time.Now()
 
 	topts := options.MergeTransactionOptions(opts...)
 	coreOpts := &session.TransactionOptions{
@@ -108,8 +122,16 @@ func (s *sessionImpl) AbortTransaction(ctx context.Context) error {
 	return err
 }
 
-// CommitTransaction commits the sesson's transaction.
+// CommitTransaction commits the sesson's transaction. A commit that fails with the
+// UnknownTransactionCommitResult label is retried, with a short backoff between attempts, until it succeeds,
+// fails with another error, ctx is done, or 120 seconds have passed since the transaction started. Retried
+// commits, including a commit called again after the transaction was committed, use a w: majority write
+// concern. Each retry is published to the Retried callback of the client's command monitor.
 func (s *sessionImpl) CommitTransaction(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	err := s.CheckCommitTransaction()
 	if err != nil {
 		return err
@@ -123,6 +145,7 @@ func (s *sessionImpl) CommitTransaction(ctx context.Context) error {
 
 	if s.Client.TransactionCommitted() {
 		s.RetryingCommit = true
+		s.UpdateCommitTransactionWriteConcern()
 	}
 
 	cmd := command.CommitTransaction{
@@ -136,11 +159,44 @@ func (s *sessionImpl) CommitTransaction(ctx context.Context) error {
 			s.Committing = false
 		}()
 	}
-	_, err = driver.CommitTransaction(ctx, cmd, s.topo, description.WriteSelector())
-	if err == nil {
-		return s.Client.CommitTransaction()
+	s.CommitAttempts = 0
+	backoff := commitRetryBackoff
+	for {
+		_, err = driver.CommitTransaction(ctx, cmd, s.topo, description.WriteSelector())
+		if err == nil {
+			return s.Client.CommitTransaction()
+		}
+		if !s.shouldRetryCommit(ctx, err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxCommitRetryBackoff {
+			backoff = maxCommitRetryBackoff
+		}
+		s.UpdateCommitTransactionWriteConcern()
+		driver.PublishRetryWrite(ctx, s.topo, "commitTransaction", s.CommitAttempts+1, err, nil)
 	}
-	return err
+}
+
+// shouldRetryCommit returns true if a failed commit should be sent again. Commits labeled
+// UnknownTransactionCommitResult may or may not have been applied; retrying them is safe because
+// commitTransaction is idempotent, so a commit that already succeeded succeeds again.
+func (s *sessionImpl) shouldRetryCommit(ctx context.Context, err error) bool {
+	cerr, ok := err.(command.Error)
+	if !ok || !cerr.HasErrorLabel(command.UnknownTransactionCommitResult) {
+		return false
+	}
+	// MaxTimeMSExpired means the user's own time limit for the commit ran out
+	if cerr.Code == 50 {
+		return false
+	}
+
+	return ctx.Err() == nil && time.Since(s.txnStarted) < commitRetryTimeout
 }
 
 func (s *sessionImpl) ClusterTime() bsonx.Doc {
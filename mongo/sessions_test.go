@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/event"
 	"github.com/mongodb/mongo-go-driver/internal/testutil"
 	"github.com/mongodb/mongo-go-driver/internal/testutil/helpers"
@@ -699,3 +700,302 @@ func TestSessions(t *testing.T) {
 		}
 	})
 }
+
+func TestSessions_SnapshotReads(t *testing.T) {
+	if os.Getenv("TOPOLOGY") != "replica_set" {
+		t.Skip("snapshot reads are only tested against replica sets")
+	}
+	versionStr, err := getServerVersion(createTestDatabase(t, nil))
+	require.NoError(t, err)
+	if compareVersions(t, versionStr, "5.0") < 0 {
+		t.Skip("snapshot reads require MongoDB 5.0 or later")
+	}
+
+	var started []*event.CommandStartedEvent
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			switch cse.CommandName {
+			case "find", "aggregate", "distinct":
+				started = append(started, cse)
+			}
+		},
+	}
+	client := createSessionsMonitoredClient(t, monitor)
+	defer func() { _ = client.Disconnect(ctx) }()
+
+	db := client.Database("SessionsTestSnapshotReads")
+	require.NoError(t, db.Drop(ctx))
+	coll := db.Collection("snapshot", options.Collection().SetWriteConcern(writeconcern.New(writeconcern.WMajority())))
+	_, err = coll.InsertOne(ctx, bsonx.Doc{{"x", bsonx.Int32(1)}})
+	require.NoError(t, err)
+
+	sess, err := client.StartSession(options.Session().SetSnapshot(true))
+	require.NoError(t, err)
+	defer sess.EndSession(ctx)
+	require.Equal(t, session.ErrSnapshotTransaction, sess.StartTransaction())
+
+	countInSnapshot := func(sc SessionContext) int {
+		cursor, err := coll.Find(sc, bsonx.Doc{})
+		require.NoError(t, err)
+		var n int
+		for cursor.Next(sc) {
+			n++
+		}
+		require.NoError(t, cursor.Close(sc))
+		return n
+	}
+
+	err = WithSession(ctx, sess, func(sc SessionContext) error {
+		require.Equal(t, 1, countInSnapshot(sc))
+
+		// a write after the snapshot was chosen must not be visible to later reads in the session
+		_, err := coll.InsertOne(ctx, bsonx.Doc{{"x", bsonx.Int32(2)}})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, countInSnapshot(sc))
+
+		cursor, err := coll.Aggregate(sc, Pipeline{})
+		require.NoError(t, err)
+		var n int
+		for cursor.Next(sc) {
+			n++
+		}
+		require.NoError(t, cursor.Close(sc))
+		require.Equal(t, 1, n)
+
+		values, err := coll.Distinct(sc, "x", bsonx.Doc{})
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		return nil
+	})
+	require.NoError(t, err)
+
+	snapshotTime := sess.(*sessionImpl).SnapshotTime
+	require.NotNil(t, snapshotTime, "expected the snapshot time to be captured from the first read")
+	require.Len(t, started, 4)
+	for i, cse := range started {
+		rc := cse.Command.Lookup("readConcern").Document()
+		require.Equal(t, "snapshot", rc.Lookup("level").StringValue())
+		_, err := rc.LookupErr("afterClusterTime")
+		require.Error(t, err, "snapshot reads must not send afterClusterTime")
+
+		atClusterTime, err := rc.LookupErr("atClusterTime")
+		if i == 0 {
+			require.Error(t, err, "the first read chooses the snapshot")
+			continue
+		}
+		require.NoError(t, err)
+		ts, inc := atClusterTime.Timestamp()
+		require.Equal(t, *snapshotTime, primitive.Timestamp{T: ts, I: inc})
+	}
+}
+
+func TestSessions_CommitRetriesUnknownResult(t *testing.T) {
+	if os.Getenv("TOPOLOGY") != "replica_set" {
+		t.Skip("transactions are only tested against replica sets")
+	}
+	versionStr, err := getServerVersion(createTestDatabase(t, nil))
+	require.NoError(t, err)
+	if compareVersions(t, versionStr, "4.0") < 0 {
+		t.Skip("transactions require MongoDB 4.0 or later")
+	}
+
+	// the write concern of each commitTransaction sent and the attempt number of each retry
+	var commits []bsonx.Val
+	var attempts []int
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			if cse.CommandName == "commitTransaction" {
+				commits = append(commits, cse.Command.Lookup("writeConcern"))
+			}
+		},
+		Retried: func(ctx context.Context, e *event.CommandRetryEvent) {
+			if e.CommandName == "commitTransaction" {
+				attempts = append(attempts, e.Attempt)
+			}
+		},
+	}
+	cs := testutil.ConnString(t)
+	client, err := NewClientWithOptions(cs.String(), options.Client().SetMonitor(monitor))
+	require.NoError(t, err)
+	require.NoError(t, client.Connect(ctx))
+	defer func() { _ = client.Disconnect(ctx) }()
+
+	db := client.Database("SessionsTestCommitRetry")
+	require.NoError(t, db.Drop(ctx))
+	coll := db.Collection("commit", options.Collection().SetWriteConcern(writeconcern.New(writeconcern.WMajority())))
+	// create the collection outside of the transaction
+	_, err = coll.InsertOne(ctx, bsonx.Doc{{"x", bsonx.Int32(0)}})
+	require.NoError(t, err)
+
+	admin := client.Database("admin")
+	setFailPoint := func(t *testing.T, mode bsonx.Val) {
+		err := admin.RunCommand(ctx, bsonx.Doc{
+			{"configureFailPoint", bsonx.String("failCommand")},
+			{"mode", mode},
+			{"data", bsonx.Document(bsonx.Doc{
+				{"failCommands", bsonx.Array(bsonx.Arr{bsonx.String("commitTransaction")})},
+				{"writeConcernError", bsonx.Document(bsonx.Doc{
+					{"code", bsonx.Int32(64)},
+					{"errmsg", bsonx.String("multiple errors reported")},
+				})},
+			})},
+		}).Err()
+		require.NoError(t, err)
+	}
+	defer func() {
+		_ = admin.RunCommand(ctx, bsonx.Doc{
+			{"configureFailPoint", bsonx.String("failCommand")},
+			{"mode", bsonx.String("off")},
+		})
+	}()
+
+	retryWC := bsonx.Document(bsonx.Doc{{"w", bsonx.String("majority")}, {"wtimeout", bsonx.Int64(10000)}})
+	insertInTransaction := func(t *testing.T, sess Session, x int32) {
+		require.NoError(t, sess.StartTransaction())
+		err := WithSession(ctx, sess, func(sc SessionContext) error {
+			_, err := coll.InsertOne(sc, bsonx.Doc{{"x", bsonx.Int32(x)}})
+			return err
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("retried until it succeeds", func(t *testing.T) {
+		setFailPoint(t, bsonx.Document(bsonx.Doc{{"times", bsonx.Int32(1)}}))
+
+		sess, err := client.StartSession()
+		require.NoError(t, err)
+		defer sess.EndSession(ctx)
+		insertInTransaction(t, sess, 1)
+
+		commits, attempts = nil, nil
+		require.NoError(t, sess.CommitTransaction(ctx))
+		require.Len(t, commits, 2)
+		require.Equal(t, []int{2}, attempts)
+		require.Equal(t, bsonx.Val{}, commits[0])
+		require.True(t, retryWC.Equal(commits[1]), "expected retry write concern %v, got %v", retryWC, commits[1])
+
+		count, err := coll.CountDocuments(ctx, bsonx.Doc{{"x", bsonx.Int32(1)}})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+	})
+	t.Run("returns the unknown result when ctx is done", func(t *testing.T) {
+		setFailPoint(t, bsonx.String("alwaysOn"))
+
+		sess, err := client.StartSession()
+		require.NoError(t, err)
+		defer sess.EndSession(ctx)
+		insertInTransaction(t, sess, 2)
+
+		commits, attempts = nil, nil
+		commitCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err = sess.CommitTransaction(commitCtx)
+		require.True(t, time.Since(start) < 5*time.Second, "commit retried past the context deadline")
+		cerr, ok := err.(command.Error)
+		require.True(t, ok, "expected command.Error, got %T: %v", err, err)
+		require.True(t, cerr.HasErrorLabel(command.UnknownTransactionCommitResult))
+		require.False(t, cerr.HasErrorLabel(command.TransientTransactionError))
+
+		// the backoff keeps the number of attempts well below a tight loop
+		require.True(t, len(commits) > 1 && len(commits) < 50, "unexpected number of commits: %d", len(commits))
+		for _, wc := range commits[1:] {
+			require.True(t, retryWC.Equal(wc), "expected retry write concern %v, got %v", retryWC, wc)
+		}
+		// every commit after the first is published as a retry with the next attempt number
+		require.Len(t, attempts, len(commits)-1)
+		for i, attempt := range attempts {
+			require.Equal(t, i+2, attempt)
+		}
+	})
+}
+
+func TestSessions_DistinctAndCountInTransaction(t *testing.T) {
+	if os.Getenv("TOPOLOGY") != "replica_set" {
+		t.Skip("transactions are only tested against replica sets")
+	}
+	versionStr, err := getServerVersion(createTestDatabase(t, nil))
+	require.NoError(t, err)
+	if compareVersions(t, versionStr, "4.0") < 0 {
+		t.Skip("transactions require MongoDB 4.0 or later")
+	}
+
+	var started []*event.CommandStartedEvent
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			switch cse.CommandName {
+			case "aggregate", "distinct", "count":
+				started = append(started, cse)
+			}
+		},
+	}
+	client := createSessionsMonitoredClient(t, monitor)
+	defer func() { _ = client.Disconnect(ctx) }()
+
+	db := client.Database("SessionsTestDistinctAndCount")
+	require.NoError(t, db.Drop(ctx))
+	coll := db.Collection("txn", options.Collection().SetWriteConcern(writeconcern.New(writeconcern.WMajority())))
+	// create the collection outside of the transaction
+	_, err = coll.InsertOne(ctx, bsonx.Doc{{"x", bsonx.Int32(1)}})
+	require.NoError(t, err)
+
+	sess, err := client.StartSession()
+	require.NoError(t, err)
+	defer sess.EndSession(ctx)
+
+	require.NoError(t, sess.StartTransaction())
+	started = nil
+	err = WithSession(ctx, sess, func(sc SessionContext) error {
+		count, err := coll.CountDocuments(sc, bsonx.Doc{})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+
+		_, err = coll.InsertOne(sc, bsonx.Doc{{"x", bsonx.Int32(2)}})
+		require.NoError(t, err)
+
+		// reads in the transaction see its own uncommitted writes
+		values, err := coll.Distinct(sc, "x", bsonx.Doc{})
+		require.NoError(t, err)
+		require.Len(t, values, 2)
+
+		count, err = coll.CountDocuments(sc, bsonx.Doc{})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), count)
+
+		_, err = coll.EstimatedDocumentCount(sc)
+		require.Equal(t, ErrEstimatedCountInTransaction, err)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, started, 3, "estimatedDocumentCount must not send a command in a transaction")
+	txnNumber := sess.(*sessionImpl).TxnNumber
+	for i, cse := range started {
+		_, err := cse.Command.LookupErr("lsid")
+		require.NoError(t, err, "expected %s to include the session", cse.CommandName)
+		require.Equal(t, txnNumber, cse.Command.Lookup("txnNumber").Int64())
+		require.False(t, cse.Command.Lookup("autocommit").Boolean())
+
+		_, err = cse.Command.LookupErr("startTransaction")
+		if i == 0 {
+			require.NoError(t, err, "the first command in the transaction must start it")
+			continue
+		}
+		require.Error(t, err)
+	}
+
+	// the uncommitted insert is not visible outside of the transaction
+	count, err := coll.CountDocuments(ctx, bsonx.Doc{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	require.NoError(t, sess.CommitTransaction(ctx))
+	count, err = coll.CountDocuments(ctx, bsonx.Doc{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	count, err = coll.EstimatedDocumentCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
@@ -0,0 +1,78 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
+)
+
+// ErrProjectionExcludesID is returned by FindOneAndUpdateImages when the projection does not return the _id
+// field, which is needed to read the updated document.
+var ErrProjectionExcludesID = errors.New("the projection must include the _id field")
+
+// UpdateImagesResult represents the original and updated documents returned by
+// FindOneAndUpdateImages. If the operation returned an error, the Err method of
+// UpdateImagesResult will return that error.
+type UpdateImagesResult struct {
+	err    error
+	before bson.Raw
+	after  bson.Raw
+	reg    *bsoncodec.Registry
+}
+
+// Decode will attempt to decode the original document into before and the
+// updated document into after. Either destination may be nil, in which case
+// that document is not decoded. If there was an error from the operation that
+// created this UpdateImagesResult then the error will be returned.
+//
+// If the update inserted a new document there is no original document and
+// before is left unchanged. If the updated document could not be read, Decode
+// returns ErrNoDocuments after decoding the original document.
+func (ur *UpdateImagesResult) Decode(before, after interface{}) error {
+	if ur.err != nil {
+		return ur.err
+	}
+	if ur.reg == nil {
+		return bson.ErrNilRegistry
+	}
+
+	if before != nil && ur.before != nil {
+		if err := bson.UnmarshalWithRegistry(ur.reg, ur.before, before); err != nil {
+			return err
+		}
+	}
+	if ur.after == nil {
+		return ErrNoDocuments
+	}
+	if after == nil {
+		return nil
+	}
+	return bson.UnmarshalWithRegistry(ur.reg, ur.after, after)
+}
+
+// DecodeBytes will return the original and updated documents as bson.Raw. The
+// original document is nil if the update inserted a new document. If there was
+// an error from the operation that created this UpdateImagesResult then the
+// error will be returned.
+func (ur *UpdateImagesResult) DecodeBytes() (before bson.Raw, after bson.Raw, err error) {
+	if ur.err != nil {
+		return nil, nil, ur.err
+	}
+	if ur.after == nil {
+		return ur.before, nil, ErrNoDocuments
+	}
+	return ur.before, ur.after, nil
+}
+
+// Err will return the error from the operation that created this
+// UpdateImagesResult. If there was no error, nil is returned.
+func (ur *UpdateImagesResult) Err() error {
+	return ur.err
+}
@@ -0,0 +1,61 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"github.com/mongodb/mongo-go-driver/x/network/connstring"
+)
+
+// ConnString is a parsed and validated MongoDB connection string, as returned by ParseURI.
+type ConnString struct {
+	Original string   // The connection string that was parsed.
+	SRV      bool     // Whether the connection string uses the mongodb+srv scheme.
+	Hosts    []string // The hosts to connect to. For an SRV connection string these are the hosts from DNS.
+	Database string   // The database in the path of the connection string, if any.
+	Username string   // The username in the user information of the connection string, if any.
+
+	AuthMechanism           string            // The authentication mechanism, or empty for the default.
+	AuthMechanismProperties map[string]string // The authentication mechanism properties.
+	AuthSource              string            // The database to authenticate against, after applying defaults.
+
+	// Options contains the value or values of every option, keyed by the lower case option name. For an SRV
+	// connection string this includes the options from the TXT record.
+	Options map[string][]string
+	// UnknownOptions contains the options in Options that are not recognized by the driver.
+	UnknownOptions map[string][]string
+}
+
+// ParseURI parses and validates the connection string uri without creating a Client. The same validation is
+// applied as by NewClient, so a connection string accepted by ParseURI is accepted by NewClient.
+//
+// A mongodb+srv connection string must have a single host without a port. Its SRV and TXT records are looked
+// up in DNS to find the hosts and additional options, so ParseURI performs network I/O for SRV connection
+// strings.
+func ParseURI(uri string) (*ConnString, error) {
+	cs, err := connstring.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConnString{
+		Original:                cs.Original,
+		SRV:                     cs.SRV,
+		Hosts:                   cs.Hosts,
+		Database:                cs.Database,
+		Username:                cs.Username,
+		AuthMechanism:           cs.AuthMechanism,
+		AuthMechanismProperties: cs.AuthMechanismProperties,
+		AuthSource:              cs.AuthSource,
+		Options:                 cs.Options,
+		UnknownOptions:          cs.UnknownOptions,
+	}, nil
+}
+
+// String returns the connection string that was parsed.
+func (cs *ConnString) String() string {
+	return cs.Original
+}
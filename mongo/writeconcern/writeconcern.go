@@ -88,10 +88,51 @@ func WTimeout(d time.Duration) Option {
 	}
 }
 
+// WithOptions returns a copy of the WriteConcern with the given options applied. It can be called on a nil
+// WriteConcern, which is equivalent to calling New.
+func (wc *WriteConcern) WithOptions(options ...Option) *WriteConcern {
+	concern := &WriteConcern{}
+	if wc != nil {
+		*concern = *wc
+	}
+
+	for _, option := range options {
+		option(concern)
+	}
+
+	return concern
+}
+
+// GetWTimeout returns the time limit of the write concern, or zero if it has none.
+func (wc *WriteConcern) GetWTimeout() time.Duration {
+	if wc == nil {
+		return 0
+	}
+	return wc.wTimeout
+}
+
+// Validate returns an error if the write concern cannot be sent to the server, such as when it requests both
+// w=0 and j=true. A nil write concern is valid.
+func (wc *WriteConcern) Validate() error {
+	if wc == nil {
+		return nil
+	}
+	if !wc.IsValid() {
+		return ErrInconsistent
+	}
+	if w, ok := wc.w.(int); ok && w < 0 {
+		return ErrNegativeW
+	}
+	if wc.wTimeout < 0 {
+		return ErrNegativeWTimeout
+	}
+	return nil
+}
+
 // MarshalBSONElement marshals the write concern into a *bsonx.Element.
 func (wc *WriteConcern) MarshalBSONElement() (bsonx.Elem, error) {
-	if !wc.IsValid() {
-		return bsonx.Elem{}, ErrInconsistent
+	if err := wc.Validate(); err != nil {
+		return bsonx.Elem{}, err
 	}
 
 	elems := bsonx.Doc{}
@@ -99,10 +140,6 @@ func (wc *WriteConcern) MarshalBSONElement() (bsonx.Elem, error) {
 	if wc.w != nil {
 		switch t := wc.w.(type) {
 		case int:
-			if t < 0 {
-				return bsonx.Elem{}, ErrNegativeW
-			}
-
 			elems = append(elems, bsonx.Elem{"w", bsonx.Int32(int32(t))})
 		case string:
 			elems = append(elems, bsonx.Elem{"w", bsonx.String(t)})
@@ -113,10 +150,6 @@ func (wc *WriteConcern) MarshalBSONElement() (bsonx.Elem, error) {
 		elems = append(elems, bsonx.Elem{"j", bsonx.Boolean(wc.j)})
 	}
 
-	if wc.wTimeout < 0 {
-		return bsonx.Elem{}, ErrNegativeWTimeout
-	}
-
 	if wc.wTimeout != 0 {
 		elems = append(elems, bsonx.Elem{"wtimeout", bsonx.Int64(int64(wc.wTimeout / time.Millisecond))})
 	}
@@ -0,0 +1,55 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package writeconcern
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name string
+		wc   *WriteConcern
+		err  error
+	}{
+		{"nil", nil, nil},
+		{"empty", New(), nil},
+		{"majority journaled", New(WMajority(), J(true)), nil},
+		{"unacknowledged", New(W(0)), nil},
+		{"unacknowledged journaled", New(W(0), J(true)), ErrInconsistent},
+		{"negative w", New(W(-1)), ErrNegativeW},
+		{"negative wtimeout", New(W(1), WTimeout(-time.Second)), ErrNegativeWTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.err, tc.wc.Validate())
+			if tc.wc == nil || tc.err == nil {
+				return
+			}
+			_, err := tc.wc.MarshalBSONElement()
+			require.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func TestWithOptions(t *testing.T) {
+	t.Run("copies", func(t *testing.T) {
+		wc := New(W(1), J(true), WTimeout(time.Second))
+		updated := wc.WithOptions(WMajority())
+		require.Equal(t, New(WMajority(), J(true), WTimeout(time.Second)), updated)
+		require.Equal(t, New(W(1), J(true), WTimeout(time.Second)), wc)
+	})
+	t.Run("nil", func(t *testing.T) {
+		var wc *WriteConcern
+		require.Equal(t, New(WMajority()), wc.WithOptions(WMajority()))
+		require.Equal(t, time.Duration(0), wc.GetWTimeout())
+	})
+}
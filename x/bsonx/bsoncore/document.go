@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 
 	"github.com/go-stack/stack"
 	"github.com/mongodb/mongo-go-driver/bson/bsontype"
@@ -109,6 +110,9 @@ var ErrEmptyKey = errors.New("empty key provided")
 // ErrElementNotFound indicates that an Element matching a certain condition does not exist.
 var ErrElementNotFound = errors.New("element not found")
 
+// ErrInvalidKey indicates that a key provided to modify a document contains a null byte.
+var ErrInvalidKey = errors.New("key cannot contain a null byte")
+
 // ErrOutOfBounds indicates that an index provided to access something was invalid.
 var ErrOutOfBounds = errors.New("out of bounds")
 
@@ -131,7 +135,7 @@ func NewDocumentFromReader(r io.Reader) (Document, error) {
 	}
 
 	length, _, _ := readi32(lengthBytes[:]) // ignore ok since we always have enough bytes to read a length
-	if length < 0 {
+	if length < 5 {
 		return nil, ErrInvalidLength
 	}
 	document := make([]byte, length)
@@ -241,6 +245,84 @@ func (d Document) IndexErr(index uint) (Element, error) {
 	return nil, ErrOutOfBounds
 }
 
+// SetValue returns a copy of d in which the value of the first element with the given key is replaced by v. If d
+// has no such element, one is appended to the end of the copy. Only the replaced element and the length of the
+// document are rewritten; the other elements are copied as is and keep their order. Embedded documents are not
+// searched, so key is a top-level key.
+func (d Document) SetValue(key string, v Value) (Document, error) {
+	data, _, ok := readValue(v.Data, v.Type)
+	if !ok {
+		return nil, NewInsufficientBytesError(v.Data, v.Data)
+	}
+
+	start, end, err := d.elementBounds(key)
+	if err != nil && err != ErrElementNotFound {
+		return nil, err
+	}
+	length, _, _ := ReadLength(d) // checked by elementBounds
+	d = d[:length]
+
+	dst := make([]byte, 0, start+len(key)+2+len(data)+len(d)-end)
+	dst = append(dst, d[:start]...)
+	dst = AppendHeader(dst, v.Type, key)
+	dst = append(dst, data...)
+	dst = append(dst, d[end:]...)
+	return UpdateLength(dst, 0, int32(len(dst))), nil
+}
+
+// DeleteKey returns a copy of d without the first element with the given key. The other elements are copied as
+// is and keep their order. ErrElementNotFound is returned if d has no element with the key.
+func (d Document) DeleteKey(key string) (Document, error) {
+	start, end, err := d.elementBounds(key)
+	if err != nil {
+		return nil, err
+	}
+	length, _, _ := ReadLength(d) // checked by elementBounds
+	d = d[:length]
+
+	dst := make([]byte, 0, len(d)-(end-start))
+	dst = append(dst, d[:start]...)
+	dst = append(dst, d[end:]...)
+	return UpdateLength(dst, 0, int32(len(dst))), nil
+}
+
+// elementBounds returns the offsets in d of the start and end of the first element with the given key. If there is
+// no such element, both offsets are those of the null byte that terminates d and ErrElementNotFound is returned.
+func (d Document) elementBounds(key string) (int, int, error) {
+	if strings.IndexByte(key, 0x00) != -1 {
+		return 0, 0, ErrInvalidKey
+	}
+	length, rem, ok := ReadLength(d)
+	if !ok {
+		return 0, 0, NewInsufficientBytesError(d, rem)
+	}
+	if length < 5 {
+		return 0, 0, ErrInvalidLength
+	}
+	if int(length) > len(d) {
+		return 0, 0, d.lengtherror(int(length), len(d))
+	}
+	if d[length-1] != 0x00 {
+		return 0, 0, ErrMissingNull
+	}
+
+	keyBytes := []byte(key)
+	pos := 4
+	rem = d[pos : length-1]
+	for len(rem) > 0 {
+		var elem Element
+		elem, rem, ok = ReadElement(rem)
+		if !ok {
+			return 0, 0, NewInsufficientBytesError(d, rem)
+		}
+		if elem.CompareKey(keyBytes) {
+			return pos, pos + len(elem), nil
+		}
+		pos += len(elem)
+	}
+	return pos, pos, ErrElementNotFound
+}
+
 // DebugString outputs a human readable version of Document. It will attempt to stringify the
 // valid components of the document even if the entire document is not valid.
 func (d Document) DebugString() string {
@@ -296,6 +296,12 @@ func TestDocument(t *testing.T) {
 				nil,
 				io.EOF,
 			},
+			{
+				"length too small",
+				bytes.NewBuffer([]byte{3, 0, 0, 0}),
+				nil,
+				ErrInvalidLength,
+			},
 			{
 				"empty document",
 				bytes.NewBuffer([]byte{5, 0, 0, 0, 0}),
@@ -409,4 +415,85 @@ func TestDocument(t *testing.T) {
 			})
 		}
 	})
+	t.Run("SetValue", func(t *testing.T) {
+		doc := Document(BuildDocument(nil, AppendStringElement(AppendInt32Element(AppendDoubleElement(nil, "pi", 3.14159), "a", 1), "hello", "world!")))
+		str := Value{Type: bsontype.String, Data: AppendString(nil, "hi")}
+		testCases := []struct {
+			name string
+			doc  Document
+			key  string
+			val  Value
+			want Document
+			err  error
+		}{
+			{"Replace First", doc, "pi", str,
+				BuildDocument(nil, AppendStringElement(AppendInt32Element(AppendStringElement(nil, "pi", "hi"), "a", 1), "hello", "world!")), nil},
+			{"Replace Middle", doc, "a", str,
+				BuildDocument(nil, AppendStringElement(AppendStringElement(AppendDoubleElement(nil, "pi", 3.14159), "a", "hi"), "hello", "world!")), nil},
+			{"Replace Last", doc, "hello", Value{Type: bsontype.Null},
+				BuildDocument(nil, AppendNullElement(AppendInt32Element(AppendDoubleElement(nil, "pi", 3.14159), "a", 1), "hello")), nil},
+			{"Append", doc, "b", str,
+				BuildDocument(nil, AppendStringElement(AppendStringElement(AppendInt32Element(AppendDoubleElement(nil, "pi", 3.14159), "a", 1), "hello", "world!"), "b", "hi")), nil},
+			{"Append To Empty", BuildDocument(nil, nil), "b", str, BuildDocument(nil, AppendStringElement(nil, "b", "hi")), nil},
+			{"Trailing Bytes", append(doc[:len(doc):len(doc)], 0x01, 0x02), "a", str,
+				BuildDocument(nil, AppendStringElement(AppendStringElement(AppendDoubleElement(nil, "pi", 3.14159), "a", "hi"), "hello", "world!")), nil},
+			{"Invalid Value", doc, "a", Value{Type: bsontype.String, Data: []byte{0x05}}, nil, NewInsufficientBytesError(nil, nil)},
+			{"Invalid Key", doc, "a\x00b", str, nil, ErrInvalidKey},
+			{"Invalid Length", Document{0x03, 0x00, 0x00, 0x00, 0x00}, "a", str, nil, ErrInvalidLength},
+			{"Missing Null", Document{0x05, 0x00, 0x00, 0x00, 0x01}, "a", str, nil, ErrMissingNull},
+			{"Invalid Element", BuildDocument(nil, AppendHeader(nil, bsontype.Double, "foo")), "a", str, nil, NewInsufficientBytesError(nil, nil)},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				original := append(Document(nil), tc.doc...)
+				got, err := tc.doc.SetValue(tc.key, tc.val)
+				if !compareErrors(err, tc.err) {
+					t.Errorf("errors do not match. got %v; want %v", err, tc.err)
+				}
+				if !bytes.Equal(got, tc.want) {
+					t.Errorf("documents do not match. got %v; want %v", got.DebugString(), tc.want.DebugString())
+				}
+				if !bytes.Equal(tc.doc, original) {
+					t.Errorf("original document was modified")
+				}
+			})
+		}
+	})
+	t.Run("DeleteKey", func(t *testing.T) {
+		doc := Document(BuildDocument(nil, AppendStringElement(AppendInt32Element(AppendDoubleElement(nil, "pi", 3.14159), "a", 1), "hello", "world!")))
+		testCases := []struct {
+			name string
+			key  string
+			want Document
+			err  error
+		}{
+			{"First", "pi", BuildDocument(nil, AppendStringElement(AppendInt32Element(nil, "a", 1), "hello", "world!")), nil},
+			{"Middle", "a", BuildDocument(nil, AppendStringElement(AppendDoubleElement(nil, "pi", 3.14159), "hello", "world!")), nil},
+			{"Last", "hello", BuildDocument(nil, AppendInt32Element(AppendDoubleElement(nil, "pi", 3.14159), "a", 1)), nil},
+			{"Not Found", "b", nil, ErrElementNotFound},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := doc.DeleteKey(tc.key)
+				if !compareErrors(err, tc.err) {
+					t.Errorf("errors do not match. got %v; want %v", err, tc.err)
+				}
+				if !bytes.Equal(got, tc.want) {
+					t.Errorf("documents do not match. got %v; want %v", got.DebugString(), tc.want.DebugString())
+				}
+			})
+		}
+
+		t.Run("Only Element", func(t *testing.T) {
+			got, err := Document(BuildDocument(nil, AppendInt32Element(nil, "a", 1))).DeleteKey("a")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, BuildDocument(nil, nil)) {
+				t.Errorf("expected an empty document, got %v", got.DebugString())
+			}
+		})
+	})
 }
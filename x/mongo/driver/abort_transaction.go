@@ -57,6 +57,9 @@ func abortTransaction(
 		// Conditions for retry write support are the same as that of sessions
 		return result.TransactionResult{}, oldErr
 	}
+	if oldErr != nil {
+		PublishRetryWrite(ctx, topo, "abortTransaction", retryWriteAttempt, oldErr, nil)
+	}
 
 	conn, err := ss.Connection(ctx)
 	if err != nil {
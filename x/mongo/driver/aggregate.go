@@ -35,11 +35,9 @@ func Aggregate(
 	opts ...*options.AggregateOptions,
 ) (command.Cursor, error) {
 
-	dollarOut := cmd.HasDollarOut()
-
 	var ss *topology.SelectedServer
 	var err error
-	switch dollarOut {
+	switch cmd.HasOutputStage() {
 	case true:
 		ss, err = topo.SelectServer(ctx, writeSelector)
 		if err != nil {
@@ -75,6 +73,9 @@ func Aggregate(
 	}
 
 	aggOpts := options.MergeAggregateOptions(opts...)
+	if aggOpts.CausalConsistency != nil {
+		cmd.SkipAfterClusterTime = !*aggOpts.CausalConsistency
+	}
 
 	if aggOpts.AllowDiskUse != nil {
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"allowDiskUse", bsonx.Boolean(*aggOpts.AllowDiskUse)})
@@ -103,7 +104,11 @@ func Aggregate(
 		})
 	}
 	if aggOpts.Comment != nil {
-		cmd.Opts = append(cmd.Opts, bsonx.Elem{"comment", bsonx.String(*aggOpts.Comment)})
+		commentElem, err := commentToElement("comment", aggOpts.Comment, desc.WireVersion, registry)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Opts = append(cmd.Opts, commentElem)
 	}
 	if aggOpts.Hint != nil {
 		hintElem, err := interfaceToElement("hint", aggOpts.Hint, registry)
@@ -114,7 +119,12 @@ func Aggregate(
 		cmd.Opts = append(cmd.Opts, hintElem)
 	}
 
-	c, err := cmd.RoundTrip(ctx, desc, ss, conn)
+	var cb command.CursorBuilder = ss
+	if aggOpts.Prefetch != nil && *aggOpts.Prefetch {
+		cb = prefetchCursorBuilder{ss.Server}
+	}
+
+	c, err := cmd.RoundTrip(ctx, desc, cb, conn)
 	if err != nil {
 		closeImplicitSession(cmd.Session)
 	}
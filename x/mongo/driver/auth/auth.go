@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/mongodb/mongo-go-driver/x/network/address"
 	"github.com/mongodb/mongo-go-driver/x/network/command"
 	"github.com/mongodb/mongo-go-driver/x/network/connection"
@@ -30,6 +31,8 @@ func init() {
 	RegisterAuthenticatorFactory(PLAIN, newPlainAuthenticator)
 	RegisterAuthenticatorFactory(GSSAPI, newGSSAPIAuthenticator)
 	RegisterAuthenticatorFactory(MongoDBX509, newMongoDBX509Authenticator)
+	RegisterAuthenticatorFactory(MongoDBAWS, newMongoDBAWSAuthenticator)
+	RegisterAuthenticatorFactory(MongoDBOIDC, newOIDCAuthenticator)
 }
 
 // CreateAuthenticator creates an authenticator.
@@ -104,17 +107,31 @@ type HandshakeOptions struct {
 // Handshaker creates a connection handshaker for the given authenticator.
 func Handshaker(h connection.Handshaker, options *HandshakeOptions) connection.Handshaker {
 	return connection.HandshakerFunc(func(ctx context.Context, addr address.Address, rw wiremessage.ReadWriter) (description.Server, error) {
-		desc, err := (&command.Handshake{
+		handshake := &command.Handshake{
 			Client:             command.ClientDoc(options.AppName),
 			Compressors:        options.Compressors,
 			SaslSupportedMechs: options.DBUser,
-		}).Handshake(ctx, addr, rw)
+		}
+
+		speculative, _ := options.Authenticator.(SpeculativeAuthenticator)
+		if speculative != nil {
+			cmd, err := speculative.SpeculativeCommand(ctx)
+			if err != nil {
+				return description.Server{}, newAuthError("speculative authentication failure", err)
+			}
+			handshake.SpeculativeAuthenticate = cmd
+		}
 
+		desc, err := handshake.Handshake(ctx, addr, rw)
 		if err != nil {
 			return description.Server{}, newAuthError("handshake failure", err)
 		}
 
-		err = options.Authenticator.Auth(ctx, desc, rw)
+		if reply := handshake.SpeculativeResponse(); speculative != nil && reply != nil {
+			err = speculative.FinishSpeculative(ctx, desc, rw, reply)
+		} else {
+			err = options.Authenticator.Auth(ctx, desc, rw)
+		}
 		if err != nil {
 			return description.Server{}, newAuthError("auth error", err)
 		}
@@ -131,6 +148,27 @@ type Authenticator interface {
 	Auth(context.Context, description.Server, wiremessage.ReadWriter) error
 }
 
+// SpeculativeAuthenticator is an Authenticator that can begin its conversation in the connection handshake.
+type SpeculativeAuthenticator interface {
+	Authenticator
+
+	// SpeculativeCommand returns the first command of the conversation to send with isMaster, or nil to
+	// authenticate after the handshake instead.
+	SpeculativeCommand(context.Context) (bsonx.Doc, error)
+
+	// FinishSpeculative completes the conversation using the server's reply to the speculative command.
+	FinishSpeculative(context.Context, description.Server, wiremessage.ReadWriter, bsonx.Doc) error
+}
+
+// Reauthenticator is an Authenticator that must do more than repeat Auth when the server requires a connection
+// to be authenticated again, such as discarding cached credentials the server no longer accepts.
+type Reauthenticator interface {
+	Authenticator
+
+	// Reauth authenticates a connection that was authenticated before.
+	Reauth(context.Context, description.Server, wiremessage.ReadWriter) error
+}
+
 func newAuthError(msg string, inner error) error {
 	return &Error{
 		message: msg,
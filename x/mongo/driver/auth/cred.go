@@ -6,11 +6,35 @@
 
 package auth
 
+import "context"
+
+// PasswordProvider returns the password to authenticate with. It is called each time a connection is
+// authenticated, so it may be called concurrently and must be safe for concurrent use.
+type PasswordProvider func(ctx context.Context) (string, error)
+
 // Cred is a user's credential.
+//
+// If PasswordProvider is set, it is used instead of Password by the SCRAM-SHA-1, SCRAM-SHA-256,
+// MONGODB-CR and PLAIN mechanisms. OIDCMachineCallback supplies access tokens to the MONGODB-OIDC mechanism.
 type Cred struct {
-	Source      string
-	Username    string
-	Password    string
-	PasswordSet bool
-	Props       map[string]string
+	Source              string
+	Username            string
+	Password            string
+	PasswordSet         bool
+	PasswordProvider    PasswordProvider
+	OIDCMachineCallback OIDCCallback
+	Props               map[string]string
+}
+
+// password returns the password to authenticate with, calling the PasswordProvider if there is one.
+func (c *Cred) password(ctx context.Context) (string, error) {
+	if c.PasswordProvider == nil {
+		return c.Password, nil
+	}
+
+	password, err := c.PasswordProvider(ctx)
+	if err != nil {
+		return "", newAuthError("error retrieving password from provider", err)
+	}
+	return password, nil
 }
@@ -0,0 +1,387 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
+)
+
+// MongoDBAWS is the mechanism name for MongoDBAWS.
+const MongoDBAWS = "MONGODB-AWS"
+
+const (
+	awsSessionTokenProp = "AWS_SESSION_TOKEN"
+
+	awsNonceLength    = 32
+	awsGS2CBFlagNone  = 110 // 'n', the client does not support channel binding
+	awsRequestBody    = "Action=GetCallerIdentity&Version=2011-06-15"
+	awsDefaultRegion  = "us-east-1"
+	awsDefaultSTSHost = "sts.amazonaws.com"
+
+	// awsRefreshWindow is how long before their expiration temporary credentials are renewed, so a connection
+	// is never authenticated with credentials that expire during the conversation.
+	awsRefreshWindow = 5 * time.Minute
+)
+
+// awsSTSEndpoint is the endpoint AssumeRoleWithWebIdentity requests are sent to. It is a variable so tests can
+// replace it.
+var awsSTSEndpoint = "https://sts.amazonaws.com/"
+
+func newMongoDBAWSAuthenticator(cred *Cred) (Authenticator, error) {
+	if cred.Username != "" && cred.Password == "" {
+		return nil, newAuthError("a secret access key is required when an access key id is provided", nil)
+	}
+	if cred.Username == "" && cred.Password != "" {
+		return nil, newAuthError("an access key id is required when a secret access key is provided", nil)
+	}
+
+	a := &MongoDBAWSAuthenticator{
+		AccessKeyID:     cred.Username,
+		SecretAccessKey: cred.Password,
+	}
+	if cred.Props != nil {
+		a.SessionToken = cred.Props[awsSessionTokenProp]
+	}
+	return a, nil
+}
+
+// MongoDBAWSAuthenticator uses AWS IAM credentials over SASL to authenticate a connection.
+//
+// Credentials are taken from the first of the following that is set:
+//
+//  1. The AccessKeyID, SecretAccessKey and SessionToken fields, which are filled in from the username,
+//     password and AWS_SESSION_TOKEN auth mechanism property of a connection string.
+//  2. The AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables.
+//  3. The AWS_WEB_IDENTITY_TOKEN_FILE and AWS_ROLE_ARN environment variables, which are exchanged for
+//     temporary credentials using AssumeRoleWithWebIdentity. This is the mechanism used by IAM roles for
+//     Kubernetes service accounts. AWS_ROLE_SESSION_NAME is used as the role session name if it is set.
+//
+// Temporary credentials obtained from a web identity are cached and renewed shortly before they expire.
+type MongoDBAWSAuthenticator struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	mu     sync.Mutex
+	cached *awsCredentials
+	now    func() time.Time
+}
+
+// Auth authenticates the connection.
+func (a *MongoDBAWSAuthenticator) Auth(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter) error {
+	creds, err := a.credentials(ctx)
+	if err != nil {
+		return newError(err, MongoDBAWS)
+	}
+
+	return ConductSaslConversation(ctx, desc, rw, "$external", &awsSaslClient{
+		creds: creds,
+		now:   a.timeNow,
+	})
+}
+
+func (a *MongoDBAWSAuthenticator) timeNow() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+	return time.Now()
+}
+
+// credentials returns the credentials to authenticate with, assuming the role given by the environment if
+// neither explicit nor environment credentials are set.
+func (a *MongoDBAWSAuthenticator) credentials(ctx context.Context) (awsCredentials, error) {
+	if a.AccessKeyID != "" {
+		return awsCredentials{
+			AccessKeyID:     a.AccessKeyID,
+			SecretAccessKey: a.SecretAccessKey,
+			SessionToken:    a.SessionToken,
+		}, nil
+	}
+
+	if id := os.Getenv("AWS_ACCESS_KEY_ID"); id != "" {
+		secret := os.Getenv("AWS_SECRET_ACCESS_KEY")
+		if secret == "" {
+			return awsCredentials{}, errors.New("AWS_SECRET_ACCESS_KEY must be set when AWS_ACCESS_KEY_ID is set")
+		}
+		return awsCredentials{
+			AccessKeyID:     id,
+			SecretAccessKey: secret,
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}, nil
+	}
+
+	tokenFile := os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE")
+	roleARN := os.Getenv("AWS_ROLE_ARN")
+	if tokenFile == "" || roleARN == "" {
+		return awsCredentials{}, errors.New("no AWS credentials found: set an access key id and secret access key, " +
+			"or AWS_WEB_IDENTITY_TOKEN_FILE and AWS_ROLE_ARN")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.cached != nil && a.timeNow().Add(awsRefreshWindow).Before(a.cached.Expiration) {
+		return *a.cached, nil
+	}
+
+	creds, err := assumeRoleWithWebIdentity(ctx, tokenFile, roleARN, os.Getenv("AWS_ROLE_SESSION_NAME"), a.timeNow())
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	a.cached = &creds
+	return creds, nil
+}
+
+// awsCredentials are the credentials used to sign the GetCallerIdentity request. Expiration is the zero time
+// for long-lived credentials.
+type awsCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      time.Time
+}
+
+// assumeRoleWithWebIdentity exchanges the token in tokenFile for temporary credentials for roleARN.
+func assumeRoleWithWebIdentity(ctx context.Context, tokenFile, roleARN, sessionName string, now time.Time) (awsCredentials, error) {
+	token, err := ioutil.ReadFile(tokenFile)
+	if err != nil {
+		return awsCredentials{}, fmt.Errorf("unable to read web identity token: %v", err)
+	}
+
+	if sessionName == "" {
+		sessionName = "mongo-go-driver-" + strconv.FormatInt(now.UnixNano(), 10)
+	}
+
+	query := url.Values{}
+	query.Set("Action", "AssumeRoleWithWebIdentity")
+	query.Set("Version", "2011-06-15")
+	query.Set("RoleArn", roleARN)
+	query.Set("RoleSessionName", sessionName)
+	query.Set("WebIdentityToken", strings.TrimSpace(string(token)))
+
+	req, err := http.NewRequest(http.MethodPost, awsSTSEndpoint, strings.NewReader(query.Encode()))
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return awsCredentials{}, fmt.Errorf("AssumeRoleWithWebIdentity request failed: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return awsCredentials{}, fmt.Errorf("unable to read AssumeRoleWithWebIdentity response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return awsCredentials{}, fmt.Errorf("AssumeRoleWithWebIdentity failed with status %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Response struct {
+			Result struct {
+				Credentials struct {
+					AccessKeyID     string  `json:"AccessKeyId"`
+					SecretAccessKey string  `json:"SecretAccessKey"`
+					SessionToken    string  `json:"SessionToken"`
+					Expiration      float64 `json:"Expiration"`
+				} `json:"Credentials"`
+			} `json:"AssumeRoleWithWebIdentityResult"`
+		} `json:"AssumeRoleWithWebIdentityResponse"`
+	}
+	if err = json.Unmarshal(body, &result); err != nil {
+		return awsCredentials{}, fmt.Errorf("unable to parse AssumeRoleWithWebIdentity response: %v", err)
+	}
+
+	c := result.Response.Result.Credentials
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		return awsCredentials{}, errors.New("AssumeRoleWithWebIdentity response did not contain credentials")
+	}
+
+	return awsCredentials{
+		AccessKeyID:     c.AccessKeyID,
+		SecretAccessKey: c.SecretAccessKey,
+		SessionToken:    c.SessionToken,
+		Expiration:      time.Unix(int64(c.Expiration), 0),
+	}, nil
+}
+
+type awsSaslClient struct {
+	creds       awsCredentials
+	now         func() time.Time
+	clientNonce []byte
+	done        bool
+}
+
+func (c *awsSaslClient) Start() (string, []byte, error) {
+	c.clientNonce = make([]byte, awsNonceLength)
+	if _, err := rand.Read(c.clientNonce); err != nil {
+		return MongoDBAWS, nil, err
+	}
+
+	payload, err := bsonx.Doc{
+		{"r", bsonx.Binary(0x00, c.clientNonce)},
+		{"p", bsonx.Int32(awsGS2CBFlagNone)},
+	}.MarshalBSON()
+	return MongoDBAWS, payload, err
+}
+
+func (c *awsSaslClient) Next(challenge []byte) ([]byte, error) {
+	if c.done {
+		return nil, newAuthError("unexpected server challenge", nil)
+	}
+
+	var reply struct {
+		Nonce []byte `bson:"s"`
+		Host  string `bson:"h"`
+	}
+	if err := bson.Unmarshal(challenge, &reply); err != nil {
+		return nil, newAuthError("invalid server challenge", err)
+	}
+	if len(reply.Nonce) != 2*awsNonceLength || !bytes.Equal(reply.Nonce[:awsNonceLength], c.clientNonce) {
+		return nil, newAuthError("server nonce does not match the client nonce", nil)
+	}
+	if err := validateAWSHost(reply.Host); err != nil {
+		return nil, err
+	}
+
+	authorization, date := signAWSRequest(c.creds, reply.Host, reply.Nonce, c.now())
+	final := bsonx.Doc{
+		{"a", bsonx.String(authorization)},
+		{"d", bsonx.String(date)},
+	}
+	if c.creds.SessionToken != "" {
+		final = append(final, bsonx.Elem{"t", bsonx.String(c.creds.SessionToken)})
+	}
+
+	c.done = true
+	return final.MarshalBSON()
+}
+
+func (c *awsSaslClient) Completed() bool {
+	return c.done
+}
+
+func validateAWSHost(host string) error {
+	if host == "" || len(host) > 255 {
+		return newAuthError(fmt.Sprintf("invalid STS host %q", host), nil)
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" {
+			return newAuthError(fmt.Sprintf("invalid STS host %q", host), nil)
+		}
+	}
+	return nil
+}
+
+// awsRegion derives the signing region from the STS host sent by the server.
+func awsRegion(host string) string {
+	if host == awsDefaultSTSHost {
+		return awsDefaultRegion
+	}
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 {
+		return awsDefaultRegion
+	}
+	return labels[1]
+}
+
+// signAWSRequest signs the GetCallerIdentity request for host with AWS Signature Version 4, returning the
+// Authorization header and the X-Amz-Date it was signed for.
+func signAWSRequest(creds awsCredentials, host string, serverNonce []byte, now time.Time) (string, string) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	region := awsRegion(host)
+	scope := strings.Join([]string{date, region, "sts", "aws4_request"}, "/")
+
+	headers := [][2]string{
+		{"content-length", strconv.Itoa(len(awsRequestBody))},
+		{"content-type", "application/x-www-form-urlencoded"},
+		{"host", host},
+		{"x-amz-date", amzDate},
+	}
+	if creds.SessionToken != "" {
+		headers = append(headers, [2]string{"x-amz-security-token", creds.SessionToken})
+	}
+	headers = append(headers,
+		[2]string{"x-mongodb-gs2-cb-flag", "n"},
+		[2]string{"x-mongodb-server-nonce", base64.StdEncoding.EncodeToString(serverNonce)},
+	)
+
+	var canonicalHeaders bytes.Buffer
+	names := make([]string, 0, len(headers))
+	for _, h := range headers {
+		canonicalHeaders.WriteString(h[0] + ":" + h[1] + "\n")
+		names = append(names, h[0])
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		"/",
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		hexSHA256([]byte(awsRequestBody)),
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "sts")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	authorization := fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature)
+	return authorization, amzDate
+}
+
+func hexSHA256(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,220 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/internal"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWSRegion(t *testing.T) {
+	testCases := []struct {
+		host   string
+		region string
+	}{
+		{"sts.amazonaws.com", "us-east-1"},
+		{"sts.us-west-2.amazonaws.com", "us-west-2"},
+		{"sts.eu-central-1.amazonaws.com", "eu-central-1"},
+		{"localhost", "us-east-1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.host, func(t *testing.T) {
+			require.Equal(t, tc.region, awsRegion(tc.host))
+		})
+	}
+}
+
+func TestSignAWSRequest(t *testing.T) {
+	nonce := make([]byte, 64)
+	for i := range nonce {
+		nonce[i] = byte(i)
+	}
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	creds := awsCredentials{AccessKeyID: "key", SecretAccessKey: "secret", SessionToken: "token"}
+
+	authorization, date := signAWSRequest(creds, "sts.us-west-2.amazonaws.com", nonce, now)
+	require.Equal(t, "20200102T030405Z", date)
+	require.Equal(t, "AWS4-HMAC-SHA256 Credential=key/20200102/us-west-2/sts/aws4_request, "+
+		"SignedHeaders=content-length;content-type;host;x-amz-date;x-amz-security-token;"+
+		"x-mongodb-gs2-cb-flag;x-mongodb-server-nonce, "+
+		"Signature=726935e21c256de63c377df4d6e8af905ce5a1ee936fc686106473a905345177", authorization)
+
+	creds.SessionToken = ""
+	authorization, _ = signAWSRequest(creds, "sts.us-west-2.amazonaws.com", nonce, now)
+	require.Contains(t, authorization, "SignedHeaders=content-length;content-type;host;x-amz-date;x-mongodb-gs2-cb-flag;")
+}
+
+func TestMongoDBAWSAuthenticator_Conversation(t *testing.T) {
+	resps := make(chan wiremessage.WireMessage, 1)
+	c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 2), ReadResp: resps}
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	authenticator := &MongoDBAWSAuthenticator{
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+		SessionToken:    "token",
+		now:             func() time.Time { return now },
+	}
+
+	var final bsonx.Doc
+	go func() {
+		start := awsCommand(t, <-c.Written)
+		_, payload := start.Lookup("payload").Binary()
+		first, err := bsonx.ReadDoc(payload)
+		if err != nil {
+			t.Errorf("unable to read client first message: %v", err)
+			return
+		}
+		_, clientNonce := first.Lookup("r").Binary()
+		serverNonce := append(append([]byte{}, clientNonce...), make([]byte, awsNonceLength)...)
+		challenge, _ := bsonx.Doc{
+			{"s", bsonx.Binary(0x00, serverNonce)},
+			{"h", bsonx.String("sts.amazonaws.com")},
+		}.MarshalBSON()
+		resps <- internal.MakeReply(t, bsonx.Doc{
+			{"ok", bsonx.Int32(1)},
+			{"conversationId", bsonx.Int32(1)},
+			{"payload", bsonx.Binary(0x00, challenge)},
+			{"done", bsonx.Boolean(false)},
+		})
+
+		cont := awsCommand(t, <-c.Written)
+		_, payload = cont.Lookup("payload").Binary()
+		final, err = bsonx.ReadDoc(payload)
+		if err != nil {
+			t.Errorf("unable to read client final message: %v", err)
+		}
+		resps <- internal.MakeReply(t, bsonx.Doc{
+			{"ok", bsonx.Int32(1)},
+			{"conversationId", bsonx.Int32(1)},
+			{"payload", bsonx.Binary(0x00, []byte{})},
+			{"done", bsonx.Boolean(true)},
+		})
+	}()
+
+	err := authenticator.Auth(context.Background(), description.Server{
+		WireVersion: &description.VersionRange{Max: 6},
+	}, c)
+	require.NoError(t, err)
+	require.Equal(t, "20200102T030405Z", final.Lookup("d").StringValue())
+	require.Equal(t, "token", final.Lookup("t").StringValue())
+	require.Contains(t, final.Lookup("a").StringValue(), "Credential=key/20200102/us-east-1/sts/aws4_request")
+}
+
+func TestMongoDBAWSAuthenticator_InvalidServerNonce(t *testing.T) {
+	client := &awsSaslClient{creds: awsCredentials{AccessKeyID: "key", SecretAccessKey: "secret"}, now: time.Now}
+	_, _, err := client.Start()
+	require.NoError(t, err)
+
+	challenge, _ := bsonx.Doc{
+		{"s", bsonx.Binary(0x00, make([]byte, 2*awsNonceLength))},
+		{"h", bsonx.String("sts.amazonaws.com")},
+	}.MarshalBSON()
+	_, err = client.Next(challenge)
+	require.Error(t, err)
+}
+
+func TestMongoDBAWSAuthenticator_WebIdentity(t *testing.T) {
+	var requests int
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unable to parse form: %v", err)
+		}
+		if r.Form.Get("Action") != "AssumeRoleWithWebIdentity" || r.Form.Get("WebIdentityToken") != "jwt" ||
+			r.Form.Get("RoleArn") != "arn:aws:iam::123456789012:role/driver" || r.Form.Get("RoleSessionName") != "test" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"AssumeRoleWithWebIdentityResponse":{"AssumeRoleWithWebIdentityResult":{"Credentials":`+
+			`{"AccessKeyId":"key%d","SecretAccessKey":"secret","SessionToken":"token","Expiration":%d}}}}`,
+			requests, now.Add(time.Hour).Unix())
+	}))
+	defer server.Close()
+
+	defer func(endpoint string) { awsSTSEndpoint = endpoint }(awsSTSEndpoint)
+	awsSTSEndpoint = server.URL
+
+	dir, err := ioutil.TempDir("", "mongodbaws")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte("jwt\n"), 0600))
+
+	env := map[string]string{
+		"AWS_ACCESS_KEY_ID":           "",
+		"AWS_SECRET_ACCESS_KEY":       "",
+		"AWS_SESSION_TOKEN":           "",
+		"AWS_WEB_IDENTITY_TOKEN_FILE": tokenFile,
+		"AWS_ROLE_ARN":                "arn:aws:iam::123456789012:role/driver",
+		"AWS_ROLE_SESSION_NAME":       "test",
+	}
+	for k, v := range env {
+		old, set := os.LookupEnv(k)
+		require.NoError(t, os.Setenv(k, v))
+		defer func(k, old string, set bool) {
+			if set {
+				_ = os.Setenv(k, old)
+			} else {
+				_ = os.Unsetenv(k)
+			}
+		}(k, old, set)
+	}
+
+	a := &MongoDBAWSAuthenticator{now: func() time.Time { return now }}
+
+	creds, err := a.credentials(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "key1", creds.AccessKeyID)
+	require.Equal(t, "token", creds.SessionToken)
+	require.Equal(t, now.Add(time.Hour).Unix(), creds.Expiration.Unix())
+
+	now = now.Add(30 * time.Minute)
+	creds, err = a.credentials(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "key1", creds.AccessKeyID, "cached credentials should be used until they are about to expire")
+
+	now = now.Add(26 * time.Minute)
+	creds, err = a.credentials(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "key2", creds.AccessKeyID, "credentials should be renewed before they expire")
+	require.Equal(t, 2, requests)
+
+	require.NoError(t, os.Setenv("AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/other"))
+	a = &MongoDBAWSAuthenticator{now: func() time.Time { return now }}
+	_, err = a.credentials(context.Background())
+	require.Error(t, err)
+}
+
+func awsCommand(t *testing.T, wm wiremessage.WireMessage) bsonx.Doc {
+	var raw []byte
+	switch converted := wm.(type) {
+	case wiremessage.Query:
+		raw = converted.Query
+	case wiremessage.Msg:
+		raw = converted.Sections[0].(wiremessage.SectionBody).Document
+	}
+	doc, err := bsonx.ReadDoc(raw)
+	if err != nil {
+		t.Errorf("unable to read command: %v", err)
+	}
+	return doc
+}
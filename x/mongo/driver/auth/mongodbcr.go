@@ -27,19 +27,23 @@ const MONGODBCR = "MONGODB-CR"
 
 func newMongoDBCRAuthenticator(cred *Cred) (Authenticator, error) {
 	return &MongoDBCRAuthenticator{
-		DB:       cred.Source,
-		Username: cred.Username,
-		Password: cred.Password,
+		DB:               cred.Source,
+		Username:         cred.Username,
+		Password:         cred.Password,
+		PasswordProvider: cred.PasswordProvider,
 	}, nil
 }
 
 // MongoDBCRAuthenticator uses the MONGODB-CR algorithm to authenticate a connection.
 //
+// If PasswordProvider is set, it is called on every authentication and its result is used instead of Password.
+//
 // The MONGODB-CR authentication mechanism is deprecated in MongoDB 4.0.
 type MongoDBCRAuthenticator struct {
-	DB       string
-	Username string
-	Password string
+	DB               string
+	Username         string
+	Password         string
+	PasswordProvider PasswordProvider
 }
 
 // Auth authenticates the connection.
@@ -52,6 +56,12 @@ func (a *MongoDBCRAuthenticator) Auth(ctx context.Context, desc description.Serv
 		return nil
 	}
 
+	cred := &Cred{Password: a.Password, PasswordProvider: a.PasswordProvider}
+	password, err := cred.password(ctx)
+	if err != nil {
+		return err
+	}
+
 	db := a.DB
 	if db == "" {
 		db = defaultAuthDB
@@ -79,7 +89,7 @@ func (a *MongoDBCRAuthenticator) Auth(ctx context.Context, desc description.Serv
 			{"authenticate", bsonx.Int32(1)},
 			{"user", bsonx.String(a.Username)},
 			{"nonce", bsonx.String(getNonceResult.Nonce)},
-			{"key", bsonx.String(a.createKey(getNonceResult.Nonce))},
+			{"key", bsonx.String(a.createKey(getNonceResult.Nonce, password))},
 		},
 	}
 	_, err = cmd.RoundTrip(ctx, ssdesc, rw)
@@ -90,11 +100,11 @@ func (a *MongoDBCRAuthenticator) Auth(ctx context.Context, desc description.Serv
 	return nil
 }
 
-func (a *MongoDBCRAuthenticator) createKey(nonce string) string {
+func (a *MongoDBCRAuthenticator) createKey(nonce, password string) string {
 	h := md5.New()
 
 	_, _ = io.WriteString(h, nonce)
 	_, _ = io.WriteString(h, a.Username)
-	_, _ = io.WriteString(h, mongoPasswordDigest(a.Username, a.Password))
+	_, _ = io.WriteString(h, mongoPasswordDigest(a.Username, password))
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
@@ -0,0 +1,234 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
+	"github.com/mongodb/mongo-go-driver/x/network/connstring"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
+)
+
+// MongoDBOIDC is the mechanism name for MONGODB-OIDC.
+const MongoDBOIDC = "MONGODB-OIDC"
+
+const (
+	// oidcCallbackVersion is the version of the callback API passed to OIDC callbacks.
+	oidcCallbackVersion = 1
+
+	// oidcCallbackTimeout bounds how long an OIDC callback may run if the context has no earlier deadline.
+	oidcCallbackTimeout = time.Minute
+
+	// oidcRefreshWindow is how long before their expiration cached access tokens are replaced, so a connection
+	// is never authenticated with a token that expires during the conversation.
+	oidcRefreshWindow = 5 * time.Minute
+
+	// authenticationFailedCode is returned by servers when they reject the credentials a client authenticated with.
+	authenticationFailedCode = 18
+)
+
+// OIDCArgs are the arguments passed to an OIDCCallback.
+type OIDCArgs = connstring.OIDCArgs
+
+// OIDCCredential is an access token returned by an OIDCCallback.
+type OIDCCredential = connstring.OIDCCredential
+
+// OIDCCallback obtains an access token from an identity provider. Calls to the callback for a client are
+// serialized, but it may run on any goroutine and should honor the context's deadline.
+type OIDCCallback = connstring.OIDCCallback
+
+func newOIDCAuthenticator(cred *Cred) (Authenticator, error) {
+	if cred.Source != "" && cred.Source != "$external" {
+		return nil, newAuthError("MONGODB-OIDC source must be empty or $external", nil)
+	}
+	if cred.Password != "" {
+		return nil, newAuthError("MONGODB-OIDC does not support a password", nil)
+	}
+	if cred.OIDCMachineCallback == nil {
+		return nil, newAuthError("MONGODB-OIDC requires an OIDC machine callback", nil)
+	}
+
+	return &OIDCAuthenticator{
+		Username: cred.Username,
+		Callback: cred.OIDCMachineCallback,
+	}, nil
+}
+
+// OIDCAuthenticator uses access tokens from an OIDC identity provider over SASL to authenticate a connection.
+//
+// Tokens are obtained by calling Callback and are cached and shared by every connection of the client. A cached
+// token is replaced shortly before it expires, when the server rejects it, and when the server requires a
+// connection to be reauthenticated. Once a token is cached, new connections authenticate with it speculatively
+// in the connection handshake, saving a round trip.
+type OIDCAuthenticator struct {
+	Username string
+	Callback OIDCCallback
+
+	mu     sync.Mutex
+	cached *OIDCCredential
+	now    func() time.Time
+}
+
+// Auth authenticates the connection.
+func (a *OIDCAuthenticator) Auth(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter) error {
+	token, fromCache, err := a.accessToken(ctx, desc)
+	if err != nil {
+		return newError(err, MongoDBOIDC)
+	}
+
+	err = ConductSaslConversation(ctx, desc, rw, "$external", &oidcSaslClient{token: token})
+	if err == nil || !fromCache || !isAuthenticationFailure(err) {
+		return err
+	}
+
+	// The cached token may have been revoked before it expired, so fetch a new one and try once more.
+	a.invalidate(token)
+	if token, _, err = a.accessToken(ctx, desc); err != nil {
+		return newError(err, MongoDBOIDC)
+	}
+	return ConductSaslConversation(ctx, desc, rw, "$external", &oidcSaslClient{token: token})
+}
+
+// Reauth discards the cached token, which the server no longer accepts, and authenticates the connection with a
+// new one.
+func (a *OIDCAuthenticator) Reauth(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter) error {
+	a.mu.Lock()
+	a.cached = nil
+	a.mu.Unlock()
+
+	return a.Auth(ctx, desc, rw)
+}
+
+// SpeculativeCommand returns a saslStart command with the cached access token, or nil if no valid token is cached.
+// The callback is never called from the handshake.
+func (a *OIDCAuthenticator) SpeculativeCommand(ctx context.Context) (bsonx.Doc, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.validLocked() {
+		return nil, nil
+	}
+
+	payload, err := oidcPayload(a.cached.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	return bsonx.Doc{
+		{"saslStart", bsonx.Int32(1)},
+		{"mechanism", bsonx.String(MongoDBOIDC)},
+		{"payload", bsonx.Binary(0x00, payload)},
+		{"db", bsonx.String("$external")},
+	}, nil
+}
+
+// FinishSpeculative completes the conversation the server began with the reply to SpeculativeCommand. The
+// MONGODB-OIDC conversation has a single step, so the server must report it as done.
+func (a *OIDCAuthenticator) FinishSpeculative(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter,
+	reply bsonx.Doc) error {
+
+	if done, err := reply.LookupErr("done"); err == nil && done.Boolean() {
+		return nil
+	}
+	return newError(newAuthError("speculative authentication did not complete", nil), MongoDBOIDC)
+}
+
+// accessToken returns the cached access token, calling the callback for a new one if none is cached or the cached
+// token is about to expire. fromCache reports whether the token was already cached.
+func (a *OIDCAuthenticator) accessToken(ctx context.Context, desc description.Server) (token string, fromCache bool, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.validLocked() {
+		return a.cached.AccessToken, true, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, oidcCallbackTimeout)
+	defer cancel()
+
+	cred, err := a.Callback(ctx, &OIDCArgs{
+		Version:       oidcCallbackVersion,
+		Username:      a.Username,
+		ServerAddress: desc.Addr.String(),
+	})
+	if err != nil {
+		return "", false, newAuthError("error retrieving access token from OIDC callback", err)
+	}
+	if cred == nil || cred.AccessToken == "" {
+		return "", false, newAuthError("OIDC callback returned no access token", nil)
+	}
+
+	a.cached = cred
+	return cred.AccessToken, false, nil
+}
+
+// invalidate discards the cached access token if it is token, leaving a newer token fetched by another
+// connection in place.
+func (a *OIDCAuthenticator) invalidate(token string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.cached != nil && a.cached.AccessToken == token {
+		a.cached = nil
+	}
+}
+
+func (a *OIDCAuthenticator) validLocked() bool {
+	if a.cached == nil {
+		return false
+	}
+	if a.cached.ExpiresAt == nil {
+		return true
+	}
+	return a.timeNow().Add(oidcRefreshWindow).Before(*a.cached.ExpiresAt)
+}
+
+func (a *OIDCAuthenticator) timeNow() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+	return time.Now()
+}
+
+// isAuthenticationFailure reports whether err is the server rejecting the client's credentials.
+func isAuthenticationFailure(err error) bool {
+	for {
+		switch e := err.(type) {
+		case *Error:
+			err = e.inner
+		case command.Error:
+			return e.Code == authenticationFailedCode
+		default:
+			return false
+		}
+	}
+}
+
+func oidcPayload(token string) ([]byte, error) {
+	return bsonx.Doc{{"jwt", bsonx.String(token)}}.MarshalBSON()
+}
+
+type oidcSaslClient struct {
+	token string
+}
+
+func (c *oidcSaslClient) Start() (string, []byte, error) {
+	payload, err := oidcPayload(c.token)
+	return MongoDBOIDC, payload, err
+}
+
+func (c *oidcSaslClient) Next(challenge []byte) ([]byte, error) {
+	return nil, newAuthError("unexpected server challenge", nil)
+}
+
+func (c *oidcSaslClient) Completed() bool {
+	return true
+}
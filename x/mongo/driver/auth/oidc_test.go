@@ -0,0 +1,250 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/internal"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/address"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
+	"github.com/stretchr/testify/require"
+)
+
+var oidcDesc = description.Server{
+	Addr:        address.Address("localhost:27017"),
+	WireVersion: &description.VersionRange{Max: 6},
+}
+
+// oidcConn returns a connection that replies to each command with the next of replies.
+func oidcConn(t *testing.T, replies ...bsonx.Doc) *internal.ChannelConn {
+	resps := make(chan wiremessage.WireMessage, len(replies))
+	for _, reply := range replies {
+		resps <- internal.MakeReply(t, reply)
+	}
+	return &internal.ChannelConn{T: t, Written: make(chan wiremessage.WireMessage, len(replies)), ReadResp: resps}
+}
+
+func oidcDone() bsonx.Doc {
+	return bsonx.Doc{
+		{"ok", bsonx.Int32(1)},
+		{"conversationId", bsonx.Int32(1)},
+		{"payload", bsonx.Binary(0x00, []byte{})},
+		{"done", bsonx.Boolean(true)},
+	}
+}
+
+// oidcToken returns the access token sent by the saslStart command wm.
+func oidcToken(t *testing.T, wm wiremessage.WireMessage) string {
+	cmd := awsCommand(t, wm)
+	require.Equal(t, MongoDBOIDC, cmd.Lookup("mechanism").StringValue())
+	_, payload := cmd.Lookup("payload").Binary()
+	doc, err := bsonx.ReadDoc(payload)
+	require.NoError(t, err)
+	return doc.Lookup("jwt").StringValue()
+}
+
+// countingCallback returns a callback that returns token-1, token-2, ... expiring after lifetime, or without an
+// expiration if lifetime is zero.
+func countingCallback(calls *int, lifetime time.Duration, now time.Time) OIDCCallback {
+	return func(ctx context.Context, args *OIDCArgs) (*OIDCCredential, error) {
+		*calls++
+		cred := &OIDCCredential{AccessToken: fmt.Sprintf("token-%d", *calls)}
+		if lifetime != 0 {
+			expires := now.Add(lifetime)
+			cred.ExpiresAt = &expires
+		}
+		return cred, nil
+	}
+}
+
+func TestNewOIDCAuthenticator(t *testing.T) {
+	_, err := CreateAuthenticator(MongoDBOIDC, &Cred{Source: "$external"})
+	require.Error(t, err)
+
+	_, err = CreateAuthenticator(MongoDBOIDC, &Cred{Source: "admin", OIDCMachineCallback: countingCallback(new(int), 0, time.Now())})
+	require.Error(t, err)
+
+	_, err = CreateAuthenticator(MongoDBOIDC, &Cred{
+		Source:              "$external",
+		Password:            "pencil",
+		OIDCMachineCallback: countingCallback(new(int), 0, time.Now()),
+	})
+	require.Error(t, err)
+
+	a, err := CreateAuthenticator(MongoDBOIDC, &Cred{
+		Source:              "$external",
+		Username:            "principal",
+		OIDCMachineCallback: countingCallback(new(int), 0, time.Now()),
+	})
+	require.NoError(t, err)
+	require.IsType(t, &OIDCAuthenticator{}, a)
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("passes principal and server to the callback", func(t *testing.T) {
+		var got *OIDCArgs
+		a := &OIDCAuthenticator{
+			Username: "principal",
+			Callback: func(ctx context.Context, args *OIDCArgs) (*OIDCCredential, error) {
+				got = args
+				_, ok := ctx.Deadline()
+				require.True(t, ok)
+				return &OIDCCredential{AccessToken: "token"}, nil
+			},
+		}
+
+		c := oidcConn(t, oidcDone())
+		require.NoError(t, a.Auth(ctx, oidcDesc, c))
+		require.Equal(t, &OIDCArgs{Version: 1, Username: "principal", ServerAddress: "localhost:27017"}, got)
+		require.Equal(t, "token", oidcToken(t, <-c.Written))
+	})
+
+	t.Run("caches tokens", func(t *testing.T) {
+		var calls int
+		a := &OIDCAuthenticator{Callback: countingCallback(&calls, 0, time.Now())}
+
+		for i := 0; i < 3; i++ {
+			c := oidcConn(t, oidcDone())
+			require.NoError(t, a.Auth(ctx, oidcDesc, c))
+			require.Equal(t, "token-1", oidcToken(t, <-c.Written))
+		}
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("refreshes tokens before they expire", func(t *testing.T) {
+		var calls int
+		now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		a := &OIDCAuthenticator{Callback: countingCallback(&calls, time.Hour, now), now: func() time.Time { return now }}
+
+		c := oidcConn(t, oidcDone())
+		require.NoError(t, a.Auth(ctx, oidcDesc, c))
+		require.Equal(t, "token-1", oidcToken(t, <-c.Written))
+
+		now = now.Add(50 * time.Minute)
+		c = oidcConn(t, oidcDone())
+		require.NoError(t, a.Auth(ctx, oidcDesc, c))
+		require.Equal(t, "token-1", oidcToken(t, <-c.Written))
+
+		now = now.Add(6 * time.Minute)
+		c = oidcConn(t, oidcDone())
+		require.NoError(t, a.Auth(ctx, oidcDesc, c))
+		require.Equal(t, "token-2", oidcToken(t, <-c.Written))
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("retries once with a new token when a cached token is rejected", func(t *testing.T) {
+		var calls int
+		a := &OIDCAuthenticator{Callback: countingCallback(&calls, 0, time.Now())}
+		require.NoError(t, a.Auth(ctx, oidcDesc, oidcConn(t, oidcDone())))
+
+		rejected := bsonx.Doc{
+			{"ok", bsonx.Int32(0)},
+			{"code", bsonx.Int32(authenticationFailedCode)},
+			{"errmsg", bsonx.String("Authentication failed.")},
+		}
+		c := oidcConn(t, rejected, oidcDone())
+		require.NoError(t, a.Auth(ctx, oidcDesc, c))
+		require.Equal(t, "token-1", oidcToken(t, <-c.Written))
+		require.Equal(t, "token-2", oidcToken(t, <-c.Written))
+		require.Equal(t, 2, calls)
+
+		// a fresh token that is rejected is not retried
+		a = &OIDCAuthenticator{Callback: countingCallback(&calls, 0, time.Now())}
+		c = oidcConn(t, rejected)
+		err := a.Auth(ctx, oidcDesc, c)
+		require.Error(t, err)
+		require.Len(t, c.Written, 1)
+	})
+
+	t.Run("reauthentication replaces the cached token", func(t *testing.T) {
+		var calls int
+		a := &OIDCAuthenticator{Callback: countingCallback(&calls, 0, time.Now())}
+		require.NoError(t, a.Auth(ctx, oidcDesc, oidcConn(t, oidcDone())))
+
+		c := oidcConn(t, oidcDone())
+		require.NoError(t, a.Reauth(ctx, oidcDesc, c))
+		require.Equal(t, "token-2", oidcToken(t, <-c.Written))
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("callback errors", func(t *testing.T) {
+		a := &OIDCAuthenticator{Callback: func(context.Context, *OIDCArgs) (*OIDCCredential, error) {
+			return nil, errors.New("identity provider unavailable")
+		}}
+
+		c := oidcConn(t)
+		err := a.Auth(ctx, oidcDesc, c)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "identity provider unavailable")
+		require.Len(t, c.Written, 0)
+
+		a.Callback = func(context.Context, *OIDCArgs) (*OIDCCredential, error) { return &OIDCCredential{}, nil }
+		require.Error(t, a.Auth(ctx, oidcDesc, c))
+	})
+}
+
+func TestOIDCAuthenticator_Speculative(t *testing.T) {
+	ctx := context.Background()
+	isMaster := func(speculative bsonx.Doc) bsonx.Doc {
+		doc := bsonx.Doc{
+			{"ok", bsonx.Int32(1)},
+			{"ismaster", bsonx.Boolean(true)},
+			{"maxWireVersion", bsonx.Int32(6)},
+		}
+		if speculative != nil {
+			doc = append(doc, bsonx.Elem{"speculativeAuthenticate", bsonx.Document(speculative)})
+		}
+		return doc
+	}
+
+	var calls int
+	a := &OIDCAuthenticator{Callback: countingCallback(&calls, 0, time.Now())}
+	handshaker := Handshaker(nil, &HandshakeOptions{Authenticator: a})
+
+	cmd, err := a.SpeculativeCommand(ctx)
+	require.NoError(t, err)
+	require.Nil(t, cmd)
+
+	// Without a cached token the handshake authenticates after isMaster.
+	c := oidcConn(t, isMaster(nil), oidcDone())
+	_, err = handshaker.Handshake(ctx, oidcDesc.Addr, c)
+	require.NoError(t, err)
+	_, err = awsCommand(t, <-c.Written).LookupErr("speculativeAuthenticate")
+	require.Error(t, err)
+	require.Equal(t, "token-1", oidcToken(t, <-c.Written))
+
+	// With a cached token it is sent with isMaster and no further commands are needed.
+	c = oidcConn(t, isMaster(bsonx.Doc{{"conversationId", bsonx.Int32(1)}, {"done", bsonx.Boolean(true)}}))
+	_, err = handshaker.Handshake(ctx, oidcDesc.Addr, c)
+	require.NoError(t, err)
+	require.Len(t, c.Written, 1)
+	speculative := awsCommand(t, <-c.Written).Lookup("speculativeAuthenticate").Document()
+	require.Equal(t, "$external", speculative.Lookup("db").StringValue())
+	require.Equal(t, "token-1", oidcToken(t, wiremessage.Query{Query: mustMarshal(t, speculative)}))
+
+	// Servers that do not support speculative authentication omit the reply.
+	c = oidcConn(t, isMaster(nil), oidcDone())
+	_, err = handshaker.Handshake(ctx, oidcDesc.Addr, c)
+	require.NoError(t, err)
+	require.Len(t, c.Written, 2)
+	require.Equal(t, 1, calls)
+}
+
+func mustMarshal(t *testing.T, doc bsonx.Doc) []byte {
+	b, err := doc.MarshalBSON()
+	require.NoError(t, err)
+	return b
+}
@@ -18,22 +18,32 @@ const PLAIN = "PLAIN"
 
 func newPlainAuthenticator(cred *Cred) (Authenticator, error) {
 	return &PlainAuthenticator{
-		Username: cred.Username,
-		Password: cred.Password,
+		Username:         cred.Username,
+		Password:         cred.Password,
+		PasswordProvider: cred.PasswordProvider,
 	}, nil
 }
 
 // PlainAuthenticator uses the PLAIN algorithm over SASL to authenticate a connection.
+//
+// If PasswordProvider is set, it is called on every authentication and its result is used instead of Password.
 type PlainAuthenticator struct {
-	Username string
-	Password string
+	Username         string
+	Password         string
+	PasswordProvider PasswordProvider
 }
 
 // Auth authenticates the connection.
 func (a *PlainAuthenticator) Auth(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter) error {
+	cred := &Cred{Password: a.Password, PasswordProvider: a.PasswordProvider}
+	password, err := cred.password(ctx)
+	if err != nil {
+		return err
+	}
+
 	return ConductSaslConversation(ctx, desc, rw, "$external", &plainSaslClient{
 		username: a.Username,
-		password: a.Password,
+		password: password,
 	})
 }
 
@@ -132,3 +132,47 @@ func TestPlainAuthenticator_Succeeds(t *testing.T) {
 	}
 	compareResponses(t, <-c.Written, expectedCmd, "$external")
 }
+
+func TestPlainAuthenticator_PasswordProvider(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	authenticator := PlainAuthenticator{
+		Username: "user",
+		Password: "ignored",
+		PasswordProvider: func(context.Context) (string, error) {
+			calls++
+			return "pencil", nil
+		},
+	}
+
+	resps := make(chan wiremessage.WireMessage, 1)
+	resps <- internal.MakeReply(t, bsonx.Doc{
+		{"ok", bsonx.Int32(1)},
+		{"conversationId", bsonx.Int32(1)},
+		{"payload", bsonx.Binary(0x00, []byte{})},
+		{"done", bsonx.Boolean(true)}},
+	)
+
+	c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 1), ReadResp: resps}
+
+	err := authenticator.Auth(context.Background(), description.Server{
+		WireVersion: &description.VersionRange{
+			Max: 6,
+		},
+	}, c)
+	if err != nil {
+		t.Fatalf("expected no error but got \"%s\"", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the password provider to be called once but it was called %d times", calls)
+	}
+
+	payload, _ := base64.StdEncoding.DecodeString("AHVzZXIAcGVuY2ls")
+	expectedCmd := bsonx.Doc{
+		{"saslStart", bsonx.Int32(1)},
+		{"mechanism", bsonx.String("PLAIN")},
+		{"payload", bsonx.Binary(0x00, payload)},
+	}
+	compareResponses(t, <-c.Written, expectedCmd, "$external")
+}
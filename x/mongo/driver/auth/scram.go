@@ -29,46 +29,78 @@ const SCRAMSHA1 = "SCRAM-SHA-1"
 const SCRAMSHA256 = "SCRAM-SHA-256"
 
 func newScramSHA1Authenticator(cred *Cred) (Authenticator, error) {
-	passdigest := mongoPasswordDigest(cred.Username, cred.Password)
-	client, err := scram.SHA1.NewClientUnprepped(cred.Username, passdigest, "")
-	if err != nil {
-		return nil, newAuthError("error initializing SCRAM-SHA-1 client", err)
-	}
-	client.WithMinIterations(4096)
-	return &ScramAuthenticator{
-		mechanism: SCRAMSHA1,
-		source:    cred.Source,
-		client:    client,
-	}, nil
+	return newScramAuthenticator(SCRAMSHA1, cred)
 }
 
 func newScramSHA256Authenticator(cred *Cred) (Authenticator, error) {
-	passprep, err := stringprep.SASLprep.Prepare(cred.Password)
-	if err != nil {
-		return nil, newAuthError(fmt.Sprintf("error SASLprepping password '%s'", cred.Password), err)
+	return newScramAuthenticator(SCRAMSHA256, cred)
+}
+
+func newScramAuthenticator(mechanism string, cred *Cred) (Authenticator, error) {
+	a := &ScramAuthenticator{
+		mechanism: mechanism,
+		source:    cred.Source,
+		cred:      cred,
+	}
+
+	// Without a provider the password never changes, so the client is built once and errors are reported
+	// when the authenticator is created rather than on every connection.
+	if cred.PasswordProvider == nil {
+		client, err := newScramClient(mechanism, cred.Username, cred.Password)
+		if err != nil {
+			return nil, err
+		}
+		a.client = client
+	}
+	return a, nil
+}
+
+func newScramClient(mechanism, username, password string) (*scram.Client, error) {
+	var client *scram.Client
+	var err error
+
+	switch mechanism {
+	case SCRAMSHA256:
+		passprep, perr := stringprep.SASLprep.Prepare(password)
+		if perr != nil {
+			return nil, newAuthError(fmt.Sprintf("error SASLprepping password '%s'", password), perr)
+		}
+		client, err = scram.SHA256.NewClientUnprepped(username, passprep, "")
+	default:
+		passdigest := mongoPasswordDigest(username, password)
+		client, err = scram.SHA1.NewClientUnprepped(username, passdigest, "")
 	}
-	client, err := scram.SHA256.NewClientUnprepped(cred.Username, passprep, "")
 	if err != nil {
-		return nil, newAuthError("error initializing SCRAM-SHA-256 client", err)
+		return nil, newAuthError(fmt.Sprintf("error initializing %s client", mechanism), err)
 	}
 	client.WithMinIterations(4096)
-	return &ScramAuthenticator{
-		mechanism: SCRAMSHA256,
-		source:    cred.Source,
-		client:    client,
-	}, nil
+	return client, nil
 }
 
 // ScramAuthenticator uses the SCRAM algorithm over SASL to authenticate a connection.
 type ScramAuthenticator struct {
 	mechanism string
 	source    string
+	cred      *Cred
 	client    *scram.Client
 }
 
-// Auth authenticates the connection.
+// Auth authenticates the connection. If the authenticator was created with a PasswordProvider, the provider is
+// called for every authentication so a rotated password takes effect on the next new connection.
 func (a *ScramAuthenticator) Auth(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter) error {
-	adapter := &scramSaslAdapter{conversation: a.client.NewConversation(), mechanism: a.mechanism}
+	client := a.client
+	if client == nil {
+		password, err := a.cred.password(ctx)
+		if err != nil {
+			return err
+		}
+		client, err = newScramClient(a.mechanism, a.cred.Username, password)
+		if err != nil {
+			return err
+		}
+	}
+
+	adapter := &scramSaslAdapter{conversation: client.NewConversation(), mechanism: a.mechanism}
 	err := ConductSaslConversation(ctx, desc, rw, a.source, adapter)
 	if err != nil {
 		return newAuthError("sasl conversation error", err)
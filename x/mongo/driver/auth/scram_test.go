@@ -0,0 +1,90 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/internal"
+	. "github.com/mongodb/mongo-go-driver/x/mongo/driver/auth"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
+)
+
+func TestScramAuthenticator_PasswordProvider(t *testing.T) {
+	t.Parallel()
+
+	desc := description.Server{
+		WireVersion: &description.VersionRange{
+			Max: 7,
+		},
+	}
+
+	for _, mechanism := range []string{SCRAMSHA1, SCRAMSHA256} {
+		mechanism := mechanism
+
+		t.Run(mechanism+" calls the provider on every authentication", func(t *testing.T) {
+			var calls int
+			authenticator, err := CreateAuthenticator(mechanism, &Cred{
+				Source:   "admin",
+				Username: "user",
+				PasswordProvider: func(context.Context) (string, error) {
+					calls++
+					return "pencil", nil
+				},
+			})
+			if err != nil {
+				t.Fatalf("expected no error but got \"%s\"", err)
+			}
+
+			for i := 1; i <= 2; i++ {
+				readErr := make(chan error, 1)
+				readErr <- errors.New("connection closed")
+				c := &internal.ChannelConn{T: t, Written: make(chan wiremessage.WireMessage, 1), ReadErr: readErr}
+
+				err = authenticator.Auth(context.Background(), desc, c)
+				if err == nil {
+					t.Fatalf("expected an error but got none")
+				}
+				if calls != i {
+					t.Fatalf("expected the password provider to be called %d times but it was called %d times", i, calls)
+				}
+				if len(c.Written) != 1 {
+					t.Fatalf("expected 1 messages to be sent but had %d", len(c.Written))
+				}
+			}
+		})
+
+		t.Run(mechanism+" returns provider errors", func(t *testing.T) {
+			authenticator, err := CreateAuthenticator(mechanism, &Cred{
+				Source:   "admin",
+				Username: "user",
+				PasswordProvider: func(context.Context) (string, error) {
+					return "", errors.New("vault is sealed")
+				},
+			})
+			if err != nil {
+				t.Fatalf("expected no error but got \"%s\"", err)
+			}
+
+			c := &internal.ChannelConn{T: t, Written: make(chan wiremessage.WireMessage, 1)}
+			err = authenticator.Auth(context.Background(), desc, c)
+			if err == nil {
+				t.Fatalf("expected an error but got none")
+			}
+			if !strings.Contains(err.Error(), "vault is sealed") {
+				t.Fatalf("expected an err containing \"vault is sealed\" but got \"%s\"", err)
+			}
+			if len(c.Written) != 0 {
+				t.Fatalf("expected no messages to be sent but had %d", len(c.Written))
+			}
+		})
+	}
+}
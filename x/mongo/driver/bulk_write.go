@@ -8,6 +8,7 @@ package driver
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
 	"github.com/mongodb/mongo-go-driver/mongo/options"
@@ -40,6 +41,7 @@ func (BulkWriteException) Error() string {
 type bulkWriteBatch struct {
 	models   []WriteModel
 	canRetry bool
+	indexes  []int // the index of each model in the models passed to BulkWrite
 }
 
 // BulkWrite handles the full dispatch cycle for a bulk write operation.
@@ -90,7 +92,6 @@ func BulkWrite(
 		WriteErrors: make([]BulkWriteError, 0),
 	}
 
-	var opIndex int64 // the operation index for the upsertedIDs map
 	continueOnError := !ordered
 	for _, batch := range batches {
 		if len(batch.models) == 0 {
@@ -100,10 +101,9 @@ func BulkWrite(
 		batchRes, batchErr, err := runBatch(ctx, ns, topo, selector, ss, sess, clock, writeConcern, retryWrite,
 			bwOpts.BypassDocumentValidation, continueOnError, batch, registry)
 
-		mergeResults(&bwRes, batchRes, opIndex)
-		bwErr.WriteConcernError = batchErr.WriteConcernError
-		for i := range batchErr.WriteErrors {
-			batchErr.WriteErrors[i].Index = batchErr.WriteErrors[i].Index + int(opIndex)
+		mergeResults(&bwRes, batchRes)
+		if batchErr.WriteConcernError != nil {
+			bwErr.WriteConcernError = batchErr.WriteConcernError
 		}
 		bwErr.WriteErrors = append(bwErr.WriteErrors, batchErr.WriteErrors...)
 
@@ -112,13 +112,15 @@ func BulkWrite(
 				return result.BulkWrite{}, err
 			}
 
-			return result.BulkWrite{}, bwErr
+			bwRes.MatchedCount -= bwRes.UpsertedCount
+			return bwRes, bwErr
 		}
-
-		opIndex += int64(len(batch.models))
 	}
 
 	bwRes.MatchedCount -= bwRes.UpsertedCount
+	if len(bwErr.WriteErrors) > 0 || bwErr.WriteConcernError != nil {
+		return bwRes, bwErr
+	}
 	return bwRes, nil
 }
 
@@ -173,19 +175,38 @@ func runBatch(
 		batchRes.UpsertedCount = int64(len(res.Upserted))
 		writeErrors = res.WriteErrors
 		for _, upsert := range res.Upserted {
-			batchRes.UpsertedIDs[upsert.Index] = upsert.ID
+			batchRes.UpsertedIDs[int64(batch.index(int(upsert.Index)))] = upsert.ID
 		}
 	}
 
-	batchErr.WriteErrors = make([]BulkWriteError, 0, len(writeErrors))
+	batchErr.WriteErrors = batch.writeErrors(writeErrors)
+	return batchRes, batchErr, nil
+}
+
+// index maps the index of a model within the batch, which is how the server reports it, to its
+// index in the models passed to BulkWrite. The two differ for unordered bulk writes, where the
+// models in a batch need not have been contiguous.
+func (b bulkWriteBatch) index(i int) int {
+	if i < 0 || i >= len(b.indexes) {
+		return i
+	}
+	return b.indexes[i]
+}
+
+func (b bulkWriteBatch) writeErrors(writeErrors []result.WriteError) []BulkWriteError {
+	bwErrors := make([]BulkWriteError, 0, len(writeErrors))
 	for _, we := range writeErrors {
-		batchErr.WriteErrors = append(batchErr.WriteErrors, BulkWriteError{
+		model := b.models[0]
+		if we.Index >= 0 && we.Index < len(b.models) {
+			model = b.models[we.Index]
+		}
+		we.Index = b.index(we.Index)
+		bwErrors = append(bwErrors, BulkWriteError{
 			WriteError: we,
-			Model:      batch.models[0],
+			Model:      model,
 		})
 	}
-
-	return batchRes, batchErr, nil
+	return bwErrors
 }
 
 func runInsert(
@@ -246,6 +267,7 @@ func runInsert(
 			return res, origErr
 		}
 
+		PublishRetryWrite(ctx, topo, "insert", retryWriteAttempt, origErr, res.WriteConcernError)
 		return insert(ctx, cmd, newServer, origErr)
 	}
 
@@ -266,25 +288,9 @@ func runDelete(
 	continueOnError bool,
 	registry *bsoncodec.Registry,
 ) (result.Delete, error) {
-	docs := make([]bsonx.Doc, len(batch.models))
-	var i int
-
-	for _, model := range batch.models {
-		var doc bsonx.Doc
-		var err error
-
-		if dom, ok := model.(DeleteOneModel); ok {
-			doc, err = createDeleteDoc(dom.Filter, dom.Collation, false, registry)
-		} else if dmm, ok := model.(DeleteManyModel); ok {
-			doc, err = createDeleteDoc(dmm.Filter, dmm.Collation, true, registry)
-		}
-
-		if err != nil {
-			return result.Delete{}, err
-		}
-
-		docs[i] = doc
-		i++
+	docs, err := createDeleteDocs(batch.models, registry)
+	if err != nil {
+		return result.Delete{}, err
 	}
 
 	cmd := command.Delete{
@@ -313,6 +319,7 @@ func runDelete(
 			return res, origErr
 		}
 
+		PublishRetryWrite(ctx, topo, "delete", retryWriteAttempt, origErr, res.WriteConcernError)
 		return delete(ctx, cmd, newServer, origErr)
 	}
 
@@ -334,28 +341,9 @@ func runUpdate(
 	continueOnError bool,
 	registry *bsoncodec.Registry,
 ) (result.Update, error) {
-	docs := make([]bsonx.Doc, len(batch.models))
-
-	for i, model := range batch.models {
-		var doc bsonx.Doc
-		var err error
-
-		if rom, ok := model.(ReplaceOneModel); ok {
-			doc, err = createUpdateDoc(rom.Filter, rom.Replacement, options.ArrayFilters{}, false, rom.UpdateModel, false,
-				registry)
-		} else if uom, ok := model.(UpdateOneModel); ok {
-			doc, err = createUpdateDoc(uom.Filter, uom.Update, uom.ArrayFilters, uom.ArrayFiltersSet, uom.UpdateModel, false,
-				registry)
-		} else if umm, ok := model.(UpdateManyModel); ok {
-			doc, err = createUpdateDoc(umm.Filter, umm.Update, umm.ArrayFilters, umm.ArrayFiltersSet, umm.UpdateModel, true,
-				registry)
-		}
-
-		if err != nil {
-			return result.Update{}, err
-		}
-
-		docs[i] = doc
+	docs, err := createUpdateDocs(batch.models, registry)
+	if err != nil {
+		return result.Update{}, err
 	}
 
 	cmd := command.Update{
@@ -389,6 +377,7 @@ func runUpdate(
 			return res, origErr
 		}
 
+		PublishRetryWrite(ctx, topo, "update", retryWriteAttempt, origErr, res.WriteConcernError)
 		return update(ctx, cmd, newServer, origErr)
 	}
 
@@ -447,7 +436,7 @@ func createBatches(models []WriteModel, ordered bool) []bulkWriteBatch {
 	updateInd := -1
 	deleteInd := -1
 
-	for _, model := range models {
+	for idx, model := range models {
 		switch converted := model.(type) {
 		case InsertOneModel:
 			if insertInd == -1 {
@@ -457,6 +446,7 @@ func createBatches(models []WriteModel, ordered bool) []bulkWriteBatch {
 			}
 
 			batches[insertInd].models = append(batches[insertInd].models, model)
+			batches[insertInd].indexes = append(batches[insertInd].indexes, idx)
 		case DeleteOneModel, DeleteManyModel:
 			if deleteInd == -1 {
 				deleteInd = numBatches
@@ -464,6 +454,7 @@ func createBatches(models []WriteModel, ordered bool) []bulkWriteBatch {
 			}
 
 			batches[deleteInd].models = append(batches[deleteInd].models, model)
+			batches[deleteInd].indexes = append(batches[deleteInd].indexes, idx)
 			if _, ok := converted.(DeleteManyModel); ok {
 				batches[deleteInd].canRetry = false
 			}
@@ -474,6 +465,7 @@ func createBatches(models []WriteModel, ordered bool) []bulkWriteBatch {
 			}
 
 			batches[updateInd].models = append(batches[updateInd].models, model)
+			batches[updateInd].indexes = append(batches[updateInd].indexes, idx)
 			if _, ok := converted.(UpdateManyModel); ok {
 				batches[updateInd].canRetry = false
 			}
@@ -488,7 +480,7 @@ func createOrderedBatches(models []WriteModel) []bulkWriteBatch {
 	var prevKind command.WriteCommandKind = -1
 	i := -1 // batch index
 
-	for _, model := range models {
+	for idx, model := range models {
 		var createNewBatch bool
 		var canRetry bool
 		var newKind command.WriteCommandKind
@@ -518,10 +510,12 @@ func createOrderedBatches(models []WriteModel) []bulkWriteBatch {
 			batches = append(batches, bulkWriteBatch{
 				models:   []WriteModel{model},
 				canRetry: canRetry,
+				indexes:  []int{idx},
 			})
 			i++
 		} else {
 			batches[i].models = append(batches[i].models, model)
+			batches[i].indexes = append(batches[i].indexes, idx)
 			if !canRetry {
 				batches[i].canRetry = false // don't make it true if it was already false
 			}
@@ -542,6 +536,67 @@ func shouldRetry(cmdErr error, wcErr *result.WriteConcernError) bool {
 	return false
 }
 
+// createUpdateDocs returns the entries of the updates array for a batch of update and replace models. Each
+// model's collation, upsert and array filters are encoded in its own entry, so models in one batch can differ.
+func createUpdateDocs(models []WriteModel, registry *bsoncodec.Registry) ([]bsonx.Doc, error) {
+	docs := make([]bsonx.Doc, len(models))
+
+	for i, model := range models {
+		var doc bsonx.Doc
+		var err error
+
+		switch converted := model.(type) {
+		case ReplaceOneModel:
+			doc, err = createUpdateDoc(converted.Filter, converted.Replacement, options.ArrayFilters{}, false,
+				converted.UpdateModel, false, registry)
+		case UpdateOneModel:
+			doc, err = createUpdateDoc(converted.Filter, converted.Update, converted.ArrayFilters,
+				converted.ArrayFiltersSet, converted.UpdateModel, false, registry)
+		case UpdateManyModel:
+			doc, err = createUpdateDoc(converted.Filter, converted.Update, converted.ArrayFilters,
+				converted.ArrayFiltersSet, converted.UpdateModel, true, registry)
+		default:
+			return nil, fmt.Errorf("%T is not an update or replace model", model)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		docs[i] = doc
+	}
+
+	return docs, nil
+}
+
+// createDeleteDocs returns the entries of the deletes array for a batch of delete models, each with its own
+// collation.
+func createDeleteDocs(models []WriteModel, registry *bsoncodec.Registry) ([]bsonx.Doc, error) {
+	docs := make([]bsonx.Doc, len(models))
+
+	for i, model := range models {
+		var doc bsonx.Doc
+		var err error
+
+		switch converted := model.(type) {
+		case DeleteOneModel:
+			doc, err = createDeleteDoc(converted.Filter, converted.Collation, false, registry)
+		case DeleteManyModel:
+			doc, err = createDeleteDoc(converted.Filter, converted.Collation, true, registry)
+		default:
+			return nil, fmt.Errorf("%T is not a delete model", model)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		docs[i] = doc
+	}
+
+	return docs, nil
+}
+
 func createUpdateDoc(
 	filter interface{},
 	update interface{},
@@ -614,7 +669,7 @@ func createDeleteDoc(
 	return doc, nil
 }
 
-func mergeResults(aggResult *result.BulkWrite, newResult result.BulkWrite, opIndex int64) {
+func mergeResults(aggResult *result.BulkWrite, newResult result.BulkWrite) {
 	aggResult.InsertedCount += newResult.InsertedCount
 	aggResult.MatchedCount += newResult.MatchedCount
 	aggResult.ModifiedCount += newResult.ModifiedCount
@@ -622,6 +677,6 @@ func mergeResults(aggResult *result.BulkWrite, newResult result.BulkWrite, opInd
 	aggResult.UpsertedCount += newResult.UpsertedCount
 
 	for index, upsertID := range newResult.UpsertedIDs {
-		aggResult.UpsertedIDs[index+opIndex] = upsertID
+		aggResult.UpsertedIDs[index] = upsertID
 	}
 }
@@ -9,6 +9,10 @@ package driver
 import (
 	"testing"
 
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/result"
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,18 +31,18 @@ func TestBulkWrite(t *testing.T) {
 		}
 
 		expectedOrdered := []bulkWriteBatch{
-			{[]WriteModel{InsertOneModel{}, InsertOneModel{}}, true},
-			{[]WriteModel{UpdateOneModel{}, UpdateManyModel{}}, false},
-			{[]WriteModel{DeleteOneModel{}, DeleteManyModel{}}, false},
-			{[]WriteModel{InsertOneModel{}}, true},
-			{[]WriteModel{UpdateManyModel{}}, false},
-			{[]WriteModel{DeleteOneModel{}}, true},
+			{[]WriteModel{InsertOneModel{}, InsertOneModel{}}, true, []int{0, 1}},
+			{[]WriteModel{UpdateOneModel{}, UpdateManyModel{}}, false, []int{2, 3}},
+			{[]WriteModel{DeleteOneModel{}, DeleteManyModel{}}, false, []int{4, 5}},
+			{[]WriteModel{InsertOneModel{}}, true, []int{6}},
+			{[]WriteModel{UpdateManyModel{}}, false, []int{7}},
+			{[]WriteModel{DeleteOneModel{}}, true, []int{8}},
 		}
 
 		expectedUnordered := []bulkWriteBatch{
-			{[]WriteModel{InsertOneModel{}, InsertOneModel{}, InsertOneModel{}}, true},
-			{[]WriteModel{UpdateOneModel{}, UpdateManyModel{}, UpdateManyModel{}}, false},
-			{[]WriteModel{DeleteOneModel{}, DeleteManyModel{}, DeleteOneModel{}}, false},
+			{[]WriteModel{InsertOneModel{}, InsertOneModel{}, InsertOneModel{}}, true, []int{0, 1, 6}},
+			{[]WriteModel{UpdateOneModel{}, UpdateManyModel{}, UpdateManyModel{}}, false, []int{2, 3, 7}},
+			{[]WriteModel{DeleteOneModel{}, DeleteManyModel{}, DeleteOneModel{}}, false, []int{4, 5, 8}},
 		}
 
 		testCases := []struct {
@@ -63,4 +67,65 @@ func TestBulkWrite(t *testing.T) {
 			})
 		}
 	})
+	t.Run("TestBatchWriteErrors", func(t *testing.T) {
+		models := []WriteModel{
+			InsertOneModel{Document: 0},
+			DeleteOneModel{},
+			InsertOneModel{Document: 2},
+			UpdateOneModel{},
+			InsertOneModel{Document: 4},
+		}
+
+		batches := createBatches(models, false)
+		require.Equal(t, []int{0, 2, 4}, batches[0].indexes)
+
+		got := batches[0].writeErrors([]result.WriteError{{Index: 0, Code: 11000}, {Index: 2, Code: 11000}})
+		require.Len(t, got, 2)
+		require.Equal(t, 0, got[0].Index)
+		require.Equal(t, models[0], got[0].Model)
+		require.Equal(t, 4, got[1].Index)
+		require.Equal(t, models[4], got[1].Model)
+		require.Equal(t, 2, batches[0].index(1))
+	})
+	t.Run("TestPerModelCollation", func(t *testing.T) {
+		caseInsensitive := &options.Collation{Locale: "en", Strength: 2}
+		french := &options.Collation{Locale: "fr"}
+		filter := bsonx.Doc{{"name", bsonx.String("alice")}}
+		update := bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"seen", bsonx.Boolean(true)}})}}
+
+		collation := func(doc bsonx.Doc) bsonx.Doc {
+			val, err := doc.LookupErr("collation")
+			if err != nil {
+				return nil
+			}
+			return val.Document()
+		}
+
+		updates, err := createUpdateDocs([]WriteModel{
+			UpdateOneModel{Filter: filter, Update: update, UpdateModel: UpdateModel{Collation: caseInsensitive}},
+			UpdateManyModel{Filter: filter, Update: update},
+			ReplaceOneModel{Filter: filter, Replacement: filter, UpdateModel: UpdateModel{Collation: french}},
+			UpdateManyModel{Filter: filter, Update: update, UpdateModel: UpdateModel{Collation: caseInsensitive}},
+		}, bson.DefaultRegistry)
+		require.NoError(t, err)
+		require.Len(t, updates, 4)
+		require.Equal(t, caseInsensitive.ToDocument(), collation(updates[0]))
+		require.Nil(t, collation(updates[1]))
+		require.Equal(t, french.ToDocument(), collation(updates[2]))
+		require.Equal(t, caseInsensitive.ToDocument(), collation(updates[3]))
+
+		deletes, err := createDeleteDocs([]WriteModel{
+			DeleteManyModel{Filter: filter},
+			DeleteOneModel{Filter: filter, Collation: caseInsensitive},
+			DeleteManyModel{Filter: filter, Collation: french},
+		}, bson.DefaultRegistry)
+		require.NoError(t, err)
+		require.Len(t, deletes, 3)
+		require.Nil(t, collation(deletes[0]))
+		require.Equal(t, caseInsensitive.ToDocument(), collation(deletes[1]))
+		require.Equal(t, french.ToDocument(), collation(deletes[2]))
+
+		_, err = createDeleteDocs([]WriteModel{UpdateOneModel{Filter: filter, Update: update}}, bson.DefaultRegistry)
+		require.Error(t, err)
+	})
 }
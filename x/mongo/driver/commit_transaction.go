@@ -27,6 +27,7 @@ func CommitTransaction(
 	if cerr, ok := err.(command.Error); ok && err != nil {
 		// Retry if appropriate
 		if cerr.Retryable() {
+			cmd.Session.UpdateCommitTransactionWriteConcern()
 			res, err = commitTransaction(ctx, cmd, topo, selector, cerr)
 			if cerr2, ok := err.(command.Error); ok && err != nil {
 				// Retry failures also get label
@@ -63,6 +64,10 @@ func commitTransaction(
 		// Conditions for retry write support are the same as that of sessions
 		return result.TransactionResult{}, oldErr
 	}
+	if oldErr != nil {
+		PublishRetryWrite(ctx, topo, "commitTransaction", cmd.Session.CommitAttempts+1, oldErr, nil)
+	}
+	cmd.Session.CommitAttempts++
 
 	conn, err := ss.Connection(ctx)
 	if err != nil {
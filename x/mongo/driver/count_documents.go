@@ -75,6 +75,13 @@ func CountDocuments(
 		}
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"collation", bsonx.Document(countOpts.Collation.ToDocument())})
 	}
+	if countOpts.Comment != nil {
+		commentElem, err := commentToElement("comment", countOpts.Comment, desc.WireVersion, registry)
+		if err != nil {
+			return 0, err
+		}
+		cmd.Opts = append(cmd.Opts, commentElem)
+	}
 	if countOpts.Hint != nil {
 		hintElem, err := interfaceToElement("hint", countOpts.Hint, registry)
 		if err != nil {
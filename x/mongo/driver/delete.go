@@ -55,6 +55,13 @@ func Delete(
 		}
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"collation", bsonx.Document(deleteOpts.Collation.ToDocument())})
 	}
+	if deleteOpts.Comment != nil {
+		commentElem, err := commentToElement("comment", deleteOpts.Comment, ss.Description().WireVersion, nil)
+		if err != nil {
+			return result.Delete{}, err
+		}
+		cmd.Opts = append(cmd.Opts, commentElem)
+	}
 
 	// Execute in a single trip if retry writes not supported, or retry not enabled
 	if !retrySupported(topo, ss.Description(), cmd.Session, cmd.WriteConcern) || !retryWrite {
@@ -79,6 +86,7 @@ func Delete(
 			return res, originalErr
 		}
 
+		PublishRetryWrite(ctx, topo, "delete", retryWriteAttempt, originalErr, res.WriteConcernError)
 		return delete(ctx, cmd, ss, cerr)
 	}
 	return res, originalErr
@@ -13,6 +13,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/session"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
 )
 
 // ErrCollation is caused if a collation is given for an invalid server version.
@@ -21,6 +22,12 @@ var ErrCollation = errors.New("collation cannot be set for server versions < 3.4
 // ErrArrayFilters is caused if array filters are given for an invalid server version.
 var ErrArrayFilters = errors.New("array filters cannot be set for server versions < 3.6")
 
+// ErrNonStringComment is caused if a comment that is not a string is given for an invalid server version.
+var ErrNonStringComment = errors.New("comments other than strings cannot be set for server versions < 4.4")
+
+// minNonStringCommentWireVersion is the minimum wire version that accepts comments of any BSON type.
+const minNonStringCommentWireVersion = 9
+
 func interfaceToDocument(val interface{}, registry *bsoncodec.Registry) (bsonx.Doc, error) {
 	if val == nil {
 		return bsonx.Doc{}, nil
@@ -60,6 +67,33 @@ func interfaceToElement(key string, i interface{}, registry *bsoncodec.Registry)
 	}
 }
 
+// commentToElement converts a comment option into an element with the given key. Strings are accepted by every
+// server version; other values are marshaled with the registry and require server version 4.4 or later.
+func commentToElement(key string, comment interface{}, wireVersion *description.VersionRange,
+	registry *bsoncodec.Registry) (bsonx.Elem, error) {
+
+	if str, ok := comment.(string); ok {
+		return bsonx.Elem{key, bsonx.String(str)}, nil
+	}
+	if wireVersion == nil || wireVersion.Max < minNonStringCommentWireVersion {
+		return bsonx.Elem{}, ErrNonStringComment
+	}
+
+	if registry == nil {
+		registry = bson.DefaultRegistry
+	}
+	b, err := bson.MarshalWithRegistry(registry, bson.D{{key, comment}})
+	if err != nil {
+		return bsonx.Elem{}, err
+	}
+	doc, err := bsonx.ReadDoc(b)
+	if err != nil {
+		return bsonx.Elem{}, err
+	}
+
+	return doc[0], nil
+}
+
 func closeImplicitSession(sess *session.Client) {
 	if sess != nil && sess.SessionType == session.Implicit {
 		sess.EndSession()
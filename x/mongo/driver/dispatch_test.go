@@ -0,0 +1,46 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package driver
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentToElement(t *testing.T) {
+	wire8 := &description.VersionRange{Min: 0, Max: 8}
+	wire9 := &description.VersionRange{Min: 0, Max: 9}
+	docComment := bson.D{{"foo", "bar"}}
+
+	testCases := []struct {
+		name        string
+		comment     interface{}
+		wireVersion *description.VersionRange
+		expected    bsonx.Elem
+		err         error
+	}{
+		{"string without wire version", "hello", nil, bsonx.Elem{"comment", bsonx.String("hello")}, nil},
+		{"string on 4.2", "hello", wire8, bsonx.Elem{"comment", bsonx.String("hello")}, nil},
+		{"document on 4.4", docComment, wire9,
+			bsonx.Elem{"comment", bsonx.Document(bsonx.Doc{{"foo", bsonx.String("bar")}})}, nil},
+		{"int on 4.4", int32(1), wire9, bsonx.Elem{"comment", bsonx.Int32(1)}, nil},
+		{"document on 4.2", docComment, wire8, bsonx.Elem{}, ErrNonStringComment},
+		{"document without wire version", docComment, nil, bsonx.Elem{}, ErrNonStringComment},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			elem, err := commentToElement("comment", tc.comment, tc.wireVersion, nil)
+			require.Equal(t, tc.err, err)
+			require.True(t, tc.expected.Equal(elem), "expected %v, got %v", tc.expected, elem)
+		})
+	}
+}
@@ -73,6 +73,13 @@ func Distinct(
 		}
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"collation", bsonx.Document(distinctOpts.Collation.ToDocument())})
 	}
+	if distinctOpts.Comment != nil {
+		commentElem, err := commentToElement("comment", distinctOpts.Comment, desc.WireVersion, nil)
+		if err != nil {
+			return result.Distinct{}, err
+		}
+		cmd.Opts = append(cmd.Opts, commentElem)
+	}
 
 	return cmd.RoundTrip(ctx, desc, conn)
 }
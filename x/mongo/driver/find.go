@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"errors"
+	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
 	"github.com/mongodb/mongo-go-driver/mongo/options"
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
@@ -69,6 +70,9 @@ func Find(
 	}
 
 	fo := options.MergeFindOptions(opts...)
+	if fo.CausalConsistency != nil {
+		cmd.SkipAfterClusterTime = !*fo.CausalConsistency
+	}
 	if fo.AllowPartialResults != nil {
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"allowPartialResults", bsonx.Boolean(*fo.AllowPartialResults)})
 	}
@@ -88,7 +92,11 @@ func Find(
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"collation", bsonx.Document(fo.Collation.ToDocument())})
 	}
 	if fo.Comment != nil {
-		cmd.Opts = append(cmd.Opts, bsonx.Elem{"comment", bsonx.String(*fo.Comment)})
+		commentElem, err := commentToElement("comment", fo.Comment, desc.WireVersion, registry)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Opts = append(cmd.Opts, commentElem)
 	}
 	if fo.CursorType != nil {
 		switch *fo.CursorType {
@@ -167,7 +175,15 @@ func Find(
 		cmd.Opts = append(cmd.Opts, sortElem)
 	}
 
-	c, err := cmd.RoundTrip(ctx, desc, ss, conn)
+	var cb command.CursorBuilder = ss
+	switch {
+	case fo.Exhaust != nil && *fo.Exhaust:
+		cb = exhaustCursorBuilder{ss.Server}
+	case fo.Prefetch != nil && *fo.Prefetch:
+		cb = prefetchCursorBuilder{ss.Server}
+	}
+
+	c, err := cmd.RoundTrip(ctx, desc, cb, conn)
 	if err != nil {
 		closeImplicitSession(cmd.Session)
 	}
@@ -175,6 +191,28 @@ func Find(
 	return c, err
 }
 
+// exhaustCursorBuilder is a command.CursorBuilder that builds exhaust cursors.
+type exhaustCursorBuilder struct {
+	*topology.Server
+}
+
+func (ecb exhaustCursorBuilder) BuildCursor(result bson.Raw, clientSession *session.Client,
+	clock *session.ClusterClock, opts ...bsonx.Elem) (command.Cursor, error) {
+
+	return ecb.BuildExhaustCursor(result, clientSession, clock, opts...)
+}
+
+// prefetchCursorBuilder is a command.CursorBuilder that builds cursors that fetch their next batch in the background.
+type prefetchCursorBuilder struct {
+	*topology.Server
+}
+
+func (pcb prefetchCursorBuilder) BuildCursor(result bson.Raw, clientSession *session.Client,
+	clock *session.ClusterClock, opts ...bsonx.Elem) (command.Cursor, error) {
+
+	return pcb.BuildPrefetchCursor(result, clientSession, clock, opts...)
+}
+
 // legacyFind handles the dispatch and execution of a find operation against a pre-3.2 server.
 func legacyFind(
 	ctx context.Context,
@@ -284,7 +322,12 @@ func createLegacyOptionsDoc(fo *options.FindOptions, registry *bsoncodec.Registr
 		return nil, ErrCollation
 	}
 	if fo.Comment != nil {
-		optsDoc = append(optsDoc, bsonx.Elem{"$comment", bsonx.String(*fo.Comment)})
+		// servers that only speak OP_QUERY predate non-string comments
+		commentElem, err := commentToElement("$comment", fo.Comment, nil, registry)
+		if err != nil {
+			return nil, err
+		}
+		optsDoc = append(optsDoc, commentElem)
 	}
 	if fo.Hint != nil {
 		hintElem, err := interfaceToElement("$hint", fo.Hint, registry)
@@ -100,6 +100,7 @@ func FindOneAndDelete(
 			return result.FindAndModify{}, originalErr
 		}
 
+		PublishRetryWrite(ctx, topo, "findAndModify", retryWriteAttempt, originalErr, nil)
 		return findOneAndDelete(ctx, cmd, ss, cerr)
 	}
 
@@ -109,6 +109,7 @@ func FindOneAndReplace(
 			return result.FindAndModify{}, originalErr
 		}
 
+		PublishRetryWrite(ctx, topo, "findAndModify", retryWriteAttempt, originalErr, nil)
 		return findOneAndReplace(ctx, cmd, ss, cerr)
 	}
 
@@ -117,6 +117,7 @@ func FindOneAndUpdate(
 			return result.FindAndModify{}, originalErr
 		}
 
+		PublishRetryWrite(ctx, topo, "findAndModify", retryWriteAttempt, originalErr, nil)
 		return findOneAndUpdate(ctx, cmd, ss, cerr)
 	}
 
@@ -81,6 +81,7 @@ func Insert(
 			return res, originalErr
 		}
 
+		PublishRetryWrite(ctx, topo, "insert", retryWriteAttempt, originalErr, res.WriteConcernError)
 		return insert(ctx, cmd, ss, cerr)
 	}
 
@@ -0,0 +1,86 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package logger
+
+import (
+	"context"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/event"
+)
+
+// CommandMonitor returns a command monitor that logs the commands it is notified of for ComponentCommand and
+// then notifies next, which may be nil. Started and succeeded commands are logged at LevelDebug, and failed and
+// retried ones at LevelInfo. Command and reply documents are redacted as they are for next, so they contain
+// credentials if next disables redaction. CommandMonitor returns next if commands are not logged.
+func (l *Logger) CommandMonitor(next *event.CommandMonitor) *event.CommandMonitor {
+	if !l.Enabled(LevelInfo, ComponentCommand) {
+		return next
+	}
+	if next == nil {
+		next = &event.CommandMonitor{}
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			if l.Enabled(LevelDebug, ComponentCommand) {
+				l.Print(LevelDebug, ComponentCommand, "Command started",
+					"commandName", evt.CommandName,
+					"databaseName", evt.DatabaseName,
+					"requestId", evt.RequestID,
+					"driverConnectionId", evt.ConnectionID,
+					"command", l.FormatDocument(evt.Command),
+				)
+			}
+			if next.Started != nil {
+				next.Started(ctx, evt)
+			}
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			if l.Enabled(LevelDebug, ComponentCommand) {
+				l.Print(LevelDebug, ComponentCommand, "Command succeeded",
+					"commandName", evt.CommandName,
+					"requestId", evt.RequestID,
+					"driverConnectionId", evt.ConnectionID,
+					"durationMS", durationMS(evt.DurationNanos),
+					"reply", l.FormatDocument(evt.Reply),
+				)
+			}
+			if next.Succeeded != nil {
+				next.Succeeded(ctx, evt)
+			}
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			l.Print(LevelInfo, ComponentCommand, "Command failed",
+				"commandName", evt.CommandName,
+				"requestId", evt.RequestID,
+				"driverConnectionId", evt.ConnectionID,
+				"durationMS", durationMS(evt.DurationNanos),
+				"failure", evt.Failure,
+			)
+			if next.Failed != nil {
+				next.Failed(ctx, evt)
+			}
+		},
+		Retried: func(ctx context.Context, evt *event.CommandRetryEvent) {
+			l.Print(LevelInfo, ComponentCommand, "Command retried",
+				"commandName", evt.CommandName,
+				"attempt", evt.Attempt,
+				"failure", evt.Failure,
+				"write", evt.Write,
+			)
+			if next.Retried != nil {
+				next.Retried(ctx, evt)
+			}
+		},
+		DisableRedaction: next.DisableRedaction,
+	}
+}
+
+func durationMS(nanos int64) int64 {
+	return int64(time.Duration(nanos) / time.Millisecond)
+}
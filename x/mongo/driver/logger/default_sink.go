@@ -0,0 +1,36 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build !go1.21
+// +build !go1.21
+
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+)
+
+type stdSink struct {
+	l *log.Logger
+}
+
+func (s stdSink) Log(level Level, component Component, msg string, keysAndValues ...interface{}) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "level=%s component=%s msg=%q", level, component, msg)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fmt.Fprintf(&b, " %v=%q", keysAndValues[i], fmt.Sprint(keysAndValues[i+1]))
+	}
+	s.l.Print(b.String())
+}
+
+// defaultSink writes every message it receives to standard error. log/slog is only available from Go 1.21, so
+// messages are written with the log package instead.
+func defaultSink() Sink {
+	return stdSink{l: log.New(os.Stderr, "", log.LstdFlags)}
+}
@@ -0,0 +1,239 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package logger contains the leveled, component-scoped logger the driver uses to report what it is doing
+// internally, such as the commands it runs, changes to the topology, the connections it opens and closes, and
+// the outcome of server selection.
+//
+// Levels are configured per component, programmatically or with the MONGODB_LOG_ALL and
+// MONGODB_LOG_<COMPONENT> environment variables, where <COMPONENT> is one of COMMAND, TOPOLOGY,
+// SERVER_SELECTION and CONNECTION. MONGODB_LOG_ALL takes precedence over the component variables, and
+// levels set programmatically take precedence over both. MONGODB_LOG_MAX_DOCUMENT_LENGTH limits the length
+// of the documents included in messages.
+package logger
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+)
+
+// DefaultMaxDocumentLength is the length, in bytes, that the extended JSON of documents included in
+// messages is truncated to by default.
+const DefaultMaxDocumentLength = 1000
+
+// Level is the severity of a message. A component configured with a level logs the messages of that level
+// and of every more severe level.
+type Level int
+
+// These constants are the levels a component can be configured with, from least to most verbose.
+const (
+	LevelOff Level = iota
+	LevelError
+	LevelWarn
+	LevelInfo
+	LevelDebug
+)
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelOff:
+		return "off"
+	case LevelError:
+		return "error"
+	case LevelWarn:
+		return "warn"
+	case LevelInfo:
+		return "info"
+	case LevelDebug:
+		return "debug"
+	}
+	return "Level(" + strconv.Itoa(int(l)) + ")"
+}
+
+// ParseLevel returns the level with the given name, ignoring case. The names of the logging specification,
+// such as "emergency", "notice" and "trace", are mapped to the nearest level. It returns false if the name is
+// not recognized.
+func ParseLevel(s string) (Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "off":
+		return LevelOff, true
+	case "emergency", "alert", "critical", "error":
+		return LevelError, true
+	case "warning", "warn", "notice":
+		return LevelWarn, true
+	case "informational", "info":
+		return LevelInfo, true
+	case "debug", "trace":
+		return LevelDebug, true
+	}
+	return LevelOff, false
+}
+
+// Component is the part of the driver a message is about.
+type Component int
+
+// These constants are the components of the driver that log messages. ComponentAll is only used to configure
+// the level of every component at once.
+const (
+	ComponentAll Component = iota
+	ComponentCommand
+	ComponentTopology
+	ComponentServerSelection
+	ComponentConnection
+)
+
+// components are the components that log messages, in the order of their constants.
+var components = []Component{ComponentCommand, ComponentTopology, ComponentServerSelection, ComponentConnection}
+
+// String returns the name of the component.
+func (c Component) String() string {
+	switch c {
+	case ComponentAll:
+		return "all"
+	case ComponentCommand:
+		return "command"
+	case ComponentTopology:
+		return "topology"
+	case ComponentServerSelection:
+		return "serverSelection"
+	case ComponentConnection:
+		return "connection"
+	}
+	return "Component(" + strconv.Itoa(int(c)) + ")"
+}
+
+// envVar returns the name of the environment variable that configures the level of the component.
+func (c Component) envVar() string {
+	switch c {
+	case ComponentCommand:
+		return "MONGODB_LOG_COMMAND"
+	case ComponentTopology:
+		return "MONGODB_LOG_TOPOLOGY"
+	case ComponentServerSelection:
+		return "MONGODB_LOG_SERVER_SELECTION"
+	case ComponentConnection:
+		return "MONGODB_LOG_CONNECTION"
+	}
+	return "MONGODB_LOG_ALL"
+}
+
+// Sink receives the messages of a Logger. The message is a short constant description of what happened, and
+// keysAndValues holds alternating keys and values that describe the particular occurrence, e.g. the name of a
+// command and the address of the server it ran on. Log may be called concurrently from multiple goroutines and
+// must be safe for concurrent use.
+type Sink interface {
+	Log(level Level, component Component, msg string, keysAndValues ...interface{})
+}
+
+// Logger sends the messages of the components whose level allows them to a Sink. A nil *Logger logs nothing,
+// so it can be used wherever logging is disabled.
+type Logger struct {
+	sink              Sink
+	levels            map[Component]Level
+	maxDocumentLength uint
+}
+
+// New creates a Logger that sends messages to sink, or to a sink writing to standard error if sink is nil.
+// The level of each component is read from the environment and then overridden by levels, where the level of
+// ComponentAll applies to every component without its own entry. A maxDocumentLength of zero uses the value
+// of MONGODB_LOG_MAX_DOCUMENT_LENGTH, or DefaultMaxDocumentLength if that is not set either. New returns nil
+// if every component is off.
+func New(sink Sink, maxDocumentLength uint, levels map[Component]Level) *Logger {
+	l := &Logger{
+		sink:              sink,
+		levels:            make(map[Component]Level, len(components)),
+		maxDocumentLength: maxDocumentLength,
+	}
+
+	all, allSet := levelFromEnv(ComponentAll)
+	for _, c := range components {
+		if allSet {
+			l.levels[c] = all
+			continue
+		}
+		if lvl, ok := levelFromEnv(c); ok {
+			l.levels[c] = lvl
+		}
+	}
+	if lvl, ok := levels[ComponentAll]; ok {
+		for _, c := range components {
+			l.levels[c] = lvl
+		}
+	}
+	for c, lvl := range levels {
+		if c != ComponentAll {
+			l.levels[c] = lvl
+		}
+	}
+
+	enabled := false
+	for _, lvl := range l.levels {
+		enabled = enabled || lvl > LevelOff
+	}
+	if !enabled {
+		return nil
+	}
+
+	if l.maxDocumentLength == 0 {
+		l.maxDocumentLength = DefaultMaxDocumentLength
+		if n, err := strconv.ParseUint(os.Getenv("MONGODB_LOG_MAX_DOCUMENT_LENGTH"), 10, 0); err == nil && n > 0 {
+			l.maxDocumentLength = uint(n)
+		}
+	}
+	if l.sink == nil {
+		l.sink = defaultSink()
+	}
+	return l
+}
+
+// levelFromEnv returns the level set for the component by its environment variable. Unrecognized levels are
+// ignored.
+func levelFromEnv(c Component) (Level, bool) {
+	s, ok := os.LookupEnv(c.envVar())
+	if !ok {
+		return LevelOff, false
+	}
+	return ParseLevel(s)
+}
+
+// Enabled returns true if messages of the given level are logged for the component.
+func (l *Logger) Enabled(level Level, component Component) bool {
+	if l == nil || level == LevelOff {
+		return false
+	}
+	return level <= l.levels[component]
+}
+
+// Print sends the message to the sink if messages of the given level are logged for the component.
+func (l *Logger) Print(level Level, component Component, msg string, keysAndValues ...interface{}) {
+	if !l.Enabled(level, component) {
+		return
+	}
+	l.sink.Log(level, component, msg, keysAndValues...)
+}
+
+// FormatDocument returns the relaxed extended JSON of doc, truncated to the maximum document length. A
+// truncated document ends with "...".
+func (l *Logger) FormatDocument(doc interface{}) string {
+	b, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return "<invalid document: " + err.Error() + ">"
+	}
+	if l == nil || uint(len(b)) <= l.maxDocumentLength {
+		return string(b)
+	}
+
+	n := int(l.maxDocumentLength)
+	// don't cut a multi-byte character in half
+	for n > 0 && b[n]&0xC0 == 0x80 {
+		n--
+	}
+	return string(b[:n]) + "..."
+}
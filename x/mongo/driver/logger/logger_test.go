@@ -0,0 +1,252 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package logger
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/event"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+type message struct {
+	level         Level
+	component     Component
+	msg           string
+	keysAndValues []interface{}
+}
+
+type recordingSink struct {
+	sync.Mutex
+	messages []message
+}
+
+func (s *recordingSink) Log(level Level, component Component, msg string, keysAndValues ...interface{}) {
+	s.Lock()
+	defer s.Unlock()
+	s.messages = append(s.messages, message{level, component, msg, keysAndValues})
+}
+
+// setenv replaces the logging environment variables with env. The returned function restores them.
+func setenv(t *testing.T, env map[string]string) func() {
+	t.Helper()
+	old := make(map[string]string)
+	for _, c := range append([]Component{ComponentAll}, components...) {
+		key := c.envVar()
+		if v, ok := os.LookupEnv(key); ok {
+			old[key] = v
+		}
+		require.NoError(t, os.Unsetenv(key))
+		if v, ok := env[key]; ok {
+			require.NoError(t, os.Setenv(key, v))
+		}
+	}
+
+	return func() {
+		for _, c := range append([]Component{ComponentAll}, components...) {
+			key := c.envVar()
+			if v, ok := old[key]; ok {
+				_ = os.Setenv(key, v)
+			} else {
+				_ = os.Unsetenv(key)
+			}
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		levels   map[Component]Level
+		expected map[Component]Level // nil if the logger is off
+	}{
+		{"off by default", nil, nil, nil},
+		{"off when every component is off", map[string]string{"MONGODB_LOG_ALL": "off"}, nil, nil},
+		{
+			"component variable",
+			map[string]string{"MONGODB_LOG_COMMAND": "debug"},
+			nil,
+			map[Component]Level{ComponentCommand: LevelDebug},
+		},
+		{
+			"all variable takes precedence over component variables",
+			map[string]string{"MONGODB_LOG_ALL": "warning", "MONGODB_LOG_TOPOLOGY": "trace"},
+			nil,
+			map[Component]Level{
+				ComponentCommand:         LevelWarn,
+				ComponentTopology:        LevelWarn,
+				ComponentServerSelection: LevelWarn,
+				ComponentConnection:      LevelWarn,
+			},
+		},
+		{
+			"unrecognized levels are ignored",
+			map[string]string{"MONGODB_LOG_CONNECTION": "verbose", "MONGODB_LOG_COMMAND": "ERROR"},
+			nil,
+			map[Component]Level{ComponentCommand: LevelError},
+		},
+		{
+			"options take precedence over variables",
+			map[string]string{"MONGODB_LOG_ALL": "info"},
+			map[Component]Level{ComponentAll: LevelError, ComponentConnection: LevelDebug},
+			map[Component]Level{
+				ComponentCommand:         LevelError,
+				ComponentTopology:        LevelError,
+				ComponentServerSelection: LevelError,
+				ComponentConnection:      LevelDebug,
+			},
+		},
+		{
+			"options can turn logging off",
+			map[string]string{"MONGODB_LOG_COMMAND": "debug"},
+			map[Component]Level{ComponentCommand: LevelOff},
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer setenv(t, tc.env)()
+			l := New(&recordingSink{}, 0, tc.levels)
+			if tc.expected == nil {
+				require.Nil(t, l)
+				return
+			}
+			require.NotNil(t, l)
+			for _, c := range components {
+				require.Equal(t, tc.expected[c], l.levels[c], "level of %s", c)
+			}
+		})
+	}
+}
+
+func TestLogger_Print(t *testing.T) {
+	defer setenv(t, nil)()
+	sink := &recordingSink{}
+	l := New(sink, 0, map[Component]Level{ComponentTopology: LevelInfo})
+
+	l.Print(LevelInfo, ComponentTopology, "logged", "key", "value")
+	l.Print(LevelError, ComponentTopology, "more severe")
+	l.Print(LevelDebug, ComponentTopology, "too verbose")
+	l.Print(LevelError, ComponentCommand, "other component")
+	l.Print(LevelOff, ComponentTopology, "off")
+
+	require.Equal(t, []message{
+		{LevelInfo, ComponentTopology, "logged", []interface{}{"key", "value"}},
+		{LevelError, ComponentTopology, "more severe", nil},
+	}, sink.messages)
+
+	var nilLogger *Logger
+	require.False(t, nilLogger.Enabled(LevelError, ComponentTopology))
+	nilLogger.Print(LevelError, ComponentTopology, "not logged")
+}
+
+func TestLogger_FormatDocument(t *testing.T) {
+	doc := bsonx.Doc{{"find", bsonx.String("coll")}, {"filter", bsonx.Document(bsonx.Doc{{"x", bsonx.String("é")}})}}
+	full := `{"find":"coll","filter":{"x":"é"}}`
+
+	t.Run("shorter than the max", func(t *testing.T) {
+		l := New(&recordingSink{}, 1000, map[Component]Level{ComponentAll: LevelDebug})
+		require.Equal(t, full, l.FormatDocument(doc))
+	})
+	t.Run("truncated", func(t *testing.T) {
+		l := New(&recordingSink{}, 10, map[Component]Level{ComponentAll: LevelDebug})
+		require.Equal(t, full[:10]+"...", l.FormatDocument(doc))
+	})
+	t.Run("does not split characters", func(t *testing.T) {
+		idx := strings.Index(full, "é")
+		l := New(&recordingSink{}, uint(idx+1), map[Component]Level{ComponentAll: LevelDebug})
+		require.Equal(t, full[:idx]+"...", l.FormatDocument(doc))
+	})
+	t.Run("max from the environment", func(t *testing.T) {
+		old, ok := os.LookupEnv("MONGODB_LOG_MAX_DOCUMENT_LENGTH")
+		require.NoError(t, os.Setenv("MONGODB_LOG_MAX_DOCUMENT_LENGTH", "5"))
+		defer func() {
+			if ok {
+				_ = os.Setenv("MONGODB_LOG_MAX_DOCUMENT_LENGTH", old)
+			} else {
+				_ = os.Unsetenv("MONGODB_LOG_MAX_DOCUMENT_LENGTH")
+			}
+		}()
+		l := New(&recordingSink{}, 0, map[Component]Level{ComponentAll: LevelDebug})
+		require.Equal(t, full[:5]+"...", l.FormatDocument(doc))
+	})
+}
+
+func TestLogger_CommandMonitor(t *testing.T) {
+	defer setenv(t, nil)()
+	t.Run("returns next when commands are not logged", func(t *testing.T) {
+		next := &event.CommandMonitor{}
+		l := New(&recordingSink{}, 0, map[Component]Level{ComponentTopology: LevelDebug})
+		require.True(t, l.CommandMonitor(next) == next)
+	})
+	t.Run("logs and forwards events", func(t *testing.T) {
+		var started, succeeded, failed int
+		next := &event.CommandMonitor{
+			Started:          func(context.Context, *event.CommandStartedEvent) { started++ },
+			Succeeded:        func(context.Context, *event.CommandSucceededEvent) { succeeded++ },
+			Failed:           func(context.Context, *event.CommandFailedEvent) { failed++ },
+			DisableRedaction: true,
+		}
+		sink := &recordingSink{}
+		l := New(sink, 0, map[Component]Level{ComponentCommand: LevelDebug})
+		monitor := l.CommandMonitor(next)
+		require.True(t, monitor.DisableRedaction)
+
+		finished := event.CommandFinishedEvent{
+			DurationNanos: 2500000,
+			CommandName:   "ping",
+			RequestID:     1,
+			ConnectionID:  "localhost:27017[-1]",
+		}
+		monitor.Started(context.Background(), &event.CommandStartedEvent{
+			Command:      bsonx.Doc{{"ping", bsonx.Int32(1)}},
+			DatabaseName: "admin",
+			CommandName:  "ping",
+			RequestID:    1,
+			ConnectionID: "localhost:27017[-1]",
+		})
+		monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{
+			CommandFinishedEvent: finished,
+			Reply:                bsonx.Doc{{"ok", bsonx.Double(1)}},
+		})
+		monitor.Failed(context.Background(), &event.CommandFailedEvent{
+			CommandFinishedEvent: finished,
+			Failure:              "boom",
+		})
+
+		require.Equal(t, []int{1, 1, 1}, []int{started, succeeded, failed})
+		require.Len(t, sink.messages, 3)
+		require.Equal(t, message{LevelDebug, ComponentCommand, "Command started", []interface{}{
+			"commandName", "ping",
+			"databaseName", "admin",
+			"requestId", int64(1),
+			"driverConnectionId", "localhost:27017[-1]",
+			"command", `{"ping":1}`,
+		}}, sink.messages[0])
+		require.Equal(t, "Command succeeded", sink.messages[1].msg)
+		require.Contains(t, sink.messages[1].keysAndValues, int64(2))
+		require.Equal(t, LevelInfo, sink.messages[2].level)
+		require.Contains(t, sink.messages[2].keysAndValues, "boom")
+	})
+	t.Run("logs only failures at info", func(t *testing.T) {
+		sink := &recordingSink{}
+		l := New(sink, 0, map[Component]Level{ComponentCommand: LevelInfo})
+		monitor := l.CommandMonitor(nil)
+		monitor.Started(context.Background(), &event.CommandStartedEvent{CommandName: "ping"})
+		monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{})
+		monitor.Retried(context.Background(), &event.CommandRetryEvent{CommandName: "insert", Attempt: 2})
+		require.Len(t, sink.messages, 1)
+		require.Equal(t, "Command retried", sink.messages[0].msg)
+	})
+}
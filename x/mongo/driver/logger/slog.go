@@ -0,0 +1,51 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build go1.21
+// +build go1.21
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+type slogSink struct {
+	l *slog.Logger
+}
+
+// NewSlogSink returns a Sink that writes messages to l. Each record has a "component" attribute holding the
+// name of the component, followed by the keys and values of the message.
+func NewSlogSink(l *slog.Logger) Sink {
+	return slogSink{l: l}
+}
+
+func (s slogSink) Log(level Level, component Component, msg string, keysAndValues ...interface{}) {
+	var lvl slog.Level
+	switch level {
+	case LevelError:
+		lvl = slog.LevelError
+	case LevelWarn:
+		lvl = slog.LevelWarn
+	case LevelInfo:
+		lvl = slog.LevelInfo
+	default:
+		lvl = slog.LevelDebug
+	}
+
+	args := make([]interface{}, 0, len(keysAndValues)+2)
+	args = append(args, "component", component.String())
+	args = append(args, keysAndValues...)
+	s.l.Log(context.Background(), lvl, msg, args...)
+}
+
+// defaultSink writes every message it receives as JSON to standard error. Filtering by level is done by the
+// Logger, so the handler accepts every level.
+func defaultSink() Sink {
+	return NewSlogSink(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+}
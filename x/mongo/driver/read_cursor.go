@@ -17,7 +17,8 @@ import (
 )
 
 // ReadCursor handles the full dispatch cycle and execution of a read command against the provided topology and returns
-// a Cursor over the resulting BSON reader.
+// a Cursor over the resulting BSON reader. The cursor runs its getMores against the server selected for cmd, and in
+// the session of cmd, so it can be used with a non-primary read preference and inside transactions.
 func ReadCursor(
 	ctx context.Context,
 	cmd command.Read,
@@ -39,6 +40,14 @@ func ReadCursor(
 	}
 	defer conn.Close()
 
+	if cmd.Session != nil && cmd.Session.TransactionRunning() {
+		// As with Read, the read preference given to cmd is an operation level read preference, so it is not
+		// replaced by the transaction's read preference.
+		if err = checkTransactionReadPref(cmd.ReadPref); err != nil {
+			return nil, err
+		}
+	}
+
 	if cmd.Session == nil && topo.SupportsSessions() {
 		cmd.Session, err = session.NewClientSession(pool, clientID, session.Implicit)
 		if err != nil {
@@ -48,13 +57,13 @@ func ReadCursor(
 
 	rdr, err := cmd.RoundTrip(ctx, desc, conn)
 	if err != nil {
-		cmd.Session.EndSession()
+		closeImplicitSession(cmd.Session)
 		return nil, err
 	}
 
 	cursor, err := ss.BuildCursor(rdr, cmd.Session, cmd.Clock)
 	if err != nil {
-		cmd.Session.EndSession()
+		closeImplicitSession(cmd.Session)
 		return nil, err
 	}
 
@@ -8,6 +8,7 @@ package session
 
 import (
 	"errors"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
@@ -38,6 +39,9 @@ var ErrCommitAfterAbort = errors.New("cannot call commitTransaction after callin
 // ErrUnackWCUnsupported is returned if an unacknowledged write concern is supported for a transaciton.
 var ErrUnackWCUnsupported = errors.New("transactions do not support unacknowledged write concerns")
 
+// ErrSnapshotTransaction is returned if a transaction is started in a snapshot session.
+var ErrSnapshotTransaction = errors.New("transactions are not supported in snapshot sessions")
+
 // Type describes the type of the session
 type Type uint8
 
@@ -73,6 +77,15 @@ type Client struct {
 	Aborting       bool
 	RetryWrite     bool
 
+	// CommitAttempts is the number of commitTransaction commands sent by the current attempt to commit the
+	// transaction, including retries.
+	CommitAttempts int
+
+	// Snapshot is true if reads in the session read from a single point in time. SnapshotTime is the
+	// atClusterTime of that point, captured from the first read in the session.
+	Snapshot     bool
+	SnapshotTime *primitive.Timestamp
+
 	// options for the current transaction
 	// most recently set by transactionopt
 	CurrentRc *readconcern.ReadConcern
@@ -137,6 +150,11 @@ func NewClientSession(pool *Pool, clientID uuid.UUID, sessionType Type, opts ...
 	if mergedOpts.CausalConsistency != nil {
 		c.Consistent = *mergedOpts.CausalConsistency
 	}
+	if mergedOpts.Snapshot != nil && *mergedOpts.Snapshot {
+		// snapshot reads are already consistent, so afterClusterTime must not be sent
+		c.Snapshot = true
+		c.Consistent = false
+	}
 	if mergedOpts.DefaultReadPreference != nil {
 		c.transactionRp = mergedOpts.DefaultReadPreference
 	}
@@ -186,6 +204,19 @@ func (c *Client) AdvanceOperationTime(opTime *primitive.Timestamp) error {
 	return nil
 }
 
+// AdvanceSnapshotTime records the cluster time read from by a snapshot session. Only the first time is
+// kept so that every read in the session sees the same snapshot.
+func (c *Client) AdvanceSnapshotTime(atClusterTime *primitive.Timestamp) error {
+	if c.Terminated {
+		return ErrSessionEnded
+	}
+
+	if c.Snapshot && c.SnapshotTime == nil {
+		c.SnapshotTime = atClusterTime
+	}
+	return nil
+}
+
 // UpdateUseTime updates the session's last used time.
 // Must be called whenver this session is used to send a command to the server.
 func (c *Client) UpdateUseTime() error {
@@ -232,6 +263,9 @@ func (c *Client) TransactionCommitted() bool {
 // CheckStartTransaction checks to see if allowed to start transaction and returns
 // an error if not allowed
 func (c *Client) CheckStartTransaction() error {
+	if c.Snapshot {
+		return ErrSnapshotTransaction
+	}
 	if c.state == InProgress || c.state == Starting {
 		return ErrTransactInProgress
 	}
@@ -267,6 +301,11 @@ func (c *Client) StartTransaction(opts *TransactionOptions) error {
 		c.CurrentWc = c.transactionWc
 	}
 
+	if err = c.CurrentWc.Validate(); err != nil {
+		c.clearTransactionOpts()
+		return err
+	}
+
 	if !writeconcern.AckWrite(c.CurrentWc) {
 		c.clearTransactionOpts()
 		return ErrUnackWCUnsupported
@@ -298,6 +337,17 @@ func (c *Client) CommitTransaction() error {
 	return nil
 }
 
+// UpdateCommitTransactionWriteConcern sets the write concern of the transaction to w: majority for a retried
+// commit, keeping any other options. The first attempt may have failed because its write concern could not be
+// satisfied, and a retry must not wait forever, so a wtimeout of 10 seconds is added if none was set.
+func (c *Client) UpdateCommitTransactionWriteConcern() {
+	wTimeout := c.CurrentWc.GetWTimeout()
+	if wTimeout == 0 {
+		wTimeout = 10 * time.Second
+	}
+	c.CurrentWc = c.CurrentWc.WithOptions(writeconcern.WMajority(), writeconcern.WTimeout(wTimeout))
+}
+
 // CheckAbortTransaction checks to see if allowed to abort transaction and returns
 // an error if not allowed.
 func (c *Client) CheckAbortTransaction() error {
@@ -339,6 +389,7 @@ func (c *Client) ApplyCommand() {
 
 func (c *Client) clearTransactionOpts() {
 	c.RetryingCommit = false
+	c.CommitAttempts = 0
 	c.Aborting = false
 	c.Committing = false
 	c.CurrentWc = nil
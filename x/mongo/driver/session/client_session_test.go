@@ -8,9 +8,11 @@ package session
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/internal/testutil/helpers"
+	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/uuid"
 	"github.com/stretchr/testify/require"
@@ -193,4 +195,63 @@ func TestClientSession(t *testing.T) {
 			t.Errorf("expected error, got %v", err)
 		}
 	})
+
+	t.Run("TestInconsistentTransactionWriteConcern", func(t *testing.T) {
+		id, _ := uuid.New()
+		sess, err := NewClientSession(&Pool{}, id, Explicit, nil)
+		require.Nil(t, err, "Unexpected error")
+
+		wc := writeconcern.New(writeconcern.W(0), writeconcern.J(true))
+		err = sess.StartTransaction(&TransactionOptions{WriteConcern: wc})
+		if err != writeconcern.ErrInconsistent {
+			t.Errorf("expected error %v, got %v", writeconcern.ErrInconsistent, err)
+		}
+		if sess.state != None {
+			t.Errorf("incorrect session state, expected None, received %v", sess.state)
+		}
+		if sess.CurrentWc != nil {
+			t.Errorf("expected transaction options to be cleared, got write concern %v", sess.CurrentWc)
+		}
+	})
+
+	t.Run("TestUpdateCommitTransactionWriteConcern", func(t *testing.T) {
+		id, _ := uuid.New()
+		sess, err := NewClientSession(&Pool{}, id, Explicit, nil)
+		require.Nil(t, err, "Unexpected error")
+
+		err = sess.StartTransaction(nil)
+		require.Nil(t, err, "error starting transaction: %s", err)
+		sess.UpdateCommitTransactionWriteConcern()
+		require.Equal(t, writeconcern.New(writeconcern.WMajority(), writeconcern.WTimeout(10*time.Second)), sess.CurrentWc)
+
+		err = sess.AbortTransaction()
+		require.Nil(t, err, "error aborting transaction: %s", err)
+		sess.ApplyCommand()
+
+		wc := writeconcern.New(writeconcern.W(1), writeconcern.J(true), writeconcern.WTimeout(time.Second))
+		err = sess.StartTransaction(&TransactionOptions{WriteConcern: wc})
+		require.Nil(t, err, "error starting transaction: %s", err)
+		sess.UpdateCommitTransactionWriteConcern()
+		require.Equal(t, writeconcern.New(writeconcern.WMajority(), writeconcern.J(true), writeconcern.WTimeout(time.Second)), sess.CurrentWc)
+		require.Equal(t, writeconcern.New(writeconcern.W(1), writeconcern.J(true), writeconcern.WTimeout(time.Second)), wc)
+	})
+
+	t.Run("TestSnapshotSession", func(t *testing.T) {
+		id, _ := uuid.New()
+		snapshot := true
+		sess, err := NewClientSession(&Pool{}, id, Explicit, sessionOpts, &ClientOptions{Snapshot: &snapshot})
+		require.Nil(t, err, "Unexpected error")
+		require.True(t, sess.Snapshot)
+		require.False(t, sess.Consistent, "snapshot sessions should not be causally consistent")
+
+		first := &primitive.Timestamp{T: 10, I: 1}
+		require.Nil(t, sess.AdvanceSnapshotTime(first), "Unexpected error")
+		require.Nil(t, sess.AdvanceSnapshotTime(&primitive.Timestamp{T: 20, I: 1}), "Unexpected error")
+		compareOperationTimes(t, first, sess.SnapshotTime)
+
+		err = sess.StartTransaction(nil)
+		if err != ErrSnapshotTransaction {
+			t.Errorf("expected error %v, got %v", ErrSnapshotTransaction, err)
+		}
+	})
 }
@@ -18,6 +18,7 @@ type ClientOptions struct {
 	DefaultReadConcern    *readconcern.ReadConcern
 	DefaultWriteConcern   *writeconcern.WriteConcern
 	DefaultReadPreference *readpref.ReadPref
+	Snapshot              *bool
 }
 
 // TransactionOptions represents all possible options for starting a transaction in a session.
@@ -45,6 +46,9 @@ func mergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.DefaultWriteConcern != nil {
 			c.DefaultWriteConcern = opt.DefaultWriteConcern
 		}
+		if opt.Snapshot != nil {
+			c.Snapshot = opt.Snapshot
+		}
 	}
 
 	return c
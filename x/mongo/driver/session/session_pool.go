@@ -20,6 +20,18 @@ type Node struct {
 	prev *Node
 }
 
+// PoolStats is a snapshot of a Pool. Expired and Discarded count sessions since the pool was created.
+type PoolStats struct {
+	// Idle is the number of sessions in the pool.
+	Idle int
+	// CheckedOut is the number of sessions in use.
+	CheckedOut int
+	// Expired is the number of sessions removed because they were unused for too long.
+	Expired uint64
+	// Discarded is the number of sessions removed because the pool was full.
+	Discarded uint64
+}
+
 // Pool is a pool of server sessions that can be reused.
 type Pool struct {
 	descChan <-chan description.Topology
@@ -28,7 +40,11 @@ type Pool struct {
 	timeout  uint32
 	mutex    sync.Mutex // mutex to protect list and sessionTimeout
 
-	checkedOut int // number of sessions checked out of pool
+	checkedOut int    // number of sessions checked out of pool
+	size       int    // number of sessions in the list
+	maxSize    uint64 // maximum number of sessions in the list, or 0 for no limit
+	expired    uint64
+	discarded  uint64
 }
 
 func (p *Pool) createServerSession() (*Server, error) {
@@ -50,6 +66,45 @@ func NewPool(descChan <-chan description.Topology) *Pool {
 	return p
 }
 
+// SetMaxSize limits the number of idle sessions kept in the pool. When a session is returned to a full pool, the
+// least recently used session is discarded. A size of 0, the default, means no limit. Discarded sessions are not
+// ended, so the server removes them once they have been idle for its logicalSessionTimeoutMinutes.
+func (p *Pool) SetMaxSize(size uint64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.maxSize = size
+	for p.maxSize > 0 && uint64(p.size) > p.maxSize {
+		p.removeTail()
+		p.discarded++
+	}
+}
+
+// Stats returns a snapshot of the pool.
+func (p *Pool) Stats() PoolStats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return PoolStats{
+		Idle:       p.size,
+		CheckedOut: p.checkedOut,
+		Expired:    p.expired,
+		Discarded:  p.discarded,
+	}
+}
+
+// removeTail removes the least recently used session. It assumes the caller holds the mutex and the list is not
+// empty.
+func (p *Pool) removeTail() {
+	if p.tail.prev != nil {
+		p.tail.prev.next = nil
+	} else {
+		p.head = nil
+	}
+	p.tail = p.tail.prev
+	p.size--
+}
+
 // assumes caller has mutex to protect the pool
 func (p *Pool) updateTimeout() {
 	select {
@@ -75,6 +130,11 @@ func (p *Pool) GetSession() (*Server, error) {
 		// pull session from head of queue and return if it is valid for at least 1 more minute
 		if p.head.expired(p.timeout) {
 			p.head = p.head.next
+			if p.head != nil {
+				p.head.prev = nil
+			}
+			p.size--
+			p.expired++
 			continue
 		}
 
@@ -90,6 +150,7 @@ func (p *Pool) GetSession() (*Server, error) {
 			p.head = p.head.next
 		}
 
+		p.size--
 		p.checkedOut++
 		return session, nil
 	}
@@ -113,23 +174,29 @@ func (p *Pool) ReturnSession(ss *Server) {
 	// check sessions at end of queue for expired
 	// stop checking after hitting the first valid session
 	for p.tail != nil && p.tail.expired(p.timeout) {
-		if p.tail.prev != nil {
-			p.tail.prev.next = nil
-		}
-		p.tail = p.tail.prev
+		p.removeTail()
+		p.expired++
 	}
 
 	// session expired
 	if ss.expired(p.timeout) {
+		p.expired++
 		return
 	}
 
+	if p.maxSize > 0 && uint64(p.size) >= p.maxSize {
+		p.removeTail()
+		p.discarded++
+	}
+
 	newNode := &Node{
 		Server: ss,
 		next:   nil,
 		prev:   nil,
 	}
 
+	p.size++
+
 	// empty list
 	if p.tail == nil {
 		p.head = newNode
@@ -68,4 +68,67 @@ func TestSessionPool(t *testing.T) {
 			t.Errorf("Expired sessions not removed!")
 		}
 	})
+
+	t.Run("TestMaxSize", func(t *testing.T) {
+		descChan := make(chan description.Topology)
+		p := NewPool(descChan)
+		p.timeout = 30 // Set to some arbitrarily high number greater than 1 minute.
+		p.SetMaxSize(2)
+
+		var sessions []*Server
+		for i := 0; i < 3; i++ {
+			sess, err := p.GetSession()
+			testhelpers.RequireNil(t, err, "error getting session %s", err)
+			sessions = append(sessions, sess)
+		}
+		if stats := p.Stats(); stats.CheckedOut != 3 || stats.Idle != 0 {
+			t.Errorf("stats mismatch. got %+v", stats)
+		}
+
+		for _, sess := range sessions {
+			p.ReturnSession(sess)
+		}
+		if stats := p.Stats(); stats.Idle != 2 || stats.CheckedOut != 0 || stats.Discarded != 1 {
+			t.Errorf("stats mismatch. got %+v", stats)
+		}
+
+		// the least recently used session is the one discarded
+		var got []*Server
+		for _, expected := range []*Server{sessions[2], sessions[1]} {
+			sess, err := p.GetSession()
+			testhelpers.RequireNil(t, err, "error getting session %s", err)
+			if !sess.SessionID.Equal(expected.SessionID) {
+				t.Errorf("session ID mismatch. got %s expected %s", sess.SessionID, expected.SessionID)
+			}
+			got = append(got, sess)
+		}
+		for _, sess := range got {
+			p.ReturnSession(sess)
+		}
+
+		p.SetMaxSize(1)
+		if stats := p.Stats(); stats.Idle != 1 || stats.Discarded != 2 {
+			t.Errorf("stats mismatch. got %+v", stats)
+		}
+	})
+
+	t.Run("TestExpiredCounted", func(t *testing.T) {
+		descChan := make(chan description.Topology)
+		p := NewPool(descChan)
+		p.timeout = 30 // Set to some arbitrarily high number greater than 1 minute.
+
+		first, err := p.GetSession()
+		testhelpers.RequireNil(t, err, "error getting session %s", err)
+		second, err := p.GetSession()
+		testhelpers.RequireNil(t, err, "error getting session %s", err)
+		p.ReturnSession(first)
+
+		// shrinking the timeout expires the pooled session and the returned one
+		p.timeout = 0
+		p.ReturnSession(second)
+
+		if stats := p.Stats(); stats.Idle != 0 || stats.CheckedOut != 0 || stats.Expired != 2 {
+			t.Errorf("stats mismatch. got %+v", stats)
+		}
+	})
 }
@@ -0,0 +1,75 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package topology
+
+import (
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/event"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
+)
+
+// defaultAvailabilityDebounce is how long the topology must stay available or unavailable before a
+// TopologyMonitor is notified, if the monitor does not set its own Debounce.
+const defaultAvailabilityDebounce = 500 * time.Millisecond
+
+// monitorAvailability publishes an event to monitor whenever the topology descriptions received on updates
+// change between having no data-bearing servers and having at least one, once the new state has lasted for the
+// monitor's debounce interval. It returns when updates is closed.
+func monitorAvailability(monitor *event.TopologyMonitor, updates <-chan description.Topology) {
+	debounce := monitor.Debounce
+	if debounce == 0 {
+		debounce = defaultAvailabilityDebounce
+	}
+
+	var published bool
+	var pending *event.TopologyAvailabilityChangedEvent
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case desc, ok := <-updates:
+			if !ok {
+				return
+			}
+
+			servers := dataBearingServers(desc)
+			available := len(servers) > 0
+			if available == published {
+				// The topology returned to the published state before the debounce interval elapsed.
+				if pending != nil && !timer.Stop() {
+					<-timer.C
+				}
+				pending = nil
+				continue
+			}
+
+			if pending == nil {
+				timer.Reset(debounce)
+			}
+			pending = &event.TopologyAvailabilityChangedEvent{Available: available, Servers: servers}
+		case <-timer.C:
+			published = pending.Available
+			if monitor.AvailabilityChanged != nil {
+				monitor.AvailabilityChanged(pending)
+			}
+			pending = nil
+		}
+	}
+}
+
+func dataBearingServers(desc description.Topology) []string {
+	var servers []string
+	for _, s := range desc.Servers {
+		if s.DataBearing() {
+			servers = append(servers, s.Addr.String())
+		}
+	}
+	return servers
+}
@@ -0,0 +1,106 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package topology
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/event"
+	"github.com/mongodb/mongo-go-driver/x/network/address"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitorAvailability(t *testing.T) {
+	const debounce = 50 * time.Millisecond
+
+	available := description.Topology{Servers: []description.Server{
+		{Addr: address.Address("a:27017"), Kind: description.RSPrimary},
+		{Addr: address.Address("b:27017"), Kind: description.RSArbiter},
+	}}
+	unavailable := description.Topology{Servers: []description.Server{
+		{Addr: address.Address("a:27017")},
+		{Addr: address.Address("b:27017"), Kind: description.RSArbiter},
+	}}
+
+	start := func() (chan<- description.Topology, <-chan *event.TopologyAvailabilityChangedEvent, <-chan struct{}) {
+		updates := make(chan description.Topology)
+		events := make(chan *event.TopologyAvailabilityChangedEvent, 10)
+		done := make(chan struct{})
+		monitor := &event.TopologyMonitor{
+			AvailabilityChanged: func(e *event.TopologyAvailabilityChangedEvent) { events <- e },
+			Debounce:            debounce,
+		}
+		go func() {
+			monitorAvailability(monitor, updates)
+			close(done)
+		}()
+		return updates, events, done
+	}
+
+	next := func(t *testing.T, events <-chan *event.TopologyAvailabilityChangedEvent) *event.TopologyAvailabilityChangedEvent {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(10 * debounce):
+			t.Fatal("timed out waiting for an availability event")
+			return nil
+		}
+	}
+
+	t.Run("publishes transitions", func(t *testing.T) {
+		updates, events, done := start()
+
+		updates <- unavailable
+		updates <- available
+		e := next(t, events)
+		require.True(t, e.Available)
+		require.Equal(t, []string{"a:27017"}, e.Servers)
+
+		updates <- available
+		updates <- unavailable
+		e = next(t, events)
+		require.False(t, e.Available)
+		require.Empty(t, e.Servers)
+
+		close(updates)
+		<-done
+		require.Len(t, events, 0)
+	})
+
+	t.Run("debounces flapping", func(t *testing.T) {
+		updates, events, done := start()
+
+		updates <- available
+		require.True(t, next(t, events).Available)
+
+		for i := 0; i < 5; i++ {
+			updates <- unavailable
+			updates <- available
+		}
+		time.Sleep(2 * debounce)
+		require.Len(t, events, 0)
+
+		updates <- unavailable
+		time.Sleep(debounce / 2)
+		updates <- unavailable
+		require.False(t, next(t, events).Available)
+
+		close(updates)
+		<-done
+	})
+
+	t.Run("stops when the topology disconnects", func(t *testing.T) {
+		updates, events, done := start()
+
+		updates <- available
+		close(updates)
+		<-done
+		require.Len(t, events, 0)
+	})
+}
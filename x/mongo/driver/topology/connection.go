@@ -25,18 +25,44 @@ type sconn struct {
 	connection.Connection
 	s  *Server
 	id uint64
+
+	// lastWrite is the last wire message written and is kept until its reply is read, so the command can be sent
+	// again if the server requires the connection to be reauthenticated.
+	lastWrite wiremessage.WireMessage
 }
 
+// notMasterCodes are NotWritablePrimary (formerly NotMaster) and NotPrimaryNoSecondaryOk (formerly
+// NotMasterNoSlaveOk), returned after the server steps down.
 var notMasterCodes = []int32{10107, 13435}
+var notMasterNames = []string{"NotWritablePrimary", "NotMaster", "NotPrimaryNoSecondaryOk", "NotMasterNoSlaveOk"}
 var recoveringCodes = []int32{11600, 11602, 13436, 189, 91}
 
+// reauthenticationRequiredCode is returned by servers when the credentials a connection authenticated with, such
+// as temporary AWS credentials, have expired.
+const reauthenticationRequiredCode = 391
+
 func (sc *sconn) ReadWireMessage(ctx context.Context) (wiremessage.WireMessage, error) {
 	wm, err := sc.Connection.ReadWireMessage(ctx)
+	var cmdErr error
+	if err == nil {
+		cmdErr = command.DecodeError(wm)
+	}
+	if cerr, ok := cmdErr.(command.Error); ok && cerr.Code == reauthenticationRequiredCode && sc.lastWrite != nil {
+		if r, canReauth := sc.Connection.(connection.Reauthenticator); canReauth {
+			wm, err = sc.reauthenticateAndRetry(ctx, r)
+			cmdErr = nil
+			if err == nil {
+				// the reply to the retried command replaces the one decoded above
+				cmdErr = command.DecodeError(wm)
+			}
+		}
+	}
+	sc.lastWrite = nil
+
 	if err != nil {
 		sc.processErr(err)
 	} else {
-		e := command.DecodeError(wm)
-		sc.processErr(e)
+		sc.processErr(cmdErr)
 	}
 	return wm, err
 }
@@ -44,9 +70,34 @@ func (sc *sconn) ReadWireMessage(ctx context.Context) (wiremessage.WireMessage,
 func (sc *sconn) WriteWireMessage(ctx context.Context, wm wiremessage.WireMessage) error {
 	err := sc.Connection.WriteWireMessage(ctx, wm)
 	sc.processErr(err)
+	if err == nil {
+		sc.lastWrite = wm
+	}
 	return err
 }
 
+// reauthenticateAndRetry authenticates the connection again and sends the last command a second time, returning
+// its reply. The command is only retried once; a second ReauthenticationRequired error is returned to the caller.
+func (sc *sconn) reauthenticateAndRetry(ctx context.Context, r connection.Reauthenticator) (wiremessage.WireMessage, error) {
+	if err := r.Reauthenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := sc.Connection.WriteWireMessage(ctx, sc.lastWrite); err != nil {
+		return nil, err
+	}
+	return sc.Connection.ReadWireMessage(ctx)
+}
+
+// Discard implements the connection.Discarder interface. Connections that cannot be discarded are
+// closed normally.
+func (sc *sconn) Discard() error {
+	if d, ok := sc.Connection.(connection.Discarder); ok {
+		return d.Discard()
+	}
+	return sc.Connection.Close()
+}
+
 func (sc *sconn) processErr(err error) {
 	// TODO(GODRIVER-524) handle the rest of sdam error handling
 	// Invalidate server description if not master or node recovering error occurs
@@ -56,6 +107,9 @@ func (sc *sconn) processErr(err error) {
 		desc.LastError = err
 		// updates description to unknown
 		sc.s.updateDescription(desc, false)
+		// check the server now rather than at the next heartbeat so a new primary is discovered quickly
+		sc.s.RequestImmediateCheck()
+		return
 	}
 
 	ne, ok := err.(connection.NetworkError)
@@ -92,5 +146,10 @@ func isNotMasterError(err command.Error) bool {
 			return true
 		}
 	}
-	return strings.Contains(err.Error(), "not master")
+	for _, n := range notMasterNames {
+		if n == err.Name {
+			return true
+		}
+	}
+	return strings.Contains(err.Error(), "not master") || strings.Contains(err.Error(), "not primary")
 }
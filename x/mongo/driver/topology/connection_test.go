@@ -8,9 +8,13 @@ package topology
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/mongodb/mongo-go-driver/x/network/address"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
 	"github.com/mongodb/mongo-go-driver/x/network/connection"
 	"github.com/mongodb/mongo-go-driver/x/network/description"
 	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
@@ -69,10 +73,197 @@ func TestConnectionProcessErrSpec(t *testing.T) {
 	innerErr := netErr{}
 	connectErr := connection.NetworkError{"blah", innerErr}
 	c := connect{&connectErr}
-	sc := sconn{c, s, 1}
+	sc := sconn{Connection: c, s: s, id: 1}
 	err = sc.WriteWireMessage(ctx, nil)
 	require.NotNil(t, err)
 	desc = s.Description()
 	require.NotNil(t, desc.LastError)
 	require.Equal(t, desc.Kind, (description.ServerKind)(description.Unknown))
 }
+
+func TestConnectionStepdownErrors(t *testing.T) {
+	ctx := context.Background()
+	reply := func(doc bsonx.Doc) wiremessage.WireMessage {
+		b, err := doc.MarshalBSON()
+		require.NoError(t, err)
+		return wiremessage.Msg{Sections: []wiremessage.Section{wiremessage.SectionBody{Document: b}}}
+	}
+	failed := func(elems ...bsonx.Elem) wiremessage.WireMessage {
+		return reply(append(bsonx.Doc{{"ok", bsonx.Int32(0)}}, elems...))
+	}
+
+	testCases := []struct {
+		name     string
+		reply    wiremessage.WireMessage
+		stepdown bool
+	}{
+		{"NotWritablePrimary code", failed(bsonx.Elem{"code", bsonx.Int32(10107)}), true},
+		{"NotPrimaryNoSecondaryOk code", failed(bsonx.Elem{"code", bsonx.Int32(13435)}), true},
+		{"NotWritablePrimary name", failed(bsonx.Elem{"codeName", bsonx.String("NotWritablePrimary")}), true},
+		{"NotMasterNoSlaveOk name", failed(bsonx.Elem{"codeName", bsonx.String("NotMasterNoSlaveOk")}), true},
+		{"not primary message", failed(bsonx.Elem{"errmsg", bsonx.String("not primary")}), true},
+		{"not master message", failed(bsonx.Elem{"errmsg", bsonx.String("not master")}), true},
+		{"other error", failed(bsonx.Elem{"code", bsonx.Int32(11000)}), false},
+		{"success", reply(bsonx.Doc{{"ok", bsonx.Int32(1)}}), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewServer(address.Address("localhost"))
+			require.NoError(t, err)
+			s.connectionstate = connected
+			s.desc.Store(description.Server{Addr: s.address, Kind: description.RSPrimary})
+
+			sc := sconn{Connection: &reauthConn{responses: []wiremessage.WireMessage{tc.reply}}, s: s, id: 1}
+			_, err = sc.ReadWireMessage(ctx)
+			require.NoError(t, err)
+
+			desc := s.Description()
+			if !tc.stepdown {
+				require.Equal(t, description.ServerKind(description.RSPrimary), desc.Kind)
+				require.Len(t, s.checkNow, 0)
+				return
+			}
+			require.Equal(t, description.ServerKind(description.Unknown), desc.Kind)
+			require.Error(t, desc.LastError)
+			require.Len(t, s.checkNow, 1, "expected an immediate check to be requested")
+		})
+	}
+}
+
+// reauthConn replies to each write with the next response and records reauthentications.
+type reauthConn struct {
+	connect
+	responses []wiremessage.WireMessage
+	written   []wiremessage.WireMessage
+	reauthErr error
+	reauths   int
+}
+
+func (c *reauthConn) WriteWireMessage(ctx context.Context, wm wiremessage.WireMessage) error {
+	c.written = append(c.written, wm)
+	return nil
+}
+
+func (c *reauthConn) ReadWireMessage(ctx context.Context) (wiremessage.WireMessage, error) {
+	wm := c.responses[0]
+	c.responses = c.responses[1:]
+	return wm, nil
+}
+
+func (c *reauthConn) Reauthenticate(ctx context.Context) error {
+	c.reauths++
+	return c.reauthErr
+}
+
+func TestConnectionReauthentication(t *testing.T) {
+	ctx := context.Background()
+	msg := func(doc bsonx.Doc) wiremessage.WireMessage {
+		b, err := doc.MarshalBSON()
+		require.NoError(t, err)
+		return wiremessage.Msg{Sections: []wiremessage.Section{wiremessage.SectionBody{Document: b}}}
+	}
+	reauthRequired := msg(bsonx.Doc{
+		{"ok", bsonx.Int32(0)},
+		{"code", bsonx.Int32(reauthenticationRequiredCode)},
+		{"errmsg", bsonx.String("reauthentication required")},
+	})
+	ok := msg(bsonx.Doc{{"ok", bsonx.Int32(1)}})
+	find := msg(bsonx.Doc{{"find", bsonx.String("coll")}})
+
+	newSconn := func(t *testing.T, c *reauthConn) *sconn {
+		s, err := NewServer(address.Address("localhost"))
+		require.NoError(t, err)
+		return &sconn{Connection: c, s: s, id: 1}
+	}
+
+	t.Run("retries once after reauthenticating", func(t *testing.T) {
+		c := &reauthConn{responses: []wiremessage.WireMessage{reauthRequired, ok}}
+		sc := newSconn(t, c)
+
+		require.NoError(t, sc.WriteWireMessage(ctx, find))
+		wm, err := sc.ReadWireMessage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, ok, wm)
+		require.Equal(t, 1, c.reauths)
+		require.Equal(t, []wiremessage.WireMessage{find, find}, c.written)
+	})
+
+	t.Run("returns a second ReauthenticationRequired error", func(t *testing.T) {
+		c := &reauthConn{responses: []wiremessage.WireMessage{reauthRequired, reauthRequired}}
+		sc := newSconn(t, c)
+
+		require.NoError(t, sc.WriteWireMessage(ctx, find))
+		wm, err := sc.ReadWireMessage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, reauthRequired, wm)
+		require.Equal(t, 1, c.reauths)
+		require.Len(t, c.written, 2)
+	})
+
+	t.Run("returns reauthentication errors", func(t *testing.T) {
+		c := &reauthConn{
+			responses: []wiremessage.WireMessage{reauthRequired},
+			reauthErr: errors.New("token expired"),
+		}
+		sc := newSconn(t, c)
+
+		require.NoError(t, sc.WriteWireMessage(ctx, find))
+		_, err := sc.ReadWireMessage(ctx)
+		require.Equal(t, c.reauthErr, err)
+		require.Len(t, c.written, 1)
+	})
+
+	t.Run("does not retry replies without a preceding write", func(t *testing.T) {
+		c := &reauthConn{responses: []wiremessage.WireMessage{ok, reauthRequired}}
+		sc := newSconn(t, c)
+
+		require.NoError(t, sc.WriteWireMessage(ctx, find))
+		_, err := sc.ReadWireMessage(ctx)
+		require.NoError(t, err)
+
+		// a second reply to the same request, as sent for exhaust cursors
+		wm, err := sc.ReadWireMessage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, reauthRequired, wm)
+		require.Equal(t, 0, c.reauths)
+	})
+}
+
+// DecodeError returns the command error from a reply, so every reply read through an sconn is checked for
+// not master and node is recovering errors.
+func TestConnectionNotMasterReply(t *testing.T) {
+	ctx := context.Background()
+	doc, err := bsonx.Doc{
+		{"ok", bsonx.Int32(0)},
+		{"code", bsonx.Int32(10107)},
+		{"errmsg", bsonx.String("not master")},
+	}.MarshalBSON()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name  string
+		reply wiremessage.WireMessage
+	}{
+		{"OP_MSG", wiremessage.Msg{Sections: []wiremessage.Section{wiremessage.SectionBody{Document: doc}}}},
+		{"OP_REPLY", wiremessage.Reply{ResponseFlags: wiremessage.QueryFailure, Documents: []bson.Raw{doc}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewServer(address.Address("localhost"))
+			require.NoError(t, err)
+			s.connectionstate = connected
+			s.desc.Store(description.Server{Addr: s.address, Kind: description.RSPrimary})
+
+			sc := sconn{Connection: &reauthConn{responses: []wiremessage.WireMessage{tc.reply}}, s: s, id: 1}
+			wm, err := sc.ReadWireMessage(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tc.reply, wm)
+
+			desc := s.Description()
+			require.Equal(t, description.ServerKind(description.Unknown), desc.Kind)
+			require.Equal(t, command.Error{Code: 10107, Message: "not master"}, desc.LastError)
+		})
+	}
+}
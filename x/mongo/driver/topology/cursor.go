@@ -17,9 +17,14 @@ import (
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/session"
 	"github.com/mongodb/mongo-go-driver/x/network/command"
+	"github.com/mongodb/mongo-go-driver/x/network/connection"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
 	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
 )
 
+// minExhaustWireVersion is the minimum wire version that supports the exhaustAllowed OP_MSG flag.
+const minExhaustWireVersion = 8
+
 type cursor struct {
 	clientSession *session.Client
 	clock         *session.ClusterClock
@@ -32,12 +37,27 @@ type cursor struct {
 	opts          []bsonx.Elem
 	registry      *bsoncodec.Registry
 
+	// exhaust fields
+	exhaust     bool
+	exhaustConn connection.Connection // pinned while the server is streaming getMore replies
+
+	// prefetch fields
+	prefetch       bool
+	prefetched     chan prefetchResult // receives the reply to the getMore running in the background, if any
+	cancelPrefetch context.CancelFunc
+
 	// legacy server (< 3.2) fields
 	batchSize   int32
 	limit       int32
 	numReturned int32 // number of docs returned by server
 }
 
+// prefetchResult is the outcome of a getMore run in the background.
+type prefetchResult struct {
+	response bson.Raw
+	err      error
+}
+
 func newCursor(result bson.Raw, clientSession *session.Client, clock *session.ClusterClock, server *Server, opts ...bsonx.Elem) (command.Cursor, error) {
 	cur, err := result.LookupErr("cursor")
 	if err != nil {
@@ -137,6 +157,14 @@ func (c *cursor) ID() int64 {
 	return c.id
 }
 
+func (c *cursor) RemainingBatchLength() int {
+	// current is the index of the document last returned by Next, or -1 before the first call
+	if remaining := len(c.batch) - c.current - 1; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
 // returns true if the cursor is for a server with version < 3.2
 func (c *cursor) legacy() bool {
 	return c.server.Description().WireVersion.Max < 4
@@ -199,6 +227,10 @@ func (c *cursor) Err() error {
 	return c.err
 }
 
+func (c *cursor) ForEach(ctx context.Context, fn func(bson.Raw) error) error {
+	return command.ForEach(ctx, c, fn)
+}
+
 func (c *cursor) Close(ctx context.Context) error {
 	if ctx == nil {
 		ctx = context.Background()
@@ -209,6 +241,18 @@ func (c *cursor) Close(ctx context.Context) error {
 	}
 
 	defer c.closeImplicitSession()
+	if c.prefetched != nil {
+		// the background getMore must finish before the cursor is killed and its session is ended
+		c.cancelPrefetch()
+		<-c.prefetched
+		c.prefetched = nil
+	}
+	if c.exhaustConn != nil {
+		// The server is still streaming replies, so the connection cannot be reused. Closing it ends
+		// the stream; killCursors below cleans up the cursor if the server has not already.
+		_ = c.releaseExhaustConn(true)
+	}
+
 	conn, err := c.server.Connection(ctx)
 	if err != nil {
 		return err
@@ -349,26 +393,16 @@ func (c *cursor) getMore(ctx context.Context) {
 		return
 	}
 
-	conn, err := c.server.Connection(ctx)
-	if err != nil {
-		c.err = err
-		return
+	var response bson.Raw
+	var err error
+	switch {
+	case c.prefetched != nil:
+		response, err = c.awaitPrefetch(ctx)
+	case c.exhaustConn != nil || c.exhaustSupported():
+		response, err = c.exhaustGetMore(ctx)
+	default:
+		response, err = c.roundTripGetMore(ctx, c.getMoreCommand())
 	}
-
-	response, err := (&command.GetMore{
-		Clock:   c.clock,
-		ID:      c.id,
-		NS:      c.namespace,
-		Opts:    c.opts,
-		Session: c.clientSession,
-	}).RoundTrip(ctx, c.server.SelectedDescription(), conn)
-	if err != nil {
-		_ = conn.Close() // The command response error is more important here
-		c.err = err
-		return
-	}
-
-	err = conn.Close()
 	if err != nil {
 		c.err = err
 		return
@@ -386,6 +420,11 @@ func (c *cursor) getMore(ctx context.Context) {
 		return
 	}
 
+	// a stream that claims more replies for a finished cursor is in an unknown state
+	if c.id == 0 && c.exhaustConn != nil {
+		c.err = c.releaseExhaustConn(true)
+	}
+
 	// if this is the last getMore, close the session
 	if c.id == 0 {
 		c.closeImplicitSession()
@@ -403,8 +442,158 @@ func (c *cursor) getMore(ctx context.Context) {
 		return
 	}
 	c.batch, c.err = arr.Values()
+	if c.err == nil && c.id != 0 && c.prefetchSupported() {
+		c.startPrefetch()
+	}
+}
+
+func (c *cursor) getMoreCommand() *command.GetMore {
+	return &command.GetMore{
+		Clock:   c.clock,
+		ID:      c.id,
+		NS:      c.namespace,
+		Opts:    c.opts,
+		Session: c.clientSession,
+	}
+}
+
+func (c *cursor) roundTripGetMore(ctx context.Context, gm *command.GetMore) (bson.Raw, error) {
+	conn, err := c.server.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := gm.RoundTrip(ctx, c.server.SelectedDescription(), conn)
+	if err != nil {
+		_ = conn.Close() // The command response error is more important here
+		return nil, err
+	}
+
+	err = conn.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// returns true if this is a prefetching cursor whose next batch can be fetched in the background. Cursors on an
+// explicit session never prefetch, because the application may use the session concurrently, and exhaust cursors
+// already receive their batches without waiting for a getMore.
+func (c *cursor) prefetchSupported() bool {
+	if !c.prefetch || c.exhaust || c.legacy() {
+		return false
+	}
+	return c.clientSession == nil || c.clientSession.SessionType == session.Implicit
+}
 
-	return
+// startPrefetch sends the next getMore in the background. The command is built before the goroutine starts, so the
+// goroutine only touches the server and the cursor's implicit session, which nothing else uses until awaitPrefetch
+// or Close receives the result.
+func (c *cursor) startPrefetch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	results := make(chan prefetchResult, 1)
+	gm := c.getMoreCommand()
+
+	go func() {
+		response, err := c.roundTripGetMore(ctx, gm)
+		results <- prefetchResult{response: response, err: err}
+	}()
+
+	c.prefetched = results
+	c.cancelPrefetch = cancel
+}
+
+// awaitPrefetch returns the reply to the getMore started by startPrefetch. If ctx is done first, the getMore is
+// left running so a later call can still receive its reply.
+func (c *cursor) awaitPrefetch(ctx context.Context) (bson.Raw, error) {
+	select {
+	case res := <-c.prefetched:
+		c.prefetched = nil
+		c.cancelPrefetch()
+		return res.response, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// returns true if this is an exhaust cursor and its server can stream getMore replies
+func (c *cursor) exhaustSupported() bool {
+	if !c.exhaust {
+		return false
+	}
+
+	desc := c.server.Description()
+	return desc.Kind != description.Mongos && desc.WireVersion != nil &&
+		desc.WireVersion.Max >= minExhaustWireVersion
+}
+
+// exhaustGetMore returns the next streamed getMore reply. The first call sends a getMore with the
+// exhaustAllowed flag set and pins the connection it was sent on; the connection stays pinned until a reply
+// arrives without the moreToCome flag, at which point it is returned to the pool.
+func (c *cursor) exhaustGetMore(ctx context.Context) (bson.Raw, error) {
+	desc := c.server.SelectedDescription()
+	gm := c.getMoreCommand()
+
+	if c.exhaustConn == nil {
+		conn, err := c.server.Connection(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		wm, err := gm.Encode(desc)
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		msg, ok := wm.(wiremessage.Msg)
+		if !ok {
+			_ = conn.Close()
+			return nil, errors.New("exhaust getMore must be sent as OP_MSG")
+		}
+		msg.FlagBits |= wiremessage.ExhaustAllowed
+
+		err = conn.WriteWireMessage(ctx, msg)
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		c.exhaustConn = conn
+	}
+
+	wm, err := c.exhaustConn.ReadWireMessage(ctx)
+	if err != nil {
+		// the rest of the stream can't be read, so the connection is left in an unknown state
+		_ = c.releaseExhaustConn(true)
+		return nil, err
+	}
+
+	if msg, ok := wm.(wiremessage.Msg); !ok || msg.FlagBits&wiremessage.MoreToCome == 0 {
+		err = c.releaseExhaustConn(false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.clientSession != nil {
+		err = c.clientSession.UpdateUseTime()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return gm.Decode(desc, wm).Result()
+}
+
+// releaseExhaustConn unpins the exhaust connection. If discard is true the connection is closed instead of
+// being returned to the pool.
+func (c *cursor) releaseExhaustConn(discard bool) error {
+	conn := c.exhaustConn
+	c.exhaustConn = nil
+	if d, ok := conn.(connection.Discarder); ok && discard {
+		return d.Discard()
+	}
+	return conn.Close()
 }
 
 func validateGetMoreReply(reply wiremessage.Reply) error {
@@ -16,6 +16,8 @@ import (
 	"github.com/mongodb/mongo-go-driver/internal"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/session"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
 	"github.com/mongodb/mongo-go-driver/x/network/connection"
 	"github.com/mongodb/mongo-go-driver/x/network/description"
 	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
@@ -44,6 +46,40 @@ func TestCursorNextDoesNotPanicIfContextisNil(t *testing.T) {
 	assert.True(t, iterNext)
 }
 
+func TestCursorRemainingBatchLength(t *testing.T) {
+	c := cursor{
+		current: -1,
+		batch: []bson.RawValue{
+			{Type: bsontype.String, Value: bsoncore.AppendString(nil, "a")},
+			{Type: bsontype.String, Value: bsoncore.AppendString(nil, "b")},
+			{Type: bsontype.String, Value: bsoncore.AppendString(nil, "c")},
+		},
+	}
+
+	assert.Equal(t, 3, c.RemainingBatchLength())
+	for remaining := 2; remaining >= 0; remaining-- {
+		assert.True(t, c.Next(context.Background()))
+		assert.Equal(t, remaining, c.RemainingBatchLength())
+	}
+	assert.False(t, c.Next(context.Background()))
+	assert.Equal(t, 0, c.RemainingBatchLength())
+	assert.Equal(t, int64(0), c.ID())
+}
+
+func TestCursorRemainingBatchLengthAfterGetMore(t *testing.T) {
+	s := createDefaultConnectedServer(t, false)
+	c := cursor{
+		id:      1,
+		current: -1,
+		batch:   []bson.RawValue{},
+		server:  s,
+	}
+
+	assert.Equal(t, 0, c.RemainingBatchLength())
+	assert.True(t, c.Next(nil))
+	assert.Equal(t, len(c.batch)-1, c.RemainingBatchLength())
+}
+
 func TestCursorLoopsUntilDocAvailable(t *testing.T) {
 	// Next should loop until at least one doc is available
 	// Here, the mock pool and connection implementations (below) write
@@ -97,6 +133,237 @@ func TestCursorNextReturnsFalseIfResIdZeroAndNoMoreDocs(t *testing.T) {
 	assert.False(t, c.Next(nil))
 }
 
+func TestExhaustCursor(t *testing.T) {
+	ns := command.Namespace{DB: "db", Collection: "coll"}
+	newServer := func(t *testing.T, wireVersion int32, conns ...*exhaustConnection) (*Server, *exhaustPool) {
+		s, err := ConnectServer(nil, "127.0.0.1")
+		noerr(t, err)
+		pool := &exhaustPool{conns: conns}
+		s.pool = pool
+		s.desc.Store(description.Server{WireVersion: &description.VersionRange{Max: wireVersion}})
+		return s, pool
+	}
+	batch := func(id int64, moreToCome bool, docs ...string) exhaustReply {
+		arr := bsonx.Arr{}
+		for _, doc := range docs {
+			arr = append(arr, bsonx.Document(bsonx.Doc{{"x", bsonx.String(doc)}}))
+		}
+		return exhaustReply{doc: createOKBatchReplyDoc(id, arr), moreToCome: moreToCome}
+	}
+
+	t.Run("streams batches on one connection", func(t *testing.T) {
+		conn := &exhaustConnection{replies: []exhaustReply{
+			batch(5, true, "a"), batch(5, true, "b"), batch(0, false, "c"),
+		}}
+		s, pool := newServer(t, 8, conn)
+		c := &cursor{id: 5, current: -1, server: s, namespace: ns, exhaust: true, registry: s.cfg.registry}
+
+		for i := 0; i < 3; i++ {
+			if !c.Next(context.Background()) {
+				t.Fatalf("expected document %d, got error %v", i, c.Err())
+			}
+		}
+		if c.Next(context.Background()) {
+			t.Errorf("expected cursor to be exhausted")
+		}
+		noerr(t, c.Err())
+		if pool.gets != 1 {
+			t.Errorf("expected 1 connection checkout, got %d", pool.gets)
+		}
+		if len(conn.written) != 1 || conn.written[0].FlagBits&wiremessage.ExhaustAllowed == 0 {
+			t.Errorf("expected a single getMore with exhaustAllowed set, got %v", conn.written)
+		}
+		if !conn.closed || conn.discarded {
+			t.Errorf("expected connection to be returned to the pool")
+		}
+		if c.exhaustConn != nil {
+			t.Errorf("expected connection to be unpinned")
+		}
+	})
+	t.Run("falls back without server support", func(t *testing.T) {
+		conns := []*exhaustConnection{
+			{replies: []exhaustReply{batch(5, false, "a")}},
+			{replies: []exhaustReply{batch(0, false, "b")}},
+		}
+		s, pool := newServer(t, 7, conns...)
+		c := &cursor{id: 5, current: -1, server: s, namespace: ns, exhaust: true, registry: s.cfg.registry}
+
+		for c.Next(context.Background()) {
+		}
+		noerr(t, c.Err())
+		if pool.gets != 2 {
+			t.Errorf("expected 2 connection checkouts, got %d", pool.gets)
+		}
+		for _, conn := range conns {
+			if len(conn.written) != 1 || conn.written[0].FlagBits&wiremessage.ExhaustAllowed != 0 {
+				t.Errorf("expected a single getMore without exhaustAllowed set, got %v", conn.written)
+			}
+		}
+	})
+	t.Run("discards connection on error mid-stream", func(t *testing.T) {
+		conn := &exhaustConnection{replies: []exhaustReply{batch(5, true, "a")}}
+		s, _ := newServer(t, 8, conn)
+		c := &cursor{id: 5, current: -1, server: s, namespace: ns, exhaust: true, registry: s.cfg.registry}
+
+		if !c.Next(context.Background()) {
+			t.Fatalf("expected a document, got error %v", c.Err())
+		}
+		if c.Next(context.Background()) {
+			t.Errorf("expected Next to fail")
+		}
+		if c.Err() == nil {
+			t.Errorf("expected an error")
+		}
+		if !conn.discarded {
+			t.Errorf("expected connection to be discarded")
+		}
+		if c.exhaustConn != nil {
+			t.Errorf("expected connection to be unpinned")
+		}
+	})
+	t.Run("close mid-stream discards connection", func(t *testing.T) {
+		conn := &exhaustConnection{replies: []exhaustReply{batch(5, true, "a")}}
+		killConn := &exhaustConnection{replies: []exhaustReply{{doc: bsonx.Doc{{"ok", bsonx.Int32(1)}}}}}
+		s, pool := newServer(t, 8, conn, killConn)
+		c := &cursor{id: 5, current: -1, server: s, namespace: ns, exhaust: true, registry: s.cfg.registry}
+
+		if !c.Next(context.Background()) {
+			t.Fatalf("expected a document, got error %v", c.Err())
+		}
+		noerr(t, c.Close(context.Background()))
+		if !conn.discarded {
+			t.Errorf("expected streaming connection to be discarded")
+		}
+		if pool.gets != 2 || len(killConn.written) != 1 {
+			t.Errorf("expected killCursors to be sent on a new connection")
+		}
+		if !killConn.closed || killConn.discarded {
+			t.Errorf("expected killCursors connection to be returned to the pool")
+		}
+	})
+}
+
+func TestPrefetchCursor(t *testing.T) {
+	newServer := func(t *testing.T, conns ...*exhaustConnection) (*Server, *exhaustPool) {
+		s, err := ConnectServer(nil, "127.0.0.1")
+		noerr(t, err)
+		pool := &exhaustPool{conns: conns}
+		s.pool = pool
+		s.desc.Store(description.Server{WireVersion: &description.VersionRange{Max: 6}})
+		return s, pool
+	}
+	docs := func(docs ...string) bsonx.Arr {
+		arr := bsonx.Arr{}
+		for _, doc := range docs {
+			arr = append(arr, bsonx.Document(bsonx.Doc{{"x", bsonx.String(doc)}}))
+		}
+		return arr
+	}
+	firstBatch := func(t *testing.T, id int64, batch ...string) bson.Raw {
+		raw, err := bsonx.Doc{
+			{"ok", bsonx.Int32(1)},
+			{"cursor", bsonx.Document(bsonx.Doc{
+				{"id", bsonx.Int64(id)},
+				{"ns", bsonx.String("db.coll")},
+				{"firstBatch", bsonx.Array(docs(batch...))},
+			})},
+		}.MarshalBSON()
+		noerr(t, err)
+		return raw
+	}
+	reply := func(id int64, batch ...string) exhaustReply {
+		return exhaustReply{doc: createOKBatchReplyDoc(id, docs(batch...))}
+	}
+	next := func(t *testing.T, c command.Cursor, want string) {
+		if !c.Next(context.Background()) {
+			t.Fatalf("expected document %q, got error %v", want, c.Err())
+		}
+		var doc struct{ X string }
+		noerr(t, c.Decode(&doc))
+		if doc.X != want {
+			t.Fatalf("expected document %q, got %q", want, doc.X)
+		}
+	}
+
+	t.Run("fetches the next batch in the background", func(t *testing.T) {
+		written := make(chan struct{}, 2)
+		conns := []*exhaustConnection{
+			{replies: []exhaustReply{reply(5, "b")}, onWrite: written},
+			{replies: []exhaustReply{reply(0, "c")}, onWrite: written},
+		}
+		s, pool := newServer(t, conns...)
+		c, err := s.BuildPrefetchCursor(firstBatch(t, 5, "a"), nil, nil)
+		noerr(t, err)
+
+		// the first getMore is sent before the first batch is iterated
+		<-written
+		next(t, c, "a")
+		next(t, c, "b")
+		<-written
+		next(t, c, "c")
+		if c.Next(context.Background()) {
+			t.Errorf("expected cursor to be exhausted")
+		}
+		noerr(t, c.Err())
+		if pool.gets != 2 {
+			t.Errorf("expected 2 connection checkouts, got %d", pool.gets)
+		}
+		for _, conn := range conns {
+			if len(conn.written) != 1 || !conn.closed {
+				t.Errorf("expected one getMore per connection and the connection to be returned to the pool")
+			}
+		}
+	})
+	t.Run("background errors are returned by Next", func(t *testing.T) {
+		s, _ := newServer(t, &exhaustConnection{})
+		c, err := s.BuildPrefetchCursor(firstBatch(t, 5, "a"), nil, nil)
+		noerr(t, err)
+
+		next(t, c, "a")
+		if c.Next(context.Background()) {
+			t.Errorf("expected Next to fail")
+		}
+		if c.Err() == nil || c.Err().Error() != "intentional mock error" {
+			t.Errorf("expected the getMore error, got %v", c.Err())
+		}
+	})
+	t.Run("close cancels the background getMore", func(t *testing.T) {
+		written := make(chan struct{}, 1)
+		conn := &exhaustConnection{block: true, onWrite: written}
+		killConn := &exhaustConnection{replies: []exhaustReply{{doc: bsonx.Doc{{"ok", bsonx.Int32(1)}}}}}
+		s, pool := newServer(t, conn, killConn)
+		c, err := s.BuildPrefetchCursor(firstBatch(t, 5, "a"), nil, nil)
+		noerr(t, err)
+
+		<-written
+		noerr(t, c.Close(context.Background()))
+		if pool.gets != 2 || len(killConn.written) != 1 {
+			t.Errorf("expected killCursors to be sent on a new connection")
+		}
+		if !conn.closed {
+			t.Errorf("expected the getMore connection to be closed")
+		}
+	})
+	t.Run("explicit sessions do not prefetch", func(t *testing.T) {
+		s, pool := newServer(t, &exhaustConnection{replies: []exhaustReply{reply(0, "b")}})
+		sess := &session.Client{
+			Server:      &session.Server{SessionID: bsonx.Doc{{"id", bsonx.Int32(1)}}},
+			SessionType: session.Explicit,
+		}
+		c, err := s.BuildPrefetchCursor(firstBatch(t, 5, "a"), sess, nil)
+		noerr(t, err)
+
+		next(t, c, "a")
+		if pool.gets != 0 {
+			t.Errorf("expected no getMore before the first batch was iterated")
+		}
+		next(t, c, "b")
+		if pool.gets != 1 {
+			t.Errorf("expected 1 connection checkout, got %d", pool.gets)
+		}
+	})
+}
+
 func createDefaultConnectedServer(t *testing.T, willErr bool) *Server {
 	s, err := ConnectServer(nil, "127.0.0.1")
 	s.pool = &mockPool{t: t, willErr: willErr}
@@ -149,6 +416,10 @@ func (*mockPool) Drain() error {
 	return nil
 }
 
+func (*mockPool) Stats() connection.PoolStats {
+	return connection.PoolStats{}
+}
+
 // Mock Connection implementation that
 type mockConnection struct {
 	t       *testing.T
@@ -199,3 +470,87 @@ func (*mockConnection) Alive() bool {
 func (*mockConnection) ID() string {
 	return ""
 }
+
+// exhaustPool hands out the given connections in order.
+type exhaustPool struct {
+	conns []*exhaustConnection
+	gets  int
+}
+
+func (p *exhaustPool) Get(ctx context.Context) (connection.Connection, *description.Server, error) {
+	if p.gets >= len(p.conns) {
+		return nil, nil, errors.New("no more connections")
+	}
+	p.gets++
+	return p.conns[p.gets-1], nil, nil
+}
+
+func (*exhaustPool) Connect(ctx context.Context) error    { return nil }
+func (*exhaustPool) Disconnect(ctx context.Context) error { return nil }
+func (*exhaustPool) Drain() error                         { return nil }
+func (*exhaustPool) Stats() connection.PoolStats          { return connection.PoolStats{} }
+
+type exhaustReply struct {
+	doc        bsonx.Doc
+	moreToCome bool
+}
+
+// exhaustConnection records the OP_MSGs written to it and replies with the queued replies, returning an
+// error once they run out.
+type exhaustConnection struct {
+	replies   []exhaustReply
+	written   []wiremessage.Msg
+	closed    bool
+	discarded bool
+
+	block   bool          // if true, reads wait for the context to be done
+	onWrite chan struct{} // if set, receives a value after each write
+}
+
+func (c *exhaustConnection) WriteWireMessage(ctx context.Context, wm wiremessage.WireMessage) error {
+	msg, ok := wm.(wiremessage.Msg)
+	if !ok {
+		return errors.New("expected an OP_MSG")
+	}
+	c.written = append(c.written, msg)
+	if c.onWrite != nil {
+		c.onWrite <- struct{}{}
+	}
+	return nil
+}
+
+func (c *exhaustConnection) ReadWireMessage(ctx context.Context) (wiremessage.WireMessage, error) {
+	if c.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if len(c.replies) == 0 {
+		return nil, errors.New("intentional mock error")
+	}
+	reply := c.replies[0]
+	c.replies = c.replies[1:]
+
+	doc, err := reply.doc.MarshalBSON()
+	if err != nil {
+		return nil, err
+	}
+	msg := wiremessage.Msg{Sections: []wiremessage.Section{wiremessage.SectionBody{Document: doc}}}
+	if reply.moreToCome {
+		msg.FlagBits |= wiremessage.MoreToCome
+	}
+	return msg, nil
+}
+
+func (c *exhaustConnection) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (c *exhaustConnection) Discard() error {
+	c.discarded = true
+	return nil
+}
+
+func (*exhaustConnection) Expired() bool { return false }
+func (*exhaustConnection) Alive() bool   { return true }
+func (*exhaustConnection) ID() string    { return "" }
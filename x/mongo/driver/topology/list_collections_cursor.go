@@ -30,6 +30,10 @@ func (c *listCollectionsCursor) ID() int64 {
 	return c.cursor.ID()
 }
 
+func (c *listCollectionsCursor) RemainingBatchLength() int {
+	return c.cursor.RemainingBatchLength()
+}
+
 func (c *listCollectionsCursor) Next(ctx context.Context) bool {
 	return c.cursor.Next(ctx)
 }
@@ -56,6 +60,10 @@ func (c *listCollectionsCursor) Err() error {
 	return c.cursor.Err()
 }
 
+func (c *listCollectionsCursor) ForEach(ctx context.Context, fn func(bson.Raw) error) error {
+	return command.ForEach(ctx, c, fn)
+}
+
 func (c *listCollectionsCursor) Close(ctx context.Context) error {
 	return c.cursor.Close(ctx)
 }
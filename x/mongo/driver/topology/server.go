@@ -161,15 +161,19 @@ func (s *Server) Disconnect(ctx context.Context) error {
 	// For every call to Connect there must be at least 1 goroutine that is
 	// waiting on the done channel.
 	s.done <- struct{}{}
+	// The pool closes every connection even if ctx expires first, so the server is always
+	// disconnected once the pool returns.
 	err := s.pool.Disconnect(ctx)
-	if err != nil {
-		return err
-	}
 
 	s.closewg.Wait()
 	atomic.StoreInt32(&s.connectionstate, disconnected)
 
-	return nil
+	return err
+}
+
+// PoolStats returns a snapshot of the number of connections in the server's connection pool.
+func (s *Server) PoolStats() connection.PoolStats {
+	return s.pool.Stats()
 }
 
 // Connection gets a connection to the server.
@@ -419,8 +423,9 @@ func (s *Server) heartbeat(conn connection.Connection) (description.Server, conn
 
 	if !set {
 		desc = description.Server{
-			Addr:      s.address,
-			LastError: saved,
+			Addr:              s.address,
+			LastError:         saved,
+			HeartbeatInterval: s.cfg.heartbeatInterval,
 		}
 	}
 
@@ -450,6 +455,33 @@ func (s *Server) BuildCursor(result bson.Raw, clientSession *session.Client, clo
 	return newCursor(result, clientSession, clock, s, opts...)
 }
 
+// BuildExhaustCursor builds a cursor that streams its getMore replies over a single pinned connection
+// when the server supports it, and otherwise behaves like a cursor from BuildCursor.
+func (s *Server) BuildExhaustCursor(result bson.Raw, clientSession *session.Client, clock *session.ClusterClock, opts ...bsonx.Elem) (command.Cursor, error) {
+	cur, err := newCursor(result, clientSession, clock, s, opts...)
+	if err != nil {
+		return nil, err
+	}
+	cur.(*cursor).exhaust = true
+	return cur, nil
+}
+
+// BuildPrefetchCursor builds a cursor that sends each getMore in the background as soon as the previous batch
+// arrives, so the next batch is usually ready by the time the current one has been iterated. Cursors on an
+// explicit session behave like a cursor from BuildCursor.
+func (s *Server) BuildPrefetchCursor(result bson.Raw, clientSession *session.Client, clock *session.ClusterClock, opts ...bsonx.Elem) (command.Cursor, error) {
+	cur, err := newCursor(result, clientSession, clock, s, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c := cur.(*cursor)
+	c.prefetch = true
+	if c.id != 0 && c.prefetchSupported() {
+		c.startPrefetch()
+	}
+	return c, nil
+}
+
 // BuildLegacyCursor implements the command.CursorBuilder interface for the Server type.
 func (s *Server) BuildLegacyCursor(ns command.Namespace, cursorID int64, batch []bson.Raw, limit int32, batchSize int32) (command.Cursor, error) {
 	return newLegacyCursor(ns, cursorID, batch, limit, batchSize, s)
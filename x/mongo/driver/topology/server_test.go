@@ -22,6 +22,7 @@ type pool struct {
 	connectionError bool
 	drainCalled     atomic.Value
 	networkError    bool
+	disconnectError error
 	desc            *description.Server
 }
 
@@ -40,7 +41,7 @@ func (p *pool) Connect(ctx context.Context) error {
 }
 
 func (p *pool) Disconnect(ctx context.Context) error {
-	return nil
+	return p.disconnectError
 }
 
 func (p *pool) Drain() error {
@@ -48,6 +49,10 @@ func (p *pool) Drain() error {
 	return nil
 }
 
+func (p *pool) Stats() connection.PoolStats {
+	return connection.PoolStats{}
+}
+
 func NewPool(connectionError bool, networkError bool, desc *description.Server) (connection.Pool, error) {
 	p := &pool{
 		connectionError: connectionError,
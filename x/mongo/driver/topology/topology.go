@@ -13,14 +13,19 @@ package topology
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/rand"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
+	"github.com/mongodb/mongo-go-driver/event"
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/logger"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/session"
 	"github.com/mongodb/mongo-go-driver/x/network/address"
+	"github.com/mongodb/mongo-go-driver/x/network/connection"
 	"github.com/mongodb/mongo-go-driver/x/network/description"
 )
 
@@ -36,10 +41,69 @@ var ErrTopologyClosed = errors.New("topology is closed")
 // already connected Topology.
 var ErrTopologyConnected = errors.New("topology is connected or connecting")
 
+// ErrLoadBalancedUnsupported is returned when a topology is configured with a
+// connection string that sets loadBalanced=true. Connecting to a deployment
+// through a load balancer is not supported.
+var ErrLoadBalancedUnsupported = errors.New("loadBalanced=true is unsupported")
+
 // ErrServerSelectionTimeout is returned from server selection when the server
 // selection process took longer than allowed by the timeout.
 var ErrServerSelectionTimeout = errors.New("server selection timeout")
 
+// ServerSelectionError explains why server selection timed out. It is not
+// returned from SelectServer, which always returns ErrServerSelectionTimeout so
+// that existing comparisons against it keep working; it is available from
+// LastSelectionError instead. Desc is the last topology description selection
+// ran against and Exclusions explains why each of its servers was not suitable,
+// such as the estimated staleness of secondaries that exceed max staleness.
+// Wrapped is always ErrServerSelectionTimeout.
+type ServerSelectionError struct {
+	Wrapped    error
+	Desc       description.Topology
+	Exclusions []description.ServerExclusion
+}
+
+// Error implements the error interface.
+func (e ServerSelectionError) Error() string {
+	reasons := make([]string, 0, len(e.Exclusions))
+	for _, exclusion := range e.Exclusions {
+		reasons = append(reasons, exclusion.String())
+	}
+	return fmt.Sprintf("%v; excluded servers: %s", e.Wrapped, strings.Join(reasons, ", "))
+}
+
+// Unwrap returns the wrapped error.
+func (e ServerSelectionError) Unwrap() error {
+	return e.Wrapped
+}
+
+// newServerSelectionError explains why no server in desc was selected by ss. Servers of unknown type are never
+// passed to selectors, so they are reported here. It returns ErrServerSelectionTimeout itself if there is nothing
+// to explain.
+func newServerSelectionError(desc description.Topology, ss description.ServerSelector) error {
+	var known []description.Server
+	var exclusions []description.ServerExclusion
+	for _, s := range desc.Servers {
+		if s.Kind == description.Unknown {
+			exclusions = append(exclusions, description.ServerExclusion{
+				Addr:      s.Addr,
+				Kind:      s.Kind,
+				Reason:    description.ExcludedUnknown,
+				LastError: s.LastError,
+			})
+			continue
+		}
+		known = append(known, s)
+	}
+	if explainer, ok := ss.(description.SelectionExplainer); ok {
+		exclusions = append(exclusions, explainer.ExplainExclusions(desc, known)...)
+	}
+	if len(exclusions) == 0 {
+		return ErrServerSelectionTimeout
+	}
+	return ServerSelectionError{Wrapped: ErrServerSelectionTimeout, Desc: desc, Exclusions: exclusions}
+}
+
 // MonitorMode represents the way in which a server is monitored.
 type MonitorMode uint8
 
@@ -59,6 +123,9 @@ type Topology struct {
 
 	desc atomic.Value // holds a description.Topology
 
+	lastSelectionErr     error
+	lastSelectionErrLock sync.Mutex
+
 	done chan struct{}
 
 	fsm       *fsm
@@ -112,6 +179,21 @@ func New(opts ...Option) (*Topology, error) {
 		t.fsm.Kind = description.Single
 	}
 
+	if cfg.logger != nil {
+		// The command monitors are wrapped last so that commands are logged whichever monitors were
+		// configured before.
+		cfg.cmdMonitor = cfg.logger.CommandMonitor(cfg.cmdMonitor)
+		cfg.serverOpts = append(cfg.serverOpts, WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+			return append(
+				opts,
+				connection.WithLogger(func(*logger.Logger) *logger.Logger { return cfg.logger }),
+				connection.WithMonitor(func(m *event.CommandMonitor) *event.CommandMonitor {
+					return cfg.logger.CommandMonitor(m)
+				}),
+			)
+		}))
+	}
+
 	return t, nil
 }
 
@@ -123,6 +205,8 @@ func (t *Topology) Connect(ctx context.Context) error {
 	}
 
 	t.desc.Store(description.Topology{})
+	t.cfg.logger.Print(logger.LevelDebug, logger.ComponentTopology, "Starting topology monitoring",
+		"seedList", strings.Join(t.cfg.seedList, ","))
 	var err error
 	t.serversLock.Lock()
 	for _, a := range t.cfg.seedList {
@@ -142,20 +226,39 @@ func (t *Topology) Connect(ctx context.Context) error {
 	// After connection, make a subscription to keep the pool updated
 	sub, err := t.Subscribe()
 	t.SessionPool = session.NewPool(sub.C)
+	t.SessionPool.SetMaxSize(t.cfg.maxSessionPoolSize)
+
+	if t.cfg.topologyMonitor != nil {
+		availabilitySub, err := t.Subscribe()
+		if err != nil {
+			return err
+		}
+		go monitorAvailability(t.cfg.topologyMonitor, availabilitySub.C)
+	}
 	return err
 }
 
 // Disconnect closes the topology. It stops the monitoring thread and
-// closes all open subscriptions.
+// closes all open subscriptions. Each server waits until ctx is done for
+// its connections in use to be returned. If ctx is done first, the
+// remaining connections are closed and a connection.DisconnectError
+// reporting the total number of connections that were in use is returned.
 func (t *Topology) Disconnect(ctx context.Context) error {
 	if !atomic.CompareAndSwapInt32(&t.connectionstate, connected, disconnecting) {
 		return ErrTopologyClosed
 	}
 
+	var disconnectErr *connection.DisconnectError
 	t.serversLock.Lock()
 	t.serversClosed = true
 	for addr, server := range t.servers {
-		t.removeServer(ctx, addr, server)
+		err := t.removeServer(ctx, addr, server)
+		if de, ok := err.(connection.DisconnectError); ok {
+			if disconnectErr == nil {
+				disconnectErr = &connection.DisconnectError{Wrapped: de.Wrapped}
+			}
+			disconnectErr.InFlight += de.InFlight
+		}
 	}
 	t.serversLock.Unlock()
 
@@ -164,8 +267,12 @@ func (t *Topology) Disconnect(ctx context.Context) error {
 	t.changeswg.Wait()
 
 	t.desc.Store(description.Topology{})
+	t.cfg.logger.Print(logger.LevelDebug, logger.ComponentTopology, "Stopped topology monitoring")
 
 	atomic.StoreInt32(&t.connectionstate, disconnected)
+	if disconnectErr != nil {
+		return *disconnectErr
+	}
 	return nil
 }
 
@@ -226,9 +333,15 @@ func (t *Topology) SupportsSessions() bool {
 	return t.Description().SessionTimeoutMinutes != 0 && t.Description().Kind != description.Single
 }
 
+// CommandMonitor returns the command monitor configured with WithCommandMonitor, or nil if there is none.
+func (t *Topology) CommandMonitor() *event.CommandMonitor {
+	return t.cfg.cmdMonitor
+}
+
 // SelectServer selects a server given a selector.SelectServer complies with the
 // server selection spec, and will time out after severSelectionTimeout or when the
-// parent context is done.
+// parent context is done. When it times out it returns ErrServerSelectionTimeout;
+// LastSelectionError explains why no server was selected.
 func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelector) (*SelectedServer, error) {
 	if atomic.LoadInt32(&t.connectionstate) != connected {
 		return nil, ErrTopologyClosed
@@ -247,9 +360,16 @@ func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelect
 	}
 	defer sub.Unsubscribe()
 
+	if t.cfg.logger.Enabled(logger.LevelDebug, logger.ComponentServerSelection) {
+		t.cfg.logger.Print(logger.LevelDebug, logger.ComponentServerSelection, "Server selection started",
+			"topologyDescription", formatTopology(t.Description()))
+	}
+
 	for {
 		suitable, err := t.selectServer(ctx, sub.C, ss, ssTimeoutCh)
 		if err != nil {
+			t.cfg.logger.Print(logger.LevelInfo, logger.ComponentServerSelection, "Server selection failed",
+				"failure", err.Error())
 			return nil, err
 		}
 
@@ -257,8 +377,12 @@ func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelect
 		selectedS, err := t.FindServer(selected)
 		switch {
 		case err != nil:
+			t.cfg.logger.Print(logger.LevelInfo, logger.ComponentServerSelection, "Server selection failed",
+				"failure", err.Error())
 			return nil, err
 		case selectedS != nil:
+			t.cfg.logger.Print(logger.LevelDebug, logger.ComponentServerSelection, "Server selection succeeded",
+				"serverHost", selected.Addr.String())
 			return selectedS, nil
 		default:
 			// We don't have an actual server for the provided description.
@@ -269,6 +393,50 @@ func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelect
 	}
 }
 
+// LastSelectionError returns the error explaining the most recent server selection timeout, or nil if server
+// selection has not timed out. It is a ServerSelectionError if any server was excluded and
+// ErrServerSelectionTimeout otherwise. Concurrent selections that time out overwrite each other's explanation.
+func (t *Topology) LastSelectionError() error {
+	t.lastSelectionErrLock.Lock()
+	defer t.lastSelectionErrLock.Unlock()
+	return t.lastSelectionErr
+}
+
+// PoolStats returns a snapshot of the connection pool of every server in the topology, keyed by
+// server address. It returns an empty map if the topology is not connected.
+func (t *Topology) PoolStats() map[address.Address]connection.PoolStats {
+	stats := make(map[address.Address]connection.PoolStats)
+	if atomic.LoadInt32(&t.connectionstate) != connected {
+		return stats
+	}
+
+	t.serversLock.Lock()
+	defer t.serversLock.Unlock()
+	for addr, server := range t.servers {
+		stats[addr] = server.PoolStats()
+	}
+	return stats
+}
+
+// ServerLatencies returns the average round trip time of every server in the topology whose monitor has measured
+// one, keyed by server address. The averages are the ones server selection compares against the local threshold.
+// It returns an empty map if the topology is not connected.
+func (t *Topology) ServerLatencies() map[address.Address]time.Duration {
+	latencies := make(map[address.Address]time.Duration)
+	if atomic.LoadInt32(&t.connectionstate) != connected {
+		return latencies
+	}
+
+	t.serversLock.Lock()
+	defer t.serversLock.Unlock()
+	for addr, server := range t.servers {
+		if desc := server.Description(); desc.AverageRTTSet {
+			latencies[addr] = desc.AverageRTT
+		}
+	}
+	return latencies
+}
+
 // FindServer will attempt to find a server that fits the given server description.
 // This method will return nil, nil if a matching server could not be found.
 func (t *Topology) FindServer(selected description.Server) (*SelectedServer, error) {
@@ -298,6 +466,9 @@ func (t *Topology) selectServer(ctx context.Context, subscriptionCh <-chan descr
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		case <-timeoutCh:
+			t.lastSelectionErrLock.Lock()
+			t.lastSelectionErr = newServerSelectionError(current, ss)
+			t.lastSelectionErrLock.Unlock()
 			return nil, ErrServerSelectionTimeout
 		case current = <-subscriptionCh:
 		}
@@ -339,6 +510,12 @@ func (t *Topology) update() {
 				continue
 			}
 
+			if t.cfg.logger.Enabled(logger.LevelDebug, logger.ComponentTopology) {
+				if prev := t.Description(); !topologiesEqual(prev, current) {
+					t.cfg.logger.Print(logger.LevelDebug, logger.ComponentTopology, "Topology description changed",
+						"previousDescription", formatTopology(prev), "newDescription", formatTopology(current))
+				}
+			}
 			t.desc.Store(current)
 			t.subLock.Lock()
 			for _, ch := range t.subscribers {
@@ -381,7 +558,7 @@ func (t *Topology) apply(ctx context.Context, desc description.Server) (descript
 
 	for _, removed := range diff.Removed {
 		if s, ok := t.servers[removed.Addr]; ok {
-			t.removeServer(ctx, removed.Addr, s)
+			_ = t.removeServer(ctx, removed.Addr, s)
 		}
 	}
 
@@ -403,6 +580,8 @@ func (t *Topology) addServer(ctx context.Context, addr address.Address) error {
 	}
 
 	t.servers[addr] = svr
+	t.cfg.logger.Print(logger.LevelDebug, logger.ComponentTopology, "Starting server monitoring",
+		"serverHost", addr.String())
 	var sub *ServerSubscription
 	sub, err = svr.Subscribe()
 	if err != nil {
@@ -421,9 +600,31 @@ func (t *Topology) addServer(ctx context.Context, addr address.Address) error {
 	return nil
 }
 
-func (t *Topology) removeServer(ctx context.Context, addr address.Address, server *Server) {
-	_ = server.Disconnect(ctx)
+func (t *Topology) removeServer(ctx context.Context, addr address.Address, server *Server) error {
+	err := server.Disconnect(ctx)
 	delete(t.servers, addr)
+	t.cfg.logger.Print(logger.LevelDebug, logger.ComponentTopology, "Stopped server monitoring",
+		"serverHost", addr.String())
+	return err
+}
+
+// formatTopology summarizes desc for log messages as its kind followed by the address and kind of each of its
+// servers, e.g. "ReplicaSetWithPrimary [a:27017 RSPrimary, b:27017 RSSecondary]".
+func formatTopology(desc description.Topology) string {
+	servers := make([]string, 0, len(desc.Servers))
+	for _, s := range desc.Servers {
+		servers = append(servers, s.Addr.String()+" "+s.Kind.String())
+	}
+	return fmt.Sprintf("%s [%s]", desc.Kind, strings.Join(servers, ", "))
+}
+
+// topologiesEqual returns true if the two descriptions have the same kind and equal servers.
+func topologiesEqual(a, b description.Topology) bool {
+	if a.Kind != b.Kind {
+		return false
+	}
+	diff := description.DiffTopology(a, b)
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
 }
 
 // Subscription is a subscription to updates to the description of the Topology that created this
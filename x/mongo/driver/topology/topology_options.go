@@ -11,7 +11,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mongodb/mongo-go-driver/event"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/auth"
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/logger"
 	"github.com/mongodb/mongo-go-driver/x/network/command"
 	"github.com/mongodb/mongo-go-driver/x/network/compressor"
 	"github.com/mongodb/mongo-go-driver/x/network/connection"
@@ -28,6 +30,10 @@ type config struct {
 	serverOpts             []ServerOption
 	cs                     connstring.ConnString
 	serverSelectionTimeout time.Duration
+	topologyMonitor        *event.TopologyMonitor
+	cmdMonitor             *event.CommandMonitor
+	maxSessionPoolSize     uint64
+	logger                 *logger.Logger
 }
 
 func newConfig(opts ...Option) (*config, error) {
@@ -50,6 +56,9 @@ func newConfig(opts ...Option) (*config, error) {
 func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option {
 	return func(c *config) error {
 		cs := fn(c.cs)
+		if cs.LoadBalanced {
+			return ErrLoadBalancedUnsupported
+		}
 		c.cs = cs
 
 		if cs.ServerSelectionTimeoutSet {
@@ -85,6 +94,10 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 			c.serverOpts = append(c.serverOpts, WithHeartbeatInterval(func(time.Duration) time.Duration { return cs.HeartbeatInterval }))
 		}
 
+		if cs.MaxConnectingSet {
+			connOpts = append(connOpts, connection.WithMaxConnecting(func(uint64) uint64 { return cs.MaxConnecting }))
+		}
+
 		if cs.MaxConnIdleTime > 0 {
 			connOpts = append(connOpts, connection.WithIdleTimeout(func(time.Duration) time.Duration { return cs.MaxConnIdleTime }))
 		}
@@ -94,6 +107,10 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 			c.serverOpts = append(c.serverOpts, WithMaxIdleConnections(func(uint16) uint16 { return cs.MaxPoolSize }))
 		}
 
+		if cs.MinPoolSizeSet {
+			connOpts = append(connOpts, connection.WithWarmupConnections(func(uint64) uint64 { return cs.MinPoolSize }))
+		}
+
 		if cs.ReplicaSet != "" {
 			c.replicaSetName = cs.ReplicaSet
 		}
@@ -137,16 +154,23 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 				x509Username = b.String()
 			}
 
+			if cs.SSLGetClientCertificateSet && cs.SSLGetClientCertificate != nil {
+				tlsConfig.SetGetClientCertificate(cs.SSLGetClientCertificate)
+			}
+
 			connOpts = append(connOpts, connection.WithTLSConfig(func(*connection.TLSConfig) *connection.TLSConfig { return tlsConfig }))
 		}
 
-		if cs.Username != "" || cs.AuthMechanism == auth.MongoDBX509 || cs.AuthMechanism == auth.GSSAPI {
+		if cs.Username != "" || cs.AuthMechanism == auth.MongoDBX509 || cs.AuthMechanism == auth.GSSAPI ||
+			cs.AuthMechanism == auth.MongoDBAWS || cs.AuthMechanism == auth.MongoDBOIDC {
 			cred := &auth.Cred{
-				Source:      "admin",
-				Username:    cs.Username,
-				Password:    cs.Password,
-				PasswordSet: cs.PasswordSet,
-				Props:       cs.AuthMechanismProperties,
+				Source:              "admin",
+				Username:            cs.Username,
+				Password:            cs.Password,
+				PasswordSet:         cs.PasswordSet,
+				PasswordProvider:    cs.PasswordProvider,
+				OIDCMachineCallback: cs.OIDCMachineCallback,
+				Props:               cs.AuthMechanismProperties,
 			}
 
 			if cs.AuthSource != "" {
@@ -158,7 +182,7 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 						cred.Username = x509Username
 					}
 					fallthrough
-				case auth.GSSAPI, auth.PLAIN:
+				case auth.GSSAPI, auth.PLAIN, auth.MongoDBAWS, auth.MongoDBOIDC:
 					cred.Source = "$external"
 				default:
 					cred.Source = cs.Database
@@ -170,6 +194,12 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 				return err
 			}
 
+			connOpts = append(connOpts, connection.WithAuthenticator(func(connection.AuthenticatorFunc) connection.AuthenticatorFunc {
+				if r, ok := authenticator.(auth.Reauthenticator); ok {
+					return r.Reauth
+				}
+				return authenticator.Auth
+			}))
 			connOpts = append(connOpts, connection.WithHandshaker(func(h connection.Handshaker) connection.Handshaker {
 				options := &auth.HandshakeOptions{
 					AppName:       cs.AppName,
@@ -203,6 +233,13 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 					}
 
 					comp = append(comp, zlibComp)
+				case "zstd":
+					zstdComp, err := compressor.CreateZstd()
+					if err != nil {
+						return err
+					}
+
+					comp = append(comp, zstdComp)
 				}
 			}
 
@@ -258,6 +295,15 @@ func WithServerOptions(fn func(...ServerOption) []ServerOption) Option {
 	}
 }
 
+// WithMaxSessionPoolSize configures the maximum number of idle server sessions kept in the topology's session
+// pool. A size of zero, the default, means no limit. See session.Pool.SetMaxSize.
+func WithMaxSessionPoolSize(fn func(uint64) uint64) Option {
+	return func(cfg *config) error {
+		cfg.maxSessionPoolSize = fn(cfg.maxSessionPoolSize)
+		return nil
+	}
+}
+
 // WithServerSelectionTimeout configures a topology's server selection timeout.
 // A server selection timeout of 0 means there is no timeout for server selection.
 func WithServerSelectionTimeout(fn func(time.Duration) time.Duration) Option {
@@ -266,3 +312,29 @@ func WithServerSelectionTimeout(fn func(time.Duration) time.Duration) Option {
 		return nil
 	}
 }
+
+// WithCommandMonitor configures the command monitor notified when operations run against the topology retry a
+// command. Commands themselves are monitored by the monitor of each connection.
+func WithCommandMonitor(fn func(*event.CommandMonitor) *event.CommandMonitor) Option {
+	return func(cfg *config) error {
+		cfg.cmdMonitor = fn(cfg.cmdMonitor)
+		return nil
+	}
+}
+
+// WithTopologyMonitor configures the monitor notified when the topology becomes available or unavailable.
+func WithTopologyMonitor(fn func(*event.TopologyMonitor) *event.TopologyMonitor) Option {
+	return func(cfg *config) error {
+		cfg.topologyMonitor = fn(cfg.topologyMonitor)
+		return nil
+	}
+}
+
+// WithLogger configures the logger the topology reports changes to its description and the outcome of server
+// selection to. The logger is also used to log the commands run on, and the connections made to, its servers.
+func WithLogger(fn func(*logger.Logger) *logger.Logger) Option {
+	return func(cfg *config) error {
+		cfg.logger = fn(cfg.logger)
+		return nil
+	}
+}
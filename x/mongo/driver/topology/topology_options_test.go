@@ -31,3 +31,19 @@ func TestOptionsSetting(t *testing.T) {
 
 	assert.Equal(t, ssts, conf.serverSelectionTimeout)
 }
+
+func TestOptionsLoadBalanced(t *testing.T) {
+	for _, lb := range []bool{true, false} {
+		conf := &config{}
+		opt := WithConnString(func(connstring.ConnString) connstring.ConnString {
+			return connstring.ConnString{LoadBalanced: lb, LoadBalancedSet: true}
+		})
+
+		err := opt(conf)
+		if lb {
+			assert.Equal(t, ErrLoadBalancedUnsupported, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
@@ -9,13 +9,19 @@ package topology
 import (
 	"context"
 	"errors"
+	"reflect"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/mongodb/mongo-go-driver/mongo/readpref"
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/logger"
 	"github.com/mongodb/mongo-go-driver/x/network/address"
 	"github.com/mongodb/mongo-go-driver/x/network/command"
+	"github.com/mongodb/mongo-go-driver/x/network/connection"
 	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/stretchr/testify/require"
 )
 
 const testTimeout = 2 * time.Second
@@ -176,6 +182,79 @@ func TestServerSelection(t *testing.T) {
 			t.Errorf("Incorrect error received. got %v; want %v", err, ErrServerSelectionTimeout)
 		}
 	})
+	t.Run("Timeout explains exclusions", func(t *testing.T) {
+		now := time.Now()
+		desc := description.Topology{
+			Kind: description.ReplicaSetWithPrimary,
+			Servers: []description.Server{
+				{Addr: address.Address("one"), LastError: errors.New("connection refused")},
+				{
+					Addr: address.Address("two"), Kind: description.RSPrimary, WireVersion: &description.VersionRange{Max: 5},
+					LastWriteTime: now, LastUpdateTime: now,
+				},
+				{
+					Addr: address.Address("three"), Kind: description.RSSecondary, WireVersion: &description.VersionRange{Max: 5},
+					LastWriteTime: now.Add(-3 * time.Minute), LastUpdateTime: now, HeartbeatInterval: 10 * time.Second,
+				},
+			},
+		}
+		topo, err := New()
+		noerr(t, err)
+		subCh := make(chan description.Topology)
+		timeout := make(chan time.Time)
+		resp := make(chan error)
+		rp := readpref.Secondary(readpref.WithMaxStaleness(90 * time.Second))
+		go func() {
+			_, err := topo.selectServer(context.Background(), subCh, description.ReadPrefSelector(rp), timeout)
+			resp <- err
+		}()
+
+		// hand over the description before timing out so selection has run against it
+		subCh <- desc
+		timeout <- now
+		err = <-resp
+		require.Equal(t, ErrServerSelectionTimeout, err)
+		lastErr := topo.LastSelectionError()
+		sse, ok := lastErr.(ServerSelectionError)
+		require.True(t, ok, "expected a ServerSelectionError, got %v", lastErr)
+		require.Equal(t, ErrServerSelectionTimeout, sse.Wrapped)
+		require.Len(t, sse.Exclusions, 3)
+		require.Equal(t, description.ExcludedUnknown, sse.Exclusions[0].Reason)
+		require.Equal(t, description.ExcludedKind, sse.Exclusions[1].Reason)
+		require.Equal(t, description.ExcludedStale, sse.Exclusions[2].Reason)
+		require.Equal(t, 190*time.Second, sse.Exclusions[2].Staleness)
+		require.Contains(t, lastErr.Error(), "one:27017: unknown server (connection refused)")
+		require.Contains(t, lastErr.Error(), "three:27017: stale (estimated staleness 3m10s exceeds max staleness 1m30s)")
+	})
+	t.Run("Timeout with unknown servers returns ErrServerSelectionTimeout", func(t *testing.T) {
+		desc := description.Topology{
+			Servers: []description.Server{
+				{Addr: address.Address("one"), LastError: errors.New("connection refused")},
+			},
+		}
+		topo, err := New()
+		noerr(t, err)
+		require.Nil(t, topo.LastSelectionError())
+		subCh := make(chan description.Topology)
+		timeout := make(chan time.Time)
+		resp := make(chan error)
+		go func() {
+			_, err := topo.selectServer(context.Background(), subCh, selectFirst, timeout)
+			resp <- err
+		}()
+
+		subCh <- desc
+		timeout <- time.Now()
+		err = <-resp
+		if err != ErrServerSelectionTimeout {
+			t.Errorf("Incorrect error received. got %v; want %v", err, ErrServerSelectionTimeout)
+		}
+		sse, ok := topo.LastSelectionError().(ServerSelectionError)
+		require.True(t, ok, "expected a ServerSelectionError, got %v", topo.LastSelectionError())
+		require.Len(t, sse.Exclusions, 1)
+		require.Equal(t, description.ExcludedUnknown, sse.Exclusions[0].Reason)
+		require.Equal(t, address.Address("one"), sse.Exclusions[0].Addr)
+	})
 	t.Run("Error", func(t *testing.T) {
 		desc := description.Topology{
 			Servers: []description.Server{
@@ -454,3 +533,117 @@ func TestSessionTimeout(t *testing.T) {
 		}
 	})
 }
+
+func TestTopologyDisconnect(t *testing.T) {
+	t.Run("reports operations still in flight", func(t *testing.T) {
+		topo, err := New()
+		noerr(t, err)
+		atomic.StoreInt32(&topo.connectionstate, connected)
+
+		servers := []*Server{}
+		for _, addr := range []address.Address{"one", "two"} {
+			s, err := NewServer(addr)
+			noerr(t, err)
+			s.pool = &pool{disconnectError: connection.DisconnectError{InFlight: 2, Wrapped: context.DeadlineExceeded}}
+			atomic.StoreInt32(&s.connectionstate, connected)
+			go func(s *Server) { <-s.done }(s)
+			topo.servers[addr] = s
+			servers = append(servers, s)
+		}
+		go func() { <-topo.done }()
+
+		err = topo.Disconnect(context.Background())
+		de, ok := err.(connection.DisconnectError)
+		if !ok {
+			t.Fatalf("expected a connection.DisconnectError. got %T: %v", err, err)
+		}
+		if de.InFlight != 4 {
+			t.Errorf("incorrect number of operations in flight. got %d; want %d", de.InFlight, 4)
+		}
+		if de.Wrapped != context.DeadlineExceeded {
+			t.Errorf("incorrect wrapped error. got %v; want %v", de.Wrapped, context.DeadlineExceeded)
+		}
+		for _, s := range servers {
+			if state := atomic.LoadInt32(&s.connectionstate); state != disconnected {
+				t.Errorf("server %s should be disconnected. got state %d", s.address, state)
+			}
+		}
+		if len(topo.servers) != 0 {
+			t.Errorf("expected every server to be removed. got %d", len(topo.servers))
+		}
+		if state := atomic.LoadInt32(&topo.connectionstate); state != disconnected {
+			t.Errorf("topology should be disconnected. got state %d", state)
+		}
+	})
+	t.Run("logs that server monitoring stopped", func(t *testing.T) {
+		sink := &recordingSink{}
+		topo, err := New(WithLogger(func(*logger.Logger) *logger.Logger {
+			return logger.New(sink, 0, map[logger.Component]logger.Level{logger.ComponentTopology: logger.LevelDebug})
+		}))
+		noerr(t, err)
+		atomic.StoreInt32(&topo.connectionstate, connected)
+
+		for _, addr := range []address.Address{"one", "two"} {
+			s, err := NewServer(addr)
+			noerr(t, err)
+			s.pool = &pool{}
+			atomic.StoreInt32(&s.connectionstate, connected)
+			go func(s *Server) { <-s.done }(s)
+			topo.servers[addr] = s
+		}
+		go func() { <-topo.done }()
+
+		noerr(t, topo.Disconnect(context.Background()))
+
+		stopped := map[string]bool{}
+		for _, m := range sink.messages {
+			if m.msg == "Stopped server monitoring" {
+				stopped[m.keysAndValues[1].(string)] = true
+			}
+		}
+		if !stopped["one:27017"] || !stopped["two:27017"] || len(stopped) != 2 {
+			t.Errorf("expected server monitoring to stop for both servers. got %v", stopped)
+		}
+	})
+}
+
+type logMessage struct {
+	msg           string
+	keysAndValues []interface{}
+}
+
+type recordingSink struct {
+	sync.Mutex
+	messages []logMessage
+}
+
+func (s *recordingSink) Log(_ logger.Level, _ logger.Component, msg string, keysAndValues ...interface{}) {
+	s.Lock()
+	defer s.Unlock()
+	s.messages = append(s.messages, logMessage{msg, keysAndValues})
+}
+
+func TestTopologyServerLatencies(t *testing.T) {
+	topo, err := New()
+	noerr(t, err)
+	if latencies := topo.ServerLatencies(); len(latencies) != 0 {
+		t.Errorf("expected no latencies before connecting. got %v", latencies)
+	}
+	atomic.StoreInt32(&topo.connectionstate, connected)
+
+	for addr, rtt := range map[address.Address]time.Duration{"one": 5 * time.Millisecond, "two": 0, "three": 40 * time.Millisecond} {
+		s, err := NewServer(addr)
+		noerr(t, err)
+		desc := description.Server{Addr: addr}
+		if rtt != 0 {
+			desc = desc.SetAverageRTT(rtt)
+		}
+		s.desc.Store(desc)
+		topo.servers[addr] = s
+	}
+
+	want := map[address.Address]time.Duration{"one": 5 * time.Millisecond, "three": 40 * time.Millisecond}
+	if got := topo.ServerLatencies(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected latencies. got %v; want %v", got, want)
+	}
+}
@@ -69,6 +69,13 @@ func Update(
 		}
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"collation", bsonx.Document(updateOpts.Collation.ToDocument())})
 	}
+	if updateOpts.Comment != nil {
+		commentElem, err := commentToElement("comment", updateOpts.Comment, ss.Description().WireVersion, nil)
+		if err != nil {
+			return result.Update{}, err
+		}
+		cmd.Opts = append(cmd.Opts, commentElem)
+	}
 	if updateOpts.Upsert != nil {
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"upsert", bsonx.Boolean(*updateOpts.Upsert)})
 	}
@@ -96,6 +103,7 @@ func Update(
 			return res, originalErr
 		}
 
+		PublishRetryWrite(ctx, topo, "update", retryWriteAttempt, originalErr, res.WriteConcernError)
 		return update(ctx, cmd, ss, cerr)
 	}
 	return res, originalErr
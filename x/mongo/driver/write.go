@@ -10,12 +10,14 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/event"
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/session"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/topology"
 	"github.com/mongodb/mongo-go-driver/x/mongo/driver/uuid"
 	"github.com/mongodb/mongo-go-driver/x/network/command"
 	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/mongodb/mongo-go-driver/x/network/result"
 )
 
 // Write handles the full cycle dispatch and execution of a write command against the provided
@@ -64,6 +66,35 @@ func Write(
 	return cmd.RoundTrip(ctx, desc, conn)
 }
 
+// retryWriteAttempt is the attempt number of the retry of a retryable write. Retryable writes are retried at most
+// once.
+const retryWriteAttempt = 2
+
+// PublishRetryWrite notifies the topology's command monitor that the command named cmdName is being sent for the
+// attempt numbered attempt because it failed with cmdErr or wcErr.
+func PublishRetryWrite(ctx context.Context, topo *topology.Topology, cmdName string, attempt int, cmdErr error,
+	wcErr *result.WriteConcernError) {
+
+	monitor := topo.CommandMonitor()
+	if monitor == nil || monitor.Retried == nil {
+		return
+	}
+
+	var failure string
+	switch {
+	case cmdErr != nil:
+		failure = cmdErr.Error()
+	case wcErr != nil:
+		failure = wcErr.ErrMsg
+	}
+	monitor.Retried(ctx, &event.CommandRetryEvent{
+		CommandName: cmdName,
+		Attempt:     attempt,
+		Failure:     failure,
+		Write:       true,
+	})
+}
+
 // Retryable writes are supported if the server supports sessions, the operation is not
 // within a transaction, and the write is acknowledged
 func retrySupported(
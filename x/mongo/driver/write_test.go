@@ -0,0 +1,46 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package driver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/event"
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/topology"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
+	"github.com/mongodb/mongo-go-driver/x/network/result"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishRetryWrite(t *testing.T) {
+	var events []*event.CommandRetryEvent
+	monitor := &event.CommandMonitor{
+		Retried: func(ctx context.Context, e *event.CommandRetryEvent) { events = append(events, e) },
+	}
+	topo, err := topology.New(topology.WithCommandMonitor(func(*event.CommandMonitor) *event.CommandMonitor { return monitor }))
+	require.NoError(t, err)
+
+	cmdErr := command.Error{Code: 91, Message: "shutdown in progress"}
+	PublishRetryWrite(context.Background(), topo, "insert", retryWriteAttempt, cmdErr, nil)
+	PublishRetryWrite(context.Background(), topo, "commitTransaction", 3, nil, &result.WriteConcernError{Code: 91, ErrMsg: "write concern shutdown"})
+	require.Equal(t, []*event.CommandRetryEvent{
+		{CommandName: "insert", Attempt: 2, Failure: cmdErr.Error(), Write: true},
+		{CommandName: "commitTransaction", Attempt: 3, Failure: "write concern shutdown", Write: true},
+	}, events)
+
+	// Topologies without a monitor, or whose monitor ignores retries, are skipped.
+	topo, err = topology.New()
+	require.NoError(t, err)
+	PublishRetryWrite(context.Background(), topo, "insert", retryWriteAttempt, cmdErr, nil)
+
+	monitor.Retried = nil
+	topo, err = topology.New(topology.WithCommandMonitor(func(*event.CommandMonitor) *event.CommandMonitor { return monitor }))
+	require.NoError(t, err)
+	PublishRetryWrite(context.Background(), topo, "insert", retryWriteAttempt, cmdErr, nil)
+	require.Len(t, events, 2)
+}
@@ -21,7 +21,8 @@ import (
 
 // Aggregate represents the aggregate command.
 //
-// The aggregate command performs an aggregation.
+// The aggregate command performs an aggregation. If NS has no collection, the aggregation runs against the
+// database, as is required for pipelines that begin with a stage such as $documents.
 type Aggregate struct {
 	NS           Namespace
 	Pipeline     bsonx.Arr
@@ -33,6 +34,8 @@ type Aggregate struct {
 	Clock        *session.ClusterClock
 	Session      *session.Client
 
+	SkipAfterClusterTime bool // See Read.SkipAfterClusterTime
+
 	result Cursor
 	err    error
 }
@@ -48,17 +51,23 @@ func (a *Aggregate) Encode(desc description.SelectedServer) (wiremessage.WireMes
 }
 
 func (a *Aggregate) encode(desc description.SelectedServer) (*Read, error) {
-	if err := a.NS.Validate(); err != nil {
+	target := bsonx.String(a.NS.Collection)
+	if a.NS.Collection == "" {
+		if err := a.NS.validateDB(); err != nil {
+			return nil, err
+		}
+		target = bsonx.Int32(1)
+	} else if err := a.NS.Validate(); err != nil {
 		return nil, err
 	}
 
 	command := bsonx.Doc{
-		{"aggregate", bsonx.String(a.NS.Collection)},
+		{"aggregate", target},
 		{"pipeline", bsonx.Array(a.Pipeline)},
 	}
 
 	cursor := bsonx.Doc{}
-	hasOutStage := a.HasDollarOut()
+	hasOutStage := a.HasOutputStage()
 
 	for _, opt := range a.Opts {
 		switch opt.Key {
@@ -83,13 +92,22 @@ func (a *Aggregate) encode(desc description.SelectedServer) (*Read, error) {
 		command = append(command, element)
 	}
 
+	// Pipelines that write their results must run on the primary whatever the read preference is, or mongos would
+	// route them to a secondary.
+	rp := a.ReadPref
+	if hasOutStage {
+		rp = readpref.Primary()
+	}
+
 	return &Read{
 		DB:          a.NS.DB,
 		Command:     command,
-		ReadPref:    a.ReadPref,
+		ReadPref:    rp,
 		ReadConcern: a.ReadConcern,
 		Clock:       a.Clock,
 		Session:     a.Session,
+
+		SkipAfterClusterTime: a.SkipAfterClusterTime,
 	}, nil
 }
 
@@ -111,6 +129,20 @@ func (a *Aggregate) HasDollarOut() bool {
 	return doc[0].Key == "$out"
 }
 
+// HasOutputStage returns true if the Pipeline field ends with a $out or $merge stage, which makes the aggregation a
+// write.
+func (a *Aggregate) HasOutputStage() bool {
+	if len(a.Pipeline) == 0 {
+		return false
+	}
+
+	doc, ok := a.Pipeline[len(a.Pipeline)-1].DocumentOK()
+	if !ok || len(doc) != 1 {
+		return false
+	}
+	return doc[0].Key == "$out" || doc[0].Key == "$merge"
+}
+
 // Decode will decode the wire message using the provided server description. Errors during decoding
 // are deferred until either the Result or Err methods are called.
 func (a *Aggregate) Decode(desc description.SelectedServer, cb CursorBuilder, wm wiremessage.WireMessage) *Aggregate {
@@ -7,11 +7,15 @@
 package command
 
 import (
+	"testing"
+
 	"github.com/mongodb/mongo-go-driver/internal/testutil/helpers"
+	"github.com/mongodb/mongo-go-driver/mongo/readpref"
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/mongodb/mongo-go-driver/x/network/description"
-	"testing"
+	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAggregate(t *testing.T) {
@@ -32,6 +36,7 @@ func TestAggregate(t *testing.T) {
 	}
 	outDoc := bsonx.Doc{{"$out", bsonx.Int32(1)}}
 	outPipeline := bsonx.Arr{bsonx.Document(outDoc)}
+	mergePipeline := bsonx.Arr{bsonx.Document(bsonx.Doc{{"$merge", bsonx.Document(bsonx.Doc{{"into", bsonx.String("out")}})}})}
 
 	testCases := []struct {
 		name       string
@@ -43,6 +48,7 @@ func TestAggregate(t *testing.T) {
 		{"LegacyDescOut", legacyDesc, outPipeline, false},
 		{"NewDescNoOut", desc, bsonx.Arr{}, false},
 		{"NewDescOut", desc, outPipeline, true},
+		{"NewDescMerge", desc, mergePipeline, true},
 	}
 
 	for _, tc := range testCases {
@@ -68,3 +74,67 @@ func TestAggregate(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregate_OutputStageReadPref(t *testing.T) {
+	mongos := description.SelectedServer{
+		Server: description.Server{
+			Kind:        description.Mongos,
+			WireVersion: &description.VersionRange{Max: 8},
+		},
+		Kind: description.Sharded,
+	}
+	stage := func(key string) bsonx.Val {
+		return bsonx.Document(bsonx.Doc{{key, bsonx.Document(bsonx.Doc{{"into", bsonx.String("out")}})}})
+	}
+
+	testCases := []struct {
+		name     string
+		pipeline bsonx.Arr
+		primary  bool
+	}{
+		{"merge", bsonx.Arr{stage("$match"), stage("$merge")}, true},
+		{"out", bsonx.Arr{bsonx.Document(bsonx.Doc{{"$out", bsonx.String("out")}})}, true},
+		{"merge not last", bsonx.Arr{stage("$merge"), stage("$match")}, false},
+		{"read", bsonx.Arr{stage("$match")}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := Aggregate{
+				NS:           Namespace{DB: "db", Collection: "coll"},
+				Pipeline:     tc.pipeline,
+				ReadPref:     readpref.SecondaryPreferred(),
+				WriteConcern: writeconcern.New(writeconcern.WMajority()),
+			}
+			require.Equal(t, tc.primary, cmd.HasOutputStage())
+
+			readCmd, err := cmd.encode(mongos)
+			require.NoError(t, err)
+
+			rp := readCmd.createReadPref(mongos.Server, mongos.Kind, false)
+			if tc.primary {
+				require.Nil(t, rp)
+				require.Equal(t, readpref.PrimaryMode, readCmd.ReadPref.Mode())
+				require.Equal(t, wiremessage.QueryFlag(0), readCmd.slaveOK(mongos))
+				_, err = readCmd.Command.LookupErr("writeConcern")
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, "secondaryPreferred", rp.Lookup("mode").StringValue())
+		})
+	}
+}
+
+func TestAggregate_Database(t *testing.T) {
+	desc := description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Max: 17}}}
+
+	cmd := Aggregate{NS: Namespace{DB: "db"}, Pipeline: bsonx.Arr{}}
+	readCmd, err := cmd.encode(desc)
+	require.NoError(t, err)
+	require.Equal(t, "db", readCmd.DB)
+	require.Equal(t, bsonx.Int32(1), readCmd.Command.Lookup("aggregate"))
+
+	cmd = Aggregate{NS: Namespace{Collection: "coll"}}
+	_, err = cmd.encode(desc)
+	require.Error(t, err)
+}
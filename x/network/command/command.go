@@ -12,6 +12,7 @@ import (
 	"context"
 
 	"fmt"
+	"strconv"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/bsontype"
@@ -59,7 +60,7 @@ func DecodeError(wm wiremessage.WireMessage) error {
 
 	// If parsed successfully return the error
 	if _, ok := extractedError.(Error); ok {
-		return err
+		return extractedError
 	}
 
 	return nil
@@ -187,6 +188,27 @@ func updateOperationTime(sess *session.Client, response bson.Raw) error {
 	})
 }
 
+func updateSnapshotTime(sess *session.Client, response bson.Raw) error {
+	if sess == nil || !sess.Snapshot || sess.SnapshotTime != nil {
+		return nil
+	}
+
+	// find and aggregate return the snapshot time in the cursor, distinct at the top level
+	atClusterTime, err := response.LookupErr("cursor", "atClusterTime")
+	if err != nil {
+		atClusterTime, err = response.LookupErr("atClusterTime")
+	}
+	if err != nil {
+		return nil
+	}
+
+	t, i, ok := atClusterTime.TimestampOK()
+	if !ok {
+		return nil
+	}
+	return sess.AdvanceSnapshotTime(&primitive.Timestamp{T: t, I: i})
+}
+
 func marshalCommand(cmd bsonx.Doc) (bson.Raw, error) {
 	if cmd == nil {
 		return bson.Raw{5, 0, 0, 0, 0}, nil
@@ -258,8 +280,10 @@ func addClusterTime(cmd bsonx.Doc, desc description.SelectedServer, sess *sessio
 	return append(cmd, clusterTime...)
 }
 
-// add a read concern to a BSON doc representing a command
-func addReadConcern(cmd bsonx.Doc, desc description.SelectedServer, rc *readconcern.ReadConcern, sess *session.Client) (bsonx.Doc, error) {
+// add a read concern to a BSON doc representing a command. If skipAfterClusterTime is true, afterClusterTime is not
+// added for a causally consistent session unless the command starts a transaction.
+func addReadConcern(cmd bsonx.Doc, desc description.SelectedServer, rc *readconcern.ReadConcern, sess *session.Client,
+	skipAfterClusterTime bool) (bsonx.Doc, error) {
 	// Starting transaction's read concern overrides all others
 	if sess != nil && sess.TransactionStarting() && sess.CurrentRc != nil {
 		rc = sess.CurrentRc
@@ -274,14 +298,45 @@ func addReadConcern(cmd bsonx.Doc, desc description.SelectedServer, rc *readconc
 		return cmd, nil
 	}
 
+	// reads in a snapshot session use the session's snapshot, which is chosen by the first read
+	if sess != nil && sess.Snapshot {
+		if desc.WireVersion == nil || desc.WireVersion.Max < 13 {
+			return cmd, ErrSnapshotUnsupported
+		}
+		opts := []readconcern.Option{readconcern.Level("snapshot")}
+		if sess.SnapshotTime != nil {
+			opts = append(opts, readconcern.AtClusterTime(*sess.SnapshotTime))
+		}
+		rc = readconcern.New(opts...)
+	}
+
 	element, err := rc.MarshalBSONElement()
 	if err != nil {
 		return cmd, err
 	}
 
+	// the read concern of a starting transaction applies to the whole transaction, so it can't skip afterClusterTime
+	if sess != nil && sess.TransactionStarting() {
+		skipAfterClusterTime = false
+	}
+
 	rcDoc := element.Value.Document()
-	if description.SessionsSupported(desc.WireVersion) && sess != nil && sess.Consistent && sess.OperationTime != nil {
-		rcDoc = append(rcDoc, bsonx.Elem{"afterClusterTime", bsonx.Timestamp(sess.OperationTime.T, sess.OperationTime.I)})
+	// a read at a specific cluster time can't also wait for a later one
+	if _, err := rcDoc.LookupErr("atClusterTime"); err == nil {
+		skipAfterClusterTime = true
+	}
+	if description.SessionsSupported(desc.WireVersion) && sess != nil && sess.Consistent && sess.OperationTime != nil &&
+		!skipAfterClusterTime {
+		// an afterClusterTime set on the read concern is kept if it is later than the session's operation time
+		opTime := sess.OperationTime
+		if after, err := rcDoc.LookupErr("afterClusterTime"); err == nil {
+			t, i := after.Timestamp()
+			if t > opTime.T || t == opTime.T && i > opTime.I {
+				opTime = &primitive.Timestamp{T: t, I: i}
+			}
+			rcDoc = rcDoc.Delete("afterClusterTime")
+		}
+		rcDoc = append(rcDoc, bsonx.Elem{"afterClusterTime", bsonx.Timestamp(opTime.T, opTime.I)})
 	}
 
 	cmd = cmd.Delete(element.Key)
@@ -381,47 +436,61 @@ func opmsgCreateDocSequence(arr bsonx.Arr, identifier string) (wiremessage.Secti
 	return docSequence, nil
 }
 
-func splitBatches(docs []bsonx.Doc, maxCount, targetBatchSize int) ([][]bsonx.Doc, error) {
-	batches := [][]bsonx.Doc{}
+// maxBatchBytes returns the number of bytes of documents that can be sent in a single write command to the server
+// described by desc. With OP_MSG the documents are sent in a document sequence, so a batch is limited by
+// maxMessageSizeBytes; otherwise the documents are part of the command document, which is limited by
+// maxBsonObjectSize. Either limit is reduced by reservedCommandBufferBytes to leave room for the rest of the command.
+func maxBatchBytes(desc description.SelectedServer) int {
+	limit := int(desc.MaxDocumentSize)
+	if desc.WireVersion != nil && desc.WireVersion.Max >= wiremessage.OpmsgWireVersion && desc.MaxMessageSize > 0 {
+		limit = int(desc.MaxMessageSize)
+	}
 
-	if targetBatchSize > reservedCommandBufferBytes {
-		targetBatchSize -= reservedCommandBufferBytes
+	if limit > reservedCommandBufferBytes {
+		limit -= reservedCommandBufferBytes
 	}
+	return limit
+}
+
+// splitBatches splits docs into batches of at most maxCount documents whose combined size is at most maxBatchSize
+// bytes. The size of a document includes the overhead of its element in the command's array: a type byte, the
+// array index as a key, and the key's null terminator. A document larger than maxBatchSize is sent in a batch of
+// its own, but ErrDocumentTooLarge is returned for any document larger than maxDocSize.
+func splitBatches(docs []bsonx.Doc, maxCount, maxDocSize, maxBatchSize int) ([][]bsonx.Doc, error) {
+	batches := [][]bsonx.Doc{}
 
 	if maxCount <= 0 {
 		maxCount = 1
 	}
 
-	startAt := 0
-splitInserts:
-	for {
-		size := 0
-		batch := []bsonx.Doc{}
-	assembleBatch:
-		for idx := startAt; idx < len(docs); idx++ {
-			raw, _ := docs[idx].MarshalBSON()
-
-			if len(raw) > targetBatchSize {
-				return nil, ErrDocumentTooLarge
-			}
-			if size+len(raw) > targetBatchSize {
-				break assembleBatch
-			}
-
-			size += len(raw)
-			batch = append(batch, docs[idx])
-			startAt++
-			if len(batch) == maxCount {
-				break assembleBatch
-			}
+	batch := []bsonx.Doc{}
+	size := 0
+	for _, doc := range docs {
+		raw, err := doc.MarshalBSON()
+		if err != nil {
+			return nil, err
 		}
-		batches = append(batches, batch)
-		if startAt == len(docs) {
-			break splitInserts
+		if len(raw) > maxDocSize {
+			return nil, ErrDocumentTooLarge
 		}
+
+		docSize := arrayElementSize(len(batch), len(raw))
+		if len(batch) > 0 && (len(batch) == maxCount || size+docSize > maxBatchSize) {
+			batches = append(batches, batch)
+			batch = []bsonx.Doc{}
+			size = 0
+			docSize = arrayElementSize(0, len(raw))
+		}
+
+		size += docSize
+		batch = append(batch, doc)
 	}
 
-	return batches, nil
+	return append(batches, batch), nil
+}
+
+func arrayElementSize(idx, docLen int) int {
+	return 1 + len(strconv.Itoa(idx)) + 1 + docLen
 }
 
 func encodeBatch(
@@ -459,7 +528,7 @@ func encodeBatch(
 
 // converts batches of Write Commands to wire messages
 func batchesToWireMessage(batches []*WriteBatch, desc description.SelectedServer) ([]wiremessage.WireMessage, error) {
-	wms := make([]wiremessage.WireMessage, len(batches))
+	wms := make([]wiremessage.WireMessage, 0, len(batches))
 	for _, cmd := range batches {
 		wm, err := cmd.Encode(desc)
 		if err != nil {
@@ -484,7 +553,7 @@ func roundTripBatches(
 	cmdKind WriteCommandKind,
 ) (interface{}, []*WriteBatch, error) {
 	var res interface{}
-	var upsertIndex int64 // the operation index for the upserted IDs map
+	var opIndex int64 // the operation index of the first document in the current batch
 
 	// hold onto txnNumber, reset it when loop exits to ensure reuse of same
 	// transaction number if retry is needed
@@ -515,7 +584,10 @@ func roundTripBatches(
 				return res, batches, err
 			}
 
-			conv.WriteErrors = append(conv.WriteErrors, r.WriteErrors...)
+			conv.WriteErrors = append(conv.WriteErrors, offsetWriteErrors(r.WriteErrors, opIndex)...)
+			if r.OperationTime != nil {
+				conv.OperationTime = r.OperationTime
+			}
 
 			if r.WriteConcernError != nil {
 				conv.WriteConcernError = r.WriteConcernError
@@ -544,7 +616,10 @@ func roundTripBatches(
 				return conv, batches, err
 			}
 
-			conv.WriteErrors = append(conv.WriteErrors, r.WriteErrors...)
+			conv.WriteErrors = append(conv.WriteErrors, offsetWriteErrors(r.WriteErrors, opIndex)...)
+			if r.OperationTime != nil {
+				conv.OperationTime = r.OperationTime
+			}
 
 			if r.WriteConcernError != nil {
 				conv.WriteConcernError = r.WriteConcernError
@@ -558,7 +633,7 @@ func roundTripBatches(
 			conv.ModifiedCount += r.ModifiedCount
 			for _, upsert := range r.Upserted {
 				conv.Upserted = append(conv.Upserted, result.Upsert{
-					Index: upsert.Index + upsertIndex,
+					Index: upsert.Index + opIndex,
 					ID:    upsert.ID,
 				})
 			}
@@ -568,7 +643,6 @@ func roundTripBatches(
 			}
 
 			res = conv
-			upsertIndex += int64(cmd.numDocs)
 		case DeleteCommand:
 			if res == nil {
 				res = result.Delete{}
@@ -581,7 +655,10 @@ func roundTripBatches(
 				return conv, batches, err
 			}
 
-			conv.WriteErrors = append(conv.WriteErrors, r.WriteErrors...)
+			conv.WriteErrors = append(conv.WriteErrors, offsetWriteErrors(r.WriteErrors, opIndex)...)
+			if r.OperationTime != nil {
+				conv.OperationTime = r.OperationTime
+			}
 
 			if r.WriteConcernError != nil {
 				conv.WriteConcernError = r.WriteConcernError
@@ -600,6 +677,8 @@ func roundTripBatches(
 			res = conv
 		}
 
+		opIndex += int64(cmd.numDocs)
+
 		// Increment txnNumber for each batch
 		if sess != nil && sess.RetryWrite {
 			sess.IncrementTxnNumber()
@@ -616,6 +695,15 @@ func roundTripBatches(
 	return res, batches, nil
 }
 
+// offsetWriteErrors shifts the index of each write error, which is relative to the batch that
+// produced it, by the operation index of the batch's first document.
+func offsetWriteErrors(writeErrors []result.WriteError, offset int64) []result.WriteError {
+	for i := range writeErrors {
+		writeErrors[i].Index += int(offset)
+	}
+	return writeErrors
+}
+
 // get the firstBatch, cursor ID, and namespace from a bson.Raw
 func getCursorValues(result bson.Raw) ([]bson.RawValue, Namespace, int64, error) {
 	cur, err := result.LookupErr("cursor")
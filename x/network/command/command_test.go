@@ -7,10 +7,18 @@
 package command
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
+	"github.com/mongodb/mongo-go-driver/tag"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/session"
 	"github.com/mongodb/mongo-go-driver/x/network/description"
 	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
 )
@@ -156,3 +164,283 @@ func TestCommandEncode(t *testing.T) {
 		}
 	})
 }
+
+func TestReadPrefHedge(t *testing.T) {
+	hedged := readpref.Nearest(readpref.WithHedgeEnabled(true))
+	testCases := []struct {
+		name        string
+		serverKind  description.ServerKind
+		wireVersion *description.VersionRange
+		readPref    *readpref.ReadPref
+		expected    bsonx.Doc
+	}{
+		{"mongos", description.Mongos, &description.VersionRange{Max: 9}, hedged, bsonx.Doc{{"enabled", bsonx.Boolean(true)}}},
+		{"mongos disabled", description.Mongos, &description.VersionRange{Max: 9},
+			readpref.SecondaryPreferred(readpref.WithHedgeEnabled(false)), bsonx.Doc{{"enabled", bsonx.Boolean(false)}}},
+		{"old mongos", description.Mongos, &description.VersionRange{Max: 8}, hedged, nil},
+		{"unknown version", description.Mongos, nil, hedged, nil},
+		{"replica set member", description.RSSecondary, &description.VersionRange{Max: 9}, hedged, nil},
+		{"standalone", description.Standalone, &description.VersionRange{Max: 9}, hedged, nil},
+		{"not set", description.Mongos, &description.VersionRange{Max: 9}, readpref.Nearest(), nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &Read{DB: "foo", Command: bsonx.Doc{{"find", bsonx.String("bar")}}, ReadPref: tc.readPref}
+			server := description.Server{Kind: tc.serverKind, WireVersion: tc.wireVersion}
+			wm, err := cmd.Encode(description.SelectedServer{Server: server, Kind: description.Sharded})
+			noerr(t, err)
+
+			var doc bsonx.Doc
+			switch converted := wm.(type) {
+			case wiremessage.Msg:
+				doc, err = converted.GetMainDocument()
+				noerr(t, err)
+			case wiremessage.Query:
+				doc, err = bsonx.ReadDoc(converted.Query)
+				noerr(t, err)
+			default:
+				t.Fatalf("Unexpected wiremessage type %T", wm)
+			}
+
+			hedge, err := doc.LookupErr("$readPreference", "hedge")
+			if tc.expected == nil {
+				if err == nil {
+					t.Errorf("Did not expect hedge to be set, but it was. got %v", hedge)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected hedge to be set, but it wasn't: %v", err)
+			}
+			if !hedge.Document().Equal(tc.expected) {
+				t.Errorf("Unexpected hedge document. got %v; want %v", hedge.Document(), tc.expected)
+			}
+		})
+	}
+}
+
+func TestReadPrefTagSets(t *testing.T) {
+	rp := readpref.Nearest(readpref.WithTagSets(tag.Set{{Name: "dc", Value: "ny"}}, tag.Set{}))
+	cmd := &Read{DB: "foo", Command: bsonx.Doc{{"find", bsonx.String("bar")}}, ReadPref: rp}
+	server := description.Server{Kind: description.Mongos, WireVersion: &description.VersionRange{Max: 6}}
+	wm, err := cmd.Encode(description.SelectedServer{Server: server, Kind: description.Sharded})
+	noerr(t, err)
+
+	msg := wm.(wiremessage.Msg)
+	doc, err := msg.GetMainDocument()
+	noerr(t, err)
+	tags, err := doc.LookupErr("$readPreference", "tags")
+	noerr(t, err)
+	want := bsonx.Arr{bsonx.Document(bsonx.Doc{{"dc", bsonx.String("ny")}}), bsonx.Document(bsonx.Doc{})}
+	if !tags.Equal(bsonx.Array(want)) {
+		t.Errorf("Unexpected tag sets. got %v; want %v", tags, want)
+	}
+}
+
+func TestReadSkipAfterClusterTime(t *testing.T) {
+	desc := description.SelectedServer{
+		Server: description.Server{
+			Kind:        description.RSPrimary,
+			WireVersion: &description.VersionRange{Max: 6},
+		},
+		Kind: description.ReplicaSetWithPrimary,
+	}
+	sess := &session.Client{Consistent: true, OperationTime: &primitive.Timestamp{T: 5, I: 1}}
+
+	testCases := []struct {
+		name                 string
+		sess                 *session.Client
+		skipAfterClusterTime bool
+		want                 bool
+	}{
+		{"causal", sess, false, true},
+		{"opted out", sess, true, false},
+		{"not causally consistent", &session.Client{OperationTime: sess.OperationTime}, false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &Read{
+				DB:                   "foo",
+				Command:              bsonx.Doc{{"find", bsonx.String("bar")}},
+				ReadConcern:          readconcern.Majority(),
+				Session:              tc.sess,
+				SkipAfterClusterTime: tc.skipAfterClusterTime,
+			}
+			wm, err := cmd.Encode(desc)
+			noerr(t, err)
+
+			msg := wm.(wiremessage.Msg)
+			res, err := msg.GetMainDocument()
+			noerr(t, err)
+			if level, err := res.LookupErr("readConcern", "level"); err != nil || level.StringValue() != "majority" {
+				t.Errorf("Expected the read concern level to be sent. got %v", res)
+			}
+			_, err = res.LookupErr("readConcern", "afterClusterTime")
+			if got := err == nil; got != tc.want {
+				t.Errorf("Unexpected afterClusterTime in %v. got %v; want %v", res, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadSnapshotSession(t *testing.T) {
+	desc := description.SelectedServer{
+		Server: description.Server{
+			Kind:        description.RSPrimary,
+			WireVersion: &description.VersionRange{Max: 13},
+		},
+		Kind: description.ReplicaSetWithPrimary,
+	}
+	sess := &session.Client{Snapshot: true, OperationTime: &primitive.Timestamp{T: 5, I: 1}}
+
+	encode := func(t *testing.T, desc description.SelectedServer) bsonx.Doc {
+		cmd := &Read{
+			DB:          "foo",
+			Command:     bsonx.Doc{{"find", bsonx.String("bar")}},
+			ReadConcern: readconcern.Majority(),
+			Session:     sess,
+		}
+		wm, err := cmd.Encode(desc)
+		noerr(t, err)
+
+		msg := wm.(wiremessage.Msg)
+		res, err := msg.GetMainDocument()
+		noerr(t, err)
+		return res.Lookup("readConcern").Document()
+	}
+
+	rc := encode(t, desc)
+	if !rc.Equal(bsonx.Doc{{"level", bsonx.String("snapshot")}}) {
+		t.Errorf("Expected a snapshot read concern without a cluster time. got %v", rc)
+	}
+
+	response, err := bsonx.Doc{
+		{"cursor", bsonx.Document(bsonx.Doc{{"atClusterTime", bsonx.Timestamp(10, 2)}})},
+		{"ok", bsonx.Int32(1)},
+	}.MarshalBSON()
+	noerr(t, err)
+	noerr(t, updateSnapshotTime(sess, response))
+
+	response, err = bsonx.Doc{{"atClusterTime", bsonx.Timestamp(20, 1)}, {"ok", bsonx.Int32(1)}}.MarshalBSON()
+	noerr(t, err)
+	noerr(t, updateSnapshotTime(sess, response))
+
+	rc = encode(t, desc)
+	want := bsonx.Doc{{"level", bsonx.String("snapshot")}, {"atClusterTime", bsonx.Timestamp(10, 2)}}
+	if !rc.Equal(want) {
+		t.Errorf("Expected the first snapshot time to be reused. got %v; want %v", rc, want)
+	}
+
+	desc.WireVersion = &description.VersionRange{Max: 12}
+	cmd := &Read{DB: "foo", Command: bsonx.Doc{{"find", bsonx.String("bar")}}, ReadConcern: readconcern.New(), Session: sess}
+	if _, err := cmd.Encode(desc); err != ErrSnapshotUnsupported {
+		t.Errorf("Expected error %v. got %v", ErrSnapshotUnsupported, err)
+	}
+}
+
+func TestReadAtClusterTime(t *testing.T) {
+	desc := description.SelectedServer{
+		Server: description.Server{
+			Kind:        description.RSPrimary,
+			WireVersion: &description.VersionRange{Max: 13},
+		},
+		Kind: description.ReplicaSetWithPrimary,
+	}
+	cmd := &Read{
+		DB:          "foo",
+		Command:     bsonx.Doc{{"find", bsonx.String("bar")}},
+		ReadConcern: readconcern.New(readconcern.Level("snapshot"), readconcern.AtClusterTime(primitive.Timestamp{T: 10, I: 2})),
+		Session:     &session.Client{Consistent: true, OperationTime: &primitive.Timestamp{T: 5, I: 1}},
+	}
+	wm, err := cmd.Encode(desc)
+	noerr(t, err)
+
+	msg := wm.(wiremessage.Msg)
+	res, err := msg.GetMainDocument()
+	noerr(t, err)
+	if ts, err := res.LookupErr("readConcern", "atClusterTime"); err != nil || ts.Type() != bsontype.Timestamp {
+		t.Errorf("Expected atClusterTime to be sent. got %v", res)
+	}
+	if _, err := res.LookupErr("readConcern", "afterClusterTime"); err == nil {
+		t.Errorf("Expected afterClusterTime to be omitted with atClusterTime. got %v", res)
+	}
+}
+
+func TestReadAfterClusterTime(t *testing.T) {
+	desc := description.SelectedServer{
+		Server: description.Server{
+			Kind:        description.RSPrimary,
+			WireVersion: &description.VersionRange{Max: 6},
+		},
+		Kind: description.ReplicaSetWithPrimary,
+	}
+	rc := readconcern.Majority().WithOptions(readconcern.AfterClusterTime(primitive.Timestamp{T: 10, I: 2}))
+
+	testCases := []struct {
+		name string
+		sess *session.Client
+		want primitive.Timestamp
+	}{
+		{"without a session", nil, primitive.Timestamp{T: 10, I: 2}},
+		{"earlier session operation time", &session.Client{Consistent: true, OperationTime: &primitive.Timestamp{T: 10, I: 1}},
+			primitive.Timestamp{T: 10, I: 2}},
+		{"later session operation time", &session.Client{Consistent: true, OperationTime: &primitive.Timestamp{T: 11, I: 0}},
+			primitive.Timestamp{T: 11, I: 0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &Read{
+				DB:          "foo",
+				Command:     bsonx.Doc{{"find", bsonx.String("bar")}},
+				ReadConcern: rc,
+				Session:     tc.sess,
+			}
+			wm, err := cmd.Encode(desc)
+			noerr(t, err)
+
+			msg := wm.(wiremessage.Msg)
+			res, err := msg.GetMainDocument()
+			noerr(t, err)
+			got := res.Lookup("readConcern").Document()
+			want := bsonx.Doc{{"level", bsonx.String("majority")}, {"afterClusterTime", bsonx.Timestamp(tc.want.T, tc.want.I)}}
+			if !got.Equal(want) {
+				t.Errorf("Unexpected read concern. got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	raw := func(doc bsonx.Doc) bson.Raw {
+		b, err := doc.MarshalBSON()
+		noerr(t, err)
+		return b
+	}
+	notMaster := raw(bsonx.Doc{{"ok", bsonx.Int32(0)}, {"code", bsonx.Int32(10107)}, {"errmsg", bsonx.String("not master")}})
+	ok := raw(bsonx.Doc{{"ok", bsonx.Int32(1)}})
+
+	testCases := []struct {
+		name string
+		wm   wiremessage.WireMessage
+		want error
+	}{
+		{"OP_MSG error", wiremessage.Msg{Sections: []wiremessage.Section{wiremessage.SectionBody{Document: notMaster}}},
+			Error{Code: 10107, Message: "not master"}},
+		{"OP_MSG success", wiremessage.Msg{Sections: []wiremessage.Section{wiremessage.SectionBody{Document: ok}}}, nil},
+		{"OP_REPLY query failure", wiremessage.Reply{ResponseFlags: wiremessage.QueryFailure, Documents: []bson.Raw{notMaster}},
+			Error{Code: 10107, Message: "not master"}},
+		{"OP_REPLY without query failure", wiremessage.Reply{Documents: []bson.Raw{notMaster}}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DecodeError(tc.wm)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Unexpected error. got %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
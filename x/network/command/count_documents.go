@@ -37,6 +37,15 @@ type CountDocuments struct {
 
 // Encode will encode this command into a wire message for the given server description.
 func (c *CountDocuments) Encode(desc description.SelectedServer) (wiremessage.WireMessage, error) {
+	cmd, err := c.encode(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd.Encode(desc)
+}
+
+func (c *CountDocuments) encode(desc description.SelectedServer) (*Read, error) {
 	if err := c.NS.Validate(); err != nil {
 		return nil, err
 	}
@@ -45,7 +54,14 @@ func (c *CountDocuments) Encode(desc description.SelectedServer) (wiremessage.Wi
 	command = append(command, bsonx.Elem{"cursor", bsonx.Document(bsonx.Doc{})})
 	command = append(command, c.Opts...)
 
-	return (&Read{DB: c.NS.DB, ReadPref: c.ReadPref, Command: command}).Encode(desc)
+	return &Read{
+		Clock:       c.Clock,
+		DB:          c.NS.DB,
+		ReadPref:    c.ReadPref,
+		Command:     command,
+		ReadConcern: c.ReadConcern,
+		Session:     c.Session,
+	}, nil
 }
 
 // Decode will decode the wire message using the provided server description. Errors during decoding
@@ -56,6 +72,11 @@ func (c *CountDocuments) Decode(ctx context.Context, desc description.SelectedSe
 		c.err = err
 		return c
 	}
+
+	return c.decode(ctx, desc, cb, rdr)
+}
+
+func (c *CountDocuments) decode(ctx context.Context, desc description.SelectedServer, cb CursorBuilder, rdr bson.Raw) *CountDocuments {
 	cur, err := cb.BuildCursor(rdr, c.Session, c.Clock)
 	if err != nil {
 		c.err = err
@@ -108,18 +129,15 @@ func (c *CountDocuments) Err() error { return c.err }
 
 // RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
 func (c *CountDocuments) RoundTrip(ctx context.Context, desc description.SelectedServer, cb CursorBuilder, rw wiremessage.ReadWriter) (int64, error) {
-	wm, err := c.Encode(desc)
+	cmd, err := c.encode(desc)
 	if err != nil {
 		return 0, err
 	}
 
-	err = rw.WriteWireMessage(ctx, wm)
-	if err != nil {
-		return 0, err
-	}
-	wm, err = rw.ReadWireMessage(ctx)
+	rdr, err := cmd.RoundTrip(ctx, desc, rw)
 	if err != nil {
 		return 0, err
 	}
-	return c.Decode(ctx, desc, cb, wm).Result()
+
+	return c.decode(ctx, desc, cb, rdr).Result()
 }
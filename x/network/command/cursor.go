@@ -37,9 +37,13 @@ import (
 //		}
 //
 type Cursor interface {
-	// Get the ID of the cursor.
+	// Get the ID of the cursor. This is 0 once the server side cursor has been exhausted or closed.
 	ID() int64
 
+	// Get the number of documents left in the current batch. Once these have been iterated,
+	// the next call to Next will request another batch from the server.
+	RemainingBatchLength() int
+
 	// Get the next result from the cursor.
 	// Returns true if there were no errors and there is a next result.
 	Next(context.Context) bool
@@ -55,6 +59,10 @@ type Cursor interface {
 	// Returns the error status of the cursor
 	Err() error
 
+	// Call fn with each remaining document, stopping at the first error
+	// returned by fn or the cursor. The cursor is closed when iteration stops.
+	ForEach(context.Context, func(bson.Raw) error) error
+
 	// Close the cursor.
 	Close(context.Context) error
 }
@@ -68,8 +76,52 @@ type CursorBuilder interface {
 type emptyCursor struct{}
 
 func (ec emptyCursor) ID() int64                      { return -1 }
+func (ec emptyCursor) RemainingBatchLength() int      { return 0 }
 func (ec emptyCursor) Next(context.Context) bool      { return false }
 func (ec emptyCursor) Decode(interface{}) error       { return nil }
 func (ec emptyCursor) DecodeBytes() (bson.Raw, error) { return nil, nil }
 func (ec emptyCursor) Err() error                     { return nil }
 func (ec emptyCursor) Close(context.Context) error    { return nil }
+
+func (ec emptyCursor) ForEach(context.Context, func(bson.Raw) error) error { return nil }
+
+// ForEach calls fn with each remaining document in c, in order, and closes c once iteration
+// stops. Iteration stops when c is exhausted, when fn returns an error, when c reports an error,
+// or when ctx is done, and the first of these errors is returned. The bson.Raw passed to fn is
+// only valid until fn returns; it must be copied to be retained.
+//
+// This is intended to be used by Cursor implementations to implement their ForEach method.
+func ForEach(ctx context.Context, c Cursor, fn func(bson.Raw) error) (err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	defer func() {
+		closeErr := c.Close(ctx)
+		if err == nil && ctx.Err() == nil {
+			err = closeErr
+		}
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !c.Next(ctx) {
+			break
+		}
+
+		doc, err := c.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Err(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
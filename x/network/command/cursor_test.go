@@ -0,0 +1,110 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
+	"github.com/stretchr/testify/require"
+)
+
+type sliceCursor struct {
+	docs    []bson.Raw
+	current int
+	err     error
+	closed  bool
+}
+
+func newSliceCursor(n int) *sliceCursor {
+	c := &sliceCursor{current: -1}
+	for i := 0; i < n; i++ {
+		c.docs = append(c.docs, bson.Raw(bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "n", int32(i)))))
+	}
+	return c
+}
+
+func (c *sliceCursor) ID() int64                 { return 1 }
+func (c *sliceCursor) RemainingBatchLength() int { return len(c.docs) - c.current - 1 }
+func (c *sliceCursor) Next(context.Context) bool {
+	if c.current+1 >= len(c.docs) {
+		return false
+	}
+	c.current++
+	return true
+}
+func (c *sliceCursor) Decode(interface{}) error       { return nil }
+func (c *sliceCursor) DecodeBytes() (bson.Raw, error) { return c.docs[c.current], nil }
+func (c *sliceCursor) Err() error                     { return c.err }
+func (c *sliceCursor) ForEach(ctx context.Context, fn func(bson.Raw) error) error {
+	return ForEach(ctx, c, fn)
+}
+func (c *sliceCursor) Close(context.Context) error {
+	c.closed = true
+	return nil
+}
+
+func TestForEach(t *testing.T) {
+	collect := func(seen *[]int32) func(bson.Raw) error {
+		return func(doc bson.Raw) error {
+			*seen = append(*seen, doc.Lookup("n").Int32())
+			return nil
+		}
+	}
+
+	t.Run("visits every document", func(t *testing.T) {
+		c := newSliceCursor(3)
+		var seen []int32
+		require.NoError(t, c.ForEach(context.Background(), collect(&seen)))
+		require.Equal(t, []int32{0, 1, 2}, seen)
+		require.True(t, c.closed)
+	})
+	t.Run("stops at callback error", func(t *testing.T) {
+		c := newSliceCursor(3)
+		errStop := errors.New("stop")
+		var seen []int32
+		err := c.ForEach(context.Background(), func(doc bson.Raw) error {
+			seen = append(seen, doc.Lookup("n").Int32())
+			if len(seen) == 2 {
+				return errStop
+			}
+			return nil
+		})
+		require.Equal(t, errStop, err)
+		require.Equal(t, []int32{0, 1}, seen)
+		require.True(t, c.closed)
+	})
+	t.Run("returns cursor error", func(t *testing.T) {
+		c := newSliceCursor(1)
+		c.err = errors.New("cursor failed")
+		var seen []int32
+		require.Equal(t, c.err, c.ForEach(context.Background(), collect(&seen)))
+		require.Equal(t, []int32{0}, seen)
+		require.True(t, c.closed)
+	})
+	t.Run("stops when context is done", func(t *testing.T) {
+		c := newSliceCursor(3)
+		ctx, cancel := context.WithCancel(context.Background())
+		var seen []int32
+		err := c.ForEach(ctx, func(doc bson.Raw) error {
+			seen = append(seen, doc.Lookup("n").Int32())
+			cancel()
+			return nil
+		})
+		require.Equal(t, context.Canceled, err)
+		require.Equal(t, []int32{0}, seen)
+		require.True(t, c.closed)
+	})
+	t.Run("empty cursor", func(t *testing.T) {
+		require.NoError(t, emptyCursor{}.ForEach(context.Background(), func(bson.Raw) error {
+			return errors.New("should not be called")
+		}))
+	})
+}
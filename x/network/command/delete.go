@@ -47,7 +47,7 @@ func (d *Delete) Encode(desc description.SelectedServer) ([]wiremessage.WireMess
 }
 
 func (d *Delete) encode(desc description.SelectedServer) error {
-	batches, err := splitBatches(d.Deletes, int(desc.MaxBatchCount), int(desc.MaxDocumentSize))
+	batches, err := splitBatches(d.Deletes, int(desc.MaxBatchCount), int(desc.MaxDocumentSize), maxBatchBytes(desc))
 	if err != nil {
 		return err
 	}
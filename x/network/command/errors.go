@@ -30,6 +30,8 @@ var (
 	ErrDocumentTooLarge = errors.New("an inserted document is too large")
 	// ErrNonPrimaryRP occurs when a nonprimary read preference is used with a transaction.
 	ErrNonPrimaryRP = errors.New("read preference in a transaction must be primary")
+	// ErrSnapshotUnsupported occurs when a read in a snapshot session is sent to a server older than MongoDB 5.0.
+	ErrSnapshotUnsupported = errors.New("snapshot reads require MongoDB 5.0 or later")
 	// UnknownTransactionCommitResult is an error label for unknown transaction commit results.
 	UnknownTransactionCommitResult = "UnknownTransactionCommitResult"
 	// TransientTransactionError is an error label for transient errors with transactions.
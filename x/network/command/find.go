@@ -31,6 +31,8 @@ type Find struct {
 	Clock       *session.ClusterClock
 	Session     *session.Client
 
+	SkipAfterClusterTime bool // See Read.SkipAfterClusterTime
+
 	result Cursor
 	err    error
 }
@@ -65,6 +67,8 @@ func (f *Find) encode(desc description.SelectedServer) (*Read, error) {
 		Command:     command,
 		ReadConcern: f.ReadConcern,
 		Session:     f.Session,
+
+		SkipAfterClusterTime: f.SkipAfterClusterTime,
 	}, nil
 }
 
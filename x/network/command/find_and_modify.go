@@ -42,9 +42,10 @@ func unmarshalFindAndModifyResult(rdr bson.Raw) (result.FindAndModify, error) {
 	}
 
 	if val, err := rdr.LookupErr("lastErrorObject", "upserted"); err == nil {
-		oid, ok := val.ObjectIDOK()
-		if ok {
+		if oid, ok := val.ObjectIDOK(); ok {
 			res.LastErrorObject.Upserted = oid
+		} else {
+			res.LastErrorObject.Upserted = val
 		}
 	}
 	return res, nil
@@ -22,10 +22,14 @@ import (
 // buildInfo.
 //
 // The isMaster and buildInfo commands are used to build a server description.
+//
+// If SpeculativeAuthenticate is set, it is sent with isMaster as the first step of an authentication
+// conversation and the server's reply is available from the SpeculativeResponse method.
 type Handshake struct {
-	Client             bsonx.Doc
-	Compressors        []string
-	SaslSupportedMechs string
+	Client                  bsonx.Doc
+	Compressors             []string
+	SaslSupportedMechs      string
+	SpeculativeAuthenticate bsonx.Doc
 
 	ismstr result.IsMaster
 	err    error
@@ -35,9 +39,10 @@ type Handshake struct {
 func (h *Handshake) Encode() (wiremessage.WireMessage, error) {
 	var wm wiremessage.WireMessage
 	ismstr, err := (&IsMaster{
-		Client:             h.Client,
-		Compressors:        h.Compressors,
-		SaslSupportedMechs: h.SaslSupportedMechs,
+		Client:                  h.Client,
+		Compressors:             h.Compressors,
+		SaslSupportedMechs:      h.SaslSupportedMechs,
+		SpeculativeAuthenticate: h.SpeculativeAuthenticate,
 	}).Encode()
 	if err != nil {
 		return wm, err
@@ -66,6 +71,10 @@ func (h *Handshake) Result(addr address.Address) (description.Server, error) {
 	return description.NewServer(addr, h.ismstr), nil
 }
 
+// SpeculativeResponse returns the server's reply to the speculative authentication sent with isMaster, or nil
+// if the server did not begin the conversation.
+func (h *Handshake) SpeculativeResponse() bsonx.Doc { return h.ismstr.SpeculativeAuthenticate }
+
 // Err returns the error set on this Handshake.
 func (h *Handshake) Err() error { return h.err }
 
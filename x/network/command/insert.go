@@ -78,7 +78,7 @@ func (i *Insert) encodeBatch(docs []bsonx.Doc, desc description.SelectedServer)
 }
 
 func (i *Insert) encode(desc description.SelectedServer) error {
-	batches, err := splitBatches(i.Docs, int(desc.MaxBatchCount), int(desc.MaxDocumentSize))
+	batches, err := splitBatches(i.Docs, int(desc.MaxBatchCount), int(desc.MaxDocumentSize), maxBatchBytes(desc))
 	if err != nil {
 		return err
 	}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,7 +28,7 @@ func TestInsertCommandSplitting(t *testing.T) {
 			i.Docs = append(i.Docs, bsonx.Doc{{"a", bsonx.Int32(int32(n))}})
 		}
 
-		batches, err := splitBatches(i.Docs, 10, kilobyte) // 1kb
+		batches, err := splitBatches(i.Docs, 10, kilobyte, kilobyte) // 1kb
 		assert.NoError(t, err)
 		assert.Len(t, batches, 10)
 		for _, b := range batches {
@@ -47,7 +48,7 @@ func TestInsertCommandSplitting(t *testing.T) {
 			i.Docs = append(i.Docs, bsonx.Doc{{"a", bsonx.Int32(int32(n))}})
 		}
 
-		batches, err := splitBatches(i.Docs, 100, 32) // 32 bytes?
+		batches, err := splitBatches(i.Docs, 100, 32, 32) // 32 bytes?
 		assert.NoError(t, err)
 		assert.Len(t, batches, 50)
 		for _, b := range batches {
@@ -68,7 +69,7 @@ func TestInsertCommandSplitting(t *testing.T) {
 		}
 
 		for _, ct := range []int{-1, 0, -1000} {
-			batches, err := splitBatches(i.Docs, ct, 100*megabyte)
+			batches, err := splitBatches(i.Docs, ct, 100*megabyte, 100*megabyte)
 			assert.NoError(t, err)
 			assert.Len(t, batches, 100)
 			for _, b := range batches {
@@ -87,9 +88,99 @@ func TestInsertCommandSplitting(t *testing.T) {
 	t.Run("document_larger_than_max_size", func(t *testing.T) {
 		i := &Insert{}
 		i.Docs = append(i.Docs, bsonx.Doc{{"a", bsonx.String("bcdefghijklmnopqrstuvwxyz")}})
-		_, err := splitBatches(i.Docs, 100, 5)
+		_, err := splitBatches(i.Docs, 100, 5, 5)
 		if err != ErrDocumentTooLarge {
 			t.Errorf("Expected a too large error. got %v; want %v", err, ErrDocumentTooLarge)
 		}
 	})
+	t.Run("array_element_overhead", func(t *testing.T) {
+		i := &Insert{}
+		for n := 0; n < 9; n++ {
+			i.Docs = append(i.Docs, bsonx.Doc{{"a", bsonx.Int32(int32(n))}})
+		}
+		raw, err := i.Docs[0].MarshalBSON()
+		assert.NoError(t, err)
+
+		// three documents fit without their array element overhead, but not with it
+		batches, err := splitBatches(i.Docs, 100, 100, 3*len(raw)+8)
+		assert.NoError(t, err)
+		assert.Len(t, batches, 5)
+		for _, b := range batches[:4] {
+			assert.Len(t, b, 2)
+		}
+	})
+	t.Run("document_larger_than_batch_size", func(t *testing.T) {
+		i := &Insert{}
+		i.Docs = append(i.Docs,
+			bsonx.Doc{{"a", bsonx.Int32(1)}},
+			bsonx.Doc{{"a", bsonx.String("bcdefghijklmnopqrstuvwxyz")}},
+			bsonx.Doc{{"a", bsonx.Int32(1)}},
+		)
+		batches, err := splitBatches(i.Docs, 100, 100, 20)
+		assert.NoError(t, err)
+		assert.Len(t, batches, 3)
+	})
+	t.Run("message_size_near_limits", func(t *testing.T) {
+		const (
+			maxDocumentSize = 1024 * 1024
+			maxMessageSize  = 3 * maxDocumentSize
+		)
+
+		i := &Insert{NS: Namespace{DB: "db", Collection: "coll"}}
+		for n := 0; n < 40; n++ {
+			// four documents fill all but a few hundred bytes of maxDocumentSize less the reserved buffer
+			size := maxDocumentSize/4 - 4100 + n%10
+			i.Docs = append(i.Docs, bsonx.Doc{{"a", bsonx.Binary(0x00, make([]byte, size))}})
+		}
+
+		for _, tc := range []struct {
+			name        string
+			wireVersion int32
+		}{
+			{"OP_MSG", 6},
+			{"OP_QUERY", 5},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				desc := description.SelectedServer{
+					Server: description.Server{
+						WireVersion:     &description.VersionRange{Max: tc.wireVersion},
+						MaxBatchCount:   1000,
+						MaxDocumentSize: maxDocumentSize,
+						MaxMessageSize:  maxMessageSize,
+					},
+				}
+				i.batches = nil
+				wms, err := i.Encode(desc)
+				assert.NoError(t, err)
+
+				var total int
+				for _, b := range i.batches {
+					total += b.numDocs
+					if tc.wireVersion < 6 {
+						assert.True(t, b.numDocs <= 4, "batch of %d documents exceeds maxBsonObjectSize", b.numDocs)
+					} else {
+						assert.True(t, b.numDocs <= 12, "batch of %d documents exceeds maxMessageSizeBytes", b.numDocs)
+					}
+				}
+				assert.Equal(t, len(i.Docs), total)
+
+				for _, wm := range wms {
+					switch msg := wm.(type) {
+					case wiremessage.Query:
+						assert.True(t, len(msg.Query) <= maxDocumentSize+16*1024,
+							"command of %d bytes exceeds maxBsonObjectSize", len(msg.Query))
+					default:
+						assert.True(t, wm.Len() <= maxMessageSize, "message of %d bytes exceeds maxMessageSizeBytes", wm.Len())
+					}
+				}
+
+				// the batches should be as large as the limits allow
+				if tc.wireVersion < 6 {
+					assert.Len(t, wms, 10)
+				} else {
+					assert.Len(t, wms, 4)
+				}
+			})
+		}
+	})
 }
@@ -23,9 +23,10 @@ import (
 //
 // Since IsMaster can only be run on a connection, there is no Dispatch method.
 type IsMaster struct {
-	Client             bsonx.Doc
-	Compressors        []string
-	SaslSupportedMechs string
+	Client                  bsonx.Doc
+	Compressors             []string
+	SaslSupportedMechs      string
+	SpeculativeAuthenticate bsonx.Doc
 
 	err error
 	res result.IsMaster
@@ -40,6 +41,9 @@ func (im *IsMaster) Encode() (wiremessage.WireMessage, error) {
 	if im.SaslSupportedMechs != "" {
 		cmd = append(cmd, bsonx.Elem{"saslSupportedMechs", bsonx.String(im.SaslSupportedMechs)})
 	}
+	if im.SpeculativeAuthenticate != nil {
+		cmd = append(cmd, bsonx.Elem{"speculativeAuthenticate", bsonx.Document(im.SpeculativeAuthenticate)})
+	}
 
 	// always send compressors even if empty slice
 	array := bsonx.Arr{}
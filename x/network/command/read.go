@@ -29,13 +29,18 @@ type Read struct {
 	Clock       *session.ClusterClock
 	Session     *session.Client
 
+	// SkipAfterClusterTime opts this command out of the causal consistency of Session by not sending
+	// afterClusterTime in the read concern.
+	SkipAfterClusterTime bool
+
 	result bson.Raw
 	err    error
 }
 
-func (r *Read) createReadPref(serverKind description.ServerKind, topologyKind description.TopologyKind, isOpQuery bool) bsonx.Doc {
+func (r *Read) createReadPref(server description.Server, topologyKind description.TopologyKind, isOpQuery bool) bsonx.Doc {
 	doc := bsonx.Doc{}
 	rp := r.ReadPref
+	serverKind := server.Kind
 
 	if rp == nil {
 		if topologyKind == description.Single && serverKind != description.Mongos {
@@ -57,7 +62,8 @@ func (r *Read) createReadPref(serverKind description.ServerKind, topologyKind de
 		doc = append(doc, bsonx.Elem{"mode", bsonx.String("primaryPreferred")})
 	case readpref.SecondaryPreferredMode:
 		_, ok := r.ReadPref.MaxStaleness()
-		if serverKind == description.Mongos && isOpQuery && !ok && len(r.ReadPref.TagSets()) == 0 {
+		_, hedgeSet := r.ReadPref.HedgeEnabled()
+		if serverKind == description.Mongos && isOpQuery && !ok && len(r.ReadPref.TagSets()) == 0 && !hedgeSet {
 			return nil
 		}
 		doc = append(doc, bsonx.Elem{"mode", bsonx.String("secondaryPreferred")})
@@ -69,9 +75,7 @@ func (r *Read) createReadPref(serverKind description.ServerKind, topologyKind de
 
 	sets := make([]bsonx.Val, 0, len(r.ReadPref.TagSets()))
 	for _, ts := range r.ReadPref.TagSets() {
-		if len(ts) == 0 {
-			continue
-		}
+		// an empty tag set matches any server, so it is sent as {} to preserve the fallthrough order
 		set := bsonx.Doc{}
 		for _, t := range ts {
 			set = append(set, bsonx.Elem{t.Name, bsonx.String(t.Value)})
@@ -86,14 +90,20 @@ func (r *Read) createReadPref(serverKind description.ServerKind, topologyKind de
 		doc = append(doc, bsonx.Elem{"maxStalenessSeconds", bsonx.Int32(int32(d.Seconds()))})
 	}
 
+	// Only mongos understands the hedge option, and older versions reject it.
+	if enabled, ok := r.ReadPref.HedgeEnabled(); ok && serverKind == description.Mongos &&
+		description.HedgedReadsSupported(server.WireVersion) == nil {
+		doc = append(doc, bsonx.Elem{"hedge", bsonx.Document(bsonx.Doc{{"enabled", bsonx.Boolean(enabled)}})})
+	}
+
 	return doc
 }
 
 // addReadPref will add a read preference to the query document.
 //
 // NOTE: This method must always return either a valid bson.Reader or an error.
-func (r *Read) addReadPref(rp *readpref.ReadPref, serverKind description.ServerKind, topologyKind description.TopologyKind, query bson.Raw) (bson.Raw, error) {
-	doc := r.createReadPref(serverKind, topologyKind, true)
+func (r *Read) addReadPref(rp *readpref.ReadPref, server description.Server, topologyKind description.TopologyKind, query bson.Raw) (bson.Raw, error) {
+	doc := r.createReadPref(server, topologyKind, true)
 	if doc == nil {
 		return query, nil
 	}
@@ -116,7 +126,7 @@ func (r *Read) encodeOpMsg(desc description.SelectedServer, cmd bsonx.Doc) (wire
 		Sections:  make([]wiremessage.Section, 0),
 	}
 
-	readPrefDoc := r.createReadPref(desc.Server.Kind, desc.Kind, false)
+	readPrefDoc := r.createReadPref(desc.Server, desc.Kind, false)
 	fullDocRdr, err := opmsgAddGlobals(cmd, r.DB, readPrefDoc)
 	if err != nil {
 		return nil, err
@@ -158,7 +168,7 @@ func (r *Read) encodeOpQuery(desc description.SelectedServer, cmd bsonx.Doc) (wi
 	}
 
 	if desc.Server.Kind == description.Mongos {
-		rdr, err = r.addReadPref(r.ReadPref, desc.Server.Kind, desc.Kind, rdr)
+		rdr, err = r.addReadPref(r.ReadPref, desc.Server, desc.Kind, rdr)
 		if err != nil {
 			return nil, err
 		}
@@ -197,7 +207,7 @@ func (r *Read) decodeOpReply(wm wiremessage.WireMessage) {
 // Encode will encode this command into a wire message for the given server description.
 func (r *Read) Encode(desc description.SelectedServer) (wiremessage.WireMessage, error) {
 	cmd := r.Command.Copy()
-	cmd, err := addReadConcern(cmd, desc, r.ReadConcern, r.Session)
+	cmd, err := addReadConcern(cmd, desc, r.ReadConcern, r.Session, r.SkipAfterClusterTime)
 	if err != nil {
 		return nil, err
 	}
@@ -236,6 +246,7 @@ func (r *Read) Decode(desc description.SelectedServer, wm wiremessage.WireMessag
 
 	_ = updateClusterTimes(r.Session, r.Clock, r.result)
 	_ = updateOperationTime(r.Session, r.result)
+	_ = updateSnapshotTime(r.Session, r.result)
 	return r
 }
 
@@ -46,7 +46,7 @@ func (u *Update) Encode(desc description.SelectedServer) ([]wiremessage.WireMess
 }
 
 func (u *Update) encode(desc description.SelectedServer) error {
-	batches, err := splitBatches(u.Docs, int(desc.MaxBatchCount), int(desc.MaxDocumentSize))
+	batches, err := splitBatches(u.Docs, int(desc.MaxBatchCount), int(desc.MaxDocumentSize), maxBatchBytes(desc))
 	if err != nil {
 		return err
 	}
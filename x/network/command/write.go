@@ -125,7 +125,7 @@ func (w *Write) Encode(desc description.SelectedServer) (wiremessage.WireMessage
 	var err error
 	if w.Session != nil && w.Session.TransactionStarting() {
 		// Starting transactions have a read concern, even in writes.
-		cmd, err = addReadConcern(cmd, desc, nil, w.Session)
+		cmd, err = addReadConcern(cmd, desc, nil, w.Session, false)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,59 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build go1.9
+// +build go1.9
+
+package compressor
+
+import (
+	"github.com/klauspost/compress/zstd"
+	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
+)
+
+// ZstdCompressor uses the zstd method to compress data
+type ZstdCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+// CompressBytes uses zstd to compress a slice of bytes.
+func (z *ZstdCompressor) CompressBytes(src, dest []byte) ([]byte, error) {
+	return z.encoder.EncodeAll(src, dest[:0]), nil
+}
+
+// UncompressBytes uses zstd to uncompress a slice of bytes.
+func (z *ZstdCompressor) UncompressBytes(src, dest []byte) ([]byte, error) {
+	return z.decoder.DecodeAll(src, dest[:0])
+}
+
+// CompressorID returns the ID for the zstd compressor.
+func (z *ZstdCompressor) CompressorID() wiremessage.CompressorID {
+	return wiremessage.CompressorZstd
+}
+
+// Name returns the name for the zstd compressor.
+func (z *ZstdCompressor) Name() string {
+	return "zstd"
+}
+
+// CreateZstd creates a zstd compressor
+func CreateZstd() (Compressor, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return &ZstdCompressor{}, err
+	}
+
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return &ZstdCompressor{}, err
+	}
+
+	return &ZstdCompressor{
+		encoder: encoder,
+		decoder: decoder,
+	}, nil
+}
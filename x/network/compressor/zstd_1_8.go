@@ -0,0 +1,18 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build !go1.9
+// +build !go1.9
+
+package compressor
+
+import "errors"
+
+// CreateZstd creates a zstd compressor. The zstd library requires Go 1.9 or later, so this always returns an
+// error.
+func CreateZstd() (Compressor, error) {
+	return nil, errors.New("zstd compression requires Go 1.9 or later")
+}
@@ -8,15 +8,17 @@ type commandMetadata struct {
 	Time               time.Time
 	Legacy             bool
 	FullCollectionName string
+	Redacted           bool
 }
 
 // createMetadata creates metadata for a command.
-func createMetadata(name string, legacy bool, fullCollName string) *commandMetadata {
+func createMetadata(name string, legacy bool, fullCollName string, redacted bool) *commandMetadata {
 	return &commandMetadata{
 		Name:               name,
 		Time:               time.Now(),
 		Legacy:             legacy,
 		FullCollectionName: fullCollName,
+		Redacted:           redacted,
 	}
 }
 
@@ -50,6 +50,32 @@ type Connection interface {
 	ID() string
 }
 
+// Discarder is implemented by connections that can be closed without being returned to their pool. It is
+// used for connections left in a state that cannot be reused, such as one the server is still streaming
+// exhaust replies on.
+type Discarder interface {
+	Discard() error
+}
+
+// Reauthenticator is implemented by connections that can authenticate again after their handshake, which is
+// needed when the server returns a ReauthenticationRequired error because the credentials used for the
+// connection have expired.
+type Reauthenticator interface {
+	Reauthenticate(ctx context.Context) error
+}
+
+func reauthenticate(ctx context.Context, c Connection) error {
+	r, ok := c.(Reauthenticator)
+	if !ok {
+		return Error{ConnectionID: c.ID(), message: "connection does not support reauthentication"}
+	}
+	return r.Reauthenticate(ctx)
+}
+
+// AuthenticatorFunc authenticates a connection that has completed its handshake. desc is the description of
+// the server returned by the handshake.
+type AuthenticatorFunc func(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter) error
+
 // Dialer is used to make network connections.
 type Dialer interface {
 	DialContext(ctx context.Context, network, address string) (net.Conn, error)
@@ -105,6 +131,8 @@ type connection struct {
 	readBuf          []byte
 	writeBuf         []byte
 	wireMessageBuf   []byte // buffer to store uncompressed wire message before compressing
+	desc             description.Server
+	authenticator    AuthenticatorFunc
 }
 
 // New opens a connection to a given Addr
@@ -121,6 +149,13 @@ func New(ctx context.Context, addr address.Address, opts ...Option) (Connection,
 		return nil, nil, err
 	}
 
+	if tcpConn, ok := nc.(*net.TCPConn); ok {
+		if err = tcpConn.SetNoDelay(cfg.noDelay); err != nil {
+			_ = nc.Close()
+			return nil, nil, err
+		}
+	}
+
 	if cfg.tlsConfig != nil {
 		tlsConfig := cfg.tlsConfig.Clone()
 		nc, err = configureTLS(ctx, nc, addr, tlsConfig)
@@ -156,6 +191,7 @@ func New(ctx context.Context, addr address.Address, opts ...Option) (Connection,
 		uncompressBuf:    make([]byte, 256),
 		writeBuf:         make([]byte, 0, 256),
 		wireMessageBuf:   make([]byte, 256),
+		authenticator:    cfg.authenticator,
 	}
 
 	c.bumpIdleDeadline()
@@ -184,6 +220,7 @@ func New(ctx context.Context, addr address.Address, opts ...Option) (Connection,
 
 		}
 
+		c.desc = d
 		desc = &d
 	}
 
@@ -350,13 +387,37 @@ func (c *connection) uncompressMessage(compressed wiremessage.Compressed) ([]byt
 	return fullMessage, origHeader.OpCode, nil
 }
 
-func canMonitor(cmd string) bool {
-	if cmd == "authenticate" || cmd == "saslStart" || cmd == "saslContinue" || cmd == "getnonce" || cmd == "createUser" ||
-		cmd == "updateUser" || cmd == "copydbgetnonce" || cmd == "copydbsaslstart" || cmd == "copydb" {
+// redactedCommands are the lowercased names of the security-sensitive commands whose command and
+// reply documents are not published to command monitors.
+var redactedCommands = map[string]struct{}{
+	"authenticate":    {},
+	"saslstart":       {},
+	"saslcontinue":    {},
+	"getnonce":        {},
+	"createuser":      {},
+	"updateuser":      {},
+	"copydbgetnonce":  {},
+	"copydbsaslstart": {},
+	"copydb":          {},
+}
+
+// shouldRedact returns true if the command and reply documents for cmd must be replaced with empty
+// documents in monitoring events. Command names are compared case-insensitively, and handshakes are
+// redacted when they carry a speculative authentication.
+func (c *connection) shouldRedact(name string, cmd bsonx.Doc) bool {
+	if c.cmdMonitor.DisableRedaction {
 		return false
 	}
 
-	return true
+	name = strings.ToLower(name)
+	if _, ok := redactedCommands[name]; ok {
+		return true
+	}
+	if name == "ismaster" || name == "hello" {
+		_, err := cmd.LookupErr("speculativeAuthenticate")
+		return err == nil
+	}
+	return false
 }
 
 func (c *connection) commandStartedEvent(ctx context.Context, wm wiremessage.WireMessage) error {
@@ -425,7 +486,8 @@ func (c *connection) commandStartedEvent(ctx context.Context, wm wiremessage.Wir
 
 	startedEvent.Command = cmd
 	startedEvent.CommandName = cmd[0].Key
-	if !canMonitor(startedEvent.CommandName) {
+	redacted := c.shouldRedact(startedEvent.CommandName, cmd)
+	if redacted {
 		startedEvent.Command = emptyDoc
 	}
 
@@ -452,7 +514,7 @@ func (c *connection) commandStartedEvent(ctx context.Context, wm wiremessage.Wir
 		return nil
 	}
 
-	c.commandMap[startedEvent.RequestID] = createMetadata(startedEvent.CommandName, legacy, fullCollName)
+	c.commandMap[startedEvent.RequestID] = createMetadata(startedEvent.CommandName, legacy, fullCollName, redacted)
 	return nil
 }
 
@@ -543,7 +605,7 @@ func (c *connection) commandFinishedEvent(ctx context.Context, wm wiremessage.Wi
 	}
 
 	if success {
-		if !canMonitor(finishedEvent.CommandName) {
+		if cmdMetadata.Redacted {
 			successEvent := &event.CommandSucceededEvent{
 				Reply:                emptyDoc,
 				CommandFinishedEvent: finishedEvent,
@@ -838,6 +900,23 @@ func (c *connection) ID() string {
 	return c.id
 }
 
+// Reauthenticate implements the Reauthenticator interface by running the connection's authenticator again. Like
+// the initial authentication, the commands it sends are not monitored.
+func (c *connection) Reauthenticate(ctx context.Context) error {
+	if c.authenticator == nil {
+		return Error{ConnectionID: c.id, message: "connection cannot be reauthenticated without an authenticator"}
+	}
+
+	monitor := c.cmdMonitor
+	c.cmdMonitor = nil
+	defer func() { c.cmdMonitor = monitor }()
+
+	if err := c.authenticator(ctx, c.desc, c); err != nil {
+		return Error{ConnectionID: c.id, Wrapped: err, message: "reauthentication failed"}
+	}
+	return nil
+}
+
 func (c *connection) initialize(ctx context.Context, appName string) error {
 	return nil
 }
@@ -7,10 +7,29 @@
 package connection
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
 	"net"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/event"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/address"
+	"github.com/mongodb/mongo-go-driver/x/network/compressor"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
+	"github.com/stretchr/testify/require"
 )
 
 // bootstrapConnection creates a listener that will listen for a single connection
@@ -87,3 +106,237 @@ func (d *dialer) lenclosed() int {
 	defer d.Unlock()
 	return len(d.closed)
 }
+
+func TestCompressMessageZstd(t *testing.T) {
+	zstdComp, err := compressor.CreateZstd()
+	require.NoError(t, err)
+
+	c := &connection{
+		compressor:    zstdComp,
+		compressorMap: map[wiremessage.CompressorID]compressor.Compressor{wiremessage.CompressorZstd: zstdComp},
+	}
+
+	doc, err := bsonx.Doc{
+		{"insert", bsonx.String("foo")},
+		{"payload", bsonx.String(string(bytes.Repeat([]byte("zstd"), 1<<16)))},
+	}.MarshalBSON()
+	require.NoError(t, err)
+	msg := wiremessage.Msg{
+		MsgHeader: wiremessage.Header{RequestID: 42},
+		Sections:  []wiremessage.Section{wiremessage.SectionBody{Document: doc}},
+	}
+	original, err := msg.MarshalWireMessage()
+	require.NoError(t, err)
+
+	wm, err := c.compressMessage(msg)
+	require.NoError(t, err)
+	compressed, ok := wm.(wiremessage.Compressed)
+	require.True(t, ok, "expected an OP_COMPRESSED message, got %T", wm)
+
+	b, err := compressed.MarshalWireMessage()
+	require.NoError(t, err)
+	require.True(t, len(b) < len(original), "expected compressed message to be smaller than %d bytes, got %d", len(original), len(b))
+	// header (16) + original opcode (4) + uncompressed size (4)
+	require.Equal(t, byte(wiremessage.CompressorZstd), b[24])
+
+	var read wiremessage.Compressed
+	require.NoError(t, read.UnmarshalWireMessage(b))
+	uncompressed, opcode, err := c.uncompressMessage(read)
+	require.NoError(t, err)
+	require.Equal(t, wiremessage.OpMsg, opcode)
+	require.Equal(t, original, uncompressed)
+}
+
+func TestCommandMonitoringRedaction(t *testing.T) {
+	msg := func(t *testing.T, requestID, responseTo int32, doc bsonx.Doc) wiremessage.Msg {
+		b, err := doc.MarshalBSON()
+		require.NoError(t, err)
+		return wiremessage.Msg{
+			MsgHeader: wiremessage.Header{RequestID: requestID, ResponseTo: responseTo},
+			Sections:  []wiremessage.Section{wiremessage.SectionBody{Document: b}},
+		}
+	}
+	reply := bsonx.Doc{{"conversationId", bsonx.Int32(1)}, {"payload", bsonx.Binary(0x00, []byte("secret"))}, {"ok", bsonx.Int32(1)}}
+
+	testCases := []struct {
+		name     string
+		cmd      bsonx.Doc
+		disable  bool
+		redacted bool
+	}{
+		{"saslStart", bsonx.Doc{{"saslStart", bsonx.Int32(1)}, {"payload", bsonx.Binary(0x00, []byte("secret"))}}, false, true},
+		{"case insensitive", bsonx.Doc{{"copydbSaslStart", bsonx.Int32(1)}}, false, true},
+		{"createUser", bsonx.Doc{{"createUser", bsonx.String("user")}, {"pwd", bsonx.String("secret")}}, false, true},
+		{"speculative handshake", bsonx.Doc{{"isMaster", bsonx.Int32(1)}, {"speculativeAuthenticate", bsonx.Document(bsonx.Doc{})}}, false, true},
+		{"handshake", bsonx.Doc{{"isMaster", bsonx.Int32(1)}}, false, false},
+		{"find", bsonx.Doc{{"find", bsonx.String("foo")}}, false, false},
+		{"disabled", bsonx.Doc{{"saslStart", bsonx.Int32(1)}, {"payload", bsonx.Binary(0x00, []byte("secret"))}}, true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var started *event.CommandStartedEvent
+			var succeeded *event.CommandSucceededEvent
+			c := &connection{
+				commandMap: make(map[int64]*commandMetadata),
+				cmdMonitor: &event.CommandMonitor{
+					Started:          func(_ context.Context, e *event.CommandStartedEvent) { started = e },
+					Succeeded:        func(_ context.Context, e *event.CommandSucceededEvent) { succeeded = e },
+					DisableRedaction: tc.disable,
+				},
+			}
+
+			cmd := append(tc.cmd.Copy(), bsonx.Elem{"$db", bsonx.String("admin")})
+			require.NoError(t, c.commandStartedEvent(context.Background(), msg(t, 1, 0, cmd)))
+			require.NoError(t, c.commandFinishedEvent(context.Background(), msg(t, 2, 1, reply)))
+
+			require.NotNil(t, started)
+			require.NotNil(t, succeeded)
+			require.Equal(t, tc.cmd[0].Key, started.CommandName)
+			if tc.redacted {
+				require.Empty(t, started.Command)
+				require.Empty(t, succeeded.Reply)
+			} else {
+				require.True(t, cmd.Equal(started.Command), "expected command %v, got %v", cmd, started.Command)
+				require.True(t, reply.Equal(succeeded.Reply), "expected reply %v, got %v", reply, succeeded.Reply)
+			}
+		})
+	}
+}
+
+func TestConnectionReauthenticate(t *testing.T) {
+	t.Run("without an authenticator", func(t *testing.T) {
+		c := &connection{id: "test"}
+		require.Error(t, c.Reauthenticate(context.Background()))
+	})
+
+	t.Run("runs the authenticator unmonitored", func(t *testing.T) {
+		monitor := &event.CommandMonitor{}
+		desc := description.Server{Addr: address.Address("localhost:27017"), Kind: description.RSPrimary}
+
+		var calls int
+		c := &connection{id: "test", desc: desc, cmdMonitor: monitor}
+		c.authenticator = func(ctx context.Context, got description.Server, rw wiremessage.ReadWriter) error {
+			calls++
+			require.Equal(t, desc, got)
+			require.Equal(t, c, rw)
+			require.Nil(t, c.cmdMonitor)
+			return nil
+		}
+
+		require.NoError(t, c.Reauthenticate(context.Background()))
+		require.Equal(t, 1, calls)
+		require.Equal(t, monitor, c.cmdMonitor)
+	})
+
+	t.Run("wraps authenticator errors", func(t *testing.T) {
+		c := &connection{id: "test"}
+		c.authenticator = func(context.Context, description.Server, wiremessage.ReadWriter) error {
+			return errors.New("token expired")
+		}
+
+		err := c.Reauthenticate(context.Background())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "reauthentication failed: token expired")
+	})
+}
+
+func newSelfSignedCertificate(t *testing.T, commonName string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestTLSClientCertificateRotation(t *testing.T) {
+	serverCert := newSelfSignedCertificate(t, "server")
+	var current atomic.Value
+	current.Store(newSelfSignedCertificate(t, "client-1"))
+
+	cfg := NewTLSConfig()
+	cfg.SetInsecure(true)
+	cfg.SetGetClientCertificate(func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		cert := current.Load().(tls.Certificate)
+		return &cert, nil
+	})
+
+	// connect performs a TLS handshake the same way New does and returns the common name of the
+	// certificate the server received.
+	connect := func() string {
+		client, server := net.Pipe()
+		defer func() { _ = client.Close() }()
+		defer func() { _ = server.Close() }()
+
+		peer := make(chan string, 1)
+		go func() {
+			conn := tls.Server(server, &tls.Config{
+				Certificates: []tls.Certificate{serverCert},
+				ClientAuth:   tls.RequireAnyClientCert,
+			})
+			if err := conn.Handshake(); err != nil {
+				peer <- err.Error()
+				return
+			}
+			peer <- conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+		}()
+
+		_, err := configureTLS(context.Background(), client, address.Address("localhost:27017"), cfg.Clone())
+		require.NoError(t, err)
+		return <-peer
+	}
+
+	require.Equal(t, "client-1", connect())
+	current.Store(newSelfSignedCertificate(t, "client-2"))
+	require.Equal(t, "client-2", connect())
+}
+
+func TestDialerKeepAlive(t *testing.T) {
+	keepAlive := func(t *testing.T, opts ...Option) time.Duration {
+		cfg, err := newConfig(opts...)
+		require.NoError(t, err)
+		d, ok := cfg.dialer.(*net.Dialer)
+		require.True(t, ok, "expected a *net.Dialer but got %T", cfg.dialer)
+		return d.KeepAlive
+	}
+
+	t.Run("default", func(t *testing.T) {
+		require.Equal(t, DefaultKeepAlive, keepAlive(t))
+	})
+	t.Run("configured", func(t *testing.T) {
+		require.Equal(t, 30*time.Second, keepAlive(t, WithKeepAlive(func(time.Duration) time.Duration { return 30 * time.Second })))
+	})
+	t.Run("zero disables keepalive", func(t *testing.T) {
+		require.True(t, keepAlive(t, WithKeepAlive(func(time.Duration) time.Duration { return 0 })) < 0)
+	})
+	t.Run("custom dialers are not changed", func(t *testing.T) {
+		custom := &net.Dialer{KeepAlive: time.Hour}
+		cfg, err := newConfig(
+			WithDialer(func(Dialer) Dialer { return custom }),
+			WithKeepAlive(func(time.Duration) time.Duration { return time.Second }),
+		)
+		require.NoError(t, err)
+		require.Equal(t, custom, cfg.dialer)
+		require.Equal(t, time.Hour, custom.KeepAlive)
+	})
+}
+
+func TestTCPNoDelay(t *testing.T) {
+	cfg, err := newConfig()
+	require.NoError(t, err)
+	require.True(t, cfg.noDelay)
+
+	addr := bootstrapConnections(t, 1, func(nc net.Conn) { _ = nc.Close() })
+	conn, _, err := New(context.Background(), address.Address(addr.String()),
+		WithTCPNoDelay(func(bool) bool { return false }))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
@@ -35,6 +35,17 @@ func (ne NetworkError) Error() string {
 	return fmt.Sprintf("connection(%s): %s", ne.ConnectionID, ne.Wrapped.Error())
 }
 
+// DisconnectError is returned from a Pool's Disconnect method when the context expires before
+// every connection in use was returned. The connections that were still in use have been closed.
+type DisconnectError struct {
+	InFlight int
+	Wrapped  error
+}
+
+func (de DisconnectError) Error() string {
+	return fmt.Sprintf("disconnected with %d operation(s) still in flight: %s", de.InFlight, de.Wrapped.Error())
+}
+
 // PoolError is an error returned from a Pool method.
 type PoolError string
 
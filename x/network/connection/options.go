@@ -11,21 +11,31 @@ import (
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/event"
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/logger"
 	"github.com/mongodb/mongo-go-driver/x/network/compressor"
 )
 
+// DefaultKeepAlive is the TCP keepalive period of connections made by the default dialer.
+const DefaultKeepAlive = 5 * time.Minute
+
 type config struct {
 	appName        string
+	authenticator  AuthenticatorFunc
 	connectTimeout time.Duration
 	dialer         Dialer
 	handshaker     Handshaker
 	idleTimeout    time.Duration
+	keepAlive      time.Duration
 	lifeTimeout    time.Duration
 	cmdMonitor     *event.CommandMonitor
+	logger         *logger.Logger
 	readTimeout    time.Duration
 	writeTimeout   time.Duration
 	tlsConfig      *TLSConfig
 	compressors    []compressor.Compressor
+	maxConnecting  uint64
+	noDelay        bool
+	warmupConns    uint64
 }
 
 func newConfig(opts ...Option) (*config, error) {
@@ -33,7 +43,10 @@ func newConfig(opts ...Option) (*config, error) {
 		connectTimeout: 30 * time.Second,
 		dialer:         nil,
 		idleTimeout:    10 * time.Minute,
+		keepAlive:      DefaultKeepAlive,
 		lifeTimeout:    30 * time.Minute,
+		maxConnecting:  2,
+		noDelay:        true,
 	}
 
 	for _, opt := range opts {
@@ -44,7 +57,12 @@ func newConfig(opts ...Option) (*config, error) {
 	}
 
 	if cfg.dialer == nil {
-		cfg.dialer = &net.Dialer{Timeout: cfg.connectTimeout}
+		keepAlive := cfg.keepAlive
+		if keepAlive == 0 {
+			// A zero KeepAlive makes net.Dialer use its own default rather than disabling keepalive.
+			keepAlive = -1
+		}
+		cfg.dialer = &net.Dialer{Timeout: cfg.connectTimeout, KeepAlive: keepAlive}
 	}
 
 	return cfg, nil
@@ -87,6 +105,15 @@ func WithDialer(fn func(Dialer) Dialer) Option {
 	}
 }
 
+// WithAuthenticator configures the function used to authenticate a connection again when the server reports that
+// its authentication has expired. The initial authentication is done by the Handshaker.
+func WithAuthenticator(fn func(AuthenticatorFunc) AuthenticatorFunc) Option {
+	return func(c *config) error {
+		c.authenticator = fn(c.authenticator)
+		return nil
+	}
+}
+
 // WithHandshaker configures the Handshaker that wll be used to initialize newly
 // dialed connections.
 func WithHandshaker(fn func(Handshaker) Handshaker) Option {
@@ -104,6 +131,16 @@ func WithIdleTimeout(fn func(time.Duration) time.Duration) Option {
 	}
 }
 
+// WithKeepAlive configures the period between TCP keepalive probes of an idle connection. A period of zero
+// disables keepalive. The default is DefaultKeepAlive. It is only used by the default dialer; a Dialer configured
+// with WithDialer controls keepalive itself.
+func WithKeepAlive(fn func(time.Duration) time.Duration) Option {
+	return func(c *config) error {
+		c.keepAlive = fn(c.keepAlive)
+		return nil
+	}
+}
+
 // WithLifeTimeout configures the maximum life of a connection.
 func WithLifeTimeout(fn func(time.Duration) time.Duration) Option {
 	return func(c *config) error {
@@ -112,6 +149,36 @@ func WithLifeTimeout(fn func(time.Duration) time.Duration) Option {
 	}
 }
 
+// WithMaxConnecting configures the maximum number of connections a pool will
+// establish concurrently. If max is 0, then there is no upper limit to the
+// number of connections being established at once. The default is 2.
+func WithMaxConnecting(fn func(uint64) uint64) Option {
+	return func(c *config) error {
+		c.maxConnecting = fn(c.maxConnecting)
+		return nil
+	}
+}
+
+// WithTCPNoDelay configures whether TCP_NODELAY is set on connections, which sends small writes immediately
+// instead of coalescing them. It applies to every TCP connection, including those made by a Dialer configured with
+// WithDialer. The default is true.
+func WithTCPNoDelay(fn func(bool) bool) Option {
+	return func(c *config) error {
+		c.noDelay = fn(c.noDelay)
+		return nil
+	}
+}
+
+// WithWarmupConnections configures the number of idle connections a pool will
+// establish in the background when it is connected. The number of connections
+// is limited by the size of the pool.
+func WithWarmupConnections(fn func(uint64) uint64) Option {
+	return func(c *config) error {
+		c.warmupConns = fn(c.warmupConns)
+		return nil
+	}
+}
+
 // WithReadTimeout configures the maximum read time for a connection.
 func WithReadTimeout(fn func(time.Duration) time.Duration) Option {
 	return func(c *config) error {
@@ -143,3 +210,12 @@ func WithMonitor(fn func(*event.CommandMonitor) *event.CommandMonitor) Option {
 		return nil
 	}
 }
+
+// WithLogger configures the logger that pools report the connections they create, close and fail to check
+// out to.
+func WithLogger(fn func(*logger.Logger) *logger.Logger) Option {
+	return func(c *config) error {
+		c.logger = fn(c.logger)
+		return nil
+	}
+}
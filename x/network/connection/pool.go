@@ -8,9 +8,11 @@ package connection
 
 import (
 	"context"
+	"math"
 	"sync"
 	"sync/atomic"
 
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/logger"
 	"github.com/mongodb/mongo-go-driver/x/network/address"
 	"github.com/mongodb/mongo-go-driver/x/network/description"
 	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
@@ -54,11 +56,14 @@ type Pool interface {
 	// either wait until all of the connections in use have been returned and
 	// closed or the context expires before returning. If the context expires
 	// via cancellation, deadline, timeout, or some other manner, implementations
-	// must close the in use connections. If this method returns with no errors,
-	// all connections managed by this pool must be closed. Calling Disconnect
-	// multiple times after a single Connect call must result in an error.
+	// must close the in use connections and return a DisconnectError. All
+	// connections managed by this pool must be closed when this method returns.
+	// Calling Disconnect multiple times after a single Connect call must result
+	// in an error.
 	Disconnect(context.Context) error
 	Drain() error
+	// Stats returns a snapshot of the number of connections in the Pool.
+	Stats() PoolStats
 }
 
 type pool struct {
@@ -71,17 +76,49 @@ type pool struct {
 	nextid     uint64
 	capacity   uint64
 	inflight   map[uint64]*pooledConnection
+	connecting *semaphore.Weighted
+	pending    int32
+	warmup     uint64
+	logger     *logger.Logger
+
+	cancelWarmup context.CancelFunc
 
 	sync.Mutex
 }
 
+// PoolStats is a snapshot of the connections in a Pool. The counts are
+// sampled separately, so under concurrent use they may not add up exactly.
+type PoolStats struct {
+	// Total is the number of established connections, idle or checked out.
+	Total int
+	// Available is the number of idle connections.
+	Available int
+	// Pending is the number of connections being established.
+	Pending int
+	// CheckedOut is the number of connections in use.
+	CheckedOut int
+}
+
 // NewPool creates a new pool that will hold size number of idle connections
 // and will create a max of capacity connections. It will use the provided
-// options.
+// options. The WithMaxConnecting and WithWarmupConnections options configure
+// the pool itself rather than each connection.
 func NewPool(addr address.Address, size, capacity uint64, opts ...Option) (Pool, error) {
 	if size > capacity {
 		return nil, ErrSizeLargerThanCapacity
 	}
+	cfg, err := newConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	maxConnecting := cfg.maxConnecting
+	if maxConnecting == 0 {
+		maxConnecting = math.MaxInt64
+	}
+	warmup := cfg.warmupConns
+	if warmup > size {
+		warmup = size
+	}
 	p := &pool{
 		address:    addr,
 		conns:      make(chan *pooledConnection, size),
@@ -90,21 +127,64 @@ func NewPool(addr address.Address, size, capacity uint64, opts ...Option) (Pool,
 		connected:  disconnected,
 		capacity:   capacity,
 		inflight:   make(map[uint64]*pooledConnection),
+		connecting: semaphore.NewWeighted(int64(maxConnecting)),
+		warmup:     warmup,
 		opts:       opts,
+		logger:     cfg.logger,
 	}
+	p.log("Connection pool created", "maxPoolSize", capacity, "maxIdlePoolSize", size)
 	return p, nil
 }
 
 func (p *pool) Drain() error {
 	atomic.AddUint64(&p.generation, 1)
+	p.log("Connection pool cleared")
 	return nil
 }
 
+// log logs a message about the pool for logger.ComponentConnection at logger.LevelDebug.
+func (p *pool) log(msg string, keysAndValues ...interface{}) {
+	if !p.logger.Enabled(logger.LevelDebug, logger.ComponentConnection) {
+		return
+	}
+	keysAndValues = append([]interface{}{"serverHost", p.address.String()}, keysAndValues...)
+	p.logger.Print(logger.LevelDebug, logger.ComponentConnection, msg, keysAndValues...)
+}
+
+func (p *pool) Stats() PoolStats {
+	p.Lock()
+	total := len(p.inflight)
+	p.Unlock()
+	available := len(p.conns)
+	if available > total {
+		// a connection was established and returned between the two samples
+		total = available
+	}
+
+	return PoolStats{
+		Total:      total,
+		Available:  available,
+		Pending:    int(atomic.LoadInt32(&p.pending)),
+		CheckedOut: total - available,
+	}
+}
+
 func (p *pool) Connect(ctx context.Context) error {
 	if !atomic.CompareAndSwapInt32(&p.connected, disconnected, connected) {
 		return ErrPoolConnected
 	}
 	atomic.AddUint64(&p.generation, 1)
+
+	if p.warmup > 0 {
+		warmupCtx, cancel := context.WithCancel(context.Background())
+		p.Lock()
+		p.cancelWarmup = cancel
+		p.Unlock()
+		for i := uint64(0); i < p.warmup; i++ {
+			go p.warm(warmupCtx)
+		}
+	}
+	p.log("Connection pool ready")
 	return nil
 }
 
@@ -113,6 +193,13 @@ func (p *pool) Disconnect(ctx context.Context) error {
 		return ErrPoolDisconnected
 	}
 
+	p.Lock()
+	if p.cancelWarmup != nil {
+		p.cancelWarmup()
+		p.cancelWarmup = nil
+	}
+	p.Unlock()
+
 	// We first clear out the idle connections, then we attempt to acquire the entire capacity
 	// semaphore. If the context is either cancelled, the deadline expires, or there is a timeout
 	// the semaphore acquire method will return an error. If that happens, we will aggressively
@@ -123,11 +210,12 @@ loop:
 		select {
 		case pc := <-p.conns:
 			// This error would be overwritten by the semaphore
-			_ = p.closeConnection(pc)
+			_ = p.closeConnection(pc, "poolClosed")
 		default:
 			break loop
 		}
 	}
+	var disconnectErr error
 	err := p.sem.Acquire(ctx, int64(p.capacity))
 	if err != nil {
 		p.Lock()
@@ -143,32 +231,43 @@ loop:
 		for _, pc := range toClose {
 			_ = pc.Close()
 		}
+		disconnectErr = DisconnectError{InFlight: len(toClose), Wrapped: err}
 	} else {
 		p.sem.Release(int64(p.capacity))
 	}
 	atomic.StoreInt32(&p.connected, disconnected)
-	return nil
+	p.log("Connection pool closed")
+	return disconnectErr
 }
 
 func (p *pool) Get(ctx context.Context) (Connection, *description.Server, error) {
 	if atomic.LoadInt32(&p.connected) != connected {
+		p.log("Connection checkout failed", "reason", "poolClosed", "error", ErrPoolClosed.Error())
 		return nil, nil, ErrPoolClosed
 	}
 
 	err := p.sem.Acquire(ctx, 1)
 	if err != nil {
+		p.log("Connection checkout failed", "reason", "timeout", "error", err.Error())
 		return nil, nil, err
 	}
 
-	return p.get(ctx)
+	c, desc, err := p.get(ctx)
+	if err != nil {
+		reason := "connectionError"
+		if err == ctx.Err() {
+			reason = "timeout"
+		}
+		p.log("Connection checkout failed", "reason", reason, "error", err.Error())
+	}
+	return c, desc, err
 }
 
 func (p *pool) get(ctx context.Context) (Connection, *description.Server, error) {
-	g := atomic.LoadUint64(&p.generation)
 	select {
 	case c := <-p.conns:
 		if c.Expired() {
-			go p.closeConnection(c)
+			go p.closeConnection(c, "stale")
 			return p.get(ctx)
 		}
 
@@ -177,51 +276,108 @@ func (p *pool) get(ctx context.Context) (Connection, *description.Server, error)
 		p.sem.Release(1)
 		return nil, nil, ctx.Err()
 	default:
-		c, desc, err := New(ctx, p.address, p.opts...)
+		err := p.connecting.Acquire(ctx, 1)
 		if err != nil {
 			p.sem.Release(1)
 			return nil, nil, err
 		}
 
-		pc := &pooledConnection{
-			Connection: c,
-			p:          p,
-			generation: g,
-			id:         atomic.AddUint64(&p.nextid, 1),
+		// Another connection may have been returned while we were waiting to
+		// establish one, in which case there is no need to dial.
+		select {
+		case c := <-p.conns:
+			if !c.Expired() {
+				p.connecting.Release(1)
+				return &acquired{Connection: c, sem: p.sem}, nil, nil
+			}
+			go p.closeConnection(c, "stale")
+		default:
 		}
-		p.Lock()
-		if atomic.LoadInt32(&p.connected) != connected {
-			p.Unlock()
+
+		pc, desc, err := p.dial(ctx)
+		if err != nil {
 			p.sem.Release(1)
-			p.closeConnection(pc)
-			return nil, nil, ErrPoolClosed
+			return nil, nil, err
 		}
-		defer p.Unlock()
-		p.inflight[pc.id] = pc
 		return &acquired{Connection: pc, sem: p.sem}, desc, nil
 	}
 }
 
-func (p *pool) closeConnection(pc *pooledConnection) error {
+// dial establishes a new connection for the pool. The caller must hold a
+// permit from the connecting semaphore, which is released once the handshake
+// has either completed or failed.
+func (p *pool) dial(ctx context.Context) (*pooledConnection, *description.Server, error) {
+	g := atomic.LoadUint64(&p.generation)
+	atomic.AddInt32(&p.pending, 1)
+	c, desc, err := New(ctx, p.address, p.opts...)
+	atomic.AddInt32(&p.pending, -1)
+	p.connecting.Release(1)
+	if err != nil {
+		return nil, nil, err
+	}
+	p.log("Connection created", "driverConnectionId", c.ID())
+
+	pc := &pooledConnection{
+		Connection: c,
+		p:          p,
+		generation: g,
+		id:         atomic.AddUint64(&p.nextid, 1),
+	}
+	p.Lock()
+	if atomic.LoadInt32(&p.connected) != connected {
+		p.Unlock()
+		p.closeConnection(pc, "poolClosed")
+		return nil, nil, ErrPoolClosed
+	}
+	defer p.Unlock()
+	p.inflight[pc.id] = pc
+	return pc, desc, nil
+}
+
+// warm establishes a single idle connection for the pool. Warming holds a
+// permit from the pool's semaphore so that Disconnect waits for it.
+func (p *pool) warm(ctx context.Context) {
+	if err := p.sem.Acquire(ctx, 1); err != nil {
+		return
+	}
+	defer p.sem.Release(1)
+
+	if err := p.connecting.Acquire(ctx, 1); err != nil {
+		return
+	}
+	pc, _, err := p.dial(ctx)
+	if err != nil {
+		return
+	}
+	_ = p.returnConnection(pc)
+}
+
+// closeConnection closes pc and removes it from the pool. The reason is reported when the connection is logged
+// as closed.
+func (p *pool) closeConnection(pc *pooledConnection, reason string) error {
 	if !atomic.CompareAndSwapInt32(&pc.closed, 0, 1) {
 		return nil
 	}
 	p.Lock()
 	delete(p.inflight, pc.id)
 	p.Unlock()
+	p.log("Connection closed", "driverConnectionId", pc.ID(), "reason", reason)
 	return pc.Connection.Close()
 }
 
 func (p *pool) returnConnection(pc *pooledConnection) error {
-	if atomic.LoadInt32(&p.connected) != connected || pc.Expired() {
-		return p.closeConnection(pc)
+	if atomic.LoadInt32(&p.connected) != connected {
+		return p.closeConnection(pc, "poolClosed")
+	}
+	if pc.Expired() {
+		return p.closeConnection(pc, "stale")
 	}
 
 	select {
 	case p.conns <- pc:
 		return nil
 	default:
-		return p.closeConnection(pc)
+		return p.closeConnection(pc, "poolFull")
 	}
 }
 
@@ -241,6 +397,10 @@ func (pc *pooledConnection) Close() error {
 	return pc.p.returnConnection(pc)
 }
 
+func (pc *pooledConnection) Reauthenticate(ctx context.Context) error {
+	return reauthenticate(ctx, pc.Connection)
+}
+
 func (pc *pooledConnection) Expired() bool {
 	return pc.Connection.Expired() || pc.p.isExpired(pc.generation)
 }
@@ -282,6 +442,35 @@ func (a *acquired) Close() error {
 	return err
 }
 
+// Discard closes the underlying connection instead of returning it to the pool and releases the
+// pool slot it holds.
+func (a *acquired) Discard() error {
+	a.Lock()
+	defer a.Unlock()
+	if a.Connection == nil {
+		return nil
+	}
+	var err error
+	if pc, ok := a.Connection.(*pooledConnection); ok {
+		err = pc.p.closeConnection(pc, "error")
+	} else {
+		err = a.Connection.Close()
+	}
+	a.sem.Release(1)
+	a.Connection = nil
+	return err
+}
+
+// Reauthenticate implements the Reauthenticator interface for connections that support it.
+func (a *acquired) Reauthenticate(ctx context.Context) error {
+	a.Lock()
+	defer a.Unlock()
+	if a.Connection == nil {
+		return ErrConnectionClosed
+	}
+	return reauthenticate(ctx, a.Connection)
+}
+
 func (a *acquired) Expired() bool {
 	a.Lock()
 	defer a.Unlock()
@@ -16,7 +16,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mongodb/mongo-go-driver/x/mongo/driver/logger"
 	"github.com/mongodb/mongo-go-driver/x/network/address"
+	"github.com/mongodb/mongo-go-driver/x/network/description"
+	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
 )
 
 func TestPool(t *testing.T) {
@@ -122,20 +125,64 @@ func TestPool(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 			cancel()
 			err = p.Disconnect(ctx)
-			noerr(t, err)
+			de, ok := err.(DisconnectError)
+			if !ok {
+				t.Fatalf("Should return a DisconnectError when connections are still in use. got %v", err)
+			}
+			if de.InFlight != 1 {
+				t.Errorf("Should report the connections still in use. got %d; want %d", de.InFlight, 1)
+			}
+			if de.Wrapped != context.Canceled {
+				t.Errorf("Should wrap the context error. got %v; want %v", de.Wrapped, context.Canceled)
+			}
 			if d.lenclosed() != 3 {
 				t.Errorf("Should have closed 3 connections, but didn't. got %d; want %d", d.lenclosed(), 3)
 			}
 			close(cleanup)
 			err = conns[2].Close()
 			noerr(t, err)
-			ok := p.(*pool).sem.TryAcquire(int64(p.(*pool).capacity))
+			ok = p.(*pool).sem.TryAcquire(int64(p.(*pool).capacity))
 			if !ok {
 				t.Errorf("clean shutdown should acquire and release semaphore, but semaphore still held")
 			} else {
 				p.(*pool).sem.Release(int64(p.(*pool).capacity))
 			}
 		})
+		t.Run("waits for inflight connections to be returned", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			p, err := NewPool(address.Address(addr.String()), 1, 1, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			c, _, err := p.Get(context.Background())
+			noerr(t, err)
+
+			// simulate a long running operation that finishes while the pool is disconnecting
+			returned := make(chan struct{})
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				_ = c.Close()
+				close(returned)
+			}()
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+			err = p.Disconnect(ctx)
+			noerr(t, err)
+			select {
+			case <-returned:
+			default:
+				t.Errorf("Disconnect should wait for the connection in use to be returned")
+			}
+			if d.lenclosed() != 1 {
+				t.Errorf("Should have closed 1 connection, but didn't. got %d; want %d", d.lenclosed(), 1)
+			}
+			close(cleanup)
+		})
 		t.Run("properly sets the connection state on return", func(t *testing.T) {
 			cleanup := make(chan struct{})
 			addr := bootstrapConnections(t, 3, func(nc net.Conn) {
@@ -625,6 +672,265 @@ func TestPool(t *testing.T) {
 			}
 		})
 	})
+	t.Run("MaxConnecting", func(t *testing.T) {
+		t.Run("limits concurrent connection establishment", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 10, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			var connecting, maxSeen, handshakes int32
+			want := errors.New("handshake error")
+			P, err := NewPool(
+				address.Address(addr.String()), 10, 10,
+				WithMaxConnecting(func(uint64) uint64 { return 2 }),
+				WithHandshaker(func(Handshaker) Handshaker {
+					return HandshakerFunc(func(context.Context, address.Address, wiremessage.ReadWriter) (description.Server, error) {
+						n := atomic.AddInt32(&connecting, 1)
+						defer atomic.AddInt32(&connecting, -1)
+						for {
+							seen := atomic.LoadInt32(&maxSeen)
+							if n <= seen || atomic.CompareAndSwapInt32(&maxSeen, seen, n) {
+								break
+							}
+						}
+						time.Sleep(10 * time.Millisecond)
+						// Fail every other handshake to make sure failures give back their permit.
+						if atomic.AddInt32(&handshakes, 1)%2 == 0 {
+							return description.Server{}, want
+						}
+						return description.Server{}, nil
+					})
+				}),
+			)
+			noerr(t, err)
+			p := P.(*pool)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					c, _, err := p.Get(context.Background())
+					if err != nil {
+						if err != want {
+							t.Errorf("Unexpected error: %v", err)
+						}
+						return
+					}
+					_ = c.Close()
+				}()
+			}
+			wg.Wait()
+
+			if got := atomic.LoadInt32(&maxSeen); got > 2 {
+				t.Errorf("Should not establish more than 2 connections concurrently. got %d", got)
+			}
+			if !p.connecting.TryAcquire(2) {
+				t.Errorf("Failed handshakes should not leak connecting permits")
+			} else {
+				p.connecting.Release(2)
+			}
+		})
+		t.Run("warms up at most size connections on Connect", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 3, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			P, err := NewPool(
+				address.Address(addr.String()), 3, 5,
+				WithDialer(func(Dialer) Dialer { return d }),
+				WithWarmupConnections(func(uint64) uint64 { return 5 }),
+			)
+			noerr(t, err)
+			p := P.(*pool)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+
+			deadline := time.Now().Add(3 * time.Second)
+			for len(p.conns) < 3 && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			if len(p.conns) != 3 {
+				t.Errorf("Should have warmed up 3 idle connections. got %d; want %d", len(p.conns), 3)
+			}
+			if d.lenopened() != 3 {
+				t.Errorf("Should have opened 3 connections. got %d; want %d", d.lenopened(), 3)
+			}
+
+			c, desc, err := p.Get(context.Background())
+			noerr(t, err)
+			if desc != nil {
+				t.Errorf("Should reuse a warmed up connection instead of dialing")
+			}
+			_ = c.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+			err = p.Disconnect(ctx)
+			noerr(t, err)
+			if d.lenclosed() != 3 {
+				t.Errorf("Should have closed 3 connections. got %d; want %d", d.lenclosed(), 3)
+			}
+		})
+	})
+	t.Run("Discard closes the connection instead of returning it", func(t *testing.T) {
+		cleanup := make(chan struct{})
+		defer close(cleanup)
+		addr := bootstrapConnections(t, 2, func(nc net.Conn) {
+			<-cleanup
+			nc.Close()
+		})
+		d := newdialer(&net.Dialer{})
+		p, err := NewPool(address.Address(addr.String()), 1, 1, WithDialer(func(Dialer) Dialer { return d }))
+		noerr(t, err)
+		err = p.Connect(context.Background())
+		noerr(t, err)
+		c, _, err := p.Get(context.Background())
+		noerr(t, err)
+		discarder, ok := c.(Discarder)
+		if !ok {
+			t.Fatalf("Expected pooled connection to implement Discarder")
+		}
+		err = discarder.Discard()
+		noerr(t, err)
+		if d.lenclosed() != 1 {
+			t.Errorf("Should have closed 1 connection, but didn't. got %d; want %d", d.lenclosed(), 1)
+		}
+		if got := p.Stats(); got != (PoolStats{}) {
+			t.Errorf("Discarded connection should not be pooled. got %+v", got)
+		}
+		// the pool slot is released, so another connection can be checked out
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		c, _, err = p.Get(ctx)
+		noerr(t, err)
+		err = c.Close()
+		noerr(t, err)
+		err = p.Disconnect(context.Background())
+		noerr(t, err)
+	})
+	t.Run("logs connection lifecycle", func(t *testing.T) {
+		cleanup := make(chan struct{})
+		defer close(cleanup)
+		addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+			<-cleanup
+			nc.Close()
+		})
+		sink := &recordingSink{}
+		l := logger.New(sink, 0, map[logger.Component]logger.Level{logger.ComponentConnection: logger.LevelDebug})
+		d := newdialer(&net.Dialer{})
+		p, err := NewPool(address.Address(addr.String()), 1, 2,
+			WithDialer(func(Dialer) Dialer { return d }),
+			WithLogger(func(*logger.Logger) *logger.Logger { return l }),
+		)
+		noerr(t, err)
+		err = p.Connect(context.Background())
+		noerr(t, err)
+		c, _, err := p.Get(context.Background())
+		noerr(t, err)
+		err = c.Close()
+		noerr(t, err)
+		err = p.Drain()
+		noerr(t, err)
+		err = p.Disconnect(context.Background())
+		noerr(t, err)
+		_, _, err = p.Get(context.Background())
+		if err != ErrPoolClosed {
+			t.Fatalf("Should get error from disconnected pool. got %v; want %v", err, ErrPoolClosed)
+		}
+
+		want := []string{
+			"Connection pool created",
+			"Connection pool ready",
+			"Connection created",
+			"Connection pool cleared",
+			"Connection closed",
+			"Connection pool closed",
+			"Connection checkout failed",
+		}
+		if len(sink.msgs) != len(want) {
+			t.Fatalf("Should log the pool events. got %v; want %v", sink.msgs, want)
+		}
+		for i := range want {
+			if sink.msgs[i] != want[i] {
+				t.Errorf("Unexpected message %d. got %q; want %q", i, sink.msgs[i], want[i])
+			}
+		}
+	})
+	t.Run("Stats", func(t *testing.T) {
+		t.Run("counts idle and checked out connections", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 2, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			p, err := NewPool(address.Address(addr.String()), 2, 2, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			if got := p.Stats(); got != (PoolStats{}) {
+				t.Errorf("Should not have any connections. got %+v", got)
+			}
+			c1, _, err := p.Get(context.Background())
+			noerr(t, err)
+			c2, _, err := p.Get(context.Background())
+			noerr(t, err)
+			want := PoolStats{Total: 2, CheckedOut: 2}
+			if got := p.Stats(); got != want {
+				t.Errorf("Incorrect stats with every connection checked out. got %+v; want %+v", got, want)
+			}
+			err = c1.Close()
+			noerr(t, err)
+			want = PoolStats{Total: 2, Available: 1, CheckedOut: 1}
+			if got := p.Stats(); got != want {
+				t.Errorf("Incorrect stats after returning a connection. got %+v; want %+v", got, want)
+			}
+			err = c2.Close()
+			noerr(t, err)
+			err = p.Disconnect(context.Background())
+			noerr(t, err)
+			if got := p.Stats(); got != (PoolStats{}) {
+				t.Errorf("Should not have any connections after disconnecting. got %+v", got)
+			}
+		})
+		t.Run("counts pending connections", func(t *testing.T) {
+			dialing := make(chan struct{})
+			unblock := make(chan struct{})
+			d := DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+				close(dialing)
+				<-unblock
+				return nil, errors.New("dial failed")
+			})
+			p, err := NewPool(address.Address("localhost:27017"), 1, 1, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			done := make(chan struct{})
+			go func() {
+				_, _, _ = p.Get(context.Background())
+				close(done)
+			}()
+			<-dialing
+			want := PoolStats{Pending: 1}
+			if got := p.Stats(); got != want {
+				t.Errorf("Incorrect stats while dialing. got %+v; want %+v", got, want)
+			}
+			close(unblock)
+			<-done
+			if got := p.Stats(); got != (PoolStats{}) {
+				t.Errorf("Should not have any connections after the dial failed. got %+v", got)
+			}
+		})
+	})
 	t.Run("Connection", func(t *testing.T) {
 		t.Run("Connection Close Does Not Error After Pool Is Disconnected", func(t *testing.T) {
 			cleanup := make(chan struct{})
@@ -643,7 +949,9 @@ func TestPool(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			cancel()
 			err = p.Disconnect(ctx)
-			noerr(t, err)
+			if _, ok := err.(DisconnectError); !ok {
+				t.Errorf("Should return a DisconnectError when a connection is still in use. got %v", err)
+			}
 			err = c1.Close()
 			if err != nil {
 				t.Errorf("Connection Close should not error after Pool is Disconnected, but got error: %v", err)
@@ -677,3 +985,14 @@ func TestPool(t *testing.T) {
 		})
 	})
 }
+
+type recordingSink struct {
+	sync.Mutex
+	msgs []string
+}
+
+func (s *recordingSink) Log(_ logger.Level, _ logger.Component, msg string, _ ...interface{}) {
+	s.Lock()
+	defer s.Unlock()
+	s.msgs = append(s.msgs, msg)
+}
@@ -40,6 +40,14 @@ func (c *TLSConfig) SetClientCertDecryptPassword(f func() string) {
 	c.clientCertPass = f
 }
 
+// SetGetClientCertificate sets a function that returns the client certificate to present
+// during the TLS handshake. It is called for every new connection, which allows the
+// certificate to be rotated without recreating the configuration. Connections that have
+// already completed their handshake are not affected.
+func (c *TLSConfig) SetGetClientCertificate(f func(*tls.CertificateRequestInfo) (*tls.Certificate, error)) {
+	c.GetClientCertificate = f
+}
+
 // SetInsecure sets whether the client should verify the server's certificate
 // chain and hostnames.
 func (c *TLSConfig) SetInsecure(allow bool) {
@@ -7,17 +7,19 @@
 package connstring
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
 	"net/url"
-	"runtime"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/internal"
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
+	"github.com/mongodb/mongo-go-driver/x/network/dns"
 	"github.com/mongodb/mongo-go-driver/x/network/wiremessage"
 )
 
@@ -31,6 +33,26 @@ func Parse(s string) (ConnString, error) {
 	return p.ConnString, err
 }
 
+// OIDCArgs are the arguments passed to an OIDCCallback.
+//
+// Version is the version of the callback API, currently 1. Username is the principal name the client was
+// configured with, if any. ServerAddress is the address of the server the connection being authenticated is to.
+type OIDCArgs struct {
+	Version       int
+	Username      string
+	ServerAddress string
+}
+
+// OIDCCredential is an access token returned by an OIDCCallback. ExpiresAt is when the token expires, or nil if
+// the identity provider did not say; tokens without an expiration are cached until the server rejects them.
+type OIDCCredential struct {
+	AccessToken string
+	ExpiresAt   *time.Time
+}
+
+// OIDCCallback obtains an access token for the MONGODB-OIDC mechanism from an identity provider.
+type OIDCCallback func(context.Context, *OIDCArgs) (*OIDCCredential, error)
+
 // ConnString represents a connection string to mongodb.
 type ConnString struct {
 	Original                           string
@@ -49,14 +71,22 @@ type ConnString struct {
 	Hosts                              []string
 	J                                  bool
 	JSet                               bool
+	LoadBalanced                       bool
+	LoadBalancedSet                    bool
 	LocalThreshold                     time.Duration
 	LocalThresholdSet                  bool
+	MaxConnecting                      uint64
+	MaxConnectingSet                   bool
 	MaxConnIdleTime                    time.Duration
 	MaxConnIdleTimeSet                 bool
 	MaxPoolSize                        uint16
 	MaxPoolSizeSet                     bool
+	MinPoolSize                        uint64
+	MinPoolSizeSet                     bool
 	Password                           string
 	PasswordSet                        bool
+	PasswordProvider                   func(context.Context) (string, error)
+	OIDCMachineCallback                OIDCCallback
 	ReadConcernLevel                   string
 	ReadPreference                     string
 	ReadPreferenceTagSets              []map[string]string
@@ -69,16 +99,24 @@ type ConnString struct {
 	ServerSelectionTimeoutSet          bool
 	SocketTimeout                      time.Duration
 	SocketTimeoutSet                   bool
+	SrvMaxHosts                        int
+	SrvMaxHostsSet                     bool
+	SrvServiceName                     string
+	SRV                                bool
 	SSL                                bool
 	SSLSet                             bool
 	SSLClientCertificateKeyFile        string
 	SSLClientCertificateKeyFileSet     bool
 	SSLClientCertificateKeyPassword    func() string
 	SSLClientCertificateKeyPasswordSet bool
+	SSLGetClientCertificate            func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
+	SSLGetClientCertificateSet         bool
 	SSLInsecure                        bool
 	SSLInsecureSet                     bool
 	SSLCaFile                          string
 	SSLCaFileSet                       bool
+	Timeout                            time.Duration
+	TimeoutSet                         bool
 	WString                            string
 	WNumber                            int
 	WNumberSet                         bool
@@ -177,46 +215,40 @@ func (p *parser) parse(original string) error {
 		hosts = uri[:idx]
 	}
 
+	extractedDatabase, err := extractDatabaseFromURI(uri[len(hosts):])
+	if err != nil {
+		return err
+	}
+
+	p.Database = extractedDatabase.db
+
+	connectionArgsFromQueryString, err := extractQueryArgsFromURI(extractedDatabase.uri)
+
 	var connectionArgsFromTXT []string
 	parsedHosts := strings.Split(hosts, ",")
 
 	if isSRV {
-		parsedHosts = strings.Split(hosts, ",")
-		if len(parsedHosts) != 1 {
-			return fmt.Errorf("URI with SRV must include one and only one hostname")
+		r := *dns.DefaultResolver
+		resolver := &r
+		if name, ok := srvOptionFromArgs(connectionArgsFromQueryString, "srvservicename"); ok {
+			resolver.SrvServiceName = name
+		}
+		if value, ok := srvOptionFromArgs(connectionArgsFromQueryString, "srvmaxhosts"); ok {
+			// invalid values are reported when the option is added below.
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				resolver.SrvMaxHosts = n
+			}
 		}
-		parsedHosts, err = fetchSeedlistFromSRV(parsedHosts[0])
+
+		parsedHosts, connectionArgsFromTXT, err = resolver.ResolveSRVAndTXT(context.Background(), hosts)
 		if err != nil {
 			return err
 		}
 
-		// error ignored because finding a TXT record should not be
-		// considered an error.
-		recordsFromTXT, _ := net.LookupTXT(hosts)
-
-		// This is a temporary fix to get around bug https://github.com/golang/go/issues/21472.
-		// It will currently incorrectly concatenate multiple TXT records to one
-		// on windows.
-		if runtime.GOOS == "windows" {
-			recordsFromTXT = []string{strings.Join(recordsFromTXT, "")}
-		}
-
-		if len(recordsFromTXT) > 1 {
-			return errors.New("multiple records from TXT not supported")
-		}
-		if len(recordsFromTXT) > 0 {
-			connectionArgsFromTXT = strings.FieldsFunc(recordsFromTXT[0], func(r rune) bool { return r == ';' || r == '&' })
-
-			err := validateTXTResult(connectionArgsFromTXT)
-			if err != nil {
-				return err
-			}
-
-		}
-
 		// SSL is enabled by default for SRV, but can be manually disabled with "ssl=false".
 		p.SSL = true
 		p.SSLSet = true
+		p.SRV = true
 	}
 
 	for _, host := range parsedHosts {
@@ -229,17 +261,6 @@ func (p *parser) parse(original string) error {
 		return fmt.Errorf("must have at least 1 host")
 	}
 
-	uri = uri[len(hosts):]
-
-	extractedDatabase, err := extractDatabaseFromURI(uri)
-	if err != nil {
-		return err
-	}
-
-	uri = extractedDatabase.uri
-	p.Database = extractedDatabase.db
-
-	connectionArgsFromQueryString, err := extractQueryArgsFromURI(uri)
 	connectionArgPairs := append(connectionArgsFromTXT, connectionArgsFromQueryString...)
 
 	for _, pair := range connectionArgPairs {
@@ -269,6 +290,22 @@ func (p *parser) parse(original string) error {
 		p.WTimeoutSet = true
 	}
 
+	if p.SrvServiceName != "" && !isSRV {
+		return fmt.Errorf("srvServiceName can only be specified with mongodb+srv")
+	}
+
+	if p.SrvMaxHostsSet && !isSRV {
+		return fmt.Errorf("srvMaxHosts can only be specified with mongodb+srv")
+	}
+
+	if p.SrvMaxHosts > 0 && p.ReplicaSet != "" {
+		return fmt.Errorf("srvMaxHosts cannot be specified with replicaSet")
+	}
+
+	if p.SrvMaxHosts > 0 && p.LoadBalanced {
+		return fmt.Errorf("srvMaxHosts cannot be specified with loadBalanced=true")
+	}
+
 	return nil
 }
 
@@ -290,7 +327,7 @@ func (p *parser) setDefaultAuthParams(dbName string) error {
 			p.AuthMechanismProperties["SERVICE_NAME"] = "mongodb"
 		}
 		fallthrough
-	case "mongodb-x509":
+	case "mongodb-x509", "mongodb-aws", "mongodb-oidc":
 		if p.AuthSource == "" {
 			p.AuthSource = "$external"
 		} else if p.AuthSource != "$external" {
@@ -339,6 +376,25 @@ func (p *parser) validateAuth() error {
 		if p.AuthMechanismProperties != nil {
 			return fmt.Errorf("MONGO-X509 cannot have mechanism properties")
 		}
+	case "mongodb-aws":
+		if p.Username != "" && p.Password == "" {
+			return fmt.Errorf("password required for MONGODB-AWS when a username is specified")
+		}
+		if p.Username == "" && p.Password != "" {
+			return fmt.Errorf("username required for MONGODB-AWS when a password is specified")
+		}
+		for k := range p.AuthMechanismProperties {
+			if k != "AWS_SESSION_TOKEN" {
+				return fmt.Errorf("invalid auth property for MONGODB-AWS")
+			}
+		}
+	case "mongodb-oidc":
+		if p.Password != "" {
+			return fmt.Errorf("password cannot be specified for MONGODB-OIDC")
+		}
+		if p.AuthMechanismProperties != nil {
+			return fmt.Errorf("MONGODB-OIDC cannot have mechanism properties")
+		}
 	case "gssapi":
 		if p.Username == "" {
 			return fmt.Errorf("username required for GSSAPI")
@@ -385,34 +441,6 @@ func (p *parser) validateAuth() error {
 	return nil
 }
 
-func fetchSeedlistFromSRV(host string) ([]string, error) {
-	var err error
-
-	_, _, err = net.SplitHostPort(host)
-
-	if err == nil {
-		// we were able to successfully extract a port from the host,
-		// but should not be able to when using SRV
-		return nil, fmt.Errorf("URI with srv must not include a port number")
-	}
-
-	_, addresses, err := net.LookupSRV("mongodb", "tcp", host)
-	if err != nil {
-		return nil, err
-	}
-	parsedHosts := make([]string, len(addresses))
-	for i, address := range addresses {
-		trimmedAddressTarget := strings.TrimSuffix(address.Target, ".")
-		err := validateSRVResult(trimmedAddressTarget, host)
-		if err != nil {
-			return nil, err
-		}
-		parsedHosts[i] = fmt.Sprintf("%s:%d", trimmedAddressTarget, address.Port)
-	}
-
-	return parsedHosts, nil
-}
-
 func (p *parser) addHost(host string) error {
 	if host == "" {
 		return nil
@@ -519,6 +547,17 @@ func (p *parser) addOption(pair string) error {
 		}
 
 		p.JSet = true
+	case "loadbalanced":
+		switch value {
+		case "true":
+			p.LoadBalanced = true
+		case "false":
+			p.LoadBalanced = false
+		default:
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+
+		p.LoadBalancedSet = true
 	case "localthresholdms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
@@ -526,6 +565,13 @@ func (p *parser) addOption(pair string) error {
 		}
 		p.LocalThreshold = time.Duration(n) * time.Millisecond
 		p.LocalThresholdSet = true
+	case "maxconnecting":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		p.MaxConnecting = uint64(n)
+		p.MaxConnectingSet = true
 	case "maxidletimems":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
@@ -540,12 +586,24 @@ func (p *parser) addOption(pair string) error {
 		}
 		p.MaxPoolSize = uint16(n)
 		p.MaxPoolSizeSet = true
+	case "minpoolsize":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		p.MinPoolSize = uint64(n)
+		p.MinPoolSizeSet = true
 	case "readconcernlevel":
 		p.ReadConcernLevel = value
 	case "readpreference":
 		p.ReadPreference = value
 	case "readpreferencetags":
 		tags := make(map[string]string)
+		if value == "" {
+			// an empty tag set matches any server and is used as the last fallback
+			p.ReadPreferenceTagSets = append(p.ReadPreferenceTagSets, tags)
+			break
+		}
 		items := strings.Split(value, ",")
 		for _, item := range items {
 			parts := strings.Split(item, ":")
@@ -573,12 +631,31 @@ func (p *parser) addOption(pair string) error {
 			return fmt.Errorf("invalid value for %s: %s", key, value)
 		}
 		p.ServerSelectionTimeout = time.Duration(n) * time.Millisecond
+	case "srvmaxhosts":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		p.SrvMaxHosts = n
+		p.SrvMaxHostsSet = true
+	case "srvservicename":
+		if err := dns.ValidateSrvServiceName(value); err != nil {
+			return err
+		}
+		p.SrvServiceName = value
 	case "sockettimeoutms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
 			return fmt.Errorf("invalid value for %s: %s", key, value)
 		}
 		p.SocketTimeout = time.Duration(n) * time.Millisecond
+	case "timeoutms":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		p.Timeout = time.Duration(n) * time.Millisecond
+		p.TimeoutSet = true
 	case "ssl":
 		switch value {
 		case "true":
@@ -671,45 +748,26 @@ func (p *parser) addOption(pair string) error {
 	return nil
 }
 
-func validateSRVResult(recordFromSRV, inputHostName string) error {
-	separatedInputDomain := strings.Split(inputHostName, ".")
-	separatedRecord := strings.Split(recordFromSRV, ".")
-	if len(separatedRecord) < 2 {
-		return errors.New("DNS name must contain at least 2 labels")
-	}
-	if len(separatedRecord) < len(separatedInputDomain) {
-		return errors.New("Domain suffix from SRV record not matched input domain")
-	}
-
-	inputDomainSuffix := separatedInputDomain[1:]
-	domainSuffixOffset := len(separatedRecord) - (len(separatedInputDomain) - 1)
-
-	recordDomainSuffix := separatedRecord[domainSuffixOffset:]
-	for ix, label := range inputDomainSuffix {
-		if label != recordDomainSuffix[ix] {
-			return errors.New("Domain suffix from SRV record not matched input domain")
-		}
-	}
-	return nil
-}
-
-var allowedTXTOptions = map[string]struct{}{
-	"authsource": {},
-	"replicaset": {},
-}
-
-func validateTXTResult(paramsFromTXT []string) error {
-	for _, param := range paramsFromTXT {
-		kv := strings.SplitN(param, "=", 2)
+// srvOptionFromArgs returns the value of the given option if it is present in the given query
+// string arguments. Options that affect the SRV lookup have to be known before the lookup, so they
+// are extracted ahead of the rest of the options.
+func srvOptionFromArgs(args []string, option string) (string, bool) {
+	for _, pair := range args {
+		kv := strings.SplitN(pair, "=", 2)
 		if len(kv) != 2 {
-			return errors.New("Invalid TXT record")
+			continue
 		}
-		key := strings.ToLower(kv[0])
-		if _, ok := allowedTXTOptions[key]; !ok {
-			return fmt.Errorf("Cannot specify option '%s' in TXT record", kv[0])
+		key, err := url.QueryUnescape(kv[0])
+		if err != nil || strings.ToLower(key) != option {
+			continue
 		}
+		value, err := url.QueryUnescape(kv[1])
+		if err != nil {
+			continue
+		}
+		return value, true
 	}
-	return nil
+	return "", false
 }
 
 func extractQueryArgsFromURI(uri string) ([]string, error) {
@@ -7,12 +7,16 @@
 package connstring_test
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"strings"
 	"testing"
 
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/x/network/connstring"
+	"github.com/mongodb/mongo-go-driver/x/network/dns"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,6 +55,11 @@ func TestAuthMechanism(t *testing.T) {
 		{s: "authMechanism=scram-sha-256", expected: "scram-sha-256"},
 		{s: "authMechanism=mongodb-CR", expected: "mongodb-CR"},
 		{s: "authMechanism=plain", expected: "plain"},
+		{s: "authMechanism=MONGODB-AWS", expected: "MONGODB-AWS"},
+		{s: "authMechanism=MONGODB-AWS&authMechanismProperties=AWS_SESSION_TOKEN:token", expected: "MONGODB-AWS"},
+		{s: "authMechanism=MONGODB-AWS&authMechanismProperties=SERVICE_NAME:mongodb", err: true},
+		{s: "authMechanism=MONGODB-AWS&authSource=admin", err: true},
+		{s: "authMechanism=MONGODB-OIDC", err: true},
 	}
 
 	for _, test := range tests {
@@ -67,6 +76,19 @@ func TestAuthMechanism(t *testing.T) {
 	}
 }
 
+func TestAuthMechanism_OIDC(t *testing.T) {
+	cs, err := connstring.Parse("mongodb://principal@localhost/?authMechanism=MONGODB-OIDC")
+	require.NoError(t, err)
+	require.Equal(t, "MONGODB-OIDC", cs.AuthMechanism)
+	require.Equal(t, "$external", cs.AuthSource)
+
+	_, err = connstring.Parse("mongodb://localhost/?authMechanism=MONGODB-OIDC&authSource=admin")
+	require.Error(t, err)
+
+	_, err = connstring.Parse("mongodb://localhost/?authMechanism=MONGODB-OIDC&authMechanismProperties=SERVICE_NAME:mongodb")
+	require.Error(t, err)
+}
+
 func TestAuthSource(t *testing.T) {
 	tests := []struct {
 		s        string
@@ -250,6 +272,37 @@ func TestMaxPoolSize(t *testing.T) {
 	}
 }
 
+func TestMaxConnectingAndMinPoolSize(t *testing.T) {
+	tests := []struct {
+		s             string
+		maxConnecting uint64
+		minPoolSize   uint64
+		err           bool
+	}{
+		{s: "maxConnecting=5", maxConnecting: 5},
+		{s: "minPoolSize=10", minPoolSize: 10},
+		{s: "maxConnecting=0&minPoolSize=0"},
+		{s: "maxConnecting=-1", err: true},
+		{s: "minPoolSize=gsdge", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.Parse(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, strings.Contains(test.s, "maxConnecting"), cs.MaxConnectingSet)
+				require.Equal(t, test.maxConnecting, cs.MaxConnecting)
+				require.Equal(t, strings.Contains(test.s, "minPoolSize"), cs.MinPoolSizeSet)
+				require.Equal(t, test.minPoolSize, cs.MinPoolSize)
+			}
+		})
+	}
+}
+
 func TestReadPreference(t *testing.T) {
 	tests := []struct {
 		s        string
@@ -284,6 +337,7 @@ func TestReadPreferenceTags(t *testing.T) {
 		{s: "readPreferenceTags=one:1", expected: []map[string]string{{"one": "1"}}},
 		{s: "readPreferenceTags=one:1,two:2", expected: []map[string]string{{"one": "1", "two": "2"}}},
 		{s: "readPreferenceTags=one:1&readPreferenceTags=two:2", expected: []map[string]string{{"one": "1"}, {"two": "2"}}},
+		{s: "readPreferenceTags=one:1&readPreferenceTags=", expected: []map[string]string{{"one": "1"}, {}}},
 		{s: "readPreferenceTags=one:1:3,two:2", err: true},
 	}
 
@@ -427,6 +481,83 @@ func TestSocketTimeout(t *testing.T) {
 	}
 }
 
+type srvStubResolver struct {
+	services []string
+}
+
+func (s *srvStubResolver) LookupSRV(_ context.Context, service, _, _ string) (string, []*net.SRV, error) {
+	s.services = append(s.services, service)
+	return "", []*net.SRV{{Target: "localhost.test.build.10gen.cc.", Port: 27017}}, nil
+}
+
+func (s *srvStubResolver) LookupTXT(context.Context, string) ([]string, error) {
+	return nil, nil
+}
+
+func TestSrvServiceName(t *testing.T) {
+	stub := &srvStubResolver{}
+	dns.DefaultResolver.Resolver = stub
+	defer func() { dns.DefaultResolver.Resolver = nil }()
+
+	tests := []struct {
+		s        string
+		expected string
+		err      bool
+	}{
+		{s: "mongodb+srv://test1.test.build.10gen.cc/", expected: "mongodb"},
+		{s: "mongodb+srv://test1.test.build.10gen.cc/?srvServiceName=customname", expected: "customname"},
+		{s: "mongodb+srv://test1.test.build.10gen.cc/?srvServiceName=", err: true},
+		{s: "mongodb+srv://test1.test.build.10gen.cc/?srvServiceName=sixteencharacter", err: true},
+		{s: "mongodb://localhost/?srvServiceName=customname", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			stub.services = nil
+			cs, err := connstring.Parse(test.s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, []string{test.expected}, stub.services)
+				require.Equal(t, []string{"localhost.test.build.10gen.cc:27017"}, cs.Hosts)
+			}
+		})
+	}
+}
+
+func TestSrvMaxHosts(t *testing.T) {
+	dns.DefaultResolver.Resolver = &srvStubResolver{}
+	defer func() { dns.DefaultResolver.Resolver = nil }()
+
+	tests := []struct {
+		s        string
+		expected int
+		err      bool
+	}{
+		{s: "mongodb+srv://test1.test.build.10gen.cc/?srvMaxHosts=0", expected: 0},
+		{s: "mongodb+srv://test1.test.build.10gen.cc/?srvMaxHosts=1", expected: 1},
+		{s: "mongodb+srv://test1.test.build.10gen.cc/?srvMaxHosts=-1", err: true},
+		{s: "mongodb+srv://test1.test.build.10gen.cc/?srvMaxHosts=1&replicaSet=repl0", err: true},
+		{s: "mongodb+srv://test1.test.build.10gen.cc/?srvMaxHosts=0&replicaSet=repl0", expected: 0},
+		{s: "mongodb+srv://test1.test.build.10gen.cc/?srvMaxHosts=1&loadBalanced=true", err: true},
+		{s: "mongodb+srv://test1.test.build.10gen.cc/?srvMaxHosts=1&loadBalanced=false", expected: 1},
+		{s: "mongodb://localhost/?srvMaxHosts=1", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			cs, err := connstring.Parse(test.s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, cs.SrvMaxHosts)
+			}
+		})
+	}
+}
+
 func TestWTimeout(t *testing.T) {
 	tests := []struct {
 		s        string
@@ -482,3 +613,30 @@ func TestCompressionOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeout(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected time.Duration
+		err      bool
+	}{
+		{s: "timeoutMS=0", expected: 0},
+		{s: "timeoutMS=100", expected: time.Duration(100) * time.Millisecond},
+		{s: "timeoutMS=-2", err: true},
+		{s: "timeoutMS=gsdge", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.Parse(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, cs.Timeout)
+				require.True(t, cs.TimeoutSet)
+			}
+		})
+	}
+}
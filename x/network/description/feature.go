@@ -30,6 +30,16 @@ func ScramSHA1Supported(wireVersion *VersionRange) error {
 	return nil
 }
 
+// HedgedReadsSupported returns an error if the given server version
+// does not support hedged reads.
+func HedgedReadsSupported(wireVersion *VersionRange) error {
+	if wireVersion == nil || wireVersion.Max < 9 {
+		return fmt.Errorf("hedged reads are only supported for servers 4.4 or newer")
+	}
+
+	return nil
+}
+
 // SessionsSupported returns true of the given server version indicates that it supports sessions.
 func SessionsSupported(wireVersion *VersionRange) bool {
 	return wireVersion != nil && wireVersion.Max >= 6
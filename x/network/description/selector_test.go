@@ -520,6 +520,25 @@ func TestSelector_Secondary_with_tags_that_do_not_match(t *testing.T) {
 	require.Len(result, 0)
 }
 
+func TestSelector_Secondary_with_tag_sets_in_order(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	subject := readpref.Secondary(
+		readpref.WithTagSets(
+			tag.Set{{Name: "a", Value: "3"}},
+			tag.Set{{Name: "a", Value: "1"}},
+			tag.Set{{Name: "a", Value: "2"}},
+		),
+	)
+
+	result, err := ReadPrefSelector(subject).SelectServer(readPrefTestTopology, readPrefTestTopology.Servers)
+
+	require.NoError(err)
+	require.Len(result, 1)
+	require.Equal([]Server{readPrefTestSecondary1}, result)
+}
+
 func TestSelector_Secondary_with_no_secondaries(t *testing.T) {
 	t.Parallel()
 
@@ -604,6 +623,46 @@ func TestSelector_Nearest_with_tags_that_do_not_match(t *testing.T) {
 	require.Len(result, 0)
 }
 
+func TestSelector_Nearest_with_tag_sets_in_order(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	subject := readpref.Nearest(
+		readpref.WithTagSets(
+			tag.Set{{Name: "a", Value: "3"}},
+			tag.Set{{Name: "a", Value: "2"}},
+			tag.Set{{Name: "a", Value: "1"}},
+		),
+	)
+
+	result, err := ReadPrefSelector(subject).SelectServer(readPrefTestTopology, readPrefTestTopology.Servers)
+
+	require.NoError(err)
+	require.Len(result, 1)
+	require.Equal([]Server{readPrefTestSecondary2}, result)
+}
+
+func TestSelector_Nearest_with_empty_tag_set_fallback(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	subject := readpref.Nearest(
+		readpref.WithTagSets(
+			tag.Set{{Name: "a", Value: "3"}},
+			tag.Set{},
+		),
+	)
+	untagged := readPrefTestSecondary2
+	untagged.Tags = nil
+	servers := []Server{readPrefTestPrimary, readPrefTestSecondary1, untagged}
+
+	result, err := ReadPrefSelector(subject).SelectServer(readPrefTestTopology, servers)
+
+	require.NoError(err)
+	require.Len(result, 3)
+	require.Equal(servers, result)
+}
+
 func TestSelector_Nearest_with_no_primary(t *testing.T) {
 	t.Parallel()
 
@@ -711,3 +770,80 @@ func TestSelector_Max_staleness_is_too_low(t *testing.T) {
 
 	require.Error(err)
 }
+
+func TestSelector_Max_staleness_uses_largest_heartbeat_interval(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	subject := readpref.Nearest(
+		readpref.WithMaxStaleness(time.Duration(100) * time.Second),
+	)
+
+	// the first server has not completed a heartbeat, so it does not report the heartbeat interval
+	unknown := Server{Addr: address.Address("localhost:27017")}
+	s := Server{
+		Addr:              address.Address("localhost:27018"),
+		HeartbeatInterval: time.Duration(95) * time.Second,
+		LastWriteTime:     time.Date(2017, 2, 11, 14, 0, 0, 0, time.UTC),
+		LastUpdateTime:    time.Date(2017, 2, 11, 14, 0, 2, 0, time.UTC),
+		Kind:              RSSecondary,
+		WireVersion:       &VersionRange{Min: 0, Max: 5},
+	}
+	c := Topology{
+		Kind:    ReplicaSetNoPrimary,
+		Servers: []Server{unknown, s},
+	}
+
+	_, err := ReadPrefSelector(subject).SelectServer(c, c.Servers)
+
+	require.Error(err)
+	require.Contains(err.Error(), "heartbeatFrequencyMS")
+}
+
+func TestSelector_ExplainExclusions(t *testing.T) {
+	t.Parallel()
+
+	stale := readPrefTestSecondary1
+	other := readPrefTestSecondary2
+	other.Addr = address.Address("localhost:27019")
+	topo := Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{readPrefTestPrimary, stale, other}}
+
+	t.Run("stale and tag mismatch", func(t *testing.T) {
+		subject := readpref.Secondary(
+			readpref.WithMaxStaleness(time.Duration(90)*time.Second),
+			readpref.WithTags("a", "1"),
+		)
+		selector := CompositeSelector([]ServerSelector{ReadPrefSelector(subject), LatencySelector(15 * time.Millisecond)})
+
+		result, err := selector.SelectServer(topo, topo.Servers)
+		require.NoError(t, err)
+		require.Empty(t, result)
+
+		exclusions := selector.(SelectionExplainer).ExplainExclusions(topo, topo.Servers)
+		require.Equal(t, []ServerExclusion{
+			{Addr: readPrefTestPrimary.Addr, Kind: RSPrimary, Reason: ExcludedKind},
+			{Addr: stale.Addr, Kind: RSSecondary, Reason: ExcludedStale, Staleness: 130 * time.Second, MaxStaleness: 90 * time.Second},
+			{Addr: other.Addr, Kind: RSSecondary, Reason: ExcludedTags},
+		}, exclusions)
+		require.Contains(t, exclusions[1].String(), "estimated staleness 2m10s exceeds max staleness 1m30s")
+	})
+
+	t.Run("stale without a primary", func(t *testing.T) {
+		subject := readpref.SecondaryPreferred(readpref.WithMaxStaleness(time.Duration(90) * time.Second))
+		noPrimary := Topology{Kind: ReplicaSetNoPrimary, Servers: []Server{stale, other}}
+
+		exclusions := ReadPrefSelector(subject).(SelectionExplainer).ExplainExclusions(noPrimary, noPrimary.Servers)
+		require.Len(t, exclusions, 1)
+		require.Equal(t, ExcludedStale, exclusions[0].Reason)
+		require.Equal(t, 130*time.Second, exclusions[0].Staleness)
+	})
+
+	t.Run("write", func(t *testing.T) {
+		exclusions := WriteSelector().(SelectionExplainer).ExplainExclusions(topo, topo.Servers)
+		require.Equal(t, []ServerExclusion{
+			{Addr: stale.Addr, Kind: RSSecondary, Reason: ExcludedKind},
+			{Addr: other.Addr, Kind: RSSecondary, Reason: ExcludedKind},
+		}, exclusions)
+		require.Equal(t, "localhost:27018: wrong server type (RSSecondary)", exclusions[0].String())
+	})
+}
@@ -142,3 +142,46 @@ func (s Server) DataBearing() bool {
 		s.Kind == Mongos ||
 		s.Kind == Standalone
 }
+
+// Equal reports whether two server descriptions describe the same server state. Fields that change on every
+// heartbeat, such as the round trip time and the last update and write times, are not compared.
+func (s Server) Equal(other Server) bool {
+	if s.Addr.String() != other.Addr.String() ||
+		s.CanonicalAddr.String() != other.CanonicalAddr.String() ||
+		s.Kind != other.Kind ||
+		s.SetName != other.SetName ||
+		s.SetVersion != other.SetVersion ||
+		s.ElectionID != other.ElectionID ||
+		s.SessionTimeoutMinutes != other.SessionTimeoutMinutes {
+		return false
+	}
+
+	if (s.WireVersion == nil) != (other.WireVersion == nil) {
+		return false
+	}
+	if s.WireVersion != nil && *s.WireVersion != *other.WireVersion {
+		return false
+	}
+
+	if (s.LastError == nil) != (other.LastError == nil) {
+		return false
+	}
+	if s.LastError != nil && s.LastError.Error() != other.LastError.Error() {
+		return false
+	}
+
+	if len(s.Members) != len(other.Members) || len(s.Tags) != len(other.Tags) {
+		return false
+	}
+	members := make(map[string]struct{}, len(s.Members))
+	for _, member := range s.Members {
+		members[member.String()] = struct{}{}
+	}
+	for _, member := range other.Members {
+		if _, ok := members[member.String()]; !ok {
+			return false
+		}
+	}
+
+	return s.Tags.ContainsAll(other.Tags)
+}
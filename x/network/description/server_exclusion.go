@@ -0,0 +1,61 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package description
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/x/network/address"
+)
+
+// ExclusionReason is the reason server selection excluded a server.
+type ExclusionReason string
+
+// These constants are the possible reasons a server is excluded.
+const (
+	// ExcludedUnknown is used for servers whose type is not known, usually because they could not be reached.
+	ExcludedUnknown ExclusionReason = "unknown server"
+	// ExcludedKind is used for servers of a type the operation cannot use, such as a secondary for a write.
+	ExcludedKind ExclusionReason = "wrong server type"
+	// ExcludedStale is used for secondaries whose estimated staleness exceeds the read preference's max staleness.
+	ExcludedStale ExclusionReason = "stale"
+	// ExcludedTags is used for servers that match none of the read preference's tag sets.
+	ExcludedTags ExclusionReason = "tag mismatch"
+)
+
+// ServerExclusion describes a server that server selection excluded and why. Staleness and MaxStaleness are only
+// set for ExcludedStale, and LastError only for ExcludedUnknown.
+type ServerExclusion struct {
+	Addr         address.Address
+	Kind         ServerKind
+	Reason       ExclusionReason
+	Staleness    time.Duration
+	MaxStaleness time.Duration
+	LastError    error
+}
+
+// String implements the fmt.Stringer interface.
+func (se ServerExclusion) String() string {
+	switch se.Reason {
+	case ExcludedKind:
+		return fmt.Sprintf("%s: %s (%s)", se.Addr, se.Reason, se.Kind)
+	case ExcludedStale:
+		return fmt.Sprintf("%s: %s (estimated staleness %s exceeds max staleness %s)", se.Addr, se.Reason, se.Staleness, se.MaxStaleness)
+	case ExcludedUnknown:
+		if se.LastError != nil {
+			return fmt.Sprintf("%s: %s (%v)", se.Addr, se.Reason, se.LastError)
+		}
+	}
+	return fmt.Sprintf("%s: %s", se.Addr, se.Reason)
+}
+
+// SelectionExplainer is implemented by ServerSelectors that can explain why they exclude servers. The explanation
+// is used to describe server selection failures and does not affect which servers are selected.
+type SelectionExplainer interface {
+	ExplainExclusions(Topology, []Server) []ServerExclusion
+}
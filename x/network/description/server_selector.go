@@ -49,6 +49,26 @@ func (cs *compositeSelector) SelectServer(t Topology, candidates []Server) ([]Se
 	return candidates, nil
 }
 
+// ExplainExclusions implements the SelectionExplainer interface. A server excluded by several of the selectors is
+// reported once, with the reason given by the first.
+func (cs *compositeSelector) ExplainExclusions(t Topology, candidates []Server) []ServerExclusion {
+	var exclusions []ServerExclusion
+	excluded := make(map[string]bool)
+	for _, sel := range cs.selectors {
+		explainer, ok := sel.(SelectionExplainer)
+		if !ok {
+			continue
+		}
+		for _, e := range explainer.ExplainExclusions(t, candidates) {
+			if !excluded[e.Addr.String()] {
+				excluded[e.Addr.String()] = true
+				exclusions = append(exclusions, e)
+			}
+		}
+	}
+	return exclusions
+}
+
 type latencySelector struct {
 	latency time.Duration
 }
@@ -95,49 +115,140 @@ func (ls *latencySelector) SelectServer(t Topology, candidates []Server) ([]Serv
 	}
 }
 
+type writeSelector struct{}
+
 // WriteSelector selects all the writable servers.
 func WriteSelector() ServerSelector {
-	return ServerSelectorFunc(func(t Topology, candidates []Server) ([]Server, error) {
-		switch t.Kind {
-		case Single:
-			return candidates, nil
-		default:
-			result := []Server{}
-			for _, candidate := range candidates {
-				switch candidate.Kind {
-				case Mongos, RSPrimary, Standalone:
-					result = append(result, candidate)
-				}
+	return writeSelector{}
+}
+
+func (writeSelector) SelectServer(t Topology, candidates []Server) ([]Server, error) {
+	switch t.Kind {
+	case Single:
+		return candidates, nil
+	default:
+		result := []Server{}
+		for _, candidate := range candidates {
+			if writable(candidate) {
+				result = append(result, candidate)
 			}
-			return result, nil
 		}
-	})
+		return result, nil
+	}
+}
+
+// ExplainExclusions implements the SelectionExplainer interface.
+func (writeSelector) ExplainExclusions(t Topology, candidates []Server) []ServerExclusion {
+	if t.Kind == Single {
+		return nil
+	}
+	var exclusions []ServerExclusion
+	for _, candidate := range candidates {
+		if !writable(candidate) {
+			exclusions = append(exclusions, ServerExclusion{Addr: candidate.Addr, Kind: candidate.Kind, Reason: ExcludedKind})
+		}
+	}
+	return exclusions
+}
+
+func writable(s Server) bool {
+	switch s.Kind {
+	case Mongos, RSPrimary, Standalone:
+		return true
+	}
+	return false
+}
+
+const (
+	// smallestMaxStaleness is the smallest max staleness allowed for a replica set.
+	smallestMaxStaleness = 90 * time.Second
+
+	// idleWritePeriod is how often a replica set primary writes a no-op to the oplog when there are no other
+	// writes, which bounds how precisely the staleness of a secondary can be estimated.
+	idleWritePeriod = 10 * time.Second
+)
+
+type readPrefSelector struct {
+	rp *readpref.ReadPref
 }
 
 // ReadPrefSelector selects servers based on the provided read preference.
 func ReadPrefSelector(rp *readpref.ReadPref) ServerSelector {
-	return ServerSelectorFunc(func(t Topology, candidates []Server) ([]Server, error) {
-		if _, set := rp.MaxStaleness(); set {
-			for _, s := range candidates {
-				if s.Kind != Unknown {
-					if err := MaxStalenessSupported(s.WireVersion); err != nil {
-						return nil, err
-					}
+	return &readPrefSelector{rp: rp}
+}
+
+func (rps *readPrefSelector) SelectServer(t Topology, candidates []Server) ([]Server, error) {
+	if _, set := rps.rp.MaxStaleness(); set {
+		for _, s := range candidates {
+			if s.Kind != Unknown {
+				if err := MaxStalenessSupported(s.WireVersion); err != nil {
+					return nil, err
 				}
 			}
 		}
+	}
 
-		switch t.Kind {
-		case Single:
-			return candidates, nil
-		case ReplicaSetNoPrimary, ReplicaSetWithPrimary:
-			return selectForReplicaSet(rp, t, candidates)
-		case Sharded:
-			return selectByKind(candidates, Mongos), nil
+	switch t.Kind {
+	case Single:
+		return candidates, nil
+	case ReplicaSetNoPrimary, ReplicaSetWithPrimary:
+		return selectForReplicaSet(rps.rp, t, candidates)
+	case Sharded:
+		return selectByKind(candidates, Mongos), nil
+	}
+
+	return nil, nil
+}
+
+// ExplainExclusions implements the SelectionExplainer interface. For a replica set, each candidate is checked
+// against the server types the mode allows, the max staleness of secondaries, and the tag sets, which apply to
+// secondaries and, in nearest mode, the primary.
+func (rps *readPrefSelector) ExplainExclusions(t Topology, candidates []Server) []ServerExclusion {
+	var exclusions []ServerExclusion
+	exclude := func(s Server, reason ExclusionReason) {
+		exclusions = append(exclusions, ServerExclusion{Addr: s.Addr, Kind: s.Kind, Reason: reason})
+	}
+
+	switch t.Kind {
+	case Sharded:
+		for _, s := range candidates {
+			if s.Kind != Mongos {
+				exclude(s, ExcludedKind)
+			}
 		}
+		return exclusions
+	case ReplicaSetNoPrimary, ReplicaSetWithPrimary:
+	default:
+		return nil
+	}
 
-		return nil, nil
-	})
+	mode := rps.rp.Mode()
+	tagSets := rps.rp.TagSets()
+	maxStaleness, staleSet := rps.rp.MaxStaleness()
+	staleness := stalenessEstimator(candidates)
+	for _, s := range candidates {
+		switch {
+		case s.Kind == RSPrimary && mode != readpref.SecondaryMode:
+			if mode == readpref.NearestMode && !matchesAnyTagSet(s, tagSets) {
+				exclude(s, ExcludedTags)
+			}
+		case s.Kind == RSSecondary && mode != readpref.PrimaryMode:
+			if estimated := staleness(s); staleSet && estimated > maxStaleness {
+				exclusions = append(exclusions, ServerExclusion{
+					Addr:         s.Addr,
+					Kind:         s.Kind,
+					Reason:       ExcludedStale,
+					Staleness:    estimated,
+					MaxStaleness: maxStaleness,
+				})
+			} else if !matchesAnyTagSet(s, tagSets) {
+				exclude(s, ExcludedTags)
+			}
+		default:
+			exclude(s, ExcludedKind)
+		}
+	}
+	return exclusions
 }
 
 func selectForReplicaSet(rp *readpref.ReadPref, t Topology, candidates []Server) ([]Server, error) {
@@ -178,45 +289,44 @@ func selectForReplicaSet(rp *readpref.ReadPref, t Topology, candidates []Server)
 
 func selectSecondaries(rp *readpref.ReadPref, candidates []Server) []Server {
 	secondaries := selectByKind(candidates, RSSecondary)
-	if len(secondaries) == 0 {
+	maxStaleness, set := rp.MaxStaleness()
+	if len(secondaries) == 0 || !set {
 		return secondaries
 	}
-	if maxStaleness, set := rp.MaxStaleness(); set {
-		primaries := selectByKind(candidates, RSPrimary)
-		if len(primaries) == 0 {
-			baseTime := secondaries[0].LastWriteTime
-			for i := 1; i < len(secondaries); i++ {
-				if secondaries[i].LastWriteTime.After(baseTime) {
-					baseTime = secondaries[i].LastWriteTime
-				}
-			}
 
-			var selected []Server
-			for _, secondary := range secondaries {
-				estimatedStaleness := baseTime.Sub(secondary.LastWriteTime) + secondary.HeartbeatInterval
-				if estimatedStaleness <= maxStaleness {
-					selected = append(selected, secondary)
-				}
-			}
-
-			return selected
+	staleness := stalenessEstimator(candidates)
+	var selected []Server
+	for _, secondary := range secondaries {
+		if staleness(secondary) <= maxStaleness {
+			selected = append(selected, secondary)
 		}
+	}
+	return selected
+}
 
+// stalenessEstimator returns a function that estimates how far a secondary among candidates lags behind: relative
+// to the primary if there is one, and otherwise to the secondary with the most recent write.
+func stalenessEstimator(candidates []Server) func(Server) time.Duration {
+	if primaries := selectByKind(candidates, RSPrimary); len(primaries) > 0 {
 		primary := primaries[0]
-
-		var selected []Server
-		for _, secondary := range secondaries {
-			estimatedStaleness := secondary.LastUpdateTime.Sub(secondary.LastWriteTime) - primary.LastUpdateTime.Sub(primary.LastWriteTime) + secondary.HeartbeatInterval
-			if estimatedStaleness <= maxStaleness {
-				selected = append(selected, secondary)
-			}
+		return func(s Server) time.Duration {
+			return s.LastUpdateTime.Sub(s.LastWriteTime) - primary.LastUpdateTime.Sub(primary.LastWriteTime) + s.HeartbeatInterval
 		}
-		return selected
 	}
 
-	return secondaries
+	var baseTime time.Time
+	for _, s := range selectByKind(candidates, RSSecondary) {
+		if s.LastWriteTime.After(baseTime) {
+			baseTime = s.LastWriteTime
+		}
+	}
+	return func(s Server) time.Duration {
+		return baseTime.Sub(s.LastWriteTime) + s.HeartbeatInterval
+	}
 }
 
+// selectByTagSet returns the candidates matching the first tag set in order that matches any candidate. An
+// empty tag set matches every candidate, including untagged ones, so it can be used as a final fallback.
 func selectByTagSet(candidates []Server, tagSets []tag.Set) []Server {
 	if len(tagSets) == 0 {
 		return candidates
@@ -225,7 +335,7 @@ func selectByTagSet(candidates []Server, tagSets []tag.Set) []Server {
 	for _, ts := range tagSets {
 		var results []Server
 		for _, s := range candidates {
-			if len(s.Tags) > 0 && s.Tags.ContainsAll(ts) {
+			if s.Tags.ContainsAll(ts) {
 				results = append(results, s)
 			}
 		}
@@ -238,6 +348,18 @@ func selectByTagSet(candidates []Server, tagSets []tag.Set) []Server {
 	return []Server{}
 }
 
+func matchesAnyTagSet(s Server, tagSets []tag.Set) bool {
+	if len(tagSets) == 0 {
+		return true
+	}
+	for _, ts := range tagSets {
+		if s.Tags.ContainsAll(ts) {
+			return true
+		}
+	}
+	return false
+}
+
 func selectByKind(candidates []Server, kind ServerKind) []Server {
 	var result []Server
 	for _, s := range candidates {
@@ -249,29 +371,32 @@ func selectByKind(candidates []Server, kind ServerKind) []Server {
 	return result
 }
 
+// verifyMaxStaleness returns an error if the read preference's max staleness is below what the driver can
+// estimate for a replica set: 90 seconds, and the heartbeat interval plus the primary's idle write period.
 func verifyMaxStaleness(rp *readpref.ReadPref, t Topology) error {
 	maxStaleness, set := rp.MaxStaleness()
 	if !set {
 		return nil
 	}
 
-	if maxStaleness < 90*time.Second {
-		return fmt.Errorf("max staleness (%s) must be greater than or equal to 90s", maxStaleness)
+	if maxStaleness < smallestMaxStaleness {
+		return fmt.Errorf("max staleness (%s) must be greater than or equal to %s", maxStaleness, smallestMaxStaleness)
 	}
 
-	if len(t.Servers) < 1 {
-		// Maybe we should return an error here instead?
-		return nil
+	// Every server is monitored with the same heartbeat interval, but servers that have not yet completed a
+	// heartbeat do not report it, so use the largest one reported.
+	var heartbeatInterval time.Duration
+	for _, s := range t.Servers {
+		if s.HeartbeatInterval > heartbeatInterval {
+			heartbeatInterval = s.HeartbeatInterval
+		}
 	}
 
-	// we'll assume all candidates have the same heartbeat interval.
-	s := t.Servers[0]
-	idleWritePeriod := 10 * time.Second
-
-	if maxStaleness < s.HeartbeatInterval+idleWritePeriod {
+	if maxStaleness < heartbeatInterval+idleWritePeriod {
 		return fmt.Errorf(
-			"max staleness (%s) must be greater than or equal to the heartbeat interval (%s) plus idle write period (%s)",
-			maxStaleness, s.HeartbeatInterval, idleWritePeriod,
+			"max staleness (%s) must be greater than or equal to the heartbeat interval (%s) plus idle write period (%s); "+
+				"increase maxStalenessSeconds or decrease heartbeatFrequencyMS",
+			maxStaleness, heartbeatInterval, idleWritePeriod,
 		)
 	}
 
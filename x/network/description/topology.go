@@ -35,15 +35,22 @@ func (t Topology) Server(addr address.Address) (Server, bool) {
 type TopologyDiff struct {
 	Added   []Server
 	Removed []Server
+	// Changed contains the new descriptions of the servers present in both topologies whose descriptions
+	// are not equal, e.g. because a secondary became the primary. The previous description of a changed
+	// server can be retrieved from the old topology with Topology.Server.
+	Changed []Server
 }
 
-// DiffTopology compares the two topology descriptions and returns the difference.
+// DiffTopology compares the two topology descriptions and returns the difference. Servers are matched by
+// address and compared with Server.Equal.
 func DiffTopology(old, new Topology) TopologyDiff {
 	var diff TopologyDiff
 
 	// TODO: do this without sorting...
-	oldServers := serverSorter(old.Servers)
-	newServers := serverSorter(new.Servers)
+	oldServers := make(serverSorter, len(old.Servers))
+	copy(oldServers, old.Servers)
+	newServers := make(serverSorter, len(new.Servers))
+	copy(newServers, new.Servers)
 
 	sort.Sort(oldServers)
 	sort.Sort(newServers)
@@ -63,6 +70,9 @@ func DiffTopology(old, new Topology) TopologyDiff {
 				diff.Removed = append(diff.Removed, oldServers[i])
 				i++
 			case 0:
+				if !oldServers[i].Equal(newServers[j]) {
+					diff.Changed = append(diff.Changed, newServers[j])
+				}
 				i++
 				j++
 			}
@@ -0,0 +1,117 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package description
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/tag"
+	"github.com/mongodb/mongo-go-driver/x/network/address"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffTopology(t *testing.T) {
+	a := Server{Addr: address.Address("a:27017"), Kind: RSPrimary, SetName: "rs", WireVersion: &VersionRange{Max: 7}}
+	b := Server{Addr: address.Address("b:27017"), Kind: RSSecondary, SetName: "rs", WireVersion: &VersionRange{Max: 7}}
+	c := Server{Addr: address.Address("c:27017"), Kind: RSArbiter, SetName: "rs"}
+
+	aStepDown := a
+	aStepDown.Kind = RSSecondary
+	bStepUp := b
+	bStepUp.Kind = RSPrimary
+	bHeartbeat := b.SetAverageRTT(5 * time.Millisecond)
+	bHeartbeat.LastUpdateTime = time.Now()
+
+	testCases := []struct {
+		name    string
+		old     []Server
+		new     []Server
+		added   []Server
+		removed []Server
+		changed []Server
+	}{
+		{"unchanged", []Server{a, b}, []Server{b, a}, nil, nil, nil},
+		{"added", []Server{a}, []Server{a, b}, []Server{b}, nil, nil},
+		{"removed", []Server{a, b, c}, []Server{b}, nil, []Server{a, c}, nil},
+		{"failover", []Server{a, b}, []Server{aStepDown, bStepUp}, nil, nil, []Server{aStepDown, bStepUp}},
+		{"heartbeat only", []Server{a, b}, []Server{a, bHeartbeat}, nil, nil, nil},
+		{"mixed", []Server{c, a}, []Server{bStepUp, aStepDown}, []Server{bStepUp}, []Server{c}, []Server{aStepDown}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldServers := append([]Server(nil), tc.old...)
+			diff := DiffTopology(Topology{Servers: tc.old}, Topology{Servers: tc.new})
+			require.Equal(t, tc.added, diff.Added)
+			require.Equal(t, tc.removed, diff.Removed)
+			require.Equal(t, tc.changed, diff.Changed)
+			require.Equal(t, oldServers, tc.old, "the old servers should not be reordered")
+		})
+	}
+}
+
+func TestServerEqual(t *testing.T) {
+	base := Server{
+		Addr:        address.Address("a:27017"),
+		Kind:        RSSecondary,
+		SetName:     "rs",
+		Members:     []address.Address{"a:27017", "b:27017"},
+		Tags:        tag.Set{{Name: "dc", Value: "east"}, {Name: "rack", Value: "1"}},
+		WireVersion: &VersionRange{Max: 7},
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(s Server) Server
+		equal  bool
+	}{
+		{"identical", func(s Server) Server { return s }, true},
+		{"rtt", func(s Server) Server { return s.SetAverageRTT(time.Second) }, true},
+		{"member order", func(s Server) Server {
+			s.Members = []address.Address{"b:27017", "a:27017"}
+			return s
+		}, true},
+		{"tag order", func(s Server) Server {
+			s.Tags = tag.Set{{Name: "rack", Value: "1"}, {Name: "dc", Value: "east"}}
+			return s
+		}, true},
+		{"kind", func(s Server) Server {
+			s.Kind = RSPrimary
+			return s
+		}, false},
+		{"members", func(s Server) Server {
+			s.Members = []address.Address{"a:27017", "c:27017"}
+			return s
+		}, false},
+		{"tags", func(s Server) Server {
+			s.Tags = tag.Set{{Name: "dc", Value: "west"}, {Name: "rack", Value: "1"}}
+			return s
+		}, false},
+		{"wire version", func(s Server) Server {
+			s.WireVersion = &VersionRange{Max: 8}
+			return s
+		}, false},
+		{"no wire version", func(s Server) Server {
+			s.WireVersion = nil
+			return s
+		}, false},
+		{"error", func(s Server) Server {
+			s.LastError = errors.New("connection refused")
+			return s
+		}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			other := tc.modify(base)
+			require.Equal(t, tc.equal, base.Equal(other))
+			require.Equal(t, tc.equal, other.Equal(base))
+		})
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build go1.9
+// +build go1.9
+
+package dns
+
+import (
+	"context"
+	"net"
+)
+
+// dialResolver returns a resolver that performs lookups with Go's built-in resolver over connections opened by
+// dial.
+func dialResolver(dial func(ctx context.Context, network, address string) (net.Conn, error)) NetResolver {
+	return &net.Resolver{PreferGo: true, Dial: dial}
+}
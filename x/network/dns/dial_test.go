@@ -0,0 +1,49 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build go1.9
+// +build go1.9
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type dialContextKey struct{}
+
+func TestDial(t *testing.T) {
+	errDial := errors.New("dial refused")
+	var dialed []interface{}
+	r := &Resolver{
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			dialed = append(dialed, ctx.Value(dialContextKey{}))
+			return nil, errDial
+		},
+		MaxRetries: -1,
+	}
+	ctx := context.WithValue(context.Background(), dialContextKey{}, "lookup")
+
+	_, err := r.ResolveHostFromSrvRecordsContext(ctx, "test1.test.build.10gen.cc")
+	require.Error(t, err)
+	dialedForSRV := len(dialed)
+	require.NotZero(t, dialedForSRV)
+
+	// A failed TXT lookup is treated as the absence of a record.
+	params, err := r.ResolveAdditionalQueryParametersFromTxtRecordsContext(ctx, "test1.test.build.10gen.cc")
+	require.NoError(t, err)
+	require.Empty(t, params)
+	require.True(t, len(dialed) > dialedForSRV, "expected the TXT lookup to dial")
+
+	for _, value := range dialed {
+		require.Equal(t, "lookup", value)
+	}
+}
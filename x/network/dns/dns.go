@@ -0,0 +1,560 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package dns resolves the SRV and TXT records used by mongodb+srv connection strings.
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/internal"
+)
+
+// NetResolver performs the DNS lookups needed to resolve a mongodb+srv connection string. Its
+// methods match those of *net.Resolver.
+type NetResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// defaultSrvServiceName is the SRV service name used when none is specified.
+const defaultSrvServiceName = "mongodb"
+
+// maxSrvServiceNameLength is the maximum length of an SRV service name.
+const maxSrvServiceNameLength = 15
+
+// defaultMaxRetries is the number of times a failed SRV lookup is retried by default.
+const defaultMaxRetries = 2
+
+// defaultRetryBackoff is the time waited before the first retry of an SRV lookup by default.
+const defaultRetryBackoff = 100 * time.Millisecond
+
+// TTLResolver is a NetResolver that can also report the TTL of the SRV records it returns. The
+// TTL is the minimum TTL of the records in the answer. *net.Resolver does not expose TTLs, so a
+// custom NetResolver has to implement TTLResolver for SRV polling to honor them.
+type TTLResolver interface {
+	NetResolver
+	LookupSRVWithTTL(ctx context.Context, service, proto, name string) (string, []*net.SRV, time.Duration, error)
+}
+
+// TXTRecordResolver is a NetResolver that returns each TXT record as the character-strings it is
+// made of. A single logical TXT record may be split across several character-strings, which
+// TXTRecordResolver keeps distinguishable from several separate TXT records.
+type TXTRecordResolver interface {
+	NetResolver
+	LookupTXTRecords(ctx context.Context, name string) ([][]string, error)
+}
+
+// Resolver resolves the hosts and additional connection string options for a mongodb+srv
+// connection string.
+type Resolver struct {
+	// lastSRVTTL is accessed atomically and is a time.Duration. It must be the first field so that it is 64-bit
+	// aligned on 32-bit platforms, which sync/atomic requires.
+	lastSRVTTL int64
+
+	// Resolver is used to perform the SRV and TXT lookups. If nil, a *net.Resolver is used: the
+	// one built around Dial if Dial is set and net.DefaultResolver otherwise.
+	Resolver NetResolver
+
+	// Dial, if set and Resolver is nil, is used to open the connections to DNS servers, e.g. to
+	// send queries over an encrypted transport. The lookups are then performed by Go's built-in
+	// resolver rather than the operating system's. The context passed to Dial is the one passed
+	// to the lookup method, so cancelling it or letting its deadline pass also aborts the dial.
+	// Dial requires Go 1.9 or later; with Go 1.8 it is ignored and net.DefaultResolver is used.
+	Dial func(ctx context.Context, network, address string) (net.Conn, error)
+
+	// SrvServiceName is the service name used in SRV lookups. If empty, "mongodb" is used.
+	SrvServiceName string
+
+	// SrvMaxHosts is the maximum number of hosts returned from an SRV lookup. If the lookup
+	// returns more hosts, a random sample of SrvMaxHosts hosts is returned. If zero, all of the
+	// hosts are returned.
+	SrvMaxHosts int
+
+	// AllowedTXTOptions is the set of lowercase connection string options that may be specified
+	// in a TXT record. If nil, DefaultAllowedTXTOptions is used.
+	AllowedTXTOptions map[string]struct{}
+
+	// MaxRetries is the number of times an SRV lookup is retried after a temporary failure, such
+	// as a timeout. If zero, the lookup is retried twice. If negative, lookups are not retried.
+	// Other failures, such as a missing record, are never retried.
+	MaxRetries int
+
+	// RetryBackoff is the time waited before the first retry of an SRV lookup. The time is
+	// doubled for each subsequent retry. If zero, 100 milliseconds is used.
+	RetryBackoff time.Duration
+
+	// DisableShuffle disables shuffling the hosts returned from an SRV lookup. By default, hosts
+	// with the same priority and weight are returned in a random order so that connections are
+	// spread across them. If set, the hosts are returned in the order of their priority and then
+	// in the order the NetResolver returned them.
+	DisableShuffle bool
+
+	// Rand is the source of randomness used to order and sample hosts. If nil, the top-level
+	// functions of math/rand are used. A *rand.Rand is not safe for concurrent use, so a Resolver
+	// with Rand set must not be used concurrently.
+	Rand *rand.Rand
+
+	// AllowedTargetSuffixes, if not empty, restricts the targets of SRV records to host names
+	// equal to or within one of the given domains, such as "mongodb.net". This is checked in
+	// addition to the requirement that targets share the parent domain of the SRV host.
+	AllowedTargetSuffixes []string
+
+	// AfterResolve, if set, is called with the SRV host and its validated records after each SRV
+	// lookup, including those made while polling. If it returns an error, the resolution fails
+	// with that error.
+	AfterResolve func(host string, targets []SRVRecord) error
+
+	// Monitor, if set, is called with an Event after each SRV and TXT resolution. It is called
+	// from the goroutine performing the resolution.
+	Monitor func(*Event)
+
+	rescanFrequency time.Duration
+}
+
+// DefaultResolver is the Resolver used when parsing connection strings.
+var DefaultResolver = &Resolver{}
+
+// ResolveHostFromSrvRecords looks up the SRV records for the given host and returns the hosts
+// they point to in "host:port" form.
+func (r *Resolver) ResolveHostFromSrvRecords(host string) ([]string, error) {
+	return r.ResolveHostFromSrvRecordsContext(context.Background(), host)
+}
+
+// ResolveHostFromSrvRecordsContext is like ResolveHostFromSrvRecords but aborts the lookup when
+// ctx is done.
+func (r *Resolver) ResolveHostFromSrvRecordsContext(ctx context.Context, host string) ([]string, error) {
+	host, err := singleSRVHost(host)
+	if err != nil {
+		return nil, err
+	}
+	return r.fetchSeedlistFromSRV(ctx, host)
+}
+
+// ResolveSRVAndTXT looks up the SRV and TXT records for the given host concurrently and returns
+// the hosts and connection string options they contain. It returns once both lookups have
+// completed. An SRV lookup error is returned and cancels the TXT lookup; a missing TXT record is
+// not an error. If Monitor is set, it may be called from both lookups at the same time.
+func (r *Resolver) ResolveSRVAndTXT(ctx context.Context, host string) (hosts []string, params []string, err error) {
+	host, err = singleSRVHost(host)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txtCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var txtErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		params, txtErr = r.ResolveAdditionalQueryParametersFromTxtRecordsContext(txtCtx, host)
+	}()
+
+	hosts, err = r.fetchSeedlistFromSRV(ctx, host)
+	if err != nil {
+		cancel()
+	}
+	wg.Wait()
+
+	if err != nil {
+		return nil, nil, err
+	}
+	if txtErr != nil {
+		return nil, nil, txtErr
+	}
+	return hosts, params, nil
+}
+
+// singleSRVHost returns host if it names exactly one host, which is required of a mongodb+srv
+// connection string.
+func singleSRVHost(host string) (string, error) {
+	parsedHosts := strings.Split(host, ",")
+	if len(parsedHosts) != 1 {
+		quoted := make([]string, len(parsedHosts))
+		for i, parsedHost := range parsedHosts {
+			quoted[i] = strconv.Quote(parsedHost)
+		}
+		return "", internal.WrapErrorf(ErrMultipleSRVHosts, "found %d hosts (%s)", len(parsedHosts), strings.Join(quoted, ", "))
+	}
+	return parsedHosts[0], nil
+}
+
+// ResolveAdditionalQueryParametersFromTxtRecords looks up the TXT record for the given host and
+// returns the connection string options it contains.
+func (r *Resolver) ResolveAdditionalQueryParametersFromTxtRecords(host string) ([]string, error) {
+	return r.ResolveAdditionalQueryParametersFromTxtRecordsContext(context.Background(), host)
+}
+
+// ResolveAdditionalQueryParametersFromTxtRecordsContext is like
+// ResolveAdditionalQueryParametersFromTxtRecords but aborts the lookup when ctx is done.
+func (r *Resolver) ResolveAdditionalQueryParametersFromTxtRecordsContext(ctx context.Context, host string) ([]string, error) {
+	start := time.Now()
+	connectionArgsFromTXT, count, err := r.resolveTXT(ctx, host)
+	r.publish(TXTQuery, host, start, count, err)
+	return connectionArgsFromTXT, err
+}
+
+// resolveTXT returns the connection string options in the TXT record for host and the number of
+// TXT records found.
+func (r *Resolver) resolveTXT(ctx context.Context, host string) ([]string, int, error) {
+	var connectionArgsFromTXT []string
+
+	// error ignored because finding a TXT record should not be
+	// considered an error, unless the lookup was aborted by the context.
+	recordsFromTXT, err := r.lookupTXT(ctx, host)
+	if err != nil && ctx.Err() != nil {
+		return nil, 0, internal.WrapErrorf(ctx.Err(), "TXT lookup for %s aborted", host)
+	}
+
+	if len(recordsFromTXT) > 1 {
+		return nil, len(recordsFromTXT), ErrMultipleTXTRecords
+	}
+	if len(recordsFromTXT) > 0 {
+		fields := strings.FieldsFunc(recordsFromTXT[0], func(r rune) bool { return r == ';' || r == '&' })
+
+		connectionArgsFromTXT, err = r.validateTXTResult(fields)
+		if err != nil {
+			return nil, len(recordsFromTXT), err
+		}
+	}
+
+	return connectionArgsFromTXT, len(recordsFromTXT), nil
+}
+
+// ValidateSrvServiceName returns an error if name cannot be used as an SRV service name.
+func ValidateSrvServiceName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("SRV service name must not be empty")
+	}
+	if len(name) > maxSrvServiceNameLength {
+		return fmt.Errorf("SRV service name %q must not be longer than %d characters", name, maxSrvServiceNameLength)
+	}
+	return nil
+}
+
+func (r *Resolver) srvServiceName() (string, error) {
+	if r.SrvServiceName == "" {
+		return defaultSrvServiceName, nil
+	}
+	if err := ValidateSrvServiceName(r.SrvServiceName); err != nil {
+		return "", err
+	}
+	return r.SrvServiceName, nil
+}
+
+// LastSRVTTL returns the TTL reported for the records of the last successful SRV lookup. It returns
+// zero if no lookup has succeeded or the NetResolver does not implement TTLResolver.
+func (r *Resolver) LastSRVTTL() time.Duration {
+	return time.Duration(atomic.LoadInt64(&r.lastSRVTTL))
+}
+
+// lookupSRV looks up the SRV records for host, retrying temporary failures with exponential
+// backoff.
+func (r *Resolver) lookupSRV(ctx context.Context, service, host string) ([]*net.SRV, time.Duration, error) {
+	maxRetries := r.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultMaxRetries
+	}
+	backoff := r.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		addresses, ttl, err := r.lookupSRVOnce(ctx, service, host)
+		if err == nil || attempt >= maxRetries || !isTemporary(err) {
+			return addresses, ttl, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, 0, err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+func (r *Resolver) lookupSRVOnce(ctx context.Context, service, host string) ([]*net.SRV, time.Duration, error) {
+	if ttlResolver, ok := r.netResolver().(TTLResolver); ok {
+		_, addresses, ttl, err := ttlResolver.LookupSRVWithTTL(ctx, service, "tcp", host)
+		return addresses, ttl, err
+	}
+	_, addresses, err := r.netResolver().LookupSRV(ctx, service, "tcp", host)
+	return addresses, 0, err
+}
+
+// lookupTXT returns the TXT records for host with the character-strings of each record joined.
+func (r *Resolver) lookupTXT(ctx context.Context, host string) ([]string, error) {
+	if recordResolver, ok := r.netResolver().(TXTRecordResolver); ok {
+		records, err := recordResolver.LookupTXTRecords(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		joined := make([]string, len(records))
+		for i, chunks := range records {
+			joined[i] = strings.Join(chunks, "")
+		}
+		return joined, nil
+	}
+
+	records, err := r.netResolver().LookupTXT(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	// This is a temporary fix to get around bug https://github.com/golang/go/issues/21472.
+	// It will currently incorrectly concatenate multiple TXT records to one
+	// on windows. Use a TXTRecordResolver to keep record boundaries there.
+	if runtime.GOOS == "windows" {
+		records = []string{strings.Join(records, "")}
+	}
+	return records, nil
+}
+
+// isTemporary returns true if err is a net.Error that reports itself as temporary.
+func isTemporary(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Temporary()
+}
+
+func (r *Resolver) netResolver() NetResolver {
+	switch {
+	case r.Resolver != nil:
+		return r.Resolver
+	case r.Dial != nil:
+		return dialResolver(r.Dial)
+	default:
+		return net.DefaultResolver
+	}
+}
+
+func (r *Resolver) fetchSeedlistFromSRV(ctx context.Context, host string) ([]string, error) {
+	parsedHosts, err := r.lookupHostsFromSRV(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	return r.selectHosts(nil, parsedHosts), nil
+}
+
+// lookupHostsFromSRV returns all of the hosts in the SRV record for host.
+func (r *Resolver) lookupHostsFromSRV(ctx context.Context, host string) ([]string, error) {
+	records, err := r.lookupSRVRecords(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedHosts := make([]string, len(records))
+	for i, record := range records {
+		parsedHosts[i] = record.String()
+	}
+	return parsedHosts, nil
+}
+
+// lookupSRVRecords returns the validated SRV records for host, ordered by priority and weight.
+func (r *Resolver) lookupSRVRecords(ctx context.Context, host string) ([]SRVRecord, error) {
+	start := time.Now()
+	records, err := r.resolveSRVRecords(ctx, host)
+	r.publish(SRVQuery, host, start, len(records), err)
+	return records, err
+}
+
+func (r *Resolver) resolveSRVRecords(ctx context.Context, host string) ([]SRVRecord, error) {
+	var err error
+
+	_, _, err = net.SplitHostPort(host)
+
+	if err == nil {
+		// we were able to successfully extract a port from the host,
+		// but should not be able to when using SRV
+		return nil, ErrSRVIncludesPort
+	}
+
+	if err := validateSRVHost(host); err != nil {
+		return nil, err
+	}
+
+	serviceName, err := r.srvServiceName()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, ttl, err := r.lookupSRV(ctx, serviceName, host)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, internal.WrapErrorf(ctx.Err(), "SRV lookup for %s aborted", host)
+		}
+		return nil, err
+	}
+	if len(addresses) == 0 {
+		return nil, internal.WrapErrorf(ErrNoSRVRecords, "SRV lookup for %q", host)
+	}
+	records := make([]SRVRecord, len(addresses))
+	for i, address := range addresses {
+		trimmedAddressTarget := strings.TrimSuffix(address.Target, ".")
+		err := validateSRVResult(trimmedAddressTarget, host)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.validateTargetSuffix(trimmedAddressTarget, host); err != nil {
+			return nil, err
+		}
+		records[i] = SRVRecord{
+			Target:   trimmedAddressTarget,
+			Port:     address.Port,
+			Priority: address.Priority,
+			Weight:   address.Weight,
+		}
+	}
+	if r.AfterResolve != nil {
+		if err := r.AfterResolve(host, records); err != nil {
+			return nil, err
+		}
+	}
+	r.sortSRVRecords(records)
+
+	atomic.StoreInt64(&r.lastSRVTTL, int64(ttl))
+	return records, nil
+}
+
+// selectHosts limits the resolved hosts to SrvMaxHosts hosts. Hosts in current that are still
+// resolved are kept and the remaining hosts are chosen randomly.
+func (r *Resolver) selectHosts(current, resolved []string) []string {
+	if r.SrvMaxHosts <= 0 || len(resolved) <= r.SrvMaxHosts {
+		return resolved
+	}
+
+	currentSet := make(map[string]struct{}, len(current))
+	for _, host := range current {
+		currentSet[host] = struct{}{}
+	}
+
+	selected := make([]string, 0, r.SrvMaxHosts)
+	var candidates []string
+	for _, host := range resolved {
+		if _, ok := currentSet[host]; ok && len(selected) < r.SrvMaxHosts {
+			selected = append(selected, host)
+			continue
+		}
+		candidates = append(candidates, host)
+	}
+
+	r.shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	return append(selected, candidates[:r.SrvMaxHosts-len(selected)]...)
+}
+
+// validateSRVHost checks that host is a domain name with at least two labels. A single label host,
+// such as "localhost", has no parent domain for SRV records to be validated against.
+func validateSRVHost(host string) error {
+	labels := strings.Split(strings.TrimSuffix(host, "."), ".")
+	if len(labels) < 2 {
+		return internal.WrapErrorf(ErrInvalidSRVHost, "invalid SRV host %q", host)
+	}
+	for _, label := range labels {
+		if label == "" {
+			return internal.WrapErrorf(ErrInvalidSRVHost, "invalid SRV host %q", host)
+		}
+	}
+	return nil
+}
+
+func validateSRVResult(recordFromSRV, inputHostName string) error {
+	// DNS names are case-insensitive and may be fully qualified with a trailing dot.
+	recordFromSRV = strings.ToLower(strings.TrimSuffix(recordFromSRV, "."))
+	inputHostName = strings.ToLower(strings.TrimSuffix(inputHostName, "."))
+
+	if net.ParseIP(strings.Trim(recordFromSRV, "[]")) != nil {
+		return SRVValidationError{Record: recordFromSRV, Input: inputHostName, Message: "target must be a host name, not an IP address"}
+	}
+
+	separatedInputDomain := strings.Split(inputHostName, ".")
+	separatedRecord := strings.Split(recordFromSRV, ".")
+	if len(separatedRecord) < 2 {
+		return SRVValidationError{Record: recordFromSRV, Input: inputHostName, Message: "DNS name must contain at least 2 labels"}
+	}
+	if len(separatedRecord) < len(separatedInputDomain) {
+		return SRVValidationError{Record: recordFromSRV, Input: inputHostName, Message: "Domain suffix from SRV record not matched input domain"}
+	}
+
+	// The record must be in the parent domain of the input, i.e. the input without its first label.
+	parentDomain := strings.Join(separatedInputDomain[1:], ".")
+	if !strings.HasSuffix(recordFromSRV, "."+parentDomain) {
+		return SRVValidationError{Record: recordFromSRV, Input: inputHostName, Message: "Domain suffix from SRV record not matched input domain"}
+	}
+	return nil
+}
+
+// validateTargetSuffix returns an error if AllowedTargetSuffixes is set and the target of an SRV
+// record is not within any of the domains it lists.
+func (r *Resolver) validateTargetSuffix(recordFromSRV, inputHostName string) error {
+	if len(r.AllowedTargetSuffixes) == 0 {
+		return nil
+	}
+
+	recordFromSRV = strings.ToLower(strings.TrimSuffix(recordFromSRV, "."))
+	for _, suffix := range r.AllowedTargetSuffixes {
+		suffix = strings.ToLower(strings.Trim(suffix, "."))
+		if suffix == "" {
+			continue
+		}
+		if recordFromSRV == suffix || strings.HasSuffix(recordFromSRV, "."+suffix) {
+			return nil
+		}
+	}
+	return SRVValidationError{Record: recordFromSRV, Input: inputHostName, Message: "target is not within an allowed domain"}
+}
+
+// DefaultAllowedTXTOptions is the set of connection string options that may be specified in a TXT
+// record when a Resolver does not specify its own.
+var DefaultAllowedTXTOptions = map[string]struct{}{
+	"authsource":   {},
+	"loadbalanced": {},
+	"replicaset":   {},
+}
+
+// validateTXTResult validates the options from a TXT record and returns them as key=value pairs
+// with lowercase keys and whitespace around keys and values removed. Fields that are empty
+// after trimming are dropped.
+func (r *Resolver) validateTXTResult(paramsFromTXT []string) ([]string, error) {
+	allowedTXTOptions := r.AllowedTXTOptions
+	if allowedTXTOptions == nil {
+		allowedTXTOptions = DefaultAllowedTXTOptions
+	}
+
+	normalized := make([]string, 0, len(paramsFromTXT))
+	for _, param := range paramsFromTXT {
+		param = strings.TrimSpace(param)
+		if param == "" {
+			continue
+		}
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			return nil, ErrInvalidTXTRecord
+		}
+		key := strings.ToLower(strings.TrimSpace(kv[0]))
+		if _, ok := allowedTXTOptions[key]; !ok {
+			return nil, internal.WrapErrorf(ErrInvalidTXTOption, "invalid option '%s'", kv[0])
+		}
+		normalized = append(normalized, key+"="+strings.TrimSpace(kv[1]))
+	}
+	return normalized, nil
+}
@@ -0,0 +1,522 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/internal"
+	"github.com/stretchr/testify/require"
+)
+
+type stubResolver struct {
+	srvs   []*net.SRV
+	srvErr error
+	txts   []string
+	txtErr error
+	block  bool
+
+	srvQueries []string
+	txtQueries []string
+}
+
+func (s *stubResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	s.srvQueries = append(s.srvQueries, "_"+service+"._"+proto+"."+name)
+	if s.block {
+		<-ctx.Done()
+		return "", nil, &net.DNSError{Err: ctx.Err().Error(), Name: name}
+	}
+	return "", s.srvs, s.srvErr
+}
+
+func (s *stubResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	s.txtQueries = append(s.txtQueries, name)
+	if s.block {
+		<-ctx.Done()
+		return nil, &net.DNSError{Err: ctx.Err().Error(), Name: name}
+	}
+	return s.txts, s.txtErr
+}
+
+type txtRecordStub struct {
+	stubResolver
+	records [][]string
+}
+
+func (s *txtRecordStub) LookupTXTRecords(context.Context, string) ([][]string, error) {
+	return s.records, nil
+}
+
+func TestResolveHostFromSrvRecords(t *testing.T) {
+	t.Run("uses provided resolver", func(t *testing.T) {
+		stub := &stubResolver{srvs: []*net.SRV{
+			{Target: "localhost.test.build.10gen.cc.", Port: 27017},
+			{Target: "localhost.test.build.10gen.cc.", Port: 27018},
+		}}
+		r := &Resolver{Resolver: stub, DisableShuffle: true}
+
+		hosts, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, []string{"localhost.test.build.10gen.cc:27017", "localhost.test.build.10gen.cc:27018"}, hosts)
+		require.Equal(t, []string{"_mongodb._tcp.test1.test.build.10gen.cc"}, stub.srvQueries)
+	})
+	t.Run("custom service name", func(t *testing.T) {
+		stub := &stubResolver{srvs: []*net.SRV{{Target: "localhost.test.build.10gen.cc.", Port: 27017}}}
+		r := &Resolver{Resolver: stub, SrvServiceName: "customname"}
+
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, []string{"_customname._tcp.test1.test.build.10gen.cc"}, stub.srvQueries)
+	})
+	t.Run("invalid service name", func(t *testing.T) {
+		for _, name := range []string{" ", "\t", "sixteencharacter"} {
+			stub := &stubResolver{}
+			r := &Resolver{Resolver: stub, SrvServiceName: name}
+
+			_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+			require.Error(t, err, "expected error for service name %q", name)
+			require.Empty(t, stub.srvQueries)
+		}
+	})
+	t.Run("max hosts", func(t *testing.T) {
+		stub := &stubResolver{srvs: []*net.SRV{
+			{Target: "localhost.test.build.10gen.cc.", Port: 27017},
+			{Target: "localhost.test.build.10gen.cc.", Port: 27018},
+			{Target: "localhost.test.build.10gen.cc.", Port: 27019},
+		}}
+		all := []string{
+			"localhost.test.build.10gen.cc:27017",
+			"localhost.test.build.10gen.cc:27018",
+			"localhost.test.build.10gen.cc:27019",
+		}
+
+		r := &Resolver{Resolver: stub, SrvMaxHosts: 5}
+		hosts, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, buildSet(all), buildSet(hosts))
+
+		r.SrvMaxHosts = 2
+		seen := make(map[string]struct{})
+		for i := 0; i < 100; i++ {
+			hosts, err = r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+			require.NoError(t, err)
+			require.Len(t, hosts, 2)
+			require.NotEqual(t, hosts[0], hosts[1])
+			for _, host := range hosts {
+				require.Contains(t, all, host)
+				seen[host] = struct{}{}
+			}
+		}
+		require.Len(t, seen, 3, "expected hosts to be sampled randomly")
+	})
+	t.Run("lookup error", func(t *testing.T) {
+		lookupErr := errors.New("lookup failed")
+		r := &Resolver{Resolver: &stubResolver{srvErr: lookupErr}}
+
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.Equal(t, lookupErr, err)
+	})
+	t.Run("mismatched domain", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{srvs: []*net.SRV{{Target: "localhost.example.com.", Port: 27017}}}}
+
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		validationErr, ok := internal.UnwrapError(err).(SRVValidationError)
+		require.True(t, ok, "expected SRVValidationError, got %v", err)
+		require.Equal(t, "localhost.example.com", validationErr.Record)
+		require.Equal(t, "test1.test.build.10gen.cc", validationErr.Input)
+	})
+	t.Run("no records", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{}}
+
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.Equal(t, ErrNoSRVRecords, internal.UnwrapError(err), "expected ErrNoSRVRecords, got %v", err)
+		require.Contains(t, err.Error(), `"test1.test.build.10gen.cc"`)
+	})
+	t.Run("port", func(t *testing.T) {
+		stub := &stubResolver{}
+		r := &Resolver{Resolver: stub}
+
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc:27017")
+		require.Equal(t, ErrSRVIncludesPort, err)
+		require.Empty(t, stub.srvQueries)
+	})
+	t.Run("invalid host", func(t *testing.T) {
+		for _, host := range []string{"localhost", "localhost.", "example..com", ".com"} {
+			stub := &stubResolver{}
+			r := &Resolver{Resolver: stub}
+
+			_, err := r.ResolveHostFromSrvRecords(host)
+			require.Equal(t, ErrInvalidSRVHost, internal.UnwrapError(err), "expected ErrInvalidSRVHost for %q, got %v", host, err)
+			require.Contains(t, err.Error(), host)
+			require.Empty(t, stub.srvQueries)
+		}
+	})
+	t.Run("two label host", func(t *testing.T) {
+		stub := &stubResolver{srvs: []*net.SRV{{Target: "node.example.com.", Port: 27017}}}
+		r := &Resolver{Resolver: stub}
+
+		hosts, err := r.ResolveHostFromSrvRecords("example.com")
+		require.NoError(t, err)
+		require.Equal(t, []string{"node.example.com:27017"}, hosts)
+	})
+	t.Run("multiple hosts", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{}}
+
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc,test2.test.build.10gen.cc")
+		require.Equal(t, ErrMultipleSRVHosts, internal.UnwrapError(err), "expected ErrMultipleSRVHosts, got %v", err)
+		require.Contains(t, err.Error(), `found 2 hosts ("test1.test.build.10gen.cc", "test2.test.build.10gen.cc")`)
+	})
+	t.Run("cancelled context", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{block: true}}
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+
+		_, err := r.ResolveHostFromSrvRecordsContext(ctx, "test1.test.build.10gen.cc")
+		require.Equal(t, context.Canceled, internal.UnwrapError(err), "expected context.Canceled, got %v", err)
+	})
+}
+
+type flakyResolver struct {
+	stubResolver
+	errs []error
+}
+
+func (f *flakyResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		f.srvQueries = append(f.srvQueries, name)
+		return "", nil, err
+	}
+	return f.stubResolver.LookupSRV(ctx, service, proto, name)
+}
+
+func TestSRVLookupRetries(t *testing.T) {
+	srvs := []*net.SRV{{Target: "localhost.test.build.10gen.cc.", Port: 27017}}
+	timeout := &net.DNSError{Err: "i/o timeout", Name: "test1.test.build.10gen.cc", IsTimeout: true}
+	notFound := &net.DNSError{Err: "no such host", Name: "test1.test.build.10gen.cc"}
+
+	t.Run("temporary errors are retried", func(t *testing.T) {
+		stub := &flakyResolver{stubResolver: stubResolver{srvs: srvs}, errs: []error{timeout, timeout}}
+		r := &Resolver{Resolver: stub, RetryBackoff: time.Millisecond}
+
+		hosts, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, []string{"localhost.test.build.10gen.cc:27017"}, hosts)
+		require.Len(t, stub.srvQueries, 3)
+	})
+	t.Run("retries are bounded", func(t *testing.T) {
+		stub := &flakyResolver{stubResolver: stubResolver{srvs: srvs}, errs: []error{timeout, timeout, timeout, timeout}}
+		r := &Resolver{Resolver: stub, MaxRetries: 1, RetryBackoff: time.Millisecond}
+
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.Equal(t, timeout, err)
+		require.Len(t, stub.srvQueries, 2)
+	})
+	t.Run("retries can be disabled", func(t *testing.T) {
+		stub := &flakyResolver{stubResolver: stubResolver{srvs: srvs}, errs: []error{timeout}}
+		r := &Resolver{Resolver: stub, MaxRetries: -1}
+
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.Equal(t, timeout, err)
+		require.Len(t, stub.srvQueries, 1)
+	})
+	t.Run("permanent errors are not retried", func(t *testing.T) {
+		stub := &flakyResolver{stubResolver: stubResolver{srvs: srvs}, errs: []error{notFound}}
+		r := &Resolver{Resolver: stub, RetryBackoff: time.Millisecond}
+
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.Equal(t, notFound, err)
+		require.Len(t, stub.srvQueries, 1)
+	})
+}
+
+// rendezvousResolver answers an SRV lookup only once a TXT lookup has started.
+type rendezvousResolver struct {
+	stubResolver
+	txtStarted chan struct{}
+}
+
+func (r *rendezvousResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	select {
+	case <-r.txtStarted:
+	case <-time.After(5 * time.Second):
+		return "", nil, errors.New("TXT lookup did not start")
+	}
+	return r.stubResolver.LookupSRV(ctx, service, proto, name)
+}
+
+func (r *rendezvousResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	close(r.txtStarted)
+	return r.stubResolver.LookupTXT(ctx, name)
+}
+
+func TestResolveSRVAndTXT(t *testing.T) {
+	srvs := []*net.SRV{{Target: "localhost.test.build.10gen.cc.", Port: 27017}}
+
+	t.Run("lookups run concurrently", func(t *testing.T) {
+		stub := &rendezvousResolver{
+			stubResolver: stubResolver{srvs: srvs, txts: []string{"replicaSet=repl0"}},
+			txtStarted:   make(chan struct{}),
+		}
+		r := &Resolver{Resolver: stub}
+
+		hosts, params, err := r.ResolveSRVAndTXT(context.Background(), "test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, []string{"localhost.test.build.10gen.cc:27017"}, hosts)
+		require.Equal(t, []string{"replicaset=repl0"}, params)
+	})
+	t.Run("missing TXT record", func(t *testing.T) {
+		notFound := &net.DNSError{Err: "no such host", Name: "test1.test.build.10gen.cc"}
+		r := &Resolver{Resolver: &stubResolver{srvs: srvs, txtErr: notFound}}
+
+		hosts, params, err := r.ResolveSRVAndTXT(context.Background(), "test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, []string{"localhost.test.build.10gen.cc:27017"}, hosts)
+		require.Empty(t, params)
+	})
+	t.Run("SRV error", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{txts: []string{"replicaSet=repl0"}}}
+
+		_, _, err := r.ResolveSRVAndTXT(context.Background(), "test1.test.build.10gen.cc")
+		require.Equal(t, ErrNoSRVRecords, internal.UnwrapError(err), "expected ErrNoSRVRecords, got %v", err)
+	})
+	t.Run("invalid TXT record", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{srvs: srvs, txts: []string{"ssl=false"}}}
+
+		_, _, err := r.ResolveSRVAndTXT(context.Background(), "test1.test.build.10gen.cc")
+		require.Equal(t, ErrInvalidTXTOption, internal.UnwrapError(err), "expected ErrInvalidTXTOption, got %v", err)
+	})
+	t.Run("multiple hosts", func(t *testing.T) {
+		stub := &stubResolver{srvs: srvs}
+		r := &Resolver{Resolver: stub}
+
+		_, _, err := r.ResolveSRVAndTXT(context.Background(), "test1.test.build.10gen.cc,test2.test.build.10gen.cc")
+		require.Equal(t, ErrMultipleSRVHosts, internal.UnwrapError(err), "expected ErrMultipleSRVHosts, got %v", err)
+		require.Empty(t, stub.srvQueries)
+		require.Empty(t, stub.txtQueries)
+	})
+}
+
+func TestResolveAdditionalQueryParametersFromTxtRecords(t *testing.T) {
+	t.Run("uses provided resolver", func(t *testing.T) {
+		stub := &stubResolver{txts: []string{"replicaSet=repl0&authSource=thisDB"}}
+		r := &Resolver{Resolver: stub}
+
+		params, err := r.ResolveAdditionalQueryParametersFromTxtRecords("test5.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, []string{"replicaset=repl0", "authsource=thisDB"}, params)
+		require.Equal(t, []string{"test5.test.build.10gen.cc"}, stub.txtQueries)
+	})
+	t.Run("missing record is not an error", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{txtErr: errors.New("no such host")}}
+
+		params, err := r.ResolveAdditionalQueryParametersFromTxtRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Empty(t, params)
+	})
+	t.Run("disallowed option", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{txts: []string{"ssl=false"}}}
+
+		_, err := r.ResolveAdditionalQueryParametersFromTxtRecords("test1.test.build.10gen.cc")
+		require.Equal(t, ErrInvalidTXTOption, internal.UnwrapError(err), "expected ErrInvalidTXTOption, got %v", err)
+	})
+	t.Run("whitespace and mixed case", func(t *testing.T) {
+		records := map[string][]string{
+			"authSource = admin":                          {"authsource=admin"},
+			"\tAUTHSOURCE=admin\t&\treplicaSet =\trepl0 ": {"authsource=admin", "replicaset=repl0"},
+			"authSource=admin; ReplicaSet=repl0;":         {"authsource=admin", "replicaset=repl0"},
+			"authSource=admin& &replicaSet=repl0":         {"authsource=admin", "replicaset=repl0"},
+		}
+		for record, expected := range records {
+			r := &Resolver{Resolver: &stubResolver{txts: []string{record}}}
+
+			params, err := r.ResolveAdditionalQueryParametersFromTxtRecords("test1.test.build.10gen.cc")
+			require.NoError(t, err, "unexpected error for record %q", record)
+			require.Equal(t, expected, params, "unexpected params for record %q", record)
+		}
+	})
+	t.Run("invalid record", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{txts: []string{"authSource"}}}
+
+		_, err := r.ResolveAdditionalQueryParametersFromTxtRecords("test1.test.build.10gen.cc")
+		require.Equal(t, ErrInvalidTXTRecord, err)
+	})
+	t.Run("load balanced", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{txts: []string{"loadBalanced=true"}}}
+
+		params, err := r.ResolveAdditionalQueryParametersFromTxtRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, []string{"loadbalanced=true"}, params)
+	})
+	t.Run("custom allowed options", func(t *testing.T) {
+		r := &Resolver{
+			Resolver:          &stubResolver{txts: []string{"appName=foo"}},
+			AllowedTXTOptions: map[string]struct{}{"appname": {}},
+		}
+
+		params, err := r.ResolveAdditionalQueryParametersFromTxtRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, []string{"appname=foo"}, params)
+
+		r.Resolver = &stubResolver{txts: []string{"authSource=thisDB"}}
+		_, err = r.ResolveAdditionalQueryParametersFromTxtRecords("test1.test.build.10gen.cc")
+		require.Error(t, err)
+	})
+	t.Run("one record with multiple strings", func(t *testing.T) {
+		stub := &txtRecordStub{records: [][]string{{"replicaSet=repl0&", "authSource=thisDB"}}}
+		r := &Resolver{Resolver: stub}
+
+		params, err := r.ResolveAdditionalQueryParametersFromTxtRecords("test6.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, []string{"replicaset=repl0", "authsource=thisDB"}, params)
+	})
+	t.Run("multiple records", func(t *testing.T) {
+		stub := &txtRecordStub{records: [][]string{{"replicaSet=repl0"}, {"authSource=thisDB"}}}
+		r := &Resolver{Resolver: stub}
+
+		_, err := r.ResolveAdditionalQueryParametersFromTxtRecords("test6.test.build.10gen.cc")
+		require.Equal(t, ErrMultipleTXTRecords, err)
+	})
+	t.Run("cancelled context", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{block: true}}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := r.ResolveAdditionalQueryParametersFromTxtRecordsContext(ctx, "test1.test.build.10gen.cc")
+		require.Equal(t, context.Canceled, internal.UnwrapError(err), "expected context.Canceled, got %v", err)
+	})
+}
+
+func buildSet(list []string) map[string]struct{} {
+	set := map[string]struct{}{}
+	for _, s := range list {
+		set[s] = struct{}{}
+	}
+	return set
+}
+
+func TestValidateSRVResult(t *testing.T) {
+	tests := []struct {
+		record string
+		input  string
+		err    bool
+	}{
+		{record: "node.example.com", input: "cluster.example.com"},
+		{record: "node.sub.example.com", input: "cluster.example.com"},
+		{record: "NODE.Example.COM", input: "cluster.example.com"},
+		{record: "node.example.com.", input: "cluster.example.com."},
+		{record: "node.example.com", input: "example.com"},
+		{record: "example.com", input: "cluster.example.com", err: true},
+		{record: "node.example.org", input: "cluster.example.com", err: true},
+		{record: "node.sub.example.org", input: "cluster.example.com", err: true},
+		{record: "node.notexample.com", input: "cluster.example.com", err: true},
+		{record: "localhost", input: "cluster.example.com", err: true},
+		{record: "127.0.0.1", input: "cluster.example.com", err: true},
+		{record: "::1", input: "cluster.example.com", err: true},
+		{record: "[2001:db8::1]", input: "cluster.example.com", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.record+" "+test.input, func(t *testing.T) {
+			err := validateSRVResult(test.record, test.input)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAllowedTargetSuffixes(t *testing.T) {
+	tests := []struct {
+		record   string
+		suffixes []string
+		err      bool
+	}{
+		{record: "node.example.com", suffixes: nil},
+		{record: "node.example.com", suffixes: []string{"example.com"}},
+		{record: "node.sub.example.com", suffixes: []string{"other.com", "Example.COM."}},
+		{record: "node.sub.example.com", suffixes: []string{".sub.example.com"}},
+		{record: "node.example.com", suffixes: []string{"sub.example.com"}, err: true},
+		{record: "node.notexample.com", suffixes: []string{"example.com"}, err: true},
+		{record: "node.example.com", suffixes: []string{""}, err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.record, func(t *testing.T) {
+			r := &Resolver{AllowedTargetSuffixes: test.suffixes}
+			err := r.validateTargetSuffix(test.record, "cluster.example.com")
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAfterResolve(t *testing.T) {
+	srvs := []*net.SRV{
+		{Target: "localhost.test.build.10gen.cc.", Port: 27017},
+		{Target: "localhost.test.build.10gen.cc.", Port: 27018},
+	}
+
+	t.Run("receives validated records", func(t *testing.T) {
+		var host string
+		var targets []SRVRecord
+		r := &Resolver{
+			Resolver: &stubResolver{srvs: srvs},
+			AfterResolve: func(h string, records []SRVRecord) error {
+				host, targets = h, records
+				return nil
+			},
+			DisableShuffle: true,
+		}
+
+		hosts, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, []string{"localhost.test.build.10gen.cc:27017", "localhost.test.build.10gen.cc:27018"}, hosts)
+		require.Equal(t, "test1.test.build.10gen.cc", host)
+		require.Equal(t, []SRVRecord{
+			{Target: "localhost.test.build.10gen.cc", Port: 27017},
+			{Target: "localhost.test.build.10gen.cc", Port: 27018},
+		}, targets)
+	})
+	t.Run("error is returned", func(t *testing.T) {
+		errVeto := errors.New("target not allowed")
+		r := &Resolver{
+			Resolver:     &stubResolver{srvs: srvs},
+			AfterResolve: func(string, []SRVRecord) error { return errVeto },
+		}
+
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.Equal(t, errVeto, err)
+	})
+	t.Run("not called for invalid records", func(t *testing.T) {
+		called := false
+		r := &Resolver{
+			Resolver: &stubResolver{srvs: []*net.SRV{{Target: "localhost.example.org.", Port: 27017}}},
+			AfterResolve: func(string, []SRVRecord) error {
+				called = true
+				return nil
+			},
+		}
+
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.Error(t, err)
+		require.False(t, called)
+	})
+}
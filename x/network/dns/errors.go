@@ -0,0 +1,44 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dns
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrSRVIncludesPort occurs when the host of a mongodb+srv connection string includes a port.
+	ErrSRVIncludesPort = errors.New("URI with srv must not include a port number")
+	// ErrInvalidSRVHost occurs when the host of a mongodb+srv connection string is not a domain
+	// name with at least two labels, such as "example.com".
+	ErrInvalidSRVHost = errors.New("host must be a domain name with at least 2 labels")
+	// ErrMultipleSRVHosts occurs when a mongodb+srv connection string includes more than one host.
+	ErrMultipleSRVHosts = errors.New("URI with SRV must include one and only one hostname")
+	// ErrNoSRVRecords occurs when an SRV lookup succeeds but returns no records.
+	ErrNoSRVRecords = errors.New("no records returned")
+	// ErrMultipleTXTRecords occurs when the host of a mongodb+srv connection string has more than
+	// one TXT record.
+	ErrMultipleTXTRecords = errors.New("multiple records from TXT not supported")
+	// ErrInvalidTXTRecord occurs when a TXT record contains something other than key=value pairs.
+	ErrInvalidTXTRecord = errors.New("Invalid TXT record")
+	// ErrInvalidTXTOption occurs when a TXT record contains an option that is not allowed there.
+	ErrInvalidTXTOption = errors.New("option cannot be specified in TXT record")
+)
+
+// SRVValidationError occurs when a record returned from an SRV lookup is not valid for the host
+// that was looked up.
+type SRVValidationError struct {
+	Record  string
+	Input   string
+	Message string
+}
+
+// Error implements the error interface.
+func (e SRVValidationError) Error() string {
+	return fmt.Sprintf("invalid SRV record %s for %s: %s", e.Record, e.Input, e.Message)
+}
@@ -0,0 +1,41 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dns
+
+import "time"
+
+// QueryType is the type of DNS record looked up by a resolution.
+type QueryType string
+
+// QueryType constants.
+const (
+	SRVQuery QueryType = "SRV"
+	TXTQuery QueryType = "TXT"
+)
+
+// Event represents an event generated when an SRV or TXT resolution completes. Retries of a failed
+// lookup are part of the same resolution.
+type Event struct {
+	QueryType   QueryType
+	Host        string
+	Duration    time.Duration
+	RecordCount int
+	Err         error
+}
+
+func (r *Resolver) publish(queryType QueryType, host string, start time.Time, count int, err error) {
+	if r.Monitor == nil {
+		return
+	}
+	r.Monitor(&Event{
+		QueryType:   queryType,
+		Host:        host,
+		Duration:    time.Since(start),
+		RecordCount: count,
+		Err:         err,
+	})
+}
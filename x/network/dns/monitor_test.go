@@ -0,0 +1,70 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dns
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitor(t *testing.T) {
+	var events []*Event
+	monitor := func(e *Event) { events = append(events, e) }
+
+	t.Run("SRV", func(t *testing.T) {
+		events = nil
+		r := &Resolver{
+			Resolver: &stubResolver{srvs: []*net.SRV{
+				{Target: "localhost.test.build.10gen.cc.", Port: 27017},
+				{Target: "localhost.test.build.10gen.cc.", Port: 27018},
+			}},
+			Monitor: monitor,
+		}
+
+		hosts, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Len(t, hosts, 2)
+		require.Len(t, events, 1)
+		require.Equal(t, SRVQuery, events[0].QueryType)
+		require.Equal(t, "test1.test.build.10gen.cc", events[0].Host)
+		require.Equal(t, 2, events[0].RecordCount)
+		require.NoError(t, events[0].Err)
+		require.True(t, events[0].Duration >= 0)
+	})
+	t.Run("SRV error", func(t *testing.T) {
+		events = nil
+		lookupErr := errors.New("lookup failed")
+		r := &Resolver{Resolver: &stubResolver{srvErr: lookupErr}, Monitor: monitor}
+
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.Equal(t, lookupErr, err)
+		require.Len(t, events, 1)
+		require.Equal(t, lookupErr, events[0].Err)
+		require.Equal(t, 0, events[0].RecordCount)
+	})
+	t.Run("TXT", func(t *testing.T) {
+		events = nil
+		r := &Resolver{Resolver: &stubResolver{txts: []string{"replicaSet=repl0"}}, Monitor: monitor}
+
+		params, err := r.ResolveAdditionalQueryParametersFromTxtRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, []string{"replicaset=repl0"}, params)
+		require.Len(t, events, 1)
+		require.Equal(t, TXTQuery, events[0].QueryType)
+		require.Equal(t, 1, events[0].RecordCount)
+		require.NoError(t, events[0].Err)
+	})
+	t.Run("nil monitor", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{txts: []string{"replicaSet=repl0"}}}
+
+		_, err := r.ResolveAdditionalQueryParametersFromTxtRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dns
+
+import (
+	"context"
+	"time"
+)
+
+// minRescanInterval is the minimum amount of time between SRV lookups when polling.
+const minRescanInterval = 60 * time.Second
+
+// PollSRV resolves the SRV records for host and calls fn with the resulting hosts. The records are
+// then re-resolved and fn is called again whenever the set of hosts changes. Lookups happen every
+// 60 seconds or, if the NetResolver implements TTLResolver, after the TTL of the last records when
+// that is longer. Failed lookups and lookups that return no hosts are ignored and the previous hosts are
+// kept. If SrvMaxHosts is set, hosts that are still present in the SRV record are kept in the
+// sample and only replaced hosts are chosen randomly.
+//
+// PollSRV blocks until ctx is done. fn is called from the goroutine running PollSRV.
+func (r *Resolver) PollSRV(ctx context.Context, host string, fn func([]string)) {
+	var current []string
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		resolved, err := r.lookupHostsFromSRV(ctx, host)
+		if err == nil && len(resolved) > 0 {
+			hosts := r.selectHosts(current, resolved)
+			if current == nil || !sameHosts(current, hosts) {
+				current = hosts
+				fn(hosts)
+			}
+		}
+
+		timer.Reset(r.rescanInterval())
+	}
+}
+
+func (r *Resolver) rescanInterval() time.Duration {
+	// rescanFrequency is only set by tests.
+	if r.rescanFrequency > 0 {
+		return r.rescanFrequency
+	}
+	if ttl := r.LastSRVTTL(); ttl > minRescanInterval {
+		return ttl
+	}
+	return minRescanInterval
+}
+
+func sameHosts(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	set := make(map[string]struct{}, len(a))
+	for _, host := range a {
+		set[host] = struct{}{}
+	}
+	for _, host := range b {
+		if _, ok := set[host]; !ok {
+			return false
+		}
+	}
+	return true
+}
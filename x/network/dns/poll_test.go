@@ -0,0 +1,146 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pollStub struct {
+	sync.Mutex
+	srvs []*net.SRV
+	err  error
+}
+
+func (p *pollStub) set(srvs []*net.SRV, err error) {
+	p.Lock()
+	defer p.Unlock()
+	p.srvs = srvs
+	p.err = err
+}
+
+func (p *pollStub) LookupSRV(context.Context, string, string, string) (string, []*net.SRV, error) {
+	p.Lock()
+	defer p.Unlock()
+	return "", p.srvs, p.err
+}
+
+func (p *pollStub) LookupTXT(context.Context, string) ([]string, error) {
+	return nil, nil
+}
+
+func TestPollSRV(t *testing.T) {
+	first := []*net.SRV{
+		{Target: "localhost.test.build.10gen.cc.", Port: 27017},
+		{Target: "localhost.test.build.10gen.cc.", Port: 27018},
+	}
+	second := []*net.SRV{
+		{Target: "localhost.test.build.10gen.cc.", Port: 27018},
+		{Target: "localhost.test.build.10gen.cc.", Port: 27019},
+	}
+
+	stub := &pollStub{srvs: first}
+	r := &Resolver{Resolver: stub, DisableShuffle: true, rescanFrequency: 5 * time.Millisecond}
+
+	updates := make(chan []string, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.PollSRV(ctx, "test1.test.build.10gen.cc", func(hosts []string) { updates <- hosts })
+		close(done)
+	}()
+
+	require.Equal(t, []string{"localhost.test.build.10gen.cc:27017", "localhost.test.build.10gen.cc:27018"}, <-updates)
+
+	// failed lookups keep the previous hosts.
+	stub.set(nil, errors.New("lookup failed"))
+	time.Sleep(20 * time.Millisecond)
+	require.Len(t, updates, 0)
+
+	stub.set(second, nil)
+	require.Equal(t, []string{"localhost.test.build.10gen.cc:27018", "localhost.test.build.10gen.cc:27019"}, <-updates)
+
+	// unchanged records do not call fn.
+	time.Sleep(20 * time.Millisecond)
+	require.Len(t, updates, 0)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("PollSRV did not return after the context was cancelled")
+	}
+}
+
+func TestPollSRVMaxHosts(t *testing.T) {
+	stub := &pollStub{srvs: []*net.SRV{
+		{Target: "localhost.test.build.10gen.cc.", Port: 27017},
+		{Target: "localhost.test.build.10gen.cc.", Port: 27018},
+	}}
+	r := &Resolver{Resolver: stub, SrvMaxHosts: 2, rescanFrequency: 5 * time.Millisecond}
+
+	updates := make(chan []string, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.PollSRV(ctx, "test1.test.build.10gen.cc", func(hosts []string) { updates <- hosts })
+
+	require.Len(t, <-updates, 2)
+
+	// the remaining host is kept and the removed one is replaced.
+	stub.set([]*net.SRV{
+		{Target: "localhost.test.build.10gen.cc.", Port: 27018},
+		{Target: "localhost.test.build.10gen.cc.", Port: 27019},
+		{Target: "localhost.test.build.10gen.cc.", Port: 27020},
+	}, nil)
+	hosts := <-updates
+	require.Len(t, hosts, 2)
+	require.Equal(t, "localhost.test.build.10gen.cc:27018", hosts[0])
+	require.NotEqual(t, "localhost.test.build.10gen.cc:27017", hosts[1])
+}
+
+type ttlStub struct {
+	pollStub
+	ttl time.Duration
+}
+
+func (t *ttlStub) LookupSRVWithTTL(ctx context.Context, service, proto, name string) (string, []*net.SRV, time.Duration, error) {
+	cname, srvs, err := t.LookupSRV(ctx, service, proto, name)
+	return cname, srvs, t.ttl, err
+}
+
+func TestRescanInterval(t *testing.T) {
+	srvs := []*net.SRV{{Target: "localhost.test.build.10gen.cc.", Port: 27017}}
+
+	t.Run("no ttl", func(t *testing.T) {
+		r := &Resolver{Resolver: &pollStub{srvs: srvs}}
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, time.Duration(0), r.LastSRVTTL())
+		require.Equal(t, 60*time.Second, r.rescanInterval())
+	})
+	t.Run("ttl below minimum", func(t *testing.T) {
+		r := &Resolver{Resolver: &ttlStub{pollStub: pollStub{srvs: srvs}, ttl: 30 * time.Second}}
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, 30*time.Second, r.LastSRVTTL())
+		require.Equal(t, 60*time.Second, r.rescanInterval())
+	})
+	t.Run("ttl above minimum", func(t *testing.T) {
+		r := &Resolver{Resolver: &ttlStub{pollStub: pollStub{srvs: srvs}, ttl: 5 * time.Minute}}
+		_, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, 5*time.Minute, r.LastSRVTTL())
+		require.Equal(t, 5*time.Minute, r.rescanInterval())
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dns
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// SRVRecord is a single record returned from an SRV lookup.
+type SRVRecord struct {
+	Target   string
+	Port     uint16
+	Priority uint16
+	Weight   uint16
+}
+
+// String returns the address of the record in "host:port" form.
+func (s SRVRecord) String() string {
+	return fmt.Sprintf("%s:%d", s.Target, s.Port)
+}
+
+// ResolveSRVRecords looks up the SRV records for the given host. The records are ordered by
+// ascending priority and, within the same priority, randomly according to their weights as
+// described in RFC 2782. Records with the same priority and weight are shuffled. If
+// DisableShuffle is set, the records are only ordered by priority.
+func (r *Resolver) ResolveSRVRecords(host string) ([]SRVRecord, error) {
+	return r.ResolveSRVRecordsContext(context.Background(), host)
+}
+
+// ResolveSRVRecordsContext is like ResolveSRVRecords but aborts the lookup when ctx is done.
+func (r *Resolver) ResolveSRVRecordsContext(ctx context.Context, host string) ([]SRVRecord, error) {
+	return r.lookupSRVRecords(ctx, host)
+}
+
+// sortSRVRecords orders records by priority and shuffles records with the same priority by
+// weight. Priority and weight take precedence over the initial shuffle, which only breaks ties.
+func (r *Resolver) sortSRVRecords(records []SRVRecord) {
+	if r.DisableShuffle {
+		sort.SliceStable(records, func(i, j int) bool {
+			return records[i].Priority < records[j].Priority
+		})
+		return
+	}
+
+	r.shuffle(len(records), func(i, j int) {
+		records[i], records[j] = records[j], records[i]
+	})
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Priority < records[j].Priority
+	})
+
+	for start := 0; start < len(records); {
+		end := start + 1
+		for end < len(records) && records[end].Priority == records[start].Priority {
+			end++
+		}
+		r.shuffleByWeight(records[start:end])
+		start = end
+	}
+}
+
+// shuffleByWeight repeatedly picks the next record with a probability proportional to its
+// weight. Records with a weight of zero are only picked once all others have been.
+func (r *Resolver) shuffleByWeight(records []SRVRecord) {
+	sum := 0
+	for _, record := range records {
+		sum += int(record.Weight)
+	}
+
+	for sum > 0 && len(records) > 1 {
+		n := r.intn(sum)
+		s := 0
+		for i := range records {
+			s += int(records[i].Weight)
+			if s > n {
+				records[0], records[i] = records[i], records[0]
+				break
+			}
+		}
+		sum -= int(records[0].Weight)
+		records = records[1:]
+	}
+}
+
+func (r *Resolver) intn(n int) int {
+	if r.Rand != nil {
+		return r.Rand.Intn(n)
+	}
+	return rand.Intn(n)
+}
+
+// shuffle randomly permutes n elements with a Fisher-Yates shuffle. rand.Shuffle is not used because it
+// requires Go 1.10.
+func (r *Resolver) shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		swap(i, r.intn(i+1))
+	}
+}
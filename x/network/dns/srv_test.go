@@ -0,0 +1,112 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dns
+
+import (
+	"math/rand"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSRVRecords(t *testing.T) {
+	stub := &stubResolver{srvs: []*net.SRV{
+		{Target: "b.test.build.10gen.cc.", Port: 27017, Priority: 20, Weight: 1},
+		{Target: "a.test.build.10gen.cc.", Port: 27018, Priority: 10, Weight: 5},
+	}}
+	r := &Resolver{Resolver: stub}
+
+	records, err := r.ResolveSRVRecords("test1.test.build.10gen.cc")
+	require.NoError(t, err)
+	require.Equal(t, []SRVRecord{
+		{Target: "a.test.build.10gen.cc", Port: 27018, Priority: 10, Weight: 5},
+		{Target: "b.test.build.10gen.cc", Port: 27017, Priority: 20, Weight: 1},
+	}, records)
+
+	hosts, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.test.build.10gen.cc:27018", "b.test.build.10gen.cc:27017"}, hosts)
+}
+
+func TestSortSRVRecords(t *testing.T) {
+	t.Run("priority", func(t *testing.T) {
+		records := []SRVRecord{
+			{Target: "c", Priority: 3},
+			{Target: "a", Priority: 1},
+			{Target: "b", Priority: 2},
+		}
+		(&Resolver{}).sortSRVRecords(records)
+		require.Equal(t, "a", records[0].Target)
+		require.Equal(t, "b", records[1].Target)
+		require.Equal(t, "c", records[2].Target)
+	})
+	t.Run("weight", func(t *testing.T) {
+		// "heavy" has 99% of the weight, so it should nearly always come first.
+		heavyFirst := 0
+		for i := 0; i < 1000; i++ {
+			records := []SRVRecord{
+				{Target: "light", Priority: 1, Weight: 1},
+				{Target: "heavy", Priority: 1, Weight: 99},
+				{Target: "zero", Priority: 1, Weight: 0},
+				{Target: "low-priority", Priority: 2, Weight: 1000},
+			}
+			(&Resolver{}).sortSRVRecords(records)
+			if records[0].Target == "heavy" {
+				heavyFirst++
+			}
+			require.Equal(t, "zero", records[2].Target)
+			require.Equal(t, "low-priority", records[3].Target)
+		}
+		require.True(t, heavyFirst > 900, "heavy record was first %d/1000 times", heavyFirst)
+	})
+}
+
+func TestShuffleSRVRecords(t *testing.T) {
+	srvs := []*net.SRV{
+		{Target: "a.test.build.10gen.cc.", Port: 27017},
+		{Target: "b.test.build.10gen.cc.", Port: 27017},
+		{Target: "c.test.build.10gen.cc.", Port: 27017},
+		{Target: "d.test.build.10gen.cc.", Port: 27017},
+	}
+	inOrder := []string{
+		"a.test.build.10gen.cc:27017",
+		"b.test.build.10gen.cc:27017",
+		"c.test.build.10gen.cc:27017",
+		"d.test.build.10gen.cc:27017",
+	}
+
+	t.Run("shuffled by default", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{srvs: srvs}}
+		orders := make(map[string]struct{})
+		for i := 0; i < 100; i++ {
+			hosts, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+			require.NoError(t, err)
+			require.Equal(t, buildSet(inOrder), buildSet(hosts))
+			orders[strings.Join(hosts, ",")] = struct{}{}
+		}
+		require.True(t, len(orders) > 1, "expected hosts to be shuffled")
+	})
+	t.Run("deterministic when seeded", func(t *testing.T) {
+		first, err := (&Resolver{Resolver: &stubResolver{srvs: srvs}, Rand: rand.New(rand.NewSource(42))}).
+			ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		second, err := (&Resolver{Resolver: &stubResolver{srvs: srvs}, Rand: rand.New(rand.NewSource(42))}).
+			ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+		require.NoError(t, err)
+		require.Equal(t, first, second)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		r := &Resolver{Resolver: &stubResolver{srvs: srvs}, DisableShuffle: true}
+		for i := 0; i < 10; i++ {
+			hosts, err := r.ResolveHostFromSrvRecords("test1.test.build.10gen.cc")
+			require.NoError(t, err)
+			require.Equal(t, inOrder, hosts)
+		}
+	})
+}
@@ -322,6 +322,9 @@ func serveropts(t *testing.T, opts ...topology.ServerOption) []topology.ServerOp
 			case "zlib":
 				zlibComp, _ := compressor.CreateZlib(cs.ZlibLevel)
 				comp = append(comp, zlibComp)
+			case "zstd":
+				zstdComp, _ := compressor.CreateZstd()
+				comp = append(comp, zstdComp)
 			}
 		}
 
@@ -24,8 +24,9 @@ type Upsert struct {
 // Insert is a result from an Insert command.
 type Insert struct {
 	N                 int
-	WriteErrors       []WriteError       `bson:"writeErrors"`
-	WriteConcernError *WriteConcernError `bson:"writeConcernError"`
+	WriteErrors       []WriteError         `bson:"writeErrors"`
+	WriteConcernError *WriteConcernError   `bson:"writeConcernError"`
+	OperationTime     *primitive.Timestamp `bson:"operationTime"`
 }
 
 // StartSession is a result from a StartSession command.
@@ -39,17 +40,19 @@ type EndSessions struct{}
 // Delete is a result from a Delete command.
 type Delete struct {
 	N                 int
-	WriteErrors       []WriteError       `bson:"writeErrors"`
-	WriteConcernError *WriteConcernError `bson:"writeConcernError"`
+	WriteErrors       []WriteError         `bson:"writeErrors"`
+	WriteConcernError *WriteConcernError   `bson:"writeConcernError"`
+	OperationTime     *primitive.Timestamp `bson:"operationTime"`
 }
 
 // Update is a result of an Update command.
 type Update struct {
-	MatchedCount      int64              `bson:"n"`
-	ModifiedCount     int64              `bson:"nModified"`
-	Upserted          []Upsert           `bson:"upserted"`
-	WriteErrors       []WriteError       `bson:"writeErrors"`
-	WriteConcernError *WriteConcernError `bson:"writeConcernError"`
+	MatchedCount      int64                `bson:"n"`
+	ModifiedCount     int64                `bson:"nModified"`
+	Upserted          []Upsert             `bson:"upserted"`
+	WriteErrors       []WriteError         `bson:"writeErrors"`
+	WriteConcernError *WriteConcernError   `bson:"writeConcernError"`
+	OperationTime     *primitive.Timestamp `bson:"operationTime"`
 }
 
 // Distinct is a result from a Distinct command.
@@ -118,6 +121,7 @@ type IsMaster struct {
 	Secondary                    bool               `bson:"secondary,omitempty"`
 	SetName                      string             `bson:"setName,omitempty"`
 	SetVersion                   uint32             `bson:"setVersion,omitempty"`
+	SpeculativeAuthenticate      bsonx.Doc          `bson:"speculativeAuthenticate,omitempty"`
 	Tags                         map[string]string  `bson:"tags,omitempty"`
 }
 
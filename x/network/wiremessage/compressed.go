@@ -104,6 +104,7 @@ const (
 	CompressorNoOp CompressorID = iota
 	CompressorSnappy
 	CompressorZLib
+	CompressorZstd
 )
 
 // DefaultZlibLevel is the default level for zlib compression